@@ -64,6 +64,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&PipelineStructureList{},
 		&Release{},
 		&ReleaseList{},
+		&Scheduler{},
+		&SchedulerList{},
 		&SourceRepository{},
 		&SourceRepositoryList{},
 		&Team{},
@@ -39,6 +39,22 @@ type EnvironmentSpec struct {
 
 	// RemoteCluster flag indicates if the Environment is deployed in a separate cluster to the Development Environment
 	RemoteCluster bool `json:"remoteCluster,omitempty" protobuf:"bytes,12,opt,name=remoteCluster"`
+
+	// ReviewPolicy the policy jx enforces on the Pull Requests which promote to the Environment
+	ReviewPolicy *ReviewPolicy `json:"reviewPolicy,omitempty" protobuf:"bytes,13,opt,name=reviewPolicy"`
+}
+
+// ReviewPolicy defines how the Pull Requests which promote to an Environment are labelled, who is asked to review
+// them and how many approvals they need before jx merges them
+type ReviewPolicy struct {
+	// Labels are added to the Pull Requests
+	Labels []string `json:"labels,omitempty" protobuf:"bytes,1,rep,name=labels"`
+	// Reviewers are requested to review the Pull Requests
+	Reviewers []string `json:"reviewers,omitempty" protobuf:"bytes,2,rep,name=reviewers"`
+	// RequestOwners requests reviews from the approvers in the OWNERS file of the Environment git repository
+	RequestOwners bool `json:"requestOwners,omitempty" protobuf:"bytes,3,opt,name=requestOwners"`
+	// RequiredApprovals is the number of approving reviews needed before jx merges the Pull Requests
+	RequiredApprovals int `json:"requiredApprovals,omitempty" protobuf:"bytes,4,opt,name=requiredApprovals"`
 }
 
 // EnvironmentStatus is the status for an Environment resource
@@ -195,6 +211,32 @@ type TeamSettings struct {
 
 	// AppsPrefixes is the list of prefixes for appNames
 	AppsPrefixes []string `json:"appPrefixes,omitempty" protobuf:"bytes,27,opt,name=appPrefixes"`
+
+	// BranchProtection is the protection applied to the branches of the source repositories of the team
+	BranchProtection *BranchProtection `json:"branchProtection,omitempty" protobuf:"bytes,28,opt,name=branchProtection"`
+
+	// ChangelogTemplate is the go template used to generate the changelog of releases for repositories which do not have their own template
+	ChangelogTemplate string `json:"changelogTemplate,omitempty" protobuf:"bytes,29,opt,name=changelogTemplate"`
+
+	// DefaultScheduler is the Scheduler which decides the jobs of the source repositories of the team. The Scheduler
+	// of a SourceRepository is applied on top of it
+	DefaultScheduler ResourceReference `json:"defaultScheduler,omitempty" protobuf:"bytes,30,opt,name=defaultScheduler"`
+}
+
+// BranchProtection is the protection of the branches of the source repositories of a team
+type BranchProtection struct {
+	// Branches the names of the protected branches which defaults to master
+	Branches []string `json:"branches,omitempty" protobuf:"bytes,1,rep,name=branches"`
+	// RequiredStatusContexts the commit status contexts which must pass before a pull request can be merged
+	RequiredStatusContexts []string `json:"requiredStatusContexts,omitempty" protobuf:"bytes,2,rep,name=requiredStatusContexts"`
+	// RequiredApprovals the number of approving reviews a pull request needs before it can be merged
+	RequiredApprovals int `json:"requiredApprovals,omitempty" protobuf:"bytes,3,opt,name=requiredApprovals"`
+	// RestrictPushes only allows the PushUsers and PushTeams to push to the branches
+	RestrictPushes bool     `json:"restrictPushes,omitempty" protobuf:"bytes,4,opt,name=restrictPushes"`
+	PushUsers      []string `json:"pushUsers,omitempty" protobuf:"bytes,5,rep,name=pushUsers"`
+	PushTeams      []string `json:"pushTeams,omitempty" protobuf:"bytes,6,rep,name=pushTeams"`
+	// EnforceAdmins applies the protection to administrators too
+	EnforceAdmins bool `json:"enforceAdmins,omitempty" protobuf:"bytes,7,opt,name=enforceAdmins"`
 }
 
 // StorageLocation
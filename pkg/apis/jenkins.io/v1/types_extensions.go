@@ -475,6 +475,8 @@ type SourceRepositorySpec struct {
 	Provider string `json:"provider,omitempty" protobuf:"bytes,2,opt,name=provider"`
 	Org      string `json:"org,omitempty" protobuf:"bytes,3,opt,name=org"`
 	Repo     string `json:"repo,omitempty" protobuf:"bytes,4,opt,name=repo"`
+	// Scheduler is the Scheduler of the repository which is applied on top of the default Scheduler of the team
+	Scheduler ResourceReference `json:"scheduler,omitempty" protobuf:"bytes,5,opt,name=scheduler"`
 }
 
 // AppSpec provides details of the metadata for an App
@@ -119,7 +119,27 @@ const (
 	MeasurementCount   = "count"
 )
 
+// Recommended measurements for test results
+const (
+	TestResultsMeasurementTotal   = "Total"
+	TestResultsMeasurementPassed  = "Passed"
+	TestResultsMeasurementFailed  = "Failed"
+	TestResultsMeasurementSkipped = "Skipped"
+)
+
 const (
 	FactTypeCoverage              = "jx.coverage"
 	FactTypeStaticProgramAnalysis = "jx.staticProgramAnalysis"
+	FactTypeTestResults           = "jx.testResults"
+	FactTypeStatement             = "jx.statement"
+)
+
+// Recommended labels for Facts about a pipeline
+const (
+	FactLabelSubjectKind  = "subjectkind"
+	FactLabelPipelineName = "pipelineName"
+	FactLabelOrg          = "org"
+	FactLabelRepo         = "repo"
+	FactLabelBranch       = "branch"
+	FactLabelBuildNumber  = "buildNumber"
 )
@@ -98,6 +98,22 @@ type PromoteActivityStep struct {
 	PullRequest    *PromotePullRequestStep `json:"pullRequest,omitempty" protobuf:"bytes,2,opt,name=pullRequest"`
 	Update         *PromoteUpdateStep      `json:"update,omitempty" protobuf:"bytes,3,opt,name=update"`
 	ApplicationURL string                  `json:"applicationURL,omitempty" protobuf:"bytes,4,opt,name=environment"`
+	Gates          *PromoteGatesStep       `json:"gates,omitempty" protobuf:"bytes,5,opt,name=gates"`
+}
+
+// PromoteGatesStep is the step of evaluating the gates which must pass before a version is promoted to an environment.
+// The description contains the reason when the gates block the promotion
+type PromoteGatesStep struct {
+	CoreActivityStep `json:",inline"`
+
+	Results []GateResult `json:"results,omitempty" protobuf:"bytes,1,rep,name=results"`
+}
+
+// GateResult is the result of evaluating a promotion gate
+type GateResult struct {
+	Gate    string `json:"gate" protobuf:"bytes,1,opt,name=gate"`
+	Passed  bool   `json:"passed" protobuf:"bytes,2,opt,name=passed"`
+	Message string `json:"message,omitempty" protobuf:"bytes,3,opt,name=message"`
 }
 
 // GitStatus the status of a git commit in terms of CI/CD
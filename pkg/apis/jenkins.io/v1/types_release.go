@@ -45,6 +45,10 @@ type ReleaseSpec struct {
 	ReleaseNotesURL string          `json:"releaseNotesURL,omitempty" protobuf:"bytes,8,opt,name=releaseNotesURL"`
 	GitRepository   string          `json:"gitRepository,omitempty" protobuf:"bytes,9,opt,name=gitRepository"`
 	GitOwner        string          `json:"gitOwner,omitempty" protobuf:"bytes,10,opt,name=gitOwner"`
+	// SBOMURL the URL of the software bill of materials of the release generated by 'jx step sbom'
+	SBOMURL string `json:"sbomURL,omitempty" protobuf:"bytes,11,opt,name=sbomURL"`
+	// ProvenanceURL the URL of the signed build provenance of the release generated by 'jx step provenance'
+	ProvenanceURL string `json:"provenanceURL,omitempty" protobuf:"bytes,12,opt,name=provenanceURL"`
 }
 
 // ReleaseStatus is the status of a release
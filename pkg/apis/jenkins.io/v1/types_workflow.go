@@ -45,6 +45,8 @@ type PromoteWorkflowStep struct {
 type WorkflowPreconditions struct {
 	// the names of the environments which need to have promoted before this step can be triggered
 	Environments []string `json:"environments,omitempty" protobuf:"bytes,1,opt,name=environments"`
+	// the gate expressions such as 'coverage >= 80' or 'cve <= Medium' which must pass before this step can be triggered
+	Gates []string `json:"gates,omitempty" protobuf:"bytes,2,rep,name=gates"`
 }
 
 // WorkflowStatus is the status for an Environment resource
@@ -9,6 +9,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	time "time"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -113,6 +114,42 @@ func (in *AppSpec) DeepCopy() *AppSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Approve) DeepCopyInto(out *Approve) {
+	*out = *in
+	if in.IssueRequired != nil {
+		in, out := &in.IssueRequired, &out.IssueRequired
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RequireSelfApproval != nil {
+		in, out := &in.RequireSelfApproval, &out.RequireSelfApproval
+		*out = new(bool)
+		**out = **in
+	}
+	if in.LgtmActsAsApprove != nil {
+		in, out := &in.LgtmActsAsApprove, &out.LgtmActsAsApprove
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IgnoreReviewState != nil {
+		in, out := &in.IgnoreReviewState, &out.IgnoreReviewState
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Approve.
+func (in *Approve) DeepCopy() *Approve {
+	if in == nil {
+		return nil
+	}
+	out := new(Approve)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Attachment) DeepCopyInto(out *Attachment) {
 	*out = *in
@@ -150,6 +187,94 @@ func (in *Binary) DeepCopy() *Binary {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BranchProtection) DeepCopyInto(out *BranchProtection) {
+	*out = *in
+	if in.Branches != nil {
+		in, out := &in.Branches, &out.Branches
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RequiredStatusContexts != nil {
+		in, out := &in.RequiredStatusContexts, &out.RequiredStatusContexts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PushUsers != nil {
+		in, out := &in.PushUsers, &out.PushUsers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PushTeams != nil {
+		in, out := &in.PushTeams, &out.PushTeams
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BranchProtection.
+func (in *BranchProtection) DeepCopy() *BranchProtection {
+	if in == nil {
+		return nil
+	}
+	out := new(BranchProtection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BranchProtectionContextPolicy) DeepCopyInto(out *BranchProtectionContextPolicy) {
+	*out = *in
+	if in.Contexts != nil {
+		in, out := &in.Contexts, &out.Contexts
+		*out = new(ReplaceableSliceOfStrings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Strict != nil {
+		in, out := &in.Strict, &out.Strict
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BranchProtectionContextPolicy.
+func (in *BranchProtectionContextPolicy) DeepCopy() *BranchProtectionContextPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(BranchProtectionContextPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Brancher) DeepCopyInto(out *Brancher) {
+	*out = *in
+	if in.SkipBranches != nil {
+		in, out := &in.SkipBranches, &out.SkipBranches
+		*out = new(ReplaceableSliceOfStrings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Branches != nil {
+		in, out := &in.Branches, &out.Branches
+		*out = new(ReplaceableSliceOfStrings)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Brancher.
+func (in *Brancher) DeepCopy() *Brancher {
+	if in == nil {
+		return nil
+	}
+	out := new(Brancher)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BuildPack) DeepCopyInto(out *BuildPack) {
 	*out = *in
@@ -418,6 +543,47 @@ func (in *CommitSummary) DeepCopy() *CommitSummary {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContextPolicy) DeepCopyInto(out *ContextPolicy) {
+	*out = *in
+	if in.SkipUnknownContexts != nil {
+		in, out := &in.SkipUnknownContexts, &out.SkipUnknownContexts
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RequiredContexts != nil {
+		in, out := &in.RequiredContexts, &out.RequiredContexts
+		*out = new(ReplaceableSliceOfStrings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RequiredIfPresentContexts != nil {
+		in, out := &in.RequiredIfPresentContexts, &out.RequiredIfPresentContexts
+		*out = new(ReplaceableSliceOfStrings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OptionalContexts != nil {
+		in, out := &in.OptionalContexts, &out.OptionalContexts
+		*out = new(ReplaceableSliceOfStrings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FromBranchProtection != nil {
+		in, out := &in.FromBranchProtection, &out.FromBranchProtection
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContextPolicy.
+func (in *ContextPolicy) DeepCopy() *ContextPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ContextPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CoreActivityStep) DeepCopyInto(out *CoreActivityStep) {
 	*out = *in
@@ -657,6 +823,11 @@ func (in *EnvironmentSpec) DeepCopyInto(out *EnvironmentSpec) {
 	out.Source = in.Source
 	in.TeamSettings.DeepCopyInto(&out.TeamSettings)
 	out.PreviewGitSpec = in.PreviewGitSpec
+	if in.ReviewPolicy != nil {
+		in, out := &in.ReviewPolicy, &out.ReviewPolicy
+		*out = new(ReviewPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1058,6 +1229,37 @@ func (in *ExtensionSpec) DeepCopy() *ExtensionSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalPlugin) DeepCopyInto(out *ExternalPlugin) {
+	*out = *in
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.Endpoint != nil {
+		in, out := &in.Endpoint, &out.Endpoint
+		*out = new(string)
+		**out = **in
+	}
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = new(ReplaceableSliceOfStrings)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalPlugin.
+func (in *ExternalPlugin) DeepCopy() *ExternalPlugin {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalPlugin)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Fact) DeepCopyInto(out *Fact) {
 	*out = *in
@@ -1172,6 +1374,22 @@ func (in *FactStatus) DeepCopy() *FactStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GateResult) DeepCopyInto(out *GateResult) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GateResult.
+func (in *GateResult) DeepCopy() *GateResult {
+	if in == nil {
+		return nil
+	}
+	out := new(GateResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GitService) DeepCopyInto(out *GitService) {
 	*out = *in
@@ -1264,6 +1482,32 @@ func (in *GitStatus) DeepCopy() *GitStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GlobalProtectionPolicy) DeepCopyInto(out *GlobalProtectionPolicy) {
+	*out = *in
+	if in.ProtectionPolicy != nil {
+		in, out := &in.ProtectionPolicy, &out.ProtectionPolicy
+		*out = new(ProtectionPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProtectTested != nil {
+		in, out := &in.ProtectTested, &out.ProtectTested
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalProtectionPolicy.
+func (in *GlobalProtectionPolicy) DeepCopy() *GlobalProtectionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(GlobalProtectionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IssueLabel) DeepCopyInto(out *IssueLabel) {
 	*out = *in
@@ -1322,6 +1566,83 @@ func (in *IssueSummary) DeepCopy() *IssueSummary {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobBase) DeepCopyInto(out *JobBase) {
+	*out = *in
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = new(ReplaceableMapOfStringString)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxConcurrency != nil {
+		in, out := &in.MaxConcurrency, &out.MaxConcurrency
+		*out = new(int)
+		**out = **in
+	}
+	if in.Agent != nil {
+		in, out := &in.Agent, &out.Agent
+		*out = new(string)
+		**out = **in
+	}
+	if in.Cluster != nil {
+		in, out := &in.Cluster, &out.Cluster
+		*out = new(string)
+		**out = **in
+	}
+	if in.Namespace != nil {
+		in, out := &in.Namespace, &out.Namespace
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobBase.
+func (in *JobBase) DeepCopy() *JobBase {
+	if in == nil {
+		return nil
+	}
+	out := new(JobBase)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Lgtm) DeepCopyInto(out *Lgtm) {
+	*out = *in
+	if in.ReviewActsAsLgtm != nil {
+		in, out := &in.ReviewActsAsLgtm, &out.ReviewActsAsLgtm
+		*out = new(bool)
+		**out = **in
+	}
+	if in.StoreTreeHash != nil {
+		in, out := &in.StoreTreeHash, &out.StoreTreeHash
+		*out = new(bool)
+		**out = **in
+	}
+	if in.StickyLgtmTeam != nil {
+		in, out := &in.StickyLgtmTeam, &out.StickyLgtmTeam
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Lgtm.
+func (in *Lgtm) DeepCopy() *Lgtm {
+	if in == nil {
+		return nil
+	}
+	out := new(Lgtm)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Measurement) DeepCopyInto(out *Measurement) {
 	*out = *in
@@ -1343,6 +1664,67 @@ func (in *Measurement) DeepCopy() *Measurement {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Merger) DeepCopyInto(out *Merger) {
+	*out = *in
+	if in.SyncPeriod != nil {
+		in, out := &in.SyncPeriod, &out.SyncPeriod
+		*out = new(time.Duration)
+		**out = **in
+	}
+	if in.StatusUpdatePeriod != nil {
+		in, out := &in.StatusUpdatePeriod, &out.StatusUpdatePeriod
+		*out = new(time.Duration)
+		**out = **in
+	}
+	if in.TargetURL != nil {
+		in, out := &in.TargetURL, &out.TargetURL
+		*out = new(string)
+		**out = **in
+	}
+	if in.PRStatusBaseURL != nil {
+		in, out := &in.PRStatusBaseURL, &out.PRStatusBaseURL
+		*out = new(string)
+		**out = **in
+	}
+	if in.BlockerLabel != nil {
+		in, out := &in.BlockerLabel, &out.BlockerLabel
+		*out = new(string)
+		**out = **in
+	}
+	if in.SquashLabel != nil {
+		in, out := &in.SquashLabel, &out.SquashLabel
+		*out = new(string)
+		**out = **in
+	}
+	if in.MaxGoroutines != nil {
+		in, out := &in.MaxGoroutines, &out.MaxGoroutines
+		*out = new(int)
+		**out = **in
+	}
+	if in.MergeType != nil {
+		in, out := &in.MergeType, &out.MergeType
+		*out = new(string)
+		**out = **in
+	}
+	if in.ContextPolicy != nil {
+		in, out := &in.ContextPolicy, &out.ContextPolicy
+		*out = new(ContextPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Merger.
+func (in *Merger) DeepCopy() *Merger {
+	if in == nil {
+		return nil
+	}
+	out := new(Merger)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Original) DeepCopyInto(out *Original) {
 	*out = *in
@@ -1757,30 +2139,206 @@ func (in *PluginSpec) DeepCopy() *PluginSpec {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PreviewActivityStep) DeepCopyInto(out *PreviewActivityStep) {
+func (in *Postsubmit) DeepCopyInto(out *Postsubmit) {
 	*out = *in
-	in.CoreActivityStep.DeepCopyInto(&out.CoreActivityStep)
+	if in.JobBase != nil {
+		in, out := &in.JobBase, &out.JobBase
+		*out = new(JobBase)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RegexpChangeMatcher != nil {
+		in, out := &in.RegexpChangeMatcher, &out.RegexpChangeMatcher
+		*out = new(RegexpChangeMatcher)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Brancher != nil {
+		in, out := &in.Brancher, &out.Brancher
+		*out = new(Brancher)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Context != nil {
+		in, out := &in.Context, &out.Context
+		*out = new(string)
+		**out = **in
+	}
+	if in.Report != nil {
+		in, out := &in.Report, &out.Report
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreviewActivityStep.
-func (in *PreviewActivityStep) DeepCopy() *PreviewActivityStep {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Postsubmit.
+func (in *Postsubmit) DeepCopy() *Postsubmit {
 	if in == nil {
 		return nil
 	}
-	out := new(PreviewActivityStep)
+	out := new(Postsubmit)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PreviewGitSpec) DeepCopyInto(out *PreviewGitSpec) {
+func (in *Postsubmits) DeepCopyInto(out *Postsubmits) {
 	*out = *in
-	out.User = in.User
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]*Postsubmit, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Postsubmit)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreviewGitSpec.
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Postsubmits.
+func (in *Postsubmits) DeepCopy() *Postsubmits {
+	if in == nil {
+		return nil
+	}
+	out := new(Postsubmits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Presubmit) DeepCopyInto(out *Presubmit) {
+	*out = *in
+	if in.JobBase != nil {
+		in, out := &in.JobBase, &out.JobBase
+		*out = new(JobBase)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Brancher != nil {
+		in, out := &in.Brancher, &out.Brancher
+		*out = new(Brancher)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RegexpChangeMatcher != nil {
+		in, out := &in.RegexpChangeMatcher, &out.RegexpChangeMatcher
+		*out = new(RegexpChangeMatcher)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AlwaysRun != nil {
+		in, out := &in.AlwaysRun, &out.AlwaysRun
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Context != nil {
+		in, out := &in.Context, &out.Context
+		*out = new(string)
+		**out = **in
+	}
+	if in.Optional != nil {
+		in, out := &in.Optional, &out.Optional
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Report != nil {
+		in, out := &in.Report, &out.Report
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Trigger != nil {
+		in, out := &in.Trigger, &out.Trigger
+		*out = new(string)
+		**out = **in
+	}
+	if in.RerunCommand != nil {
+		in, out := &in.RerunCommand, &out.RerunCommand
+		*out = new(string)
+		**out = **in
+	}
+	if in.MergeType != nil {
+		in, out := &in.MergeType, &out.MergeType
+		*out = new(string)
+		**out = **in
+	}
+	if in.Query != nil {
+		in, out := &in.Query, &out.Query
+		*out = new(Query)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Policy != nil {
+		in, out := &in.Policy, &out.Policy
+		*out = new(ProtectionPolicies)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ContextPolicy != nil {
+		in, out := &in.ContextPolicy, &out.ContextPolicy
+		*out = new(RepoContextPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Presubmit.
+func (in *Presubmit) DeepCopy() *Presubmit {
+	if in == nil {
+		return nil
+	}
+	out := new(Presubmit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Presubmits) DeepCopyInto(out *Presubmits) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]*Presubmit, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Presubmit)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Presubmits.
+func (in *Presubmits) DeepCopy() *Presubmits {
+	if in == nil {
+		return nil
+	}
+	out := new(Presubmits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreviewActivityStep) DeepCopyInto(out *PreviewActivityStep) {
+	*out = *in
+	in.CoreActivityStep.DeepCopyInto(&out.CoreActivityStep)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreviewActivityStep.
+func (in *PreviewActivityStep) DeepCopy() *PreviewActivityStep {
+	if in == nil {
+		return nil
+	}
+	out := new(PreviewActivityStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreviewGitSpec) DeepCopyInto(out *PreviewGitSpec) {
+	*out = *in
+	out.User = in.User
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreviewGitSpec.
 func (in *PreviewGitSpec) DeepCopy() *PreviewGitSpec {
 	if in == nil {
 		return nil
@@ -1804,6 +2362,11 @@ func (in *PromoteActivityStep) DeepCopyInto(out *PromoteActivityStep) {
 		*out = new(PromoteUpdateStep)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Gates != nil {
+		in, out := &in.Gates, &out.Gates
+		*out = new(PromoteGatesStep)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1817,6 +2380,28 @@ func (in *PromoteActivityStep) DeepCopy() *PromoteActivityStep {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PromoteGatesStep) DeepCopyInto(out *PromoteGatesStep) {
+	*out = *in
+	in.CoreActivityStep.DeepCopyInto(&out.CoreActivityStep)
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = make([]GateResult, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PromoteGatesStep.
+func (in *PromoteGatesStep) DeepCopy() *PromoteGatesStep {
+	if in == nil {
+		return nil
+	}
+	out := new(PromoteGatesStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PromotePullRequestStep) DeepCopyInto(out *PromotePullRequestStep) {
 	*out = *in
@@ -1872,6 +2457,165 @@ func (in *PromoteWorkflowStep) DeepCopy() *PromoteWorkflowStep {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProtectionPolicies) DeepCopyInto(out *ProtectionPolicies) {
+	*out = *in
+	if in.ProtectionPolicy != nil {
+		in, out := &in.ProtectionPolicy, &out.ProtectionPolicy
+		*out = new(ProtectionPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make(map[string]*ProtectionPolicy, len(*in))
+		for key, val := range *in {
+			var outVal *ProtectionPolicy
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = new(ProtectionPolicy)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProtectionPolicies.
+func (in *ProtectionPolicies) DeepCopy() *ProtectionPolicies {
+	if in == nil {
+		return nil
+	}
+	out := new(ProtectionPolicies)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProtectionPolicy) DeepCopyInto(out *ProtectionPolicy) {
+	*out = *in
+	if in.Protect != nil {
+		in, out := &in.Protect, &out.Protect
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RequiredStatusChecks != nil {
+		in, out := &in.RequiredStatusChecks, &out.RequiredStatusChecks
+		*out = new(BranchProtectionContextPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Admins != nil {
+		in, out := &in.Admins, &out.Admins
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Restrictions != nil {
+		in, out := &in.Restrictions, &out.Restrictions
+		*out = new(Restrictions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RequiredPullRequestReviews != nil {
+		in, out := &in.RequiredPullRequestReviews, &out.RequiredPullRequestReviews
+		*out = new(PullRequestReviewPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProtectionPolicy.
+func (in *ProtectionPolicy) DeepCopy() *ProtectionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ProtectionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PullRequestReviewPolicy) DeepCopyInto(out *PullRequestReviewPolicy) {
+	*out = *in
+	if in.DismissalRestrictions != nil {
+		in, out := &in.DismissalRestrictions, &out.DismissalRestrictions
+		*out = new(Restrictions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DismissStale != nil {
+		in, out := &in.DismissStale, &out.DismissStale
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RequireOwners != nil {
+		in, out := &in.RequireOwners, &out.RequireOwners
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Approvals != nil {
+		in, out := &in.Approvals, &out.Approvals
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PullRequestReviewPolicy.
+func (in *PullRequestReviewPolicy) DeepCopy() *PullRequestReviewPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PullRequestReviewPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Query) DeepCopyInto(out *Query) {
+	*out = *in
+	if in.ExcludedBranches != nil {
+		in, out := &in.ExcludedBranches, &out.ExcludedBranches
+		*out = new(ReplaceableSliceOfStrings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IncludedBranches != nil {
+		in, out := &in.IncludedBranches, &out.IncludedBranches
+		*out = new(ReplaceableSliceOfStrings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = new(ReplaceableSliceOfStrings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MissingLabels != nil {
+		in, out := &in.MissingLabels, &out.MissingLabels
+		*out = new(ReplaceableSliceOfStrings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Milestone != nil {
+		in, out := &in.Milestone, &out.Milestone
+		*out = new(string)
+		**out = **in
+	}
+	if in.ReviewApprovedRequired != nil {
+		in, out := &in.ReviewApprovedRequired, &out.ReviewApprovedRequired
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Query.
+func (in *Query) DeepCopy() *Query {
+	if in == nil {
+		return nil
+	}
+	out := new(Query)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *QuickStartLocation) DeepCopyInto(out *QuickStartLocation) {
 	*out = *in
@@ -1898,6 +2642,27 @@ func (in *QuickStartLocation) DeepCopy() *QuickStartLocation {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegexpChangeMatcher) DeepCopyInto(out *RegexpChangeMatcher) {
+	*out = *in
+	if in.RunIfChanged != nil {
+		in, out := &in.RunIfChanged, &out.RunIfChanged
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegexpChangeMatcher.
+func (in *RegexpChangeMatcher) DeepCopy() *RegexpChangeMatcher {
+	if in == nil {
+		return nil
+	}
+	out := new(RegexpChangeMatcher)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Release) DeepCopyInto(out *Release) {
 	*out = *in
@@ -2012,6 +2777,134 @@ func (in *ReleaseStatus) DeepCopy() *ReleaseStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplaceableMapOfStringContextPolicy) DeepCopyInto(out *ReplaceableMapOfStringContextPolicy) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make(map[string]*ContextPolicy, len(*in))
+		for key, val := range *in {
+			var outVal *ContextPolicy
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = new(ContextPolicy)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplaceableMapOfStringContextPolicy.
+func (in *ReplaceableMapOfStringContextPolicy) DeepCopy() *ReplaceableMapOfStringContextPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplaceableMapOfStringContextPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplaceableMapOfStringString) DeepCopyInto(out *ReplaceableMapOfStringString) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplaceableMapOfStringString.
+func (in *ReplaceableMapOfStringString) DeepCopy() *ReplaceableMapOfStringString {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplaceableMapOfStringString)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplaceableSliceOfExternalPlugins) DeepCopyInto(out *ReplaceableSliceOfExternalPlugins) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]*ExternalPlugin, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(ExternalPlugin)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplaceableSliceOfExternalPlugins.
+func (in *ReplaceableSliceOfExternalPlugins) DeepCopy() *ReplaceableSliceOfExternalPlugins {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplaceableSliceOfExternalPlugins)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplaceableSliceOfStrings) DeepCopyInto(out *ReplaceableSliceOfStrings) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplaceableSliceOfStrings.
+func (in *ReplaceableSliceOfStrings) DeepCopy() *ReplaceableSliceOfStrings {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplaceableSliceOfStrings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RepoContextPolicy) DeepCopyInto(out *RepoContextPolicy) {
+	*out = *in
+	if in.ContextPolicy != nil {
+		in, out := &in.ContextPolicy, &out.ContextPolicy
+		*out = new(ContextPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Branches != nil {
+		in, out := &in.Branches, &out.Branches
+		*out = new(ReplaceableMapOfStringContextPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepoContextPolicy.
+func (in *RepoContextPolicy) DeepCopy() *RepoContextPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RepoContextPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceReference) DeepCopyInto(out *ResourceReference) {
 	*out = *in
@@ -2028,6 +2921,205 @@ func (in *ResourceReference) DeepCopy() *ResourceReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Restrictions) DeepCopyInto(out *Restrictions) {
+	*out = *in
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = new(ReplaceableSliceOfStrings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Teams != nil {
+		in, out := &in.Teams, &out.Teams
+		*out = new(ReplaceableSliceOfStrings)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Restrictions.
+func (in *Restrictions) DeepCopy() *Restrictions {
+	if in == nil {
+		return nil
+	}
+	out := new(Restrictions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReviewPolicy) DeepCopyInto(out *ReviewPolicy) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Reviewers != nil {
+		in, out := &in.Reviewers, &out.Reviewers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReviewPolicy.
+func (in *ReviewPolicy) DeepCopy() *ReviewPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ReviewPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Scheduler) DeepCopyInto(out *Scheduler) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Scheduler.
+func (in *Scheduler) DeepCopy() *Scheduler {
+	if in == nil {
+		return nil
+	}
+	out := new(Scheduler)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Scheduler) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchedulerAgent) DeepCopyInto(out *SchedulerAgent) {
+	*out = *in
+	if in.Agent != nil {
+		in, out := &in.Agent, &out.Agent
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulerAgent.
+func (in *SchedulerAgent) DeepCopy() *SchedulerAgent {
+	if in == nil {
+		return nil
+	}
+	out := new(SchedulerAgent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchedulerList) DeepCopyInto(out *SchedulerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Scheduler, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulerList.
+func (in *SchedulerList) DeepCopy() *SchedulerList {
+	if in == nil {
+		return nil
+	}
+	out := new(SchedulerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SchedulerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchedulerSpec) DeepCopyInto(out *SchedulerSpec) {
+	*out = *in
+	if in.ScehdulerAgent != nil {
+		in, out := &in.ScehdulerAgent, &out.ScehdulerAgent
+		*out = new(SchedulerAgent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Policy != nil {
+		in, out := &in.Policy, &out.Policy
+		*out = new(GlobalProtectionPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Presubmits != nil {
+		in, out := &in.Presubmits, &out.Presubmits
+		*out = new(Presubmits)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Postsubmits != nil {
+		in, out := &in.Postsubmits, &out.Postsubmits
+		*out = new(Postsubmits)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Trigger != nil {
+		in, out := &in.Trigger, &out.Trigger
+		*out = new(Trigger)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Approve != nil {
+		in, out := &in.Approve, &out.Approve
+		*out = new(Approve)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LGTM != nil {
+		in, out := &in.LGTM, &out.LGTM
+		*out = new(Lgtm)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExternalPlugins != nil {
+		in, out := &in.ExternalPlugins, &out.ExternalPlugins
+		*out = new(ReplaceableSliceOfExternalPlugins)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Merger != nil {
+		in, out := &in.Merger, &out.Merger
+		*out = new(Merger)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Plugins != nil {
+		in, out := &in.Plugins, &out.Plugins
+		*out = new(ReplaceableSliceOfStrings)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulerSpec.
+func (in *SchedulerSpec) DeepCopy() *SchedulerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SchedulerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SourceRepository) DeepCopyInto(out *SourceRepository) {
 	*out = *in
@@ -2248,6 +3340,17 @@ func (in *TeamSettings) DeepCopyInto(out *TeamSettings) {
 		*out = make([]StorageLocation, len(*in))
 		copy(*out, *in)
 	}
+	if in.AppsPrefixes != nil {
+		in, out := &in.AppsPrefixes, &out.AppsPrefixes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BranchProtection != nil {
+		in, out := &in.BranchProtection, &out.BranchProtection
+		*out = new(BranchProtection)
+		(*in).DeepCopyInto(*out)
+	}
+	out.DefaultScheduler = in.DefaultScheduler
 	return
 }
 
@@ -2298,6 +3401,42 @@ func (in *TeamStatus) DeepCopy() *TeamStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Trigger) DeepCopyInto(out *Trigger) {
+	*out = *in
+	if in.TrustedOrg != nil {
+		in, out := &in.TrustedOrg, &out.TrustedOrg
+		*out = new(string)
+		**out = **in
+	}
+	if in.JoinOrgURL != nil {
+		in, out := &in.JoinOrgURL, &out.JoinOrgURL
+		*out = new(string)
+		**out = **in
+	}
+	if in.OnlyOrgMembers != nil {
+		in, out := &in.OnlyOrgMembers, &out.OnlyOrgMembers
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IgnoreOkToTest != nil {
+		in, out := &in.IgnoreOkToTest, &out.IgnoreOkToTest
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Trigger.
+func (in *Trigger) DeepCopy() *Trigger {
+	if in == nil {
+		return nil
+	}
+	out := new(Trigger)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *User) DeepCopyInto(out *User) {
 	*out = *in
@@ -2469,6 +3608,11 @@ func (in *WorkflowPreconditions) DeepCopyInto(out *WorkflowPreconditions) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Gates != nil {
+		in, out := &in.Gates, &out.Gates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
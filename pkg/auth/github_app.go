@@ -0,0 +1,265 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// GitHubAppUsername the username used with an installation token of a GitHub App for git operations over HTTPS
+	GitHubAppUsername = "x-access-token"
+
+	// gitHubAppJWTLifetime how long a JWT of a GitHub App is valid for. GitHub accepts at most 10 minutes
+	gitHubAppJWTLifetime = 9 * time.Minute
+
+	// gitHubAppTokenRefreshMargin how long before its expiry a cached installation token is refreshed
+	gitHubAppTokenRefreshMargin = 5 * time.Minute
+
+	gitHubAppMediaType = "application/vnd.github.machine-man-preview+json"
+)
+
+var (
+	gitHubAppTokensLock  sync.Mutex
+	gitHubAppTokensCache = map[string]*GitHubAppTokens{}
+)
+
+// GitHubAppTokens mints JWTs for a GitHub App and exchanges them for installation tokens of the organisations or users
+// which installed the App. The installation tokens are cached and refreshed before they expire
+type GitHubAppTokens struct {
+	APIURL     string
+	AppID      int64
+	HTTPClient *http.Client
+
+	privateKey *rsa.PrivateKey
+	now        func() time.Time
+	lock       sync.Mutex
+	tokens     map[string]*gitHubAppInstallationToken
+}
+
+type gitHubAppInstallationToken struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type gitHubAppInstallation struct {
+	ID int64 `json:"id"`
+}
+
+// NewGitHubAppTokens creates the tokens of the GitHub App with the given ID and PEM encoded private key which uses the
+// GitHub API at apiURL
+func NewGitHubAppTokens(apiURL string, appID int64, privateKey string) (*GitHubAppTokens, error) {
+	key, err := parseGitHubAppPrivateKey(privateKey)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing the private key of GitHub App %d", appID)
+	}
+	return &GitHubAppTokens{
+		APIURL:     strings.TrimSuffix(apiURL, "/"),
+		AppID:      appID,
+		HTTPClient: http.DefaultClient,
+		privateKey: key,
+		now:        time.Now,
+		tokens:     map[string]*gitHubAppInstallationToken{},
+	}, nil
+}
+
+// GitHubAppTokensFor returns the tokens of the GitHub App of the user auth. The tokens are shared by all the callers
+// in the process so that installation tokens are only minted when they are about to expire
+func GitHubAppTokensFor(apiURL string, user *UserAuth) (*GitHubAppTokens, error) {
+	if !user.IsGitHubApp() {
+		return nil, fmt.Errorf("user %s is not a GitHub App", user.Username)
+	}
+	key := fmt.Sprintf("%s#%d", strings.TrimSuffix(apiURL, "/"), user.GitHubAppID)
+	gitHubAppTokensLock.Lock()
+	defer gitHubAppTokensLock.Unlock()
+	tokens := gitHubAppTokensCache[key]
+	if tokens != nil {
+		return tokens, nil
+	}
+	tokens, err := NewGitHubAppTokens(apiURL, user.GitHubAppID, user.GitHubAppPrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	gitHubAppTokensCache[key] = tokens
+	return tokens, nil
+}
+
+// JWT returns a JSON Web Token which authenticates as the GitHub App
+func (t *GitHubAppTokens) JWT() (string, error) {
+	now := t.now()
+	header, err := json.Marshal(map[string]string{
+		"alg": "RS256",
+		"typ": "JWT",
+	})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]interface{}{
+		// allow for clock drift between us and GitHub
+		"iat": now.Add(-time.Minute).Unix(),
+		"exp": now.Add(gitHubAppJWTLifetime).Unix(),
+		"iss": t.AppID,
+	})
+	if err != nil {
+		return "", err
+	}
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	hash := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, t.privateKey, crypto.SHA256, hash[:])
+	if err != nil {
+		return "", errors.Wrapf(err, "signing the JWT of GitHub App %d", t.AppID)
+	}
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// InstallationToken returns the installation token of the GitHub App for the organisation or user owner, minting a
+// new one if there is no cached token or it is about to expire
+func (t *GitHubAppTokens) InstallationToken(owner string) (string, error) {
+	if owner == "" {
+		return "", fmt.Errorf("no owner specified for the installation token of GitHub App %d", t.AppID)
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	token := t.tokens[owner]
+	if token != nil && t.now().Add(gitHubAppTokenRefreshMargin).Before(token.ExpiresAt) {
+		return token.Token, nil
+	}
+	installation, err := t.findInstallation(owner)
+	if err != nil {
+		return "", err
+	}
+	token = &gitHubAppInstallationToken{}
+	u := fmt.Sprintf("%s/app/installations/%d/access_tokens", t.APIURL, installation.ID)
+	err = t.appRequest(http.MethodPost, u, token)
+	if err != nil {
+		return "", errors.Wrapf(err, "creating an installation token of GitHub App %d for %s", t.AppID, owner)
+	}
+	t.tokens[owner] = token
+	return token.Token, nil
+}
+
+// findInstallation finds the installation of the GitHub App for the organisation or user owner
+func (t *GitHubAppTokens) findInstallation(owner string) (*gitHubAppInstallation, error) {
+	installation := &gitHubAppInstallation{}
+	err := t.appRequest(http.MethodGet, fmt.Sprintf("%s/orgs/%s/installation", t.APIURL, owner), installation)
+	if err == nil {
+		return installation, nil
+	}
+	err2 := t.appRequest(http.MethodGet, fmt.Sprintf("%s/users/%s/installation", t.APIURL, owner), installation)
+	if err2 != nil {
+		return nil, errors.Wrapf(err, "GitHub App %d is not installed for %s", t.AppID, owner)
+	}
+	return installation, nil
+}
+
+// appRequest performs a request authenticated as the GitHub App decoding the response into result
+func (t *GitHubAppTokens) appRequest(method string, u string, result interface{}) error {
+	jwt, err := t.JWT()
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(method, u, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+jwt)
+	req.Header.Set("Accept", gitHubAppMediaType)
+	resp, err := t.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s %s returned %s: %s", method, u, resp.Status, string(data))
+	}
+	return json.Unmarshal(data, result)
+}
+
+// Transport returns a RoundTripper which authenticates each request to the GitHub API with the installation token of
+// the owner in the request path, or of defaultOwner for requests which are not for an organisation, user or repository
+func (t *GitHubAppTokens) Transport(base http.RoundTripper, defaultOwner string) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &gitHubAppTransport{
+		tokens:       t,
+		base:         base,
+		defaultOwner: defaultOwner,
+	}
+}
+
+type gitHubAppTransport struct {
+	tokens       *GitHubAppTokens
+	base         http.RoundTripper
+	defaultOwner string
+}
+
+func (t *gitHubAppTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	owner := GitHubAppRequestOwner(req.URL.Path)
+	if owner == "" {
+		owner = t.defaultOwner
+	}
+	token, err := t.tokens.InstallationToken(owner)
+	if err != nil {
+		return nil, err
+	}
+	// the request must not be modified by a RoundTripper so lets work on a copy
+	r := new(http.Request)
+	*r = *req
+	r.Header = make(http.Header, len(req.Header))
+	for k, v := range req.Header {
+		r.Header[k] = append([]string(nil), v...)
+	}
+	r.Header.Set("Authorization", "token "+token)
+	return t.base.RoundTrip(r)
+}
+
+// GitHubAppRequestOwner returns the organisation or user a GitHub API request path is for or blank if the path is not
+// for an organisation, user or repository
+func GitHubAppRequestOwner(path string) string {
+	paths := strings.Split(strings.Trim(path, "/"), "/")
+	for i := 0; i < len(paths)-1; i++ {
+		switch paths[i] {
+		case "repos", "orgs", "users":
+			return paths[i+1]
+		}
+	}
+	return ""
+}
+
+func parseGitHubAppPrivateKey(privateKey string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(privateKey))
+	if block == nil {
+		return nil, fmt.Errorf("no PEM encoded private key found")
+	}
+	key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err == nil {
+		return key, nil
+	}
+	parsed, err2 := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err2 != nil {
+		return nil, err
+	}
+	answer, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("the private key is not an RSA key")
+	}
+	return answer, nil
+}
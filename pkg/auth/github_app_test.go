@@ -0,0 +1,187 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testGitHubAppID = 1234
+
+type fakeGitHubApp struct {
+	t          *testing.T
+	publicKey  *rsa.PublicKey
+	now        time.Time
+	tokenCount int
+}
+
+func (f *fakeGitHubApp) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.verifyJWT(r.Header.Get("Authorization"))
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/orgs/jenkins-x/installation":
+		fmt.Fprint(w, `{"id": 42}`)
+	case r.Method == http.MethodGet && r.URL.Path == "/users/jstrachan/installation":
+		fmt.Fprint(w, `{"id": 43}`)
+	case r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, "/app/installations/"):
+		f.tokenCount++
+		id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/app/installations/"), "/access_tokens")
+		expires := f.now.Add(time.Hour).UTC().Format(time.RFC3339)
+		fmt.Fprintf(w, `{"token": "token-%s-%d", "expires_at": "%s"}`, id, f.tokenCount, expires)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (f *fakeGitHubApp) verifyJWT(header string) {
+	parts := strings.Split(strings.TrimPrefix(header, "Bearer "), ".")
+	if !assert.True(f.t, strings.HasPrefix(header, "Bearer "), "the request should be authenticated with a JWT") ||
+		!assert.Len(f.t, parts, 3) {
+		return
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	assert.NoError(f.t, err)
+	hash := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	err = rsa.VerifyPKCS1v15(f.publicKey, crypto.SHA256, hash[:], signature)
+	assert.NoError(f.t, err, "the JWT should be signed by the private key of the App")
+
+	data, err := base64.RawURLEncoding.DecodeString(parts[1])
+	assert.NoError(f.t, err)
+	claims := map[string]int64{}
+	err = json.Unmarshal(data, &claims)
+	assert.NoError(f.t, err)
+	assert.Equal(f.t, int64(testGitHubAppID), claims["iss"])
+	assert.True(f.t, claims["iat"] <= f.now.Unix())
+	assert.True(f.t, claims["exp"] > f.now.Unix())
+	assert.True(f.t, claims["exp"]-claims["iat"] <= int64((10*time.Minute).Seconds()))
+}
+
+func createTestGitHubApp(t *testing.T) (*fakeGitHubApp, *httptest.Server, *GitHubAppTokens) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	privateKey := string(pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	}))
+
+	app := &fakeGitHubApp{
+		t:         t,
+		publicKey: &key.PublicKey,
+		now:       time.Now(),
+	}
+	server := httptest.NewServer(app)
+	tokens, err := NewGitHubAppTokens(server.URL+"/", testGitHubAppID, privateKey)
+	require.NoError(t, err)
+	tokens.now = func() time.Time {
+		return app.now
+	}
+	return app, server, tokens
+}
+
+func TestGitHubAppInstallationToken(t *testing.T) {
+	t.Parallel()
+	app, server, tokens := createTestGitHubApp(t)
+	defer server.Close()
+
+	token, err := tokens.InstallationToken("jenkins-x")
+	require.NoError(t, err)
+	assert.Equal(t, "token-42-1", token)
+
+	token, err = tokens.InstallationToken("jstrachan")
+	require.NoError(t, err)
+	assert.Equal(t, "token-43-2", token, "should fall back to the installation of a user")
+
+	token, err = tokens.InstallationToken("jenkins-x")
+	require.NoError(t, err)
+	assert.Equal(t, "token-42-1", token, "should use the cached token")
+
+	app.now = app.now.Add(time.Hour - gitHubAppTokenRefreshMargin + time.Second)
+	token, err = tokens.InstallationToken("jenkins-x")
+	require.NoError(t, err)
+	assert.Equal(t, "token-42-3", token, "should refresh the token before it expires")
+
+	_, err = tokens.InstallationToken("cheese")
+	assert.Error(t, err, "should fail when the App is not installed")
+}
+
+func TestUserAuthGitToken(t *testing.T) {
+	t.Parallel()
+	app, server, tokens := createTestGitHubApp(t)
+	defer server.Close()
+	gitHubAppTokensLock.Lock()
+	gitHubAppTokensCache[fmt.Sprintf("%s#%d", server.URL, testGitHubAppID)] = tokens
+	gitHubAppTokensLock.Unlock()
+
+	user := &UserAuth{
+		GitHubAppID:         testGitHubAppID,
+		GitHubAppPrivateKey: "cached",
+		GitHubAppOwner:      "jenkins-x",
+	}
+	err := user.ResolveGitHubAppToken(server.URL, "")
+	require.NoError(t, err)
+	assert.Equal(t, GitHubAppUsername, user.Username)
+	assert.Equal(t, "token-42-1", user.ApiToken)
+
+	token, err := user.GitToken()
+	require.NoError(t, err)
+	assert.Equal(t, "token-42-1", token)
+
+	app.now = app.now.Add(time.Hour)
+	token, err = user.GitToken()
+	require.NoError(t, err)
+	assert.Equal(t, "token-42-2", token, "git should use a new token once the resolved one expires")
+
+	token, err = (&UserAuth{Username: "jstrachan", ApiToken: "mytoken"}).GitToken()
+	require.NoError(t, err)
+	assert.Equal(t, "mytoken", token)
+}
+
+func TestGitHubAppTransport(t *testing.T) {
+	t.Parallel()
+	_, server, tokens := createTestGitHubApp(t)
+	defer server.Close()
+
+	authorizations := map[string]string{}
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorizations[r.URL.Path] = r.Header.Get("Authorization")
+	}))
+	defer api.Close()
+
+	client := &http.Client{Transport: tokens.Transport(nil, "jstrachan")}
+	for _, path := range []string{"/repos/jenkins-x/jx/pulls", "/user"} {
+		resp, err := client.Get(api.URL + path)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	assert.Equal(t, "token token-42-1", authorizations["/repos/jenkins-x/jx/pulls"])
+	assert.Equal(t, "token token-43-2", authorizations["/user"], "should use the installation of the default owner")
+}
+
+func TestGitHubAppRequestOwner(t *testing.T) {
+	t.Parallel()
+	tests := map[string]string{
+		"/repos/jenkins-x/jx/pulls/1":  "jenkins-x",
+		"/api/v3/orgs/jenkins-x/repos": "jenkins-x",
+		"/users/jstrachan/repos":       "jstrachan",
+		"/user":                        "",
+		"/repos":                       "",
+		"/api/v3/search/repositories":  "",
+	}
+	for path, expected := range tests {
+		assert.Equal(t, expected, GitHubAppRequestOwner(path), "owner of %s", path)
+	}
+}
@@ -18,6 +18,19 @@ type UserAuth struct {
 	ApiToken    string `json:"apitoken"`
 	BearerToken string `json:"bearertoken"`
 	Password    string `json:"password,omitempty"`
+
+	// GitHubAppID the ID of the GitHub App used to mint installation tokens instead of using a personal ApiToken
+	GitHubAppID int64 `json:"githubappid,omitempty"`
+	// GitHubAppPrivateKey the PEM encoded private key of the GitHub App
+	GitHubAppPrivateKey string `json:"githubappprivatekey,omitempty"`
+	// GitHubAppOwner the organisation or user whose installation of the GitHub App is used for requests and git
+	// operations which are not for a specific organisation
+	GitHubAppOwner string `json:"githubappowner,omitempty"`
+
+	// gitHubAppAPIURL and gitHubAppInstallationOwner are set by ResolveGitHubAppToken so that GitToken can use a
+	// current installation token of the GitHub App each time git needs one
+	gitHubAppAPIURL            string
+	gitHubAppInstallationOwner string
 }
 
 type AuthConfig struct {
@@ -57,5 +57,48 @@ func CreateAuthUserFromEnvironment(prefix string) UserAuth {
 
 // IsInvalid returns true if the user auth has a valid token
 func (a *UserAuth) IsInvalid() bool {
-	return a.BearerToken == "" && (a.ApiToken == "" || a.Username == "")
+	return a.BearerToken == "" && (a.ApiToken == "" || a.Username == "") && !a.IsGitHubApp()
+}
+
+// IsGitHubApp returns true if the user auth authenticates as a GitHub App rather than with a personal token
+func (a *UserAuth) IsGitHubApp() bool {
+	return a.GitHubAppID != 0 && a.GitHubAppPrivateKey != ""
+}
+
+// ResolveGitHubAppToken sets the ApiToken of a GitHub App user auth to the installation token of the App for the
+// organisation or user owner, or the GitHubAppOwner if owner is blank, so that it can be used like a personal token.
+// As installation tokens expire after an hour git operations should use GitToken which takes a current one.
+// The apiURL is the URL of the GitHub API such as https://api.github.com
+func (a *UserAuth) ResolveGitHubAppToken(apiURL string, owner string) error {
+	if !a.IsGitHubApp() {
+		return nil
+	}
+	if owner == "" {
+		owner = a.GitHubAppOwner
+	}
+	a.gitHubAppAPIURL = apiURL
+	a.gitHubAppInstallationOwner = owner
+	token, err := a.GitToken()
+	if err != nil {
+		return err
+	}
+	a.ApiToken = token
+	if a.Username == "" {
+		a.Username = GitHubAppUsername
+	}
+	return nil
+}
+
+// GitToken returns the token to use as the password of git operations over HTTPS. For a GitHub App resolved by
+// ResolveGitHubAppToken it is the installation token from the tokens cache of the App, which mints a new token
+// before the previous one expires, otherwise it is the ApiToken
+func (a *UserAuth) GitToken() (string, error) {
+	if !a.IsGitHubApp() || a.gitHubAppAPIURL == "" {
+		return a.ApiToken, nil
+	}
+	tokens, err := GitHubAppTokensFor(a.gitHubAppAPIURL, a)
+	if err != nil {
+		return "", err
+	}
+	return tokens.InstallationToken(a.gitHubAppInstallationOwner)
 }
@@ -0,0 +1,140 @@
+package chats_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/auth"
+	"github.com/jenkins-x/jx/pkg/chats"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMattermostChatProvider(t *testing.T) {
+	t.Parallel()
+	posts := []map[string]interface{}{}
+	patches := []map[string]interface{}{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer mytoken" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		body := map[string]interface{}{}
+		data, _ := ioutil.ReadAll(r.Body)
+		if len(data) > 0 {
+			assert.NoError(t, json.Unmarshal(data, &body))
+		}
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v4/teams/name/myteam/channels/name/dev":
+			w.Write([]byte(`{"id": "ch1", "name": "dev", "team_id": "team1"}`))
+		case "GET /api/v4/channels/ch1/stats":
+			w.Write([]byte(`{"channel_id": "ch1", "member_count": 2}`))
+		case "GET /api/v4/channels/ch1/members":
+			w.Write([]byte(`[{"user_id": "u1"}, {"user_id": "u2"}]`))
+		case "GET /api/v4/users/u1":
+			w.Write([]byte(`{"id": "u1", "username": "jstrachan"}`))
+		case "POST /api/v4/posts":
+			posts = append(posts, body)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id": "post1", "channel_id": "ch1"}`))
+		case "PUT /api/v4/posts/post1/patch":
+			patches = append(patches, body)
+			w.Write([]byte(`{"id": "post1", "channel_id": "ch1"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	provider, err := chats.CreateChatProvider(chats.Mattermost, &auth.AuthServer{URL: server.URL + "/myteam", Kind: chats.Mattermost}, &auth.UserAuth{Username: "jx-bot", ApiToken: "mytoken"}, true)
+	require.NoError(t, err)
+
+	metrics, err := provider.GetChannelMetrics("#dev")
+	require.NoError(t, err)
+	assert.Equal(t, "ch1", metrics.ID)
+	assert.Equal(t, 2, metrics.MemberCount)
+	assert.Equal(t, []string{"u1", "u2"}, metrics.Members)
+	assert.Equal(t, server.URL+"/myteam/channels/dev", metrics.URL)
+
+	ref, err := provider.SendMessage("#dev", &chats.Message{
+		Text:     ":x: Pipeline **myorg/myapp/master #1** failed",
+		Title:    "myorg/myapp/master #1",
+		Color:    chats.MessageColorDanger,
+		Fields:   []chats.MessageField{{Title: "Version", Value: "0.0.1", Short: true}},
+		Mentions: []chats.Mention{{ID: "u1", Name: "@james"}, {ID: "u3", Name: "@rawlingsj"}, {Name: "@pmuir"}},
+		ThreadID: "root1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "post1", ref.ID)
+	require.Len(t, posts, 1)
+	assert.Equal(t, "ch1", posts[0]["channel_id"])
+	assert.Equal(t, "root1", posts[0]["root_id"])
+	assert.Equal(t, ":x: Pipeline **myorg/myapp/master #1** failed @jstrachan @rawlingsj @pmuir", posts[0]["message"],
+		"users should be mentioned by their Mattermost username or by name if their user cannot be found")
+	props := posts[0]["props"].(map[string]interface{})
+	attachments := props["attachments"].([]interface{})
+	require.Len(t, attachments, 1)
+	assert.Equal(t, "#d00000", attachments[0].(map[string]interface{})["color"])
+
+	_, err = provider.UpdateMessage(ref, &chats.Message{Text: "fixed"})
+	require.NoError(t, err)
+	require.Len(t, patches, 1)
+	assert.Equal(t, "fixed", patches[0]["message"])
+}
+
+func TestMSTeamsChatProvider(t *testing.T) {
+	t.Parallel()
+	cards := []chats.MSTeamsCard{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		card := chats.MSTeamsCard{}
+		err := json.NewDecoder(r.Body).Decode(&card)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		cards = append(cards, card)
+		w.Write([]byte("1"))
+	}))
+	defer server.Close()
+
+	assert.False(t, chats.RequiresUserAuth(chats.MSTeams))
+	provider, err := chats.CreateChatProvider(chats.MSTeams, &auth.AuthServer{URL: server.URL + "/webhook/abc", Kind: chats.MSTeams}, nil, true)
+	require.NoError(t, err)
+
+	ref, err := provider.SendMessage("#dev", &chats.Message{
+		Text:     ":tada: **myapp** version **0.0.1** was promoted to **staging**",
+		Title:    "https://github.com/myorg/env-staging/pull/1",
+		TitleURL: "https://github.com/myorg/env-staging/pull/1",
+		Color:    chats.MessageColorGood,
+		Fields:   []chats.MessageField{{Title: "Version", Value: "0.0.1"}},
+		Mentions: []chats.Mention{{ID: "29:abc", Name: "@jstrachan"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "#dev", ref.Channel)
+
+	_, err = provider.UpdateMessage(ref, &chats.Message{Text: "merged"})
+	require.NoError(t, err)
+
+	require.Len(t, cards, 2)
+	card := cards[0]
+	assert.Equal(t, "MessageCard", card.Type)
+	assert.Equal(t, "36a64f", card.ThemeColor)
+	assert.Equal(t, ":tada: **myapp** version **0.0.1** was promoted to **staging** @jstrachan", card.Text)
+	require.Len(t, card.Sections, 1)
+	assert.Equal(t, []chats.MSTeamsFact{{Name: "Version", Value: "0.0.1"}}, card.Sections[0].Facts)
+	require.Len(t, card.PotentialAction, 1)
+	assert.Equal(t, "https://github.com/myorg/env-staging/pull/1", card.PotentialAction[0].Targets[0].URI)
+	assert.Equal(t, "merged", cards[1].Text)
+
+	_, err = provider.GetChannelMetrics("#dev")
+	assert.Error(t, err)
+}
+
+func TestSlackMarkdown(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "*myapp* commit <https://github.com/myorg/myapp/commit/abc|abc>",
+		chats.SlackMarkdown("**myapp** commit [abc](https://github.com/myorg/myapp/commit/abc)"))
+}
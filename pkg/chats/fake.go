@@ -0,0 +1,50 @@
+package chats
+
+import (
+	"fmt"
+	"sync"
+)
+
+// FakeChatProvider is a ChatProvider which records the messages sent for use in tests
+type FakeChatProvider struct {
+	Messages map[string][]*Message
+
+	lock    sync.Mutex
+	counter int
+}
+
+// NewFakeChatProvider creates a new fake chat provider
+func NewFakeChatProvider() *FakeChatProvider {
+	return &FakeChatProvider{
+		Messages: map[string][]*Message{},
+	}
+}
+
+// GetChannelMetrics returns the metrics of the channel
+func (c *FakeChatProvider) GetChannelMetrics(name string) (*ChannelMetrics, error) {
+	return &ChannelMetrics{
+		ID:   name,
+		Name: name,
+	}, nil
+}
+
+// SendMessage records the message against the channel
+func (c *FakeChatProvider) SendMessage(channel string, message *Message) (*MessageReference, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.counter++
+	c.Messages[channel] = append(c.Messages[channel], message)
+	return &MessageReference{
+		Channel:  channel,
+		ID:       fmt.Sprintf("%d", c.counter),
+		ThreadID: message.ThreadID,
+	}, nil
+}
+
+// UpdateMessage records the update as a message against the channel
+func (c *FakeChatProvider) UpdateMessage(ref *MessageReference, message *Message) (*MessageReference, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.Messages[ref.Channel] = append(c.Messages[ref.Channel], message)
+	return ref, nil
+}
@@ -0,0 +1,295 @@
+package chats
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/auth"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+)
+
+var mattermostColors = map[MessageColor]string{
+	MessageColorGood:    "#36a64f",
+	MessageColorWarning: "#daa038",
+	MessageColorDanger:  "#d00000",
+}
+
+// MattermostChatProvider implements ChatProvider using the Mattermost REST API v4.
+//
+// The URL of the server includes the name of the team such as https://mattermost.acme.com/myteam
+type MattermostChatProvider struct {
+	Server   *auth.AuthServer
+	UserAuth *auth.UserAuth
+	BaseURL  string
+	Team     string
+	Client   *http.Client
+}
+
+type mattermostChannel struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+	TeamID      string `json:"team_id"`
+}
+
+type mattermostChannelStats struct {
+	ChannelID   string `json:"channel_id"`
+	MemberCount int    `json:"member_count"`
+}
+
+type mattermostChannelMember struct {
+	UserID string `json:"user_id"`
+}
+
+type mattermostUser struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+}
+
+type mattermostPost struct {
+	ID        string                 `json:"id,omitempty"`
+	ChannelID string                 `json:"channel_id,omitempty"`
+	RootID    string                 `json:"root_id,omitempty"`
+	Message   string                 `json:"message"`
+	Props     map[string]interface{} `json:"props,omitempty"`
+}
+
+type mattermostAttachment struct {
+	Fallback  string                      `json:"fallback,omitempty"`
+	Color     string                      `json:"color,omitempty"`
+	Title     string                      `json:"title,omitempty"`
+	TitleLink string                      `json:"title_link,omitempty"`
+	Text      string                      `json:"text,omitempty"`
+	Fields    []mattermostAttachmentField `json:"fields,omitempty"`
+}
+
+type mattermostAttachmentField struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+	Short bool   `json:"short"`
+}
+
+// CreateMattermostChatProvider creates a new Mattermost chat provider
+func CreateMattermostChatProvider(server *auth.AuthServer, userAuth *auth.UserAuth, batchMode bool) (ChatProvider, error) {
+	u := server.URL
+	if u == "" {
+		return nil, fmt.Errorf("No base URL for server!")
+	}
+	if userAuth == nil || userAuth.IsInvalid() || userAuth.ApiToken == "" {
+		return nil, fmt.Errorf("No authentication found for Mattermost server %s", u)
+	}
+	baseURL, team, err := parseMattermostURL(u)
+	if err != nil {
+		return nil, err
+	}
+	return &MattermostChatProvider{
+		Server:   server,
+		UserAuth: userAuth,
+		BaseURL:  baseURL,
+		Team:     team,
+		Client:   http.DefaultClient,
+	}, nil
+}
+
+// GetChannelMetrics returns the metrics of the channel
+func (c *MattermostChatProvider) GetChannelMetrics(name string) (*ChannelMetrics, error) {
+	metrics := &ChannelMetrics{
+		Name: name,
+	}
+	channel, err := c.findChannel(name)
+	if err != nil {
+		return metrics, err
+	}
+	stats := mattermostChannelStats{}
+	err = c.do(http.MethodGet, "channels/"+channel.ID+"/stats", nil, &stats)
+	if err != nil {
+		return metrics, err
+	}
+	members := []mattermostChannelMember{}
+	err = c.do(http.MethodGet, "channels/"+channel.ID+"/members?per_page=200", nil, &members)
+	if err != nil {
+		return metrics, err
+	}
+	metrics.ID = channel.ID
+	metrics.Name = channel.Name
+	metrics.MemberCount = stats.MemberCount
+	for _, m := range members {
+		metrics.Members = append(metrics.Members, m.UserID)
+	}
+	metrics.URL = util.UrlJoin(c.BaseURL, c.teamOf(name), "channels", channel.Name)
+	return metrics, nil
+}
+
+// SendMessage creates a post in the channel. If the message has a thread ID the post is a reply to that post
+func (c *MattermostChatProvider) SendMessage(channel string, message *Message) (*MessageReference, error) {
+	ch, err := c.findChannel(channel)
+	if err != nil {
+		return nil, err
+	}
+	post := &mattermostPost{
+		ChannelID: ch.ID,
+		RootID:    message.ThreadID,
+		Message:   c.mattermostText(message),
+	}
+	if message.Title != "" || message.Details != "" || len(message.Fields) > 0 {
+		attachment := mattermostAttachment{
+			Fallback:  message.Title,
+			Color:     mattermostColors[message.Color],
+			Title:     message.Title,
+			TitleLink: message.TitleURL,
+			Text:      message.Details,
+		}
+		for _, f := range message.Fields {
+			attachment.Fields = append(attachment.Fields, mattermostAttachmentField{
+				Title: f.Title,
+				Value: f.Value,
+				Short: f.Short,
+			})
+		}
+		post.Props = map[string]interface{}{
+			"attachments": []mattermostAttachment{attachment},
+		}
+	}
+	result := mattermostPost{}
+	err = c.do(http.MethodPost, "posts", post, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &MessageReference{
+		Channel:  channel,
+		ID:       result.ID,
+		ThreadID: result.RootID,
+	}, nil
+}
+
+// UpdateMessage patches the message text of the post
+func (c *MattermostChatProvider) UpdateMessage(ref *MessageReference, message *Message) (*MessageReference, error) {
+	patch := map[string]string{
+		"message": c.mattermostText(message),
+	}
+	result := mattermostPost{}
+	err := c.do(http.MethodPut, "posts/"+ref.ID+"/patch", patch, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &MessageReference{
+		Channel:  ref.Channel,
+		ID:       result.ID,
+		ThreadID: ref.ThreadID,
+	}, nil
+}
+
+// findChannel finds the channel by name. The name can be prefixed with the team name as 'team/channel'
+func (c *MattermostChatProvider) findChannel(name string) (*mattermostChannel, error) {
+	team := c.teamOf(name)
+	if team == "" {
+		return nil, fmt.Errorf("no Mattermost team in the server URL %s or the channel name %s", c.Server.URL, name)
+	}
+	channelName := name
+	if i := strings.LastIndex(channelName, "/"); i >= 0 {
+		channelName = channelName[i+1:]
+	}
+	channelName = strings.TrimPrefix(channelName, "#")
+	channel := &mattermostChannel{}
+	err := c.do(http.MethodGet, "teams/name/"+url.PathEscape(team)+"/channels/name/"+url.PathEscape(channelName), nil, channel)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find Mattermost channel %s in team %s", channelName, team)
+	}
+	return channel, nil
+}
+
+func (c *MattermostChatProvider) teamOf(channel string) string {
+	if i := strings.LastIndex(channel, "/"); i > 0 {
+		return channel[0:i]
+	}
+	return c.Team
+}
+
+func (c *MattermostChatProvider) do(method string, path string, body interface{}, result interface{}) error {
+	var reader *bytes.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+	u := util.UrlJoin(c.BaseURL, "api/v4", path)
+	req, err := http.NewRequest(method, u, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.UserAuth.ApiToken)
+	req.Header.Set("Content-Type", "application/json")
+	client := c.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("error response from %s %s: %s %s", method, u, resp.Status, string(data))
+	}
+	if result != nil && len(data) > 0 {
+		return json.Unmarshal(data, result)
+	}
+	return nil
+}
+
+// mattermostText appends the mentions to the text of the message. Mattermost mentions users by their username so the
+// username of each user ID is looked up, falling back to the name of the mention if the user cannot be found
+func (c *MattermostChatProvider) mattermostText(message *Message) string {
+	mentions := []string{}
+	for _, m := range message.Mentions {
+		if m.ID != "" {
+			user := mattermostUser{}
+			err := c.do(http.MethodGet, "users/"+url.PathEscape(m.ID), nil, &user)
+			if err == nil && user.Username != "" {
+				mentions = append(mentions, "@"+user.Username)
+				continue
+			}
+			if err != nil {
+				log.Warnf("failed to find the Mattermost user %s: %s\n", m.ID, err)
+			}
+		}
+		if m.Name != "" {
+			mentions = append(mentions, m.Name)
+		}
+	}
+	if len(mentions) == 0 {
+		return message.Text
+	}
+	return message.Text + " " + strings.Join(mentions, " ")
+}
+
+// parseMattermostURL returns the base URL of the server and the team name from the path of the URL
+func parseMattermostURL(serverURL string) (string, string, error) {
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		return "", "", errors.Wrapf(err, "failed to parse Mattermost URL %s", serverURL)
+	}
+	team := strings.Trim(u.Path, "/")
+	if i := strings.Index(team, "/"); i >= 0 {
+		team = team[0:i]
+	}
+	u.Path = ""
+	u.RawQuery = ""
+	u.Fragment = ""
+	return u.String(), team, nil
+}
@@ -0,0 +1,188 @@
+package chats
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/auth"
+)
+
+const (
+	msTeamsCardType    = "MessageCard"
+	msTeamsCardContext = "https://schema.org/extensions"
+)
+
+var msTeamsColors = map[MessageColor]string{
+	MessageColorGood:    "36a64f",
+	MessageColorWarning: "daa038",
+	MessageColorDanger:  "d00000",
+}
+
+// MSTeamsChatProvider implements ChatProvider by posting connector cards to a Microsoft Teams incoming webhook.
+//
+// The URL of the server is the URL of the incoming webhook of a channel so the channel name is only used for logging.
+// Incoming webhooks cannot reply to or update messages so threaded replies and updates are posted as new cards
+type MSTeamsChatProvider struct {
+	Server     *auth.AuthServer
+	WebhookURL string
+	Client     *http.Client
+}
+
+// MSTeamsCard is an Office 365 connector card
+type MSTeamsCard struct {
+	Type            string           `json:"@type"`
+	Context         string           `json:"@context"`
+	Summary         string           `json:"summary,omitempty"`
+	ThemeColor      string           `json:"themeColor,omitempty"`
+	Title           string           `json:"title,omitempty"`
+	Text            string           `json:"text,omitempty"`
+	Sections        []MSTeamsSection `json:"sections,omitempty"`
+	PotentialAction []MSTeamsAction  `json:"potentialAction,omitempty"`
+}
+
+// MSTeamsSection is a section of a connector card
+type MSTeamsSection struct {
+	ActivityTitle string        `json:"activityTitle,omitempty"`
+	Text          string        `json:"text,omitempty"`
+	Facts         []MSTeamsFact `json:"facts,omitempty"`
+	Markdown      bool          `json:"markdown"`
+}
+
+// MSTeamsFact is a name value pair of a section
+type MSTeamsFact struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// MSTeamsAction is an action which opens a link
+type MSTeamsAction struct {
+	Type    string          `json:"@type"`
+	Name    string          `json:"name"`
+	Targets []MSTeamsTarget `json:"targets"`
+}
+
+// MSTeamsTarget the target of an open URI action
+type MSTeamsTarget struct {
+	OS  string `json:"os"`
+	URI string `json:"uri"`
+}
+
+// CreateMSTeamsChatProvider creates a new Microsoft Teams chat provider for the incoming webhook URL of the server
+func CreateMSTeamsChatProvider(server *auth.AuthServer, userAuth *auth.UserAuth, batchMode bool) (ChatProvider, error) {
+	u := server.URL
+	if u == "" {
+		return nil, fmt.Errorf("No incoming webhook URL for Microsoft Teams server!")
+	}
+	return &MSTeamsChatProvider{
+		Server:     server,
+		WebhookURL: u,
+		Client:     http.DefaultClient,
+	}, nil
+}
+
+// GetChannelMetrics is not supported by incoming webhooks
+func (c *MSTeamsChatProvider) GetChannelMetrics(name string) (*ChannelMetrics, error) {
+	return &ChannelMetrics{
+		Name: name,
+	}, fmt.Errorf("channel metrics are not supported by Microsoft Teams incoming webhooks")
+}
+
+// SendMessage posts the message as a connector card to the incoming webhook
+func (c *MSTeamsChatProvider) SendMessage(channel string, message *Message) (*MessageReference, error) {
+	err := c.post(ToMSTeamsCard(message))
+	if err != nil {
+		return nil, err
+	}
+	return &MessageReference{
+		Channel:  channel,
+		ThreadID: message.ThreadID,
+	}, nil
+}
+
+// UpdateMessage posts the updated text as a new card as incoming webhooks cannot update messages
+func (c *MSTeamsChatProvider) UpdateMessage(ref *MessageReference, message *Message) (*MessageReference, error) {
+	err := c.post(ToMSTeamsCard(message))
+	if err != nil {
+		return nil, err
+	}
+	return ref, nil
+}
+
+func (c *MSTeamsChatProvider) post(card *MSTeamsCard) error {
+	data, err := json.Marshal(card)
+	if err != nil {
+		return err
+	}
+	client := c.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Post(c.WebhookURL, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, _ := ioutil.ReadAll(resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("error response posting to Microsoft Teams webhook: %s %s", resp.Status, string(body))
+	}
+	return nil
+}
+
+// ToMSTeamsCard converts the message to a connector card
+func ToMSTeamsCard(message *Message) *MSTeamsCard {
+	text := message.Text
+	mentions := []string{}
+	for _, m := range message.Mentions {
+		if m.Name != "" {
+			mentions = append(mentions, m.Name)
+		}
+	}
+	if len(mentions) > 0 {
+		text += " " + strings.Join(mentions, " ")
+	}
+	summary := message.Title
+	if summary == "" {
+		summary = message.Text
+	}
+	card := &MSTeamsCard{
+		Type:       msTeamsCardType,
+		Context:    msTeamsCardContext,
+		Summary:    summary,
+		ThemeColor: msTeamsColors[message.Color],
+		Text:       text,
+	}
+	if message.Title != "" || message.Details != "" || len(message.Fields) > 0 {
+		section := MSTeamsSection{
+			ActivityTitle: message.Title,
+			Text:          message.Details,
+			Markdown:      true,
+		}
+		for _, f := range message.Fields {
+			section.Facts = append(section.Facts, MSTeamsFact{
+				Name:  f.Title,
+				Value: f.Value,
+			})
+		}
+		card.Sections = []MSTeamsSection{section}
+	}
+	if message.TitleURL != "" {
+		card.PotentialAction = []MSTeamsAction{
+			{
+				Type: "OpenUri",
+				Name: "View",
+				Targets: []MSTeamsTarget{
+					{
+						OS:  "default",
+						URI: message.TitleURL,
+					},
+				},
+			},
+		}
+	}
+	return card
+}
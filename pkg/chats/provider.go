@@ -10,6 +10,12 @@ import (
 // ChatProvider represents an integration interface to chat
 type ChatProvider interface {
 	GetChannelMetrics(name string) (*ChannelMetrics, error)
+
+	// SendMessage sends a message to the channel. If the message has a ThreadID it is sent as a reply in that thread
+	SendMessage(channel string, message *Message) (*MessageReference, error)
+
+	// UpdateMessage updates the text of a message which was previously sent
+	UpdateMessage(ref *MessageReference, message *Message) (*MessageReference, error)
 }
 
 // ChannelMetrics metrics for a channel
@@ -21,15 +27,79 @@ type ChannelMetrics struct {
 	Members     []string
 }
 
+// MessageColor the color used to highlight a message
+type MessageColor string
+
+const (
+	// MessageColorGood used for successful pipelines and promotions
+	MessageColorGood MessageColor = "good"
+	// MessageColorWarning used for pending or waiting activities
+	MessageColorWarning MessageColor = "warning"
+	// MessageColorDanger used for failures
+	MessageColorDanger MessageColor = "danger"
+)
+
+// Message is a rich chat message
+type Message struct {
+	// Text the summary line of the message in markdown
+	Text string
+	// Title the title of the message details
+	Title string
+	// TitleURL the optional link of the title
+	TitleURL string
+	// Details the markdown body of the message details
+	Details string
+	Color   MessageColor
+	Fields  []MessageField
+	// Mentions the users to mention in the message
+	Mentions []Mention
+	// ThreadID the ID of the message to reply to
+	ThreadID string
+}
+
+// MessageField is a short name value pair shown in the message details
+type MessageField struct {
+	Title string
+	Value string
+	Short bool
+}
+
+// Mention is a user to mention in a message. If the user has no ID on the chat service the name is used instead
+type Mention struct {
+	ID   string
+	Name string
+}
+
+// MessageReference refers to a message which has been sent so it can be updated or replied to
+type MessageReference struct {
+	Channel  string
+	ID       string
+	ThreadID string
+}
+
 func (m *ChannelMetrics) ToMarkdown() string {
 	return util.MarkdownLink(m.Name, m.URL)
 }
 
+// UserIDKey returns the key of the account reference on a User which contains the user ID on the given kind of chat service
+func UserIDKey(kind string) string {
+	return fmt.Sprintf("jenkins.io/chat-%s-userid", kind)
+}
+
+// RequiresUserAuth returns false if the kind of chat provider does not need a user token such as incoming webhooks
+func RequiresUserAuth(kind string) bool {
+	return kind != MSTeams
+}
+
 // CreateChatProvider creates a new chat provider if one is available for the given kind
 func CreateChatProvider(kind string, server *auth.AuthServer, userAuth *auth.UserAuth, batchMode bool) (ChatProvider, error) {
 	switch kind {
 	case Slack:
 		return CreateSlackChatProvider(server, userAuth, batchMode)
+	case Mattermost:
+		return CreateMattermostChatProvider(server, userAuth, batchMode)
+	case MSTeams:
+		return CreateMSTeamsChatProvider(server, userAuth, batchMode)
 	default:
 		return nil, fmt.Errorf("Unsupported chat provider kind: %s", kind)
 	}
@@ -39,6 +109,12 @@ func ProviderAccessTokenURL(kind string, url string) string {
 	switch kind {
 	case Slack:
 		return "https://my.slack.com/services/new/bot"
+	case Mattermost:
+		baseURL, _, err := parseMattermostURL(url)
+		if err != nil || baseURL == "" {
+			return ""
+		}
+		return util.UrlJoin(baseURL, "/profile/security")
 	default:
 		return ""
 	}
@@ -2,6 +2,7 @@ package chats
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/jenkins-x/jx/pkg/auth"
@@ -10,6 +11,8 @@ import (
 	"github.com/nlopes/slack"
 )
 
+var markdownLinkRegex = regexp.MustCompile(`\[([^\]]*)\]\(([^)\s]+)\)`)
+
 type SlackChatProvider struct {
 	SlackClient *slack.Client
 	Server      *auth.AuthServer
@@ -62,3 +65,73 @@ func (c *SlackChatProvider) GetChannelMetrics(name string) (*ChannelMetrics, err
 	metrics.URL = util.UrlJoin(c.Server.URL, "messages", info.ID)
 	return metrics, nil
 }
+
+// SendMessage posts the message to the channel using an attachment for the message details
+func (c *SlackChatProvider) SendMessage(channel string, message *Message) (*MessageReference, error) {
+	params := slack.NewPostMessageParameters()
+	params.AsUser = true
+	params.Markdown = true
+	params.ThreadTimestamp = message.ThreadID
+	if message.Title != "" || message.Details != "" || len(message.Fields) > 0 {
+		attachment := slack.Attachment{
+			Fallback:   message.Title,
+			Color:      string(message.Color),
+			Title:      message.Title,
+			TitleLink:  message.TitleURL,
+			Text:       SlackMarkdown(message.Details),
+			MarkdownIn: []string{"text", "fields"},
+		}
+		for _, f := range message.Fields {
+			attachment.Fields = append(attachment.Fields, slack.AttachmentField{
+				Title: f.Title,
+				Value: SlackMarkdown(f.Value),
+				Short: f.Short,
+			})
+		}
+		params.Attachments = []slack.Attachment{attachment}
+	}
+	channelID, timestamp, err := c.SlackClient.PostMessage(channel, slackText(message), params)
+	if err != nil {
+		return nil, err
+	}
+	return &MessageReference{
+		Channel:  channelID,
+		ID:       timestamp,
+		ThreadID: message.ThreadID,
+	}, nil
+}
+
+// UpdateMessage updates the text of the message. Any attachments of the original message are retained
+func (c *SlackChatProvider) UpdateMessage(ref *MessageReference, message *Message) (*MessageReference, error) {
+	channelID, timestamp, _, err := c.SlackClient.UpdateMessage(ref.Channel, ref.ID, slackText(message))
+	if err != nil {
+		return nil, err
+	}
+	return &MessageReference{
+		Channel:  channelID,
+		ID:       timestamp,
+		ThreadID: ref.ThreadID,
+	}, nil
+}
+
+func slackText(message *Message) string {
+	mentions := []string{}
+	for _, m := range message.Mentions {
+		if m.ID != "" {
+			mentions = append(mentions, "<@"+m.ID+">")
+		} else if m.Name != "" {
+			mentions = append(mentions, m.Name)
+		}
+	}
+	text := SlackMarkdown(message.Text)
+	if len(mentions) == 0 {
+		return text
+	}
+	return text + " " + strings.Join(mentions, " ")
+}
+
+// SlackMarkdown converts the bold text and links of standard markdown to the Slack mrkdwn format
+func SlackMarkdown(text string) string {
+	text = markdownLinkRegex.ReplaceAllString(text, "<$2|$1>")
+	return strings.Replace(text, "**", "*", -1)
+}
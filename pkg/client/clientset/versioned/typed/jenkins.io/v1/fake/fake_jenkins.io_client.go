@@ -60,6 +60,10 @@ func (c *FakeJenkinsV1) Releases(namespace string) v1.ReleaseInterface {
 	return &FakeReleases{c, namespace}
 }
 
+func (c *FakeJenkinsV1) Schedulers(namespace string) v1.SchedulerInterface {
+	return &FakeSchedulers{c, namespace}
+}
+
 func (c *FakeJenkinsV1) SourceRepositories(namespace string) v1.SourceRepositoryInterface {
 	return &FakeSourceRepositories{c, namespace}
 }
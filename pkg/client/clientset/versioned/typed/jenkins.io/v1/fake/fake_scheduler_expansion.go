@@ -0,0 +1,9 @@
+package fake
+
+import "github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+
+// PatchUpdate takes the representation of a scheduler and updates using Patch generating a JSON patch to do so.
+// Returns the server's representation of the scheduler, and an error, if there is any.
+func (c *FakeSchedulers) PatchUpdate(app *v1.Scheduler) (*v1.Scheduler, error) {
+	return c.Update(app)
+}
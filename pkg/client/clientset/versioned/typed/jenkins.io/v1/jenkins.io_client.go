@@ -23,6 +23,7 @@ type JenkinsV1Interface interface {
 	PipelineStructuresGetter
 	PluginsGetter
 	ReleasesGetter
+	SchedulersGetter
 	SourceRepositoriesGetter
 	TeamsGetter
 	UsersGetter
@@ -82,6 +83,10 @@ func (c *JenkinsV1Client) Releases(namespace string) ReleaseInterface {
 	return newReleases(c, namespace)
 }
 
+func (c *JenkinsV1Client) Schedulers(namespace string) SchedulerInterface {
+	return newSchedulers(c, namespace)
+}
+
 func (c *JenkinsV1Client) SourceRepositories(namespace string) SourceRepositoryInterface {
 	return newSourceRepositories(c, namespace)
 }
@@ -0,0 +1,37 @@
+package v1
+
+import (
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	util "github.com/jenkins-x/jx/pkg/util/json"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// SchedulerExpansion expands the default CRUD interface for Scheduler.
+type SchedulerExpansion interface {
+	PatchUpdate(scheduler *v1.Scheduler) (result *v1.Scheduler, err error)
+}
+
+// PatchUpdate takes the representation of a scheduler and updates using Patch generating a JSON patch to do so.
+// Returns the server's representation of the scheduler, and an error, if there is any.
+func (c *schedulers) PatchUpdate(scheduler *v1.Scheduler) (*v1.Scheduler, error) {
+	resourceName := scheduler.ObjectMeta.Name
+
+	// force retrieval from cache
+	options := metav1.GetOptions{ResourceVersion: "0"}
+	orig, err := c.Get(resourceName, options)
+	if err != nil {
+		return nil, err
+	}
+
+	patch, err := util.CreatePatch(orig, scheduler)
+	if err != nil {
+		return nil, err
+	}
+	patched, err := c.Patch(resourceName, types.JSONPatchType, patch)
+	if err != nil {
+		return nil, err
+	}
+
+	return patched, nil
+}
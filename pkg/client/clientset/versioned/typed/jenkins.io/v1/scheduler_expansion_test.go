@@ -0,0 +1,124 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+	"testing"
+)
+
+var (
+	testScheduler = &v1.Scheduler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: v1.SchedulerSpec{},
+	}
+)
+
+func TestPatchUpdateSchedulerNoModification(t *testing.T) {
+	json, err := json.Marshal(testScheduler)
+	if err != nil {
+		assert.Failf(t, "unable to marshal test instance: %s", err.Error())
+	}
+	get := func(*http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: 200, Header: defaultHeaders(), Body: bytesBody(json)}, nil
+	}
+
+	patch := func(*http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: 200, Header: defaultHeaders(), Body: bytesBody(json)}, nil
+	}
+
+	fakeClient := newClientForTest(get, patch)
+
+	schedulers := schedulers{
+		client: fakeClient,
+		ns:     "default",
+	}
+
+	updated, err := schedulers.PatchUpdate(testScheduler)
+	assert.NoError(t, err)
+	assert.Equal(t, testScheduler, updated)
+}
+
+func TestPatchUpdateSchedulerWithChange(t *testing.T) {
+	agent := "tekton"
+	clonedScheduler := testScheduler.DeepCopy()
+	clonedScheduler.Spec.ScehdulerAgent = &v1.SchedulerAgent{Agent: &agent}
+
+	get := func(*http.Request) (*http.Response, error) {
+		json, err := json.Marshal(testScheduler)
+		if err != nil {
+			assert.Failf(t, "unable to marshal test instance: %s", err.Error())
+		}
+		return &http.Response{StatusCode: 200, Header: defaultHeaders(), Body: bytesBody(json)}, nil
+	}
+
+	patch := func(*http.Request) (*http.Response, error) {
+		json, err := json.Marshal(clonedScheduler)
+		if err != nil {
+			assert.Failf(t, "unable to marshal test instance: %s", err.Error())
+		}
+		return &http.Response{StatusCode: 200, Header: defaultHeaders(), Body: bytesBody(json)}, nil
+	}
+
+	fakeClient := newClientForTest(get, patch)
+
+	schedulers := schedulers{
+		client: fakeClient,
+		ns:     "default",
+	}
+
+	updated, err := schedulers.PatchUpdate(testScheduler)
+	assert.NoError(t, err)
+	assert.NotEqual(t, testScheduler, updated)
+	assert.Equal(t, agent, *updated.Spec.ScehdulerAgent.Agent)
+}
+
+func TestPatchUpdateSchedulerWithErrorInGet(t *testing.T) {
+	errorMessage := "error during GET"
+	get := func(*http.Request) (*http.Response, error) {
+		return nil, errors.New(errorMessage)
+	}
+
+	fakeClient := newClientForTest(get, nil)
+
+	schedulers := schedulers{
+		client: fakeClient,
+		ns:     "default",
+	}
+
+	updated, err := schedulers.PatchUpdate(testScheduler)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), errorMessage)
+	assert.Nil(t, updated)
+}
+
+func TestPatchUpdateSchedulerWithErrorInPatch(t *testing.T) {
+	errorMessage := "error during PATCH"
+	get := func(*http.Request) (*http.Response, error) {
+		json, err := json.Marshal(testScheduler)
+		if err != nil {
+			assert.Failf(t, "unable to marshal test instance: %s", err.Error())
+		}
+		return &http.Response{StatusCode: 200, Header: defaultHeaders(), Body: bytesBody(json)}, nil
+	}
+
+	patch := func(*http.Request) (*http.Response, error) {
+		return nil, errors.New(errorMessage)
+	}
+
+	fakeClient := newClientForTest(get, patch)
+
+	schedulers := schedulers{
+		client: fakeClient,
+		ns:     "default",
+	}
+	updated, err := schedulers.PatchUpdate(testScheduler)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), errorMessage)
+	assert.Nil(t, updated)
+}
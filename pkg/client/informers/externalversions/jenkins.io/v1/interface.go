@@ -32,6 +32,8 @@ type Interface interface {
 	Plugins() PluginInformer
 	// Releases returns a ReleaseInformer.
 	Releases() ReleaseInformer
+	// Schedulers returns a SchedulerInformer.
+	Schedulers() SchedulerInformer
 	// SourceRepositories returns a SourceRepositoryInformer.
 	SourceRepositories() SourceRepositoryInformer
 	// Teams returns a TeamInformer.
@@ -113,6 +115,11 @@ func (v *version) Releases() ReleaseInformer {
 	return &releaseInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// Schedulers returns a SchedulerInformer.
+func (v *version) Schedulers() SchedulerInformer {
+	return &schedulerInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // SourceRepositories returns a SourceRepositoryInformer.
 func (v *version) SourceRepositories() SourceRepositoryInformer {
 	return &sourceRepositoryInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -35,6 +35,7 @@ type ProjectConfig struct {
 	Workflow            string                      `json:"workflow,omitempty"`
 	PipelineConfig      *jenkinsfile.PipelineConfig `json:"pipelineConfig,omitempty"`
 	NoReleasePrepare    bool                        `json:"noReleasePrepare,omitempty"`
+	Coverage            *CoverageConfig             `json:"coverage,omitempty"`
 }
 
 type PreviewEnvironmentConfig struct {
@@ -61,6 +62,15 @@ type ChatConfig struct {
 	UserChannel      string `json:"userChannel,omitempty"`
 }
 
+// CoverageConfig configures the code coverage gate on pull requests
+type CoverageConfig struct {
+	// Threshold is the maximum drop in line or branch coverage percentage compared to the base branch
+	// before the coverage commit status fails
+	Threshold float64 `json:"threshold,omitempty"`
+	// Context is the commit status context; defaults to 'coverage'
+	Context string `json:"context,omitempty"`
+}
+
 type AddonConfig struct {
 	Name    string `json:"name,omitempty"`
 	Version string `json:"version,omitempty"`
@@ -0,0 +1,46 @@
+package coverage
+
+import "fmt"
+
+// Comparison the result of comparing a coverage report with the report of a base branch
+type Comparison struct {
+	Base      *Report
+	Current   *Report
+	Threshold float64
+}
+
+// LineDelta returns the change in line coverage percentage compared to the base report
+func (c *Comparison) LineDelta() float64 {
+	if c.Base == nil {
+		return 0
+	}
+	return c.Current.LineCoverage() - c.Base.LineCoverage()
+}
+
+// BranchDelta returns the change in branch coverage percentage compared to the base report
+func (c *Comparison) BranchDelta() float64 {
+	if c.Base == nil || c.Base.BranchesTotal() == 0 || c.Current.BranchesTotal() == 0 {
+		return 0
+	}
+	return c.Current.BranchCoverage() - c.Base.BranchCoverage()
+}
+
+// Failed returns true if either the line or branch coverage dropped by more than the threshold
+func (c *Comparison) Failed() bool {
+	return -c.LineDelta() > c.Threshold || -c.BranchDelta() > c.Threshold
+}
+
+// Description returns a short description of the comparison suitable for a commit status
+func (c *Comparison) Description() string {
+	if c.Base == nil {
+		return fmt.Sprintf("coverage %.2f%% (no base coverage found)", c.Current.LineCoverage())
+	}
+	answer := fmt.Sprintf("coverage %.2f%% (%+.2f%%)", c.Current.LineCoverage(), c.LineDelta())
+	if c.Current.BranchesTotal() > 0 {
+		answer += fmt.Sprintf(" branches %.2f%% (%+.2f%%)", c.Current.BranchCoverage(), c.BranchDelta())
+	}
+	if c.Failed() {
+		answer += fmt.Sprintf(" dropped more than %.2f%%", c.Threshold)
+	}
+	return answer
+}
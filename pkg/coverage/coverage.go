@@ -0,0 +1,192 @@
+package coverage
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/pkg/errors"
+)
+
+const (
+	// FormatGo the coverprofile format generated by 'go test -coverprofile'
+	FormatGo = "go"
+	// FormatCobertura the Cobertura XML report format
+	FormatCobertura = "cobertura"
+	// FormatJaCoCo the JaCoCo XML report format
+	FormatJaCoCo = "jacoco"
+)
+
+// Formats the supported coverage report formats
+var Formats = []string{FormatGo, FormatCobertura, FormatJaCoCo}
+
+// Report the line and branch coverage counts from a coverage report
+type Report struct {
+	LinesCovered    int
+	LinesMissed     int
+	BranchesCovered int
+	BranchesMissed  int
+}
+
+// LinesTotal returns the total number of lines
+func (r *Report) LinesTotal() int {
+	return r.LinesCovered + r.LinesMissed
+}
+
+// BranchesTotal returns the total number of branches
+func (r *Report) BranchesTotal() int {
+	return r.BranchesCovered + r.BranchesMissed
+}
+
+// LineCoverage returns the percentage of lines covered
+func (r *Report) LineCoverage() float64 {
+	return percent(r.LinesCovered, r.LinesTotal())
+}
+
+// BranchCoverage returns the percentage of branches covered
+func (r *Report) BranchCoverage() float64 {
+	return percent(r.BranchesCovered, r.BranchesTotal())
+}
+
+// Add adds the counts of the other report to this report
+func (r *Report) Add(other *Report) {
+	r.LinesCovered += other.LinesCovered
+	r.LinesMissed += other.LinesMissed
+	r.BranchesCovered += other.BranchesCovered
+	r.BranchesMissed += other.BranchesMissed
+}
+
+// String returns a human readable summary of the report
+func (r *Report) String() string {
+	answer := fmt.Sprintf("lines %.2f%% (%d/%d)", r.LineCoverage(), r.LinesCovered, r.LinesTotal())
+	if r.BranchesTotal() > 0 {
+		answer += fmt.Sprintf(" branches %.2f%% (%d/%d)", r.BranchCoverage(), r.BranchesCovered, r.BranchesTotal())
+	}
+	return answer
+}
+
+func percent(covered int, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(covered) * 100 / float64(total)
+}
+
+// DetectFormat returns the format of the given report file based on its name and content
+func DetectFormat(fileName string, data []byte) (string, error) {
+	text := strings.TrimSpace(string(data))
+	if strings.HasPrefix(text, "mode:") {
+		return FormatGo, nil
+	}
+	if strings.Contains(text, "<report") && strings.Contains(text, "<counter") {
+		return FormatJaCoCo, nil
+	}
+	if strings.Contains(text, "<coverage") {
+		return FormatCobertura, nil
+	}
+	return "", fmt.Errorf("could not detect the coverage format of file %s. Supported formats are: %s", fileName, strings.Join(Formats, ", "))
+}
+
+// ParseFile parses the given coverage report file. If no format is specified it is detected from the file contents
+func ParseFile(fileName string, format string) (*Report, error) {
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load file %s", fileName)
+	}
+	if format == "" {
+		format, err = DetectFormat(fileName, data)
+		if err != nil {
+			return nil, err
+		}
+	}
+	report, err := Parse(data, format)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse %s coverage report %s", format, filepath.Base(fileName))
+	}
+	return report, nil
+}
+
+// Parse parses the coverage report data of the given format
+func Parse(data []byte, format string) (*Report, error) {
+	switch format {
+	case FormatGo:
+		return ParseGoCoverProfile(data)
+	case FormatCobertura:
+		return ParseCobertura(data)
+	case FormatJaCoCo:
+		return ParseJaCoCo(data)
+	default:
+		return nil, fmt.Errorf("unknown coverage format %s. Supported formats are: %s", format, strings.Join(Formats, ", "))
+	}
+}
+
+// Measurements returns the Fact measurements for the report
+func (r *Report) Measurements() []v1.Measurement {
+	answer := countMeasurements(v1.CodeCoverageCountTypeLines, r.LinesCovered, r.LinesMissed)
+	if r.BranchesTotal() > 0 {
+		answer = append(answer, countMeasurements(v1.CodeCoverageCountTypeBranches, r.BranchesCovered, r.BranchesMissed)...)
+	}
+	return answer
+}
+
+func countMeasurements(countType string, covered int, missed int) []v1.Measurement {
+	tags := []string{countType}
+	return []v1.Measurement{
+		{
+			Name:             v1.CodeCoverageMeasurementTotal,
+			MeasurementType:  v1.MeasurementCount,
+			MeasurementValue: covered + missed,
+			Tags:             tags,
+		},
+		{
+			Name:             v1.CodeCoverageMeasurementMissed,
+			MeasurementType:  v1.MeasurementCount,
+			MeasurementValue: missed,
+			Tags:             tags,
+		},
+		{
+			Name:             v1.CodeCoverageMeasurementCoverage,
+			MeasurementType:  v1.MeasurementCount,
+			MeasurementValue: covered,
+			Tags:             tags,
+		},
+		{
+			Name:             v1.CodeCoverageMeasurementCoverage,
+			MeasurementType:  v1.MeasurementPercent,
+			MeasurementValue: int(percent(covered, covered+missed)),
+			Tags:             tags,
+		},
+	}
+}
+
+// ReportFromFact creates a report from the measurements of a coverage Fact
+func ReportFromFact(fact *v1.Fact) (*Report, error) {
+	if fact.Spec.FactType != v1.FactTypeCoverage {
+		return nil, fmt.Errorf("fact %s has type %s rather than %s", fact.Name, fact.Spec.FactType, v1.FactTypeCoverage)
+	}
+	answer := &Report{}
+	for _, m := range fact.Spec.Measurements {
+		if m.MeasurementType != v1.MeasurementCount || len(m.Tags) == 0 {
+			continue
+		}
+		switch m.Tags[0] {
+		case v1.CodeCoverageCountTypeLines:
+			switch m.Name {
+			case v1.CodeCoverageMeasurementCoverage:
+				answer.LinesCovered = m.MeasurementValue
+			case v1.CodeCoverageMeasurementMissed:
+				answer.LinesMissed = m.MeasurementValue
+			}
+		case v1.CodeCoverageCountTypeBranches:
+			switch m.Name {
+			case v1.CodeCoverageMeasurementCoverage:
+				answer.BranchesCovered = m.MeasurementValue
+			case v1.CodeCoverageMeasurementMissed:
+				answer.BranchesMissed = m.MeasurementValue
+			}
+		}
+	}
+	return answer, nil
+}
@@ -0,0 +1,90 @@
+package coverage_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/coverage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGoCoverProfile(t *testing.T) {
+	t.Parallel()
+	report := assertParseFile(t, "coverage.out", coverage.FormatGo)
+
+	assert.Equal(t, 6, report.LinesCovered, "LinesCovered")
+	assert.Equal(t, 5, report.LinesMissed, "LinesMissed")
+	assert.Equal(t, 0, report.BranchesTotal(), "BranchesTotal")
+}
+
+func TestParseCobertura(t *testing.T) {
+	t.Parallel()
+	report := assertParseFile(t, "cobertura.xml", coverage.FormatCobertura)
+
+	assert.Equal(t, 30, report.LinesCovered, "LinesCovered")
+	assert.Equal(t, 10, report.LinesMissed, "LinesMissed")
+	assert.Equal(t, 5, report.BranchesCovered, "BranchesCovered")
+	assert.Equal(t, 5, report.BranchesMissed, "BranchesMissed")
+	assert.Equal(t, 75.0, report.LineCoverage(), "LineCoverage")
+}
+
+func TestParseJaCoCo(t *testing.T) {
+	t.Parallel()
+	report := assertParseFile(t, "jacoco.xml", coverage.FormatJaCoCo)
+
+	assert.Equal(t, 90, report.LinesCovered, "LinesCovered")
+	assert.Equal(t, 10, report.LinesMissed, "LinesMissed")
+	assert.Equal(t, 12, report.BranchesCovered, "BranchesCovered")
+	assert.Equal(t, 4, report.BranchesMissed, "BranchesMissed")
+}
+
+func TestReportFromFact(t *testing.T) {
+	t.Parallel()
+	report := &coverage.Report{
+		LinesCovered:    90,
+		LinesMissed:     10,
+		BranchesCovered: 12,
+		BranchesMissed:  4,
+	}
+	fact := &v1.Fact{
+		Spec: v1.FactSpec{
+			FactType:     v1.FactTypeCoverage,
+			Measurements: report.Measurements(),
+		},
+	}
+	actual, err := coverage.ReportFromFact(fact)
+	require.NoError(t, err)
+	assert.Equal(t, report, actual)
+}
+
+func TestComparisonFailsWhenCoverageDropsMoreThanThreshold(t *testing.T) {
+	t.Parallel()
+	base := &coverage.Report{LinesCovered: 80, LinesMissed: 20}
+
+	comparison := &coverage.Comparison{
+		Base:      base,
+		Current:   &coverage.Report{LinesCovered: 79, LinesMissed: 21},
+		Threshold: 2,
+	}
+	assert.False(t, comparison.Failed(), "coverage dropped 1%% with threshold 2%%")
+
+	comparison.Current = &coverage.Report{LinesCovered: 70, LinesMissed: 30}
+	assert.True(t, comparison.Failed(), "coverage dropped 10%% with threshold 2%%")
+
+	comparison.Base = nil
+	assert.False(t, comparison.Failed(), "no base coverage")
+}
+
+func assertParseFile(t *testing.T, fileName string, expectedFormat string) *coverage.Report {
+	path := filepath.Join("test_data", fileName)
+	report, err := coverage.ParseFile(path, "")
+	require.NoError(t, err, "failed to parse %s", path)
+	require.NotNil(t, report)
+
+	explicit, err := coverage.ParseFile(path, expectedFormat)
+	require.NoError(t, err, "failed to parse %s with format %s", path, expectedFormat)
+	assert.Equal(t, report, explicit, "detected format should match %s", expectedFormat)
+	return report
+}
@@ -0,0 +1,138 @@
+package coverage
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseGoCoverProfile parses a coverprofile generated by 'go test -coverprofile'.
+//
+// Each block line has the form 'file.go:startLine.startCol,endLine.endCol statements count'.
+// A source line is covered if any block spanning it has a non zero count. Go does not report branches.
+func ParseGoCoverProfile(data []byte) (*Report, error) {
+	lines := map[string]map[int]bool{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "mode:") {
+			continue
+		}
+		idx := strings.LastIndex(text, ":")
+		if idx < 0 {
+			return nil, fmt.Errorf("line %d: missing file name separator in %q", lineNumber, text)
+		}
+		fileName := text[0:idx]
+		fields := strings.Fields(text[idx+1:])
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("line %d: expected 'range statements count' but got %q", lineNumber, text)
+		}
+		positions := strings.Split(fields[0], ",")
+		if len(positions) != 2 {
+			return nil, fmt.Errorf("line %d: invalid block range %q", lineNumber, fields[0])
+		}
+		start, err := parseGoPositionLine(positions[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", lineNumber, err)
+		}
+		end, err := parseGoPositionLine(positions[1])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", lineNumber, err)
+		}
+		count, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid count %q", lineNumber, fields[2])
+		}
+		fileLines := lines[fileName]
+		if fileLines == nil {
+			fileLines = map[int]bool{}
+			lines[fileName] = fileLines
+		}
+		for l := start; l <= end; l++ {
+			fileLines[l] = fileLines[l] || count > 0
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	answer := &Report{}
+	for _, fileLines := range lines {
+		for _, covered := range fileLines {
+			if covered {
+				answer.LinesCovered++
+			} else {
+				answer.LinesMissed++
+			}
+		}
+	}
+	return answer, nil
+}
+
+func parseGoPositionLine(text string) (int, error) {
+	paths := strings.SplitN(text, ".", 2)
+	line, err := strconv.Atoi(paths[0])
+	if err != nil {
+		return 0, fmt.Errorf("invalid block position %q", text)
+	}
+	return line, nil
+}
+
+type coberturaReport struct {
+	XMLName         xml.Name `xml:"coverage"`
+	LinesValid      int      `xml:"lines-valid,attr"`
+	LinesCovered    int      `xml:"lines-covered,attr"`
+	BranchesValid   int      `xml:"branches-valid,attr"`
+	BranchesCovered int      `xml:"branches-covered,attr"`
+}
+
+// ParseCobertura parses a Cobertura XML report using the totals on the root coverage element
+func ParseCobertura(data []byte) (*Report, error) {
+	report := coberturaReport{}
+	err := xml.Unmarshal(data, &report)
+	if err != nil {
+		return nil, err
+	}
+	return &Report{
+		LinesCovered:    report.LinesCovered,
+		LinesMissed:     report.LinesValid - report.LinesCovered,
+		BranchesCovered: report.BranchesCovered,
+		BranchesMissed:  report.BranchesValid - report.BranchesCovered,
+	}, nil
+}
+
+type jacocoReport struct {
+	XMLName  xml.Name        `xml:"report"`
+	Counters []jacocoCounter `xml:"counter"`
+}
+
+type jacocoCounter struct {
+	Type    string `xml:"type,attr"`
+	Missed  int    `xml:"missed,attr"`
+	Covered int    `xml:"covered,attr"`
+}
+
+// ParseJaCoCo parses a JaCoCo XML report using the counters on the root report element
+func ParseJaCoCo(data []byte) (*Report, error) {
+	report := jacocoReport{}
+	err := xml.Unmarshal(data, &report)
+	if err != nil {
+		return nil, err
+	}
+	answer := &Report{}
+	for _, counter := range report.Counters {
+		switch counter.Type {
+		case "LINE":
+			answer.LinesCovered = counter.Covered
+			answer.LinesMissed = counter.Missed
+		case "BRANCH":
+			answer.BranchesCovered = counter.Covered
+			answer.BranchesMissed = counter.Missed
+		}
+	}
+	return answer, nil
+}
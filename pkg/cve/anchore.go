@@ -115,24 +115,9 @@ func (a AnchoreProvider) GetImageVulnerabilityTable(jxClient versioned.Interface
 		// if we have an image name then lets try and match image id(s)
 		if query.ImageName != "" {
 
-			var images []Image
-			subPath := fmt.Sprintf(GetImages)
-
-			err = a.AnchoreGet(subPath, &images)
+			imageIDs, err = a.findImageIDs(query)
 			if err != nil {
-				return fmt.Errorf("error getting images %v", err)
-			}
-
-			for _, image := range images {
-				for _, d := range image.ImageDetails {
-					if d.Repo == query.ImageName {
-						// if user has provided a version and it doesn't match lets skip this image
-						if query.Vesion != "" && query.Vesion != d.Tag {
-							continue
-						}
-						imageIDs = append(imageIDs, d.ImageId)
-					}
-				}
+				return err
 			}
 			if len(imageIDs) > 0 {
 				err = a.getCVEsFromImageList(table, &vList, imageIDs)
@@ -151,6 +136,65 @@ func (a AnchoreProvider) GetImageVulnerabilityTable(jxClient versioned.Interface
 
 }
 
+// GetImageVulnerabilities returns the vulnerabilities of the images matching the image id or the image name and
+// optional version of the query
+func (a AnchoreProvider) GetImageVulnerabilities(query CVEQuery) ([]Vulnerability, error) {
+	imageIDs := []string{}
+	if query.ImageID != "" {
+		imageIDs = append(imageIDs, query.ImageID)
+	} else if query.ImageName != "" {
+		var err error
+		imageIDs, err = a.findImageIDs(query)
+		if err != nil {
+			return nil, err
+		}
+		if len(imageIDs) == 0 {
+			return nil, fmt.Errorf("no matching images found for ImageName %s and Vesion %s", query.ImageName, query.Vesion)
+		}
+	} else {
+		return nil, fmt.Errorf("choose an image name, an optinal version or anchore image id to find vulnerabilities")
+	}
+
+	answer := []Vulnerability{}
+	for _, imageID := range imageIDs {
+		var vList VulnerabilityList
+		subPath := fmt.Sprintf(getVulnerabilitiesByImageID, imageID, vulnerabilityType)
+
+		err := a.AnchoreGet(subPath, &vList)
+		if err != nil {
+			return nil, fmt.Errorf("error getting vulnerabilities for image %s: %v", imageID, err)
+		}
+		answer = append(answer, vList.Vulnerabilities...)
+	}
+	return answer, nil
+}
+
+// findImageIDs returns the ids of the analysed images whose repository is the image name of the query and whose tag
+// matches the version of the query if there is one
+func (a AnchoreProvider) findImageIDs(query CVEQuery) ([]string, error) {
+	var images []Image
+	subPath := fmt.Sprintf(GetImages)
+
+	err := a.AnchoreGet(subPath, &images)
+	if err != nil {
+		return nil, fmt.Errorf("error getting images %v", err)
+	}
+
+	imageIDs := []string{}
+	for _, image := range images {
+		for _, d := range image.ImageDetails {
+			if d.Repo == query.ImageName {
+				// if user has provided a version and it doesn't match lets skip this image
+				if query.Vesion != "" && query.Vesion != d.Tag {
+					continue
+				}
+				imageIDs = append(imageIDs, d.ImageId)
+			}
+		}
+	}
+	return imageIDs, nil
+}
+
 // AnchoreGet get command
 func (a AnchoreProvider) AnchoreGet(subPath string, rs result) error {
 
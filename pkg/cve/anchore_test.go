@@ -115,3 +115,19 @@ func (suite *AnchoreProviderTestSuite) TestGetImageVulnerabilityTable() {
 	vTable.Render()
 
 }
+
+func (suite *AnchoreProviderTestSuite) TestGetImageVulnerabilities() {
+
+	query := cve.CVEQuery{
+		ImageID: "07b67913cd8c1ffc961c402b58c4e539ee6aaeae0b08969fc653267f4b975503",
+	}
+
+	vulnerabilities, err := suite.provider.GetImageVulnerabilities(query)
+	suite.Require().NoError(err)
+
+	suite.EqualValues(9, len(vulnerabilities))
+	mostSevere := cve.MostSevere(vulnerabilities)
+	suite.Require().NotNil(mostSevere)
+	suite.EqualValues("High", mostSevere.Severity)
+	suite.EqualValues("RHSA-2018:0102", mostSevere.Vuln)
+}
@@ -19,4 +19,7 @@ type CVEQuery struct {
 }
 type CVEProvider interface {
 	GetImageVulnerabilityTable(jxClient versioned.Interface, client kubernetes.Interface, table *table.Table, query CVEQuery) error
+	// GetImageVulnerabilities returns the vulnerabilities of the images matching the image id or the image name and
+	// optional version of the query
+	GetImageVulnerabilities(query CVEQuery) ([]Vulnerability, error)
 }
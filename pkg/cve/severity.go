@@ -0,0 +1,30 @@
+package cve
+
+import (
+	"strings"
+)
+
+// Severities are the severities of vulnerabilities from the least to the most severe
+var Severities = []string{"Unknown", "Negligible", "Low", "Medium", "High", "Critical"}
+
+// SeverityLevel returns the position of the severity in Severities ignoring case or -1 if it is not a known severity
+func SeverityLevel(severity string) int {
+	for i, s := range Severities {
+		if strings.EqualFold(s, severity) {
+			return i
+		}
+	}
+	return -1
+}
+
+// MostSevere returns the most severe of the vulnerabilities or nil if there are none
+func MostSevere(vulnerabilities []Vulnerability) *Vulnerability {
+	var answer *Vulnerability
+	for i := range vulnerabilities {
+		v := &vulnerabilities[i]
+		if answer == nil || SeverityLevel(v.Severity) > SeverityLevel(answer.Severity) {
+			answer = v
+		}
+	}
+	return answer
+}
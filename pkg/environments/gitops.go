@@ -107,7 +107,18 @@ func (o *EnvironmentPullRequestOptions) Create(env *jenkinsv1.Environment, envir
 	if err != nil {
 		return nil, err
 	}
-	return o.PushEnvironmentRepo(dir, branchName, gitInfo, base, pullRequestDetails, pullRequestInfo, fork)
+	info, err := o.PushEnvironmentRepo(dir, branchName, gitInfo, base, pullRequestDetails, pullRequestInfo, fork)
+	if err != nil {
+		return nil, err
+	}
+	if info != nil && info.PullRequest != nil && env.Spec.ReviewPolicy != nil {
+		err = ApplyReviewPolicy(o.GitProvider, info.PullRequest, env.Spec.ReviewPolicy, dir)
+		if err != nil {
+			log.Warnf("Failed to apply the review policy of environment %s to Pull Request %s: %s\n", env.Name,
+				info.PullRequest.URL, err)
+		}
+	}
+	return info, nil
 }
 
 // PushEnvironmentRepo commits and pushes the changes in the repo rooted at dir.
@@ -129,21 +140,28 @@ func (o *EnvironmentPullRequestOptions) PushEnvironmentRepo(dir string, branchNa
 		log.Warnf("%s\n", "No changes made to the GitOps Environment source code. Code must be up to date!")
 		return nil, nil
 	}
-	err = o.Gitter.CommitDir(dir, pullRequestDetails.Message)
-	if err != nil {
-		return nil, err
-	}
-	// lets rebase an existing PR
-	if pullRequestInfo != nil && pullRequestInfo.PullRequestArguments.Head != "" {
-		err = o.Gitter.ForcePushBranch(dir, branchName, pullRequestInfo.PullRequestArguments.Head)
+	if o.GitProvider != nil && o.GitProvider.IsGerrit() {
+		branchName, err = o.pushGerritChange(dir, branchName, base, pullRequestDetails, pullRequestInfo)
 		if err != nil {
-			return nil, errors.Wrapf(err, "rebasing existing PR on %s", pullRequestInfo.PullRequestArguments.Head)
+			return nil, err
+		}
+	} else {
+		err = o.Gitter.CommitDir(dir, pullRequestDetails.Message)
+		if err != nil {
+			return nil, err
+		}
+		// lets rebase an existing PR
+		if pullRequestInfo != nil && pullRequestInfo.PullRequestArguments.Head != "" {
+			err = o.Gitter.ForcePushBranch(dir, branchName, pullRequestInfo.PullRequestArguments.Head)
+			if err != nil {
+				return nil, errors.Wrapf(err, "rebasing existing PR on %s", pullRequestInfo.PullRequestArguments.Head)
+			}
 		}
-	}
 
-	err = o.Gitter.Push(dir)
-	if err != nil {
-		return nil, err
+		err = o.Gitter.Push(dir)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	headPrefix := ""
@@ -176,6 +194,33 @@ func (o *EnvironmentPullRequestOptions) PushEnvironmentRepo(dir string, branchNa
 	}, nil
 }
 
+// pushGerritChange commits the changes with a Change-Id and pushes them to the magic refs/for/<base> ref so that Gerrit
+// creates a change, or a new patch set of the change of an existing PR. It returns the topic of the change which is
+// used as the head of the PR
+func (o *EnvironmentPullRequestOptions) pushGerritChange(dir string, branchName string, base string,
+	pullRequestDetails *PullRequestDetails, pullRequestInfo *gits.PullRequestInfo) (string, error) {
+	topic := branchName
+	changeID := ""
+	if pullRequestInfo != nil {
+		if pullRequestInfo.PullRequestArguments != nil && pullRequestInfo.PullRequestArguments.Head != "" {
+			topic = pullRequestInfo.PullRequestArguments.Head
+		}
+		if pullRequestInfo.PullRequest != nil {
+			changeID = gits.GerritChangeID(pullRequestInfo.PullRequest.Body)
+		}
+	}
+	err := o.Gitter.CommitDir(dir, gits.AddGerritChangeID(pullRequestDetails.Message, changeID))
+	if err != nil {
+		return topic, err
+	}
+	ref := gits.GerritRefsFor(base, topic)
+	err = o.Gitter.ForcePushBranch(dir, "HEAD", ref)
+	if err != nil {
+		return topic, errors.Wrapf(err, "pushing change to %s", ref)
+	}
+	return topic, nil
+}
+
 // ModifyChartFiles modifies the chart files in the given directory using the given modify function
 func ModifyChartFiles(dir string, details *PullRequestDetails, modifyFn ModifyChartFn, chartName string) error {
 	requirementsFile, err := helm.FindRequirementsFileName(dir)
@@ -0,0 +1,78 @@
+package environments
+
+import (
+	"path/filepath"
+
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/helm"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/pkg/errors"
+)
+
+// AppVersionHistory returns the versions of the application which have been promoted to the GitOps environment whose
+// repository is cloned into dir, starting with the current version and ending with the oldest. A version appears
+// again each time it was promoted, such as when it was rolled back to, so the order of the deployments is kept
+func AppVersionHistory(gitter gits.Gitter, dir string, app string) ([]string, error) {
+	requirementsFile, err := helm.FindRequirementsFileName(dir)
+	if err != nil {
+		return nil, err
+	}
+	path, err := filepath.Rel(dir, requirementsFile)
+	if err != nil {
+		return nil, err
+	}
+	path = filepath.ToSlash(path)
+	revisions, err := gitter.GetFileRevisions(dir, path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find the history of %s", path)
+	}
+	versions := []string{}
+	for _, revision := range revisions {
+		text, err := gitter.LoadFileFromBranch(dir, revision, path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to load %s at revision %s", path, revision)
+		}
+		requirements, err := helm.LoadRequirements([]byte(text))
+		if err != nil {
+			log.Warnf("Ignoring invalid %s at revision %s: %s\n", path, revision, err)
+			continue
+		}
+		dep := FindAppDependency(requirements, app)
+		// revisions which only change other apps keep the same version
+		if dep == nil || dep.Version == "" || (len(versions) > 0 && versions[len(versions)-1] == dep.Version) {
+			continue
+		}
+		versions = append(versions, dep.Version)
+	}
+	return versions, nil
+}
+
+// VersionBeforeCurrent returns the version deployed before the current version of the history was first introduced or
+// an empty string if there is none. Versions which were rolled back from are skipped so rolling back twice in a row
+// does not return to the version the first rollback replaced
+func VersionBeforeCurrent(history []string) string {
+	if len(history) == 0 {
+		return ""
+	}
+	first := 0
+	for i, version := range history {
+		if version == history[0] {
+			first = i
+		}
+	}
+	if first+1 < len(history) {
+		return history[first+1]
+	}
+	return ""
+}
+
+// FindAppDependency returns the dependency of the requirements whose name or alias is the application or nil if
+// there is none
+func FindAppDependency(requirements *helm.Requirements, app string) *helm.Dependency {
+	for _, dep := range requirements.Dependencies {
+		if dep != nil && (dep.Name == app || (dep.Alias != "" && dep.Alias == app)) {
+			return dep
+		}
+	}
+	return nil
+}
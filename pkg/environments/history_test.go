@@ -0,0 +1,78 @@
+package environments_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/environments"
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppVersionHistory(t *testing.T) {
+	os.Setenv("GIT_AUTHOR_NAME", "jx-bot")
+	os.Setenv("GIT_AUTHOR_EMAIL", "jx-bot@jenkins-x.io")
+	defer os.Unsetenv("GIT_AUTHOR_NAME")
+	defer os.Unsetenv("GIT_AUTHOR_EMAIL")
+
+	dir, err := ioutil.TempDir("", "test-app-version-history-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	gitter := gits.NewGoGitter()
+	require.NoError(t, gitter.Init(dir))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "env"), 0755))
+	promote := func(myappVersion string, otherVersion string) {
+		requirements := `dependencies:
+- name: myapp
+  repository: http://jenkins-x-chartmuseum:8080
+  version: ` + myappVersion + `
+- name: other
+  repository: http://jenkins-x-chartmuseum:8080
+  version: ` + otherVersion + `
+`
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "env", "requirements.yaml"), []byte(requirements), 0644))
+		require.NoError(t, gitter.Add(dir, "."))
+		require.NoError(t, gitter.CommitDir(dir, "promote"))
+	}
+	promote("1.0.0", "0.0.1")
+	promote("1.0.1", "0.0.1")
+	promote("1.0.1", "0.0.2")
+	promote("1.0.2", "0.0.2")
+	promote("1.0.0", "0.0.2")
+
+	versions, err := environments.AppVersionHistory(gitter, dir, "myapp")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1.0.0", "1.0.2", "1.0.1", "1.0.0"}, versions)
+
+	versions, err = environments.AppVersionHistory(gitter, dir, "other")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"0.0.2", "0.0.1"}, versions)
+
+	versions, err = environments.AppVersionHistory(gitter, dir, "missing")
+	require.NoError(t, err)
+	assert.Equal(t, []string{}, versions)
+}
+
+func TestVersionBeforeCurrent(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		history  []string
+		expected string
+	}{
+		{[]string{}, ""},
+		{[]string{"1.0.0"}, ""},
+		{[]string{"1.0.2", "1.0.1", "1.0.0"}, "1.0.1"},
+		// rolled back from 1.0.2 to 1.0.1 so rolling back again skips 1.0.2
+		{[]string{"1.0.1", "1.0.2", "1.0.1", "1.0.0"}, "1.0.0"},
+		// then rolled back from 1.0.1 to 1.0.0 so there is nothing older
+		{[]string{"1.0.0", "1.0.1", "1.0.2", "1.0.1", "1.0.0"}, ""},
+		{[]string{"1.0.3", "1.0.1", "1.0.2", "1.0.1", "1.0.0"}, "1.0.1"},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, environments.VersionBeforeCurrent(tc.history), "history %v", tc.history)
+	}
+}
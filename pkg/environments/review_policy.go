@@ -0,0 +1,121 @@
+package environments
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+
+	jenkinsv1 "github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/prow"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+// ApplyReviewPolicy labels the Pull Request and requests the reviewers defined by the review policy of an environment.
+// If the policy requests owners then the approvers of the OWNERS file in the environment repository cloned into dir
+// are requested too
+func ApplyReviewPolicy(provider gits.GitProvider, pr *gits.GitPullRequest, policy *jenkinsv1.ReviewPolicy, dir string) error {
+	if policy == nil || pr == nil {
+		return nil
+	}
+	if len(policy.Labels) > 0 {
+		err := provider.AddPRLabels(pr, policy.Labels)
+		if err != nil {
+			return errors.Wrapf(err, "adding labels %s", strings.Join(policy.Labels, ", "))
+		}
+	}
+	reviewers, err := ReviewPolicyReviewers(policy, dir)
+	if err != nil {
+		return err
+	}
+	// the author cannot review their own Pull Request
+	author := provider.CurrentUsername()
+	if pr.Author != nil && pr.Author.Login != "" {
+		author = pr.Author.Login
+	}
+	reviewers = util.RemoveStringFromSlice(reviewers, author)
+	if len(reviewers) == 0 {
+		return nil
+	}
+	err = provider.RequestPRReviewers(pr, reviewers)
+	if err != nil {
+		return errors.Wrapf(err, "requesting reviewers %s", strings.Join(reviewers, ", "))
+	}
+	return nil
+}
+
+// ReviewPolicyReviewers returns the reviewers of the review policy along with the approvers of the OWNERS file in dir
+// if the policy requests owners
+func ReviewPolicyReviewers(policy *jenkinsv1.ReviewPolicy, dir string) ([]string, error) {
+	answer := []string{}
+	if policy == nil {
+		return answer, nil
+	}
+	names := append([]string{}, policy.Reviewers...)
+	if policy.RequestOwners && dir != "" {
+		ownersFile := filepath.Join(dir, "OWNERS")
+		exists, err := util.FileExists(ownersFile)
+		if err != nil {
+			return answer, err
+		}
+		if exists {
+			data, err := ioutil.ReadFile(ownersFile)
+			if err != nil {
+				return answer, errors.Wrapf(err, "reading %s", ownersFile)
+			}
+			owners := prow.Owners{}
+			err = yaml.Unmarshal(data, &owners)
+			if err != nil {
+				return answer, errors.Wrapf(err, "unmarshalling %s", ownersFile)
+			}
+			names = append(names, owners.Approvers...)
+		}
+	}
+	for _, name := range names {
+		if name != "" && util.StringArrayIndex(answer, name) < 0 {
+			answer = append(answer, name)
+		}
+	}
+	return answer, nil
+}
+
+// ValidateReviewPolicy returns an error if the review policy requires approvals which cannot be checked as the git
+// provider does not support Pull Request reviews, as otherwise the promotion would wait for approvals forever
+func ValidateReviewPolicy(provider gits.GitProvider, policy *jenkinsv1.ReviewPolicy) error {
+	if policy == nil || policy.RequiredApprovals <= 0 {
+		return nil
+	}
+	if provider.Kind() == gits.KindGitea {
+		return fmt.Errorf("the review policy requires %d approvals but %s does not support Pull Request reviews so the approvals cannot be checked",
+			policy.RequiredApprovals, provider.Kind())
+	}
+	return nil
+}
+
+// ReviewPolicyApproved returns true if the Pull Request has at least the number of approvals required by the review
+// policy and no reviewer has requested changes. If not approved a description of what is missing is returned
+func ReviewPolicyApproved(provider gits.GitProvider, pr *gits.GitPullRequest, policy *jenkinsv1.ReviewPolicy) (bool, string, error) {
+	if policy == nil || policy.RequiredApprovals <= 0 {
+		return true, "", nil
+	}
+	reviews, err := provider.ListPRReviews(pr)
+	if err != nil {
+		return false, "", errors.Wrapf(err, "listing the reviews of Pull Request %s", pr.URL)
+	}
+	changesRequested := gits.ChangesRequestedReviewers(reviews)
+	if len(changesRequested) > 0 {
+		return false, fmt.Sprintf("changes requested by %s", strings.Join(changesRequested, ", ")), nil
+	}
+	approvers := gits.ApprovedReviewers(reviews)
+	if pr.Author != nil {
+		approvers = util.RemoveStringFromSlice(approvers, pr.Author.Login)
+	}
+	if len(approvers) < policy.RequiredApprovals {
+		return false, fmt.Sprintf("%d of %d required approvals", len(approvers), policy.RequiredApprovals), nil
+	}
+	return true, "", nil
+}
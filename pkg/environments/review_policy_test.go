@@ -0,0 +1,101 @@
+package environments_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	jenkinsv1 "github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/environments"
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createReviewPolicyPullRequest() (*gits.FakeProvider, *gits.FakePullRequest) {
+	repo := gits.NewFakeRepository("jstrachan", "environment-staging")
+	number := 1
+	fakePR := &gits.FakePullRequest{
+		PullRequest: &gits.GitPullRequest{
+			Owner:  "jstrachan",
+			Repo:   "environment-staging",
+			Number: &number,
+			URL:    "https://fake.git/jstrachan/environment-staging/pulls/1",
+			Author: &gits.GitUser{Login: "jenkins-x-bot"},
+		},
+	}
+	repo.PullRequests[number] = fakePR
+	return gits.NewFakeProvider(repo), fakePR
+}
+
+func TestApplyReviewPolicy(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "test_review_policy")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	owners := "approvers:\n- jenkins-x-bot\n- rawlingsj\nreviewers:\n- pmuir\n"
+	err = ioutil.WriteFile(filepath.Join(dir, "OWNERS"), []byte(owners), 0644)
+	require.NoError(t, err)
+
+	provider, fakePR := createReviewPolicyPullRequest()
+	policy := &jenkinsv1.ReviewPolicy{
+		Labels:        []string{"promotion"},
+		Reviewers:     []string{"jstrachan"},
+		RequestOwners: true,
+	}
+	err = environments.ApplyReviewPolicy(provider, fakePR.PullRequest, policy, dir)
+	require.NoError(t, err)
+
+	assert.True(t, fakePR.PullRequest.HasLabel("promotion"))
+	reviewers := []string{}
+	for _, user := range fakePR.PullRequest.RequestedReviewers {
+		reviewers = append(reviewers, user.Login)
+	}
+	assert.Equal(t, []string{"jstrachan", "rawlingsj"}, reviewers)
+}
+
+func TestReviewPolicyApproved(t *testing.T) {
+	t.Parallel()
+	provider, fakePR := createReviewPolicyPullRequest()
+	policy := &jenkinsv1.ReviewPolicy{
+		RequiredApprovals: 2,
+	}
+
+	approved, _, err := environments.ReviewPolicyApproved(provider, fakePR.PullRequest, nil)
+	require.NoError(t, err)
+	assert.True(t, approved, "no policy")
+
+	fakePR.Reviews = []*gits.GitReview{
+		{User: &gits.GitUser{Login: "jstrachan"}, State: gits.ReviewStateApproved},
+		{User: &gits.GitUser{Login: "jenkins-x-bot"}, State: gits.ReviewStateApproved},
+		{User: &gits.GitUser{Login: "rawlingsj"}, State: gits.ReviewStateCommented},
+	}
+	approved, message, err := environments.ReviewPolicyApproved(provider, fakePR.PullRequest, policy)
+	require.NoError(t, err)
+	assert.False(t, approved)
+	assert.Equal(t, "1 of 2 required approvals", message)
+
+	fakePR.Reviews = append(fakePR.Reviews, &gits.GitReview{User: &gits.GitUser{Login: "rawlingsj"}, State: gits.ReviewStateApproved})
+	approved, _, err = environments.ReviewPolicyApproved(provider, fakePR.PullRequest, policy)
+	require.NoError(t, err)
+	assert.True(t, approved)
+
+	fakePR.Reviews = append(fakePR.Reviews, &gits.GitReview{User: &gits.GitUser{Login: "jstrachan"}, State: gits.ReviewStateChangesRequested})
+	approved, message, err = environments.ReviewPolicyApproved(provider, fakePR.PullRequest, policy)
+	require.NoError(t, err)
+	assert.False(t, approved)
+	assert.Equal(t, "changes requested by jstrachan", message)
+}
+
+func TestValidateReviewPolicy(t *testing.T) {
+	t.Parallel()
+	provider, _ := createReviewPolicyPullRequest()
+	policy := &jenkinsv1.ReviewPolicy{RequiredApprovals: 1}
+	assert.NoError(t, environments.ValidateReviewPolicy(provider, policy))
+	assert.NoError(t, environments.ValidateReviewPolicy(provider, nil))
+
+	provider.Type = gits.Gitea
+	assert.Error(t, environments.ValidateReviewPolicy(provider, policy))
+	assert.NoError(t, environments.ValidateReviewPolicy(provider, &jenkinsv1.ReviewPolicy{Labels: []string{"promotion"}}))
+}
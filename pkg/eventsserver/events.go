@@ -0,0 +1,169 @@
+package eventsserver
+
+import (
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// EventKindActivity an event about the status of a whole pipeline activity
+	EventKindActivity = "Activity"
+	// EventKindStage an event about the status of a stage, promote or preview step of a pipeline
+	EventKindStage = "Stage"
+	// EventKindStep an event about the status of a step within a stage
+	EventKindStep = "Step"
+
+	// EventActionAdded the pipeline activity was created
+	EventActionAdded = "Added"
+	// EventActionUpdated the status changed
+	EventActionUpdated = "Updated"
+	// EventActionDeleted the pipeline activity was removed
+	EventActionDeleted = "Deleted"
+)
+
+// ActivityEvent is a status change of a pipeline activity, one of its stages or one of their steps
+type ActivityEvent struct {
+	Kind               string                  `json:"kind"`
+	Action             string                  `json:"action"`
+	Activity           string                  `json:"activity"`
+	Pipeline           string                  `json:"pipeline,omitempty"`
+	Build              string                  `json:"build,omitempty"`
+	Owner              string                  `json:"owner,omitempty"`
+	Repository         string                  `json:"repository,omitempty"`
+	Branch             string                  `json:"branch,omitempty"`
+	StepKind           v1.ActivityStepKindType `json:"stepKind,omitempty"`
+	Stage              string                  `json:"stage,omitempty"`
+	Step               string                  `json:"step,omitempty"`
+	Status             v1.ActivityStatusType   `json:"status,omitempty"`
+	StartedTimestamp   *metav1.Time            `json:"startedTimestamp,omitempty"`
+	CompletedTimestamp *metav1.Time            `json:"completedTimestamp,omitempty"`
+}
+
+// Filter matches events for a particular owner, repository and branch. Blank fields match everything
+type Filter struct {
+	Owner      string
+	Repository string
+	Branch     string
+}
+
+// Matches returns true if the event matches the filter
+func (f *Filter) Matches(event *ActivityEvent) bool {
+	if f == nil {
+		return true
+	}
+	return matchesValue(f.Owner, event.Owner) && matchesValue(f.Repository, event.Repository) && matchesValue(f.Branch, event.Branch)
+}
+
+func matchesValue(filter string, value string) bool {
+	return filter == "" || filter == value
+}
+
+// ActivityEvents returns the events describing the status changes between the old and new versions
+// of a pipeline activity. The old activity is nil when it was added and the new activity is nil when deleted
+func ActivityEvents(oldActivity *v1.PipelineActivity, newActivity *v1.PipelineActivity) []*ActivityEvent {
+	if newActivity == nil {
+		if oldActivity == nil {
+			return nil
+		}
+		event := activityEvent(oldActivity.DeepCopy(), EventActionDeleted)
+		return []*ActivityEvent{event}
+	}
+	activity := newActivity.DeepCopy()
+	answer := []*ActivityEvent{}
+	if oldActivity == nil {
+		answer = append(answer, activityEvent(activity, EventActionAdded))
+	} else if oldActivity.Spec.Status != activity.Spec.Status {
+		answer = append(answer, activityEvent(activity, EventActionUpdated))
+	}
+
+	oldSteps := map[string]*v1.CoreActivityStep{}
+	if oldActivity != nil {
+		for i := range oldActivity.Spec.Steps {
+			step := &oldActivity.Spec.Steps[i]
+			core := coreStep(step)
+			if core == nil {
+				continue
+			}
+			oldSteps[core.Name] = core
+			if step.Stage != nil {
+				for j := range step.Stage.Steps {
+					s := &step.Stage.Steps[j]
+					oldSteps[core.Name+"/"+s.Name] = s
+				}
+			}
+		}
+	}
+
+	for i := range activity.Spec.Steps {
+		step := &activity.Spec.Steps[i]
+		core := coreStep(step)
+		if core == nil {
+			continue
+		}
+		if statusChanged(oldSteps[core.Name], core) {
+			event := activityEvent(activity, EventActionUpdated)
+			event.Kind = EventKindStage
+			event.StepKind = step.Kind
+			populateStep(event, core)
+			event.Stage = core.Name
+			answer = append(answer, event)
+		}
+		if step.Stage != nil {
+			for j := range step.Stage.Steps {
+				s := &step.Stage.Steps[j]
+				if statusChanged(oldSteps[core.Name+"/"+s.Name], s) {
+					event := activityEvent(activity, EventActionUpdated)
+					event.Kind = EventKindStep
+					event.StepKind = step.Kind
+					populateStep(event, s)
+					event.Stage = core.Name
+					event.Step = s.Name
+					answer = append(answer, event)
+				}
+			}
+		}
+	}
+	return answer
+}
+
+func activityEvent(activity *v1.PipelineActivity, action string) *ActivityEvent {
+	spec := &activity.Spec
+	return &ActivityEvent{
+		Kind:               EventKindActivity,
+		Action:             action,
+		Activity:           activity.Name,
+		Pipeline:           spec.Pipeline,
+		Build:              spec.Build,
+		Owner:              activity.RepositoryOwner(),
+		Repository:         activity.RepositoryName(),
+		Branch:             activity.BranchName(),
+		Status:             spec.Status,
+		StartedTimestamp:   spec.StartedTimestamp,
+		CompletedTimestamp: spec.CompletedTimestamp,
+	}
+}
+
+func populateStep(event *ActivityEvent, step *v1.CoreActivityStep) {
+	event.Status = step.Status
+	event.StartedTimestamp = step.StartedTimestamp
+	event.CompletedTimestamp = step.CompletedTimestamp
+}
+
+func statusChanged(oldStep *v1.CoreActivityStep, newStep *v1.CoreActivityStep) bool {
+	if oldStep == nil {
+		return newStep.Status != v1.ActivityStatusTypeNone
+	}
+	return oldStep.Status != newStep.Status
+}
+
+func coreStep(step *v1.PipelineActivityStep) *v1.CoreActivityStep {
+	switch {
+	case step.Stage != nil:
+		return &step.Stage.CoreActivityStep
+	case step.Promote != nil:
+		return &step.Promote.CoreActivityStep
+	case step.Preview != nil:
+		return &step.Preview.CoreActivityStep
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package eventsserver_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/eventsserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestActivityEventsForStageAndStepChanges(t *testing.T) {
+	t.Parallel()
+	oldActivity := createActivity(v1.ActivityStatusTypeRunning, v1.ActivityStatusTypeRunning, v1.ActivityStatusTypeRunning)
+	newActivity := createActivity(v1.ActivityStatusTypeRunning, v1.ActivityStatusTypeRunning, v1.ActivityStatusTypeSucceeded)
+
+	events := eventsserver.ActivityEvents(oldActivity, newActivity)
+	require.Len(t, events, 1)
+	event := events[0]
+	assert.Equal(t, eventsserver.EventKindStep, event.Kind)
+	assert.Equal(t, "build", event.Stage)
+	assert.Equal(t, "compile", event.Step)
+	assert.Equal(t, v1.ActivityStatusTypeSucceeded, event.Status)
+	assert.Equal(t, "myorg", event.Owner)
+	assert.Equal(t, "myapp", event.Repository)
+	assert.Equal(t, "master", event.Branch)
+
+	oldActivity = newActivity
+	newActivity = createActivity(v1.ActivityStatusTypeSucceeded, v1.ActivityStatusTypeSucceeded, v1.ActivityStatusTypeSucceeded)
+	events = eventsserver.ActivityEvents(oldActivity, newActivity)
+	require.Len(t, events, 2)
+	assert.Equal(t, eventsserver.EventKindActivity, events[0].Kind)
+	assert.Equal(t, eventsserver.EventKindStage, events[1].Kind)
+
+	events = eventsserver.ActivityEvents(newActivity, nil)
+	require.Len(t, events, 1)
+	assert.Equal(t, eventsserver.EventActionDeleted, events[0].Action)
+}
+
+func TestServerStreamsFilteredEventsOverSSE(t *testing.T) {
+	t.Parallel()
+	server := eventsserver.NewServer("", 0, nil, nil)
+	httpServer := httptest.NewServer(server.Handler())
+	defer httpServer.Close()
+
+	resp, err := http.Get(httpServer.URL + eventsserver.EventsPath + "?owner=myorg&repo=myapp")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	other := createActivity(v1.ActivityStatusTypeRunning, v1.ActivityStatusTypeRunning, v1.ActivityStatusTypeRunning)
+	other.Spec.Pipeline = "myorg/other/master"
+	other.Spec.GitRepository = ""
+	server.OnPipelineActivity(nil, other)
+	server.OnPipelineActivity(nil, createActivity(v1.ActivityStatusTypeRunning, v1.ActivityStatusTypeNone, v1.ActivityStatusTypeNone))
+
+	lines := make(chan string, 100)
+	go func() {
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+		close(lines)
+	}()
+
+	var event eventsserver.ActivityEvent
+	timeout := time.After(10 * time.Second)
+	for {
+		select {
+		case line, ok := <-lines:
+			require.True(t, ok, "the event stream was closed")
+			if !strings.HasPrefix(line, "data: ") {
+				continue
+			}
+			err = json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &event)
+			require.NoError(t, err)
+			assert.Equal(t, "myapp", event.Repository)
+			assert.Equal(t, eventsserver.EventActionAdded, event.Action)
+			return
+		case <-timeout:
+			t.Fatal("timed out waiting for an event")
+		}
+	}
+}
+
+func TestServerChecksTheOriginOfWebSockets(t *testing.T) {
+	t.Parallel()
+	server := eventsserver.NewServer("", 0, nil, []string{"https://dashboard.example.com"})
+	httpServer := httptest.NewServer(server.Handler())
+	defer httpServer.Close()
+	wsURL := "ws" + strings.TrimPrefix(httpServer.URL, "http") + eventsserver.WebSocketPath
+
+	origins := map[string]int{
+		"":                               http.StatusSwitchingProtocols,
+		httpServer.URL:                   http.StatusSwitchingProtocols,
+		"https://dashboard.example.com":  http.StatusSwitchingProtocols,
+		"https://evil.example.com":       http.StatusForbidden,
+		"https://dashboard.example.com.": http.StatusForbidden,
+	}
+	for origin, expected := range origins {
+		header := http.Header{}
+		if origin != "" {
+			header.Set("Origin", origin)
+		}
+		conn, resp, err := websocket.DefaultDialer.Dial(wsURL, header)
+		if conn != nil {
+			conn.Close()
+		}
+		if expected == http.StatusSwitchingProtocols {
+			assert.NoError(t, err, "origin %s should be allowed", origin)
+		} else {
+			assert.Error(t, err, "origin %s should be rejected", origin)
+		}
+		if assert.NotNil(t, resp, "origin %s", origin) {
+			assert.Equal(t, expected, resp.StatusCode, "origin %s", origin)
+		}
+	}
+}
+
+func createActivity(status v1.ActivityStatusType, stageStatus v1.ActivityStatusType, stepStatus v1.ActivityStatusType) *v1.PipelineActivity {
+	return &v1.PipelineActivity{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "myorg-myapp-master-1",
+		},
+		Spec: v1.PipelineActivitySpec{
+			Pipeline:      "myorg/myapp/master",
+			Build:         "1",
+			GitOwner:      "myorg",
+			GitRepository: "myapp",
+			Status:        status,
+			Steps: []v1.PipelineActivityStep{
+				{
+					Kind: v1.ActivityStepKindTypeStage,
+					Stage: &v1.StageActivityStep{
+						CoreActivityStep: v1.CoreActivityStep{
+							Name:   "build",
+							Status: stageStatus,
+						},
+						Steps: []v1.CoreActivityStep{
+							{
+								Name:   "compile",
+								Status: stepStatus,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
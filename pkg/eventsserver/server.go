@@ -0,0 +1,251 @@
+// Package eventsserver streams PipelineActivity status changes to HTTP clients
+// using Server-Sent Events or WebSockets.
+package eventsserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/log"
+)
+
+const (
+	// EventsPath is the URL path for the Server-Sent Events stream
+	EventsPath = "/events"
+	// WebSocketPath is the URL path for the WebSocket stream
+	WebSocketPath = "/ws"
+	// HealthPath is the URL path for the HTTP endpoint that returns health status.
+	HealthPath = "/health"
+	// ReadyPath URL path for the HTTP endpoint that returns ready status.
+	ReadyPath = "/ready"
+
+	subscriberBufferSize = 100
+	keepAliveInterval    = 30 * time.Second
+)
+
+// Subscription receives the events matching its filter
+type Subscription struct {
+	Filter Filter
+	Events chan *ActivityEvent
+}
+
+// Server is an HTTP server streaming pipeline activity events to subscribers
+type Server struct {
+	bindAddress    string
+	port           int
+	ready          func() bool
+	allowedOrigins []string
+
+	lock          sync.RWMutex
+	subscriptions map[*Subscription]bool
+	upgrader      websocket.Upgrader
+}
+
+// NewServer creates a new events server.
+// Use 'bindAddress' to control the address/interface the HTTP service will listen on; to listen on all interfaces
+// (i.e. 0.0.0.0 or ::) provide a blank string.
+// Use 'allowedOrigins' to allow web pages from other origins, such as https://dashboard.example.com, to connect to the
+// WebSocket stream; pages served from the same host are always allowed and "*" allows any origin.
+func NewServer(bindAddress string, port int, ready func() bool, allowedOrigins []string) *Server {
+	s := &Server{
+		bindAddress:    bindAddress,
+		port:           port,
+		ready:          ready,
+		allowedOrigins: allowedOrigins,
+		subscriptions:  map[*Subscription]bool{},
+	}
+	s.upgrader = websocket.Upgrader{
+		CheckOrigin: s.checkOrigin,
+	}
+	return s
+}
+
+// OnPipelineActivity publishes the events for the change in the given pipeline activity.
+// It can be registered as a listener on a kube.PipelineNamespaceCache
+func (s *Server) OnPipelineActivity(oldActivity *v1.PipelineActivity, newActivity *v1.PipelineActivity) {
+	s.Publish(ActivityEvents(oldActivity, newActivity)...)
+}
+
+// Publish sends the events to all matching subscribers. Events are dropped for subscribers which are not keeping up
+func (s *Server) Publish(events ...*ActivityEvent) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	for _, event := range events {
+		for subscription := range s.subscriptions {
+			if !subscription.Filter.Matches(event) {
+				continue
+			}
+			select {
+			case subscription.Events <- event:
+			default:
+				log.Warnf("Dropping %s event for activity %s as the subscriber is not keeping up\n", event.Kind, event.Activity)
+			}
+		}
+	}
+}
+
+// Subscribe registers a new subscription for the events matching the given filter
+func (s *Server) Subscribe(filter Filter) *Subscription {
+	subscription := &Subscription{
+		Filter: filter,
+		Events: make(chan *ActivityEvent, subscriberBufferSize),
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.subscriptions[subscription] = true
+	return subscription
+}
+
+// Unsubscribe removes the subscription
+func (s *Server) Unsubscribe(subscription *Subscription) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.subscriptions, subscription)
+}
+
+// Handler returns the HTTP handler for the server
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle(EventsPath, http.HandlerFunc(s.serveSSE))
+	mux.Handle(WebSocketPath, http.HandlerFunc(s.serveWebSocket))
+	mux.Handle(HealthPath, http.HandlerFunc(s.health))
+	mux.Handle(ReadyPath, http.HandlerFunc(s.readyCheck))
+	return mux
+}
+
+// Start the HTTP server.
+// This call will block until the server exits.
+func (s *Server) Start() error {
+	log.Infof("Serving pipeline events at http://%s:%d%s and ws://%s:%d%s\n", s.bindAddress, s.port, EventsPath, s.bindAddress, s.port, WebSocketPath)
+	return http.ListenAndServe(s.bindAddress+":"+strconv.Itoa(s.port), s.Handler())
+}
+
+// FilterFromRequest creates a filter from the 'owner', 'repo' and 'branch' query parameters
+func FilterFromRequest(r *http.Request) Filter {
+	query := r.URL.Query()
+	return Filter{
+		Owner:      query.Get("owner"),
+		Repository: query.Get("repo"),
+		Branch:     query.Get("branch"),
+	}
+}
+
+func (s *Server) serveSSE(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+	subscription := s.Subscribe(FilterFromRequest(r))
+	defer s.Unsubscribe(subscription)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ticker := time.NewTicker(keepAliveInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+			flusher.Flush()
+		case event := <-subscription.Events:
+			data, err := json.Marshal(event)
+			if err != nil {
+				log.Warnf("Failed to marshal event for activity %s: %s\n", event.Activity, err)
+				continue
+			}
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Kind, data)
+			flusher.Flush()
+		}
+	}
+}
+
+func (s *Server) serveWebSocket(w http.ResponseWriter, r *http.Request) {
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Warnf("Failed to upgrade the connection from %s to a WebSocket: %s\n", r.RemoteAddr, err)
+		return
+	}
+	defer conn.Close()
+
+	subscription := s.Subscribe(FilterFromRequest(r))
+	defer s.Unsubscribe(subscription)
+
+	// lets detect when the client goes away
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(keepAliveInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-closed:
+			return
+		case <-ticker.C:
+			err = conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(10*time.Second))
+			if err != nil {
+				return
+			}
+		case event := <-subscription.Events:
+			err = conn.WriteJSON(event)
+			if err != nil {
+				log.Warnf("Failed to write event to WebSocket %s: %s\n", r.RemoteAddr, err)
+				return
+			}
+		}
+	}
+}
+
+// checkOrigin returns true if the WebSocket request has no Origin header, as sent by clients which are not browsers,
+// or its origin is the host of the server or one of the allowed origins
+func (s *Server) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err == nil && strings.EqualFold(u.Host, r.Host) {
+		return true
+	}
+	for _, allowed := range s.allowedOrigins {
+		if allowed == "*" || strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// health returns HTTP 204 if the server is healthy
+func (s *Server) health(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// readyCheck returns HTTP 204 if the pipeline cache is in sync, otherwise HTTP 503
+func (s *Server) readyCheck(w http.ResponseWriter, r *http.Request) {
+	if s.ready == nil || s.ready() {
+		w.WriteHeader(http.StatusNoContent)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+}
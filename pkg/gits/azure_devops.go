@@ -0,0 +1,1204 @@
+package gits
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/github"
+	"github.com/jenkins-x/jx/pkg/auth"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const (
+	// AzureDevOpsProfileURL the URL of the profile service of Azure DevOps Services used to find the organisations of a user
+	AzureDevOpsProfileURL = "https://app.vssps.visualstudio.com"
+
+	azureDevOpsAPIVersion       = "5.0"
+	azureDevOpsStatusGenre      = "jenkins-x"
+	azureDevOpsHookPublisher    = "tfs"
+	azureDevOpsHookConsumer     = "webHooks"
+	azureDevOpsHookAction       = "httpRequest"
+	azureDevOpsSecretHeader     = "X-Jenkins-X-Secret"
+	azureDevOpsPullRequestOpen  = "active"
+	azureDevOpsPullRequestMerge = "completed"
+	azureDevOpsRefsHeads        = "refs/heads/"
+)
+
+var (
+	// azureDevOpsHookEvents the service hook events a webhook subscribes to
+	azureDevOpsHookEvents = []string{"git.push", "git.pullrequest.created", "git.pullrequest.updated"}
+
+	azureDevOpsCommitSHARegex = regexp.MustCompile(`^[0-9a-f]{40}$`)
+)
+
+// AzureDevOpsProvider implements GitProvider for Azure DevOps Services and Azure DevOps Server using the REST API.
+//
+// The server URL is https://dev.azure.com for Azure DevOps Services or the URL of the server, such as
+// https://tfs.acme.com, for Azure DevOps Server. The organisation is the Azure DevOps organisation or the path
+// of the collection such as tfs/DefaultCollection. Repository names can be prefixed with the project name
+// as 'project/repo', otherwise the repository is found by name in the organisation
+type AzureDevOpsProvider struct {
+	Username   string
+	Server     auth.AuthServer
+	User       auth.UserAuth
+	Git        Gitter
+	Client     *http.Client
+	ProfileURL string
+}
+
+type azureDevOpsList struct {
+	Count int             `json:"count"`
+	Value json.RawMessage `json:"value"`
+}
+
+type azureDevOpsProject struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+type azureDevOpsRepository struct {
+	ID            string             `json:"id,omitempty"`
+	Name          string             `json:"name,omitempty"`
+	Project       azureDevOpsProject `json:"project,omitempty"`
+	DefaultBranch string             `json:"defaultBranch,omitempty"`
+	RemoteURL     string             `json:"remoteUrl,omitempty"`
+	SSHURL        string             `json:"sshUrl,omitempty"`
+	WebURL        string             `json:"webUrl,omitempty"`
+	IsFork        bool               `json:"isFork,omitempty"`
+}
+
+type azureDevOpsIdentity struct {
+	ID          string `json:"id,omitempty"`
+	DisplayName string `json:"displayName,omitempty"`
+	UniqueName  string `json:"uniqueName,omitempty"`
+	ImageURL    string `json:"imageUrl,omitempty"`
+}
+
+type azureDevOpsReviewer struct {
+	azureDevOpsIdentity
+	Vote int `json:"vote"`
+}
+
+type azureDevOpsCommitRef struct {
+	CommitID string `json:"commitId"`
+}
+
+type azureDevOpsPullRequest struct {
+	PullRequestID         int                   `json:"pullRequestId,omitempty"`
+	Repository            azureDevOpsRepository `json:"repository,omitempty"`
+	Status                string                `json:"status,omitempty"`
+	CreatedBy             azureDevOpsIdentity   `json:"createdBy,omitempty"`
+	CreationDate          *time.Time            `json:"creationDate,omitempty"`
+	ClosedDate            *time.Time            `json:"closedDate,omitempty"`
+	Title                 string                `json:"title,omitempty"`
+	Description           string                `json:"description,omitempty"`
+	SourceRefName         string                `json:"sourceRefName,omitempty"`
+	TargetRefName         string                `json:"targetRefName,omitempty"`
+	MergeStatus           string                `json:"mergeStatus,omitempty"`
+	LastMergeSourceCommit *azureDevOpsCommitRef `json:"lastMergeSourceCommit,omitempty"`
+	LastMergeCommit       *azureDevOpsCommitRef `json:"lastMergeCommit,omitempty"`
+	CompletionOptions     map[string]string     `json:"completionOptions,omitempty"`
+}
+
+type azureDevOpsGitUser struct {
+	Name  string     `json:"name"`
+	Email string     `json:"email"`
+	Date  *time.Time `json:"date,omitempty"`
+}
+
+type azureDevOpsCommit struct {
+	CommitID  string             `json:"commitId"`
+	Comment   string             `json:"comment"`
+	Author    azureDevOpsGitUser `json:"author"`
+	Committer azureDevOpsGitUser `json:"committer"`
+	RemoteURL string             `json:"remoteUrl"`
+}
+
+type azureDevOpsStatusContext struct {
+	Name  string `json:"name"`
+	Genre string `json:"genre,omitempty"`
+}
+
+type azureDevOpsStatus struct {
+	ID          int                      `json:"id,omitempty"`
+	State       string                   `json:"state"`
+	Description string                   `json:"description,omitempty"`
+	TargetURL   string                   `json:"targetUrl,omitempty"`
+	Context     azureDevOpsStatusContext `json:"context"`
+}
+
+type azureDevOpsItem struct {
+	ObjectID string `json:"objectId"`
+	Path     string `json:"path"`
+	Content  string `json:"content"`
+	URL      string `json:"url"`
+}
+
+type azureDevOpsSubscription struct {
+	ID               string            `json:"id,omitempty"`
+	PublisherID      string            `json:"publisherId"`
+	EventType        string            `json:"eventType"`
+	ResourceVersion  string            `json:"resourceVersion"`
+	ConsumerID       string            `json:"consumerId"`
+	ConsumerActionID string            `json:"consumerActionId"`
+	PublisherInputs  map[string]string `json:"publisherInputs"`
+	ConsumerInputs   map[string]string `json:"consumerInputs"`
+}
+
+type azureDevOpsAccount struct {
+	AccountID   string `json:"accountId"`
+	AccountName string `json:"accountName"`
+}
+
+// NewAzureDevOpsProvider creates a new Azure DevOps provider
+func NewAzureDevOpsProvider(server *auth.AuthServer, user *auth.UserAuth, git Gitter) (GitProvider, error) {
+	if server.URL == "" {
+		return nil, fmt.Errorf("no URL for the Azure DevOps server")
+	}
+	return &AzureDevOpsProvider{
+		Username:   user.Username,
+		Server:     *server,
+		User:       *user,
+		Git:        git,
+		Client:     http.DefaultClient,
+		ProfileURL: AzureDevOpsProfileURL,
+	}, nil
+}
+
+// AzureDevOpsAccessTokenURL returns the URL to click on to generate a personal access token for the Git provider
+func AzureDevOpsAccessTokenURL(url string) string {
+	return util.UrlJoin(url, "_usersSettings/tokens")
+}
+
+// IsAzureDevOpsServices returns true if the provider is for Azure DevOps Services rather than Azure DevOps Server
+func (p *AzureDevOpsProvider) IsAzureDevOpsServices() bool {
+	return SaasGitKind(p.Server.URL) == KindAzureDevOps
+}
+
+func (p *AzureDevOpsProvider) ListOrganisations() ([]GitOrganisation, error) {
+	if !p.IsAzureDevOpsServices() {
+		return nil, fmt.Errorf("listing the collections of Azure DevOps Server %s is not supported", p.Server.URL)
+	}
+	profile := azureDevOpsIdentity{}
+	err := p.do(http.MethodGet, util.UrlJoin(p.ProfileURL, "_apis/profile/profiles/me"), nil, nil, &profile)
+	if err != nil {
+		return nil, err
+	}
+	accounts := []azureDevOpsAccount{}
+	err = p.list(util.UrlJoin(p.ProfileURL, "_apis/accounts"), url.Values{"memberId": []string{profile.ID}}, &accounts)
+	if err != nil {
+		return nil, err
+	}
+	answer := []GitOrganisation{}
+	for _, account := range accounts {
+		answer = append(answer, GitOrganisation{Login: account.AccountName})
+	}
+	return answer, nil
+}
+
+func (p *AzureDevOpsProvider) ListRepositories(org string) ([]*GitRepository, error) {
+	repos, err := p.listRepositories(org)
+	if err != nil {
+		return nil, err
+	}
+	answer := []*GitRepository{}
+	for i := range repos {
+		answer = append(answer, toAzureDevOpsGitRepository(org, &repos[i]))
+	}
+	return answer, nil
+}
+
+// CreateRepository creates the repository in the project of the name, 'project/repo', or in the project with the
+// same name as the repository. Visibility is configured on the project so private is ignored
+func (p *AzureDevOpsProvider) CreateRepository(org string, name string, private bool) (*GitRepository, error) {
+	project, repoName := splitAzureDevOpsRepoName(name)
+	if project == "" {
+		project = repoName
+	}
+	repo := azureDevOpsRepository{}
+	body := map[string]string{"name": repoName}
+	err := p.do(http.MethodPost, p.apiURL(org, project, "_apis/git/repositories"), nil, body, &repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create repository %s in project %s due to: %s", repoName, project, err)
+	}
+	return toAzureDevOpsGitRepository(org, &repo), nil
+}
+
+func (p *AzureDevOpsProvider) GetRepository(org string, name string) (*GitRepository, error) {
+	repo, err := p.findRepository(org, name)
+	if err != nil {
+		return nil, err
+	}
+	return toAzureDevOpsGitRepository(org, repo), nil
+}
+
+func (p *AzureDevOpsProvider) DeleteRepository(org string, name string) error {
+	repo, err := p.findRepository(org, name)
+	if err != nil {
+		return err
+	}
+	return p.do(http.MethodDelete, p.repositoryURL(org, repo), nil, nil, nil)
+}
+
+func (p *AzureDevOpsProvider) ForkRepository(originalOrg string, name string, destinationOrg string) (*GitRepository, error) {
+	return nil, fmt.Errorf("forking repositories is not supported on Azure DevOps")
+}
+
+func (p *AzureDevOpsProvider) RenameRepository(org string, name string, newName string) (*GitRepository, error) {
+	repo, err := p.findRepository(org, name)
+	if err != nil {
+		return nil, err
+	}
+	result := azureDevOpsRepository{}
+	err = p.do(http.MethodPatch, p.repositoryURL(org, repo), nil, map[string]string{"name": newName}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return toAzureDevOpsGitRepository(org, &result), nil
+}
+
+func (p *AzureDevOpsProvider) ValidateRepositoryName(org string, name string) error {
+	repos, err := p.listRepositories(org)
+	if err != nil {
+		return err
+	}
+	if findAzureDevOpsRepository(repos, name) != nil {
+		return fmt.Errorf("repository %s already exists", p.Git.RepoName(org, name))
+	}
+	return nil
+}
+
+func (p *AzureDevOpsProvider) CreatePullRequest(data *GitPullRequestArguments) (*GitPullRequest, error) {
+	gitRepo := data.GitRepository
+	if gitRepo == nil {
+		return nil, fmt.Errorf("missing property GitRepository")
+	}
+	org := gitRepo.Organisation
+	repo, err := p.findRepository(org, azureDevOpsRepoName(gitRepo))
+	if err != nil {
+		return nil, err
+	}
+	body := &azureDevOpsPullRequest{
+		Title:         data.Title,
+		Description:   data.Body,
+		SourceRefName: azureDevOpsRef(data.Head),
+		TargetRefName: azureDevOpsRef(data.Base),
+	}
+	pr := azureDevOpsPullRequest{}
+	err = p.do(http.MethodPost, util.UrlJoin(p.repositoryURL(org, repo), "pullrequests"), nil, body, &pr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pull request from %s to %s due to: %s", data.Head, data.Base, err)
+	}
+	return toAzureDevOpsPullRequest(org, &pr), nil
+}
+
+func (p *AzureDevOpsProvider) UpdatePullRequestStatus(pr *GitPullRequest) error {
+	if pr.Number == nil {
+		return fmt.Errorf("missing Number for pull request %#v", pr)
+	}
+	result, err := p.getPullRequest(pr.Owner, *pr.Number)
+	if err != nil {
+		return err
+	}
+	*pr = *toAzureDevOpsPullRequest(pr.Owner, result)
+	return nil
+}
+
+func (p *AzureDevOpsProvider) GetPullRequest(owner string, repo *GitRepository, number int) (*GitPullRequest, error) {
+	pr, err := p.getPullRequest(owner, number)
+	if err != nil {
+		return nil, err
+	}
+	return toAzureDevOpsPullRequest(owner, pr), nil
+}
+
+// ListOpenPullRequests lists the active pull requests
+func (p *AzureDevOpsProvider) ListOpenPullRequests(owner string, repo string) ([]*GitPullRequest, error) {
+	r, err := p.findRepository(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	prs := []azureDevOpsPullRequest{}
+	params := url.Values{"searchCriteria.status": []string{azureDevOpsPullRequestOpen}}
+	err = p.list(util.UrlJoin(p.repositoryURL(owner, r), "pullrequests"), params, &prs)
+	if err != nil {
+		return nil, err
+	}
+	answer := []*GitPullRequest{}
+	for i := range prs {
+		answer = append(answer, toAzureDevOpsPullRequest(owner, &prs[i]))
+	}
+	return answer, nil
+}
+
+func (p *AzureDevOpsProvider) GetPullRequestCommits(owner string, repo *GitRepository, number int) ([]*GitCommit, error) {
+	pr, err := p.getPullRequest(owner, number)
+	if err != nil {
+		return nil, err
+	}
+	commits := []azureDevOpsCommit{}
+	u := util.UrlJoin(p.repositoryURL(owner, &pr.Repository), "pullRequests", strconv.Itoa(number), "commits")
+	err = p.list(u, nil, &commits)
+	if err != nil {
+		return nil, err
+	}
+	answer := []*GitCommit{}
+	for i := range commits {
+		answer = append(answer, toAzureDevOpsCommit(&commits[i]))
+	}
+	return answer, nil
+}
+
+// PullRequestLastCommitStatus returns the combined state of the statuses of the last commit of the pull request
+func (p *AzureDevOpsProvider) PullRequestLastCommitStatus(pr *GitPullRequest) (string, error) {
+	if pr.LastCommitSha == "" {
+		return "", fmt.Errorf("missing String for LastCommitSha %#v", pr)
+	}
+	statuses, err := p.ListCommitStatus(pr.Owner, pr.Repo, pr.LastCommitSha)
+	if err != nil {
+		return "", err
+	}
+	if len(statuses) == 0 {
+		return "", fmt.Errorf("could not find a status for repository %s/%s with ref %s", pr.Owner, pr.Repo, pr.LastCommitSha)
+	}
+	state := "success"
+	for _, status := range statuses {
+		if status.IsFailed() {
+			return status.State, nil
+		}
+		if !status.IsSuccess() {
+			state = "pending"
+		}
+	}
+	return state, nil
+}
+
+// ListCommitStatus lists the latest status of each context of the commit
+func (p *AzureDevOpsProvider) ListCommitStatus(org string, repo string, sha string) ([]*GitRepoStatus, error) {
+	r, err := p.findRepository(org, repo)
+	if err != nil {
+		return nil, err
+	}
+	statuses := []azureDevOpsStatus{}
+	u := util.UrlJoin(p.repositoryURL(org, r), "commits", sha, "statuses")
+	err = p.list(u, url.Values{"latestOnly": []string{"true"}}, &statuses)
+	if err != nil {
+		return nil, err
+	}
+	answer := []*GitRepoStatus{}
+	for _, status := range statuses {
+		answer = append(answer, &GitRepoStatus{
+			ID:          strconv.Itoa(status.ID),
+			Context:     status.Context.Name,
+			URL:         status.TargetURL,
+			TargetURL:   status.TargetURL,
+			State:       fromAzureDevOpsState(status.State),
+			Description: status.Description,
+		})
+	}
+	return answer, nil
+}
+
+func (p *AzureDevOpsProvider) ListCommits(owner string, repo string, opt *ListCommitsArguments) ([]*GitCommit, error) {
+	r, err := p.findRepository(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	params := url.Values{}
+	if opt.SHA != "" {
+		params.Set("searchCriteria.itemVersion.version", opt.SHA)
+		params.Set("searchCriteria.itemVersion.versionType", azureDevOpsVersionType(opt.SHA))
+	}
+	if opt.Path != "" {
+		params.Set("searchCriteria.itemPath", opt.Path)
+	}
+	if opt.Author != "" {
+		params.Set("searchCriteria.author", opt.Author)
+	}
+	if !opt.Since.IsZero() {
+		params.Set("searchCriteria.fromDate", opt.Since.UTC().Format(time.RFC3339))
+	}
+	if !opt.Until.IsZero() {
+		params.Set("searchCriteria.toDate", opt.Until.UTC().Format(time.RFC3339))
+	}
+	if opt.PerPage > 0 {
+		params.Set("searchCriteria.$top", strconv.Itoa(opt.PerPage))
+		if opt.Page > 1 {
+			params.Set("searchCriteria.$skip", strconv.Itoa((opt.Page-1)*opt.PerPage))
+		}
+	}
+	commits := []azureDevOpsCommit{}
+	err = p.list(util.UrlJoin(p.repositoryURL(owner, r), "commits"), params, &commits)
+	if err != nil {
+		return nil, fmt.Errorf("could not find commits for repository %s/%s due to: %s", owner, repo, err)
+	}
+	var answer []*GitCommit
+	for i := range commits {
+		answer = append(answer, toAzureDevOpsCommit(&commits[i]))
+	}
+	return answer, nil
+}
+
+// UpdateCommitStatus adds a status to the commit
+func (p *AzureDevOpsProvider) UpdateCommitStatus(org string, repo string, sha string, status *GitRepoStatus) (*GitRepoStatus, error) {
+	r, err := p.findRepository(org, repo)
+	if err != nil {
+		return nil, err
+	}
+	body := &azureDevOpsStatus{
+		State:       toAzureDevOpsState(status.State),
+		Description: status.Description,
+		TargetURL:   status.TargetURL,
+		Context: azureDevOpsStatusContext{
+			Name:  status.Context,
+			Genre: azureDevOpsStatusGenre,
+		},
+	}
+	result := azureDevOpsStatus{}
+	err = p.do(http.MethodPost, util.UrlJoin(p.repositoryURL(org, r), "commits", sha, "statuses"), nil, body, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update the status of commit %s due to: %s", sha, err)
+	}
+	return &GitRepoStatus{
+		ID:          strconv.Itoa(result.ID),
+		Context:     result.Context.Name,
+		URL:         result.TargetURL,
+		TargetURL:   result.TargetURL,
+		State:       fromAzureDevOpsState(result.State),
+		Description: result.Description,
+	}, nil
+}
+
+// MergePullRequest completes the pull request
+func (p *AzureDevOpsProvider) MergePullRequest(pr *GitPullRequest, message string) error {
+	if pr.Number == nil {
+		return fmt.Errorf("missing Number for pull request %#v", pr)
+	}
+	result, err := p.getPullRequest(pr.Owner, *pr.Number)
+	if err != nil {
+		return err
+	}
+	body := &azureDevOpsPullRequest{
+		Status:                azureDevOpsPullRequestMerge,
+		LastMergeSourceCommit: result.LastMergeSourceCommit,
+		CompletionOptions: map[string]string{
+			"mergeCommitMessage": message,
+		},
+	}
+	u := util.UrlJoin(p.repositoryURL(pr.Owner, &result.Repository), "pullrequests", strconv.Itoa(*pr.Number))
+	return p.do(http.MethodPatch, u, nil, body, nil)
+}
+
+// CreateWebHook creates service hook subscriptions for the push and pull request events of the repository
+func (p *AzureDevOpsProvider) CreateWebHook(data *GitWebHookArguments) error {
+	if data.Repo == nil {
+		return fmt.Errorf("missing property Repo")
+	}
+	org := data.Owner
+	repo, err := p.findRepository(org, azureDevOpsRepoName(data.Repo))
+	if err != nil {
+		return err
+	}
+	subscriptions, err := p.listSubscriptions(org, repo)
+	if err != nil {
+		return err
+	}
+	for _, s := range subscriptions {
+		if s.ConsumerInputs["url"] == data.URL {
+			log.Infof("Already has a webhook registered for %s\n", data.URL)
+			return nil
+		}
+	}
+	for _, event := range azureDevOpsHookEvents {
+		subscription := &azureDevOpsSubscription{
+			PublisherID:      azureDevOpsHookPublisher,
+			EventType:        event,
+			ResourceVersion:  "1.0",
+			ConsumerID:       azureDevOpsHookConsumer,
+			ConsumerActionID: azureDevOpsHookAction,
+			PublisherInputs: map[string]string{
+				"projectId":  repo.Project.ID,
+				"repository": repo.ID,
+			},
+			ConsumerInputs: azureDevOpsConsumerInputs(data.URL, data.Secret),
+		}
+		err = p.do(http.MethodPost, p.apiURL(org, "", "_apis/hooks/subscriptions"), nil, subscription, nil)
+		if err != nil {
+			return fmt.Errorf("failed to create the %s webhook for %s due to: %s", event, data.URL, err)
+		}
+	}
+	return nil
+}
+
+// ListWebHooks lists the webhooks of the repository. Each webhook is made of a service hook subscription per event
+// so the ID of a webhook is derived from its URL
+func (p *AzureDevOpsProvider) ListWebHooks(org string, repo string) ([]*GitWebHookArguments, error) {
+	r, err := p.findRepository(org, repo)
+	if err != nil {
+		return nil, err
+	}
+	subscriptions, err := p.listSubscriptions(org, r)
+	if err != nil {
+		return nil, err
+	}
+	urls := map[string]string{}
+	for _, s := range subscriptions {
+		u := s.ConsumerInputs["url"]
+		urls[u] = u
+	}
+	answer := []*GitWebHookArguments{}
+	for _, u := range util.SortedMapKeys(urls) {
+		answer = append(answer, &GitWebHookArguments{
+			ID:    azureDevOpsWebHookID(u),
+			Owner: org,
+			URL:   u,
+		})
+	}
+	return answer, nil
+}
+
+// UpdateWebHook updates the subscriptions of the webhook with the ID or the existing URL
+func (p *AzureDevOpsProvider) UpdateWebHook(data *GitWebHookArguments) error {
+	if data.Repo == nil {
+		return fmt.Errorf("missing property Repo")
+	}
+	if data.URL == "" {
+		return fmt.Errorf("missing property URL")
+	}
+	org := data.Owner
+	repo, err := p.findRepository(org, azureDevOpsRepoName(data.Repo))
+	if err != nil {
+		return err
+	}
+	subscriptions, err := p.listSubscriptions(org, repo)
+	if err != nil {
+		return err
+	}
+	updated := 0
+	for i := range subscriptions {
+		s := &subscriptions[i]
+		u := s.ConsumerInputs["url"]
+		if (data.ID != 0 && azureDevOpsWebHookID(u) == data.ID) || (data.ExistingURL != "" && u == data.ExistingURL) {
+			s.ConsumerInputs = azureDevOpsConsumerInputs(data.URL, data.Secret)
+			err = p.do(http.MethodPut, p.apiURL(org, "", "_apis/hooks/subscriptions", s.ID), nil, s, nil)
+			if err != nil {
+				return fmt.Errorf("failed to update the %s webhook %s due to: %s", s.EventType, u, err)
+			}
+			updated++
+		}
+	}
+	if updated == 0 {
+		log.Warnf("No webhooks found to update for %s/%s\n", org, repo.Name)
+	}
+	return nil
+}
+
+// GetBranchProtection is not supported on Azure DevOps
+func (p *AzureDevOpsProvider) GetBranchProtection(org string, repo string, branch string) (*GitBranchProtection, error) {
+	return nil, fmt.Errorf("branch protection is not supported by Azure DevOps")
+}
+
+// UpdateBranchProtection is not supported on Azure DevOps
+func (p *AzureDevOpsProvider) UpdateBranchProtection(org string, repo string, branch string, protection *GitBranchProtection) error {
+	return fmt.Errorf("branch protection is not supported by Azure DevOps")
+}
+
+// RemoveBranchProtection is not supported on Azure DevOps
+func (p *AzureDevOpsProvider) RemoveBranchProtection(org string, repo string, branch string) error {
+	return fmt.Errorf("branch protection is not supported by Azure DevOps")
+}
+
+// GetRateLimits returns no rate limits as Azure DevOps only reports them once requests are being delayed
+func (p *AzureDevOpsProvider) GetRateLimits() ([]*GitRateLimit, error) {
+	return []*GitRateLimit{}, nil
+}
+
+// DecorateTransport decorates the HTTP transport used to access the Azure DevOps API
+func (p *AzureDevOpsProvider) DecorateTransport(decorate func(http.RoundTripper) http.RoundTripper) {
+	var base http.RoundTripper
+	if p.Client != nil {
+		base = p.Client.Transport
+	}
+	// lets not modify a client which may be shared such as http.DefaultClient
+	p.Client = &http.Client{Transport: decorate(base)}
+}
+
+func (p *AzureDevOpsProvider) IsGitHub() bool {
+	return false
+}
+
+func (p *AzureDevOpsProvider) IsGitea() bool {
+	return false
+}
+
+func (p *AzureDevOpsProvider) IsBitbucketCloud() bool {
+	return false
+}
+
+func (p *AzureDevOpsProvider) IsBitbucketServer() bool {
+	return false
+}
+
+func (p *AzureDevOpsProvider) IsGerrit() bool {
+	return false
+}
+
+func (p *AzureDevOpsProvider) Kind() string {
+	return KindAzureDevOps
+}
+
+func (p *AzureDevOpsProvider) GetIssue(org string, name string, number int) (*GitIssue, error) {
+	log.Warn("Azure DevOps work items are not supported\n")
+	return nil, nil
+}
+
+func (p *AzureDevOpsProvider) IssueURL(org string, name string, number int, isPull bool) string {
+	if isPull {
+		repo, err := p.findRepository(org, name)
+		if err != nil {
+			return ""
+		}
+		return util.UrlJoin(repo.WebURL, "pullrequest", strconv.Itoa(number))
+	}
+	return ""
+}
+
+func (p *AzureDevOpsProvider) SearchIssues(org string, name string, query string) ([]*GitIssue, error) {
+	log.Warn("Azure DevOps work items are not supported\n")
+	return nil, nil
+}
+
+func (p *AzureDevOpsProvider) SearchIssuesClosedSince(org string, name string, t time.Time) ([]*GitIssue, error) {
+	log.Warn("Azure DevOps work items are not supported\n")
+	return nil, nil
+}
+
+func (p *AzureDevOpsProvider) CreateIssue(owner string, repo string, issue *GitIssue) (*GitIssue, error) {
+	log.Warn("Azure DevOps work items are not supported\n")
+	return nil, nil
+}
+
+func (p *AzureDevOpsProvider) HasIssues() bool {
+	return false
+}
+
+// AddPRComment adds a comment thread to the pull request
+func (p *AzureDevOpsProvider) AddPRComment(pr *GitPullRequest, comment string) error {
+	if pr.Number == nil {
+		return fmt.Errorf("missing Number for pull request %#v", pr)
+	}
+	result, err := p.getPullRequest(pr.Owner, *pr.Number)
+	if err != nil {
+		return err
+	}
+	body := map[string]interface{}{
+		"comments": []map[string]interface{}{
+			{
+				"parentCommentId": 0,
+				"content":         comment,
+				"commentType":     1,
+			},
+		},
+		"status": 1,
+	}
+	u := util.UrlJoin(p.repositoryURL(pr.Owner, &result.Repository), "pullRequests", strconv.Itoa(*pr.Number), "threads")
+	return p.do(http.MethodPost, u, nil, body, nil)
+}
+
+// RequestPRReviewers is not supported as Azure DevOps reviewers are identified by their identity IDs
+func (p *AzureDevOpsProvider) RequestPRReviewers(pr *GitPullRequest, reviewers []string) error {
+	return fmt.Errorf("requesting pull request reviewers is not supported on Azure DevOps")
+}
+
+// ListPRReviews returns the votes of the reviewers of the pull request as reviews
+func (p *AzureDevOpsProvider) ListPRReviews(pr *GitPullRequest) ([]*GitReview, error) {
+	if pr.Number == nil {
+		return nil, fmt.Errorf("missing Number for pull request %#v", pr)
+	}
+	result, err := p.getPullRequest(pr.Owner, *pr.Number)
+	if err != nil {
+		return nil, err
+	}
+	reviewers := []azureDevOpsReviewer{}
+	u := util.UrlJoin(p.repositoryURL(pr.Owner, &result.Repository), "pullRequests", strconv.Itoa(*pr.Number), "reviewers")
+	err = p.list(u, nil, &reviewers)
+	if err != nil {
+		return nil, err
+	}
+	answer := []*GitReview{}
+	for _, reviewer := range reviewers {
+		state := ""
+		switch {
+		case reviewer.Vote > 0:
+			state = ReviewStateApproved
+		case reviewer.Vote < 0:
+			state = ReviewStateChangesRequested
+		default:
+			continue
+		}
+		answer = append(answer, &GitReview{
+			User: &GitUser{
+				Login:     reviewer.UniqueName,
+				Name:      reviewer.DisplayName,
+				AvatarURL: reviewer.ImageURL,
+			},
+			State: state,
+		})
+	}
+	return answer, nil
+}
+
+// AddPRLabels adds the labels, which Azure DevOps calls tags, to the pull request
+func (p *AzureDevOpsProvider) AddPRLabels(pr *GitPullRequest, labels []string) error {
+	if pr.Number == nil {
+		return fmt.Errorf("missing Number for pull request %#v", pr)
+	}
+	result, err := p.getPullRequest(pr.Owner, *pr.Number)
+	if err != nil {
+		return err
+	}
+	u := util.UrlJoin(p.repositoryURL(pr.Owner, &result.Repository), "pullRequests", strconv.Itoa(*pr.Number), "labels")
+	for _, label := range labels {
+		err = p.do(http.MethodPost, u, nil, map[string]string{"name": label}, nil)
+		if err != nil {
+			return fmt.Errorf("failed to add label %s to pull request %d due to: %s", label, *pr.Number, err)
+		}
+	}
+	return nil
+}
+
+// RemovePRLabel removes the label from the pull request
+func (p *AzureDevOpsProvider) RemovePRLabel(pr *GitPullRequest, label string) error {
+	if pr.Number == nil {
+		return fmt.Errorf("missing Number for pull request %#v", pr)
+	}
+	result, err := p.getPullRequest(pr.Owner, *pr.Number)
+	if err != nil {
+		return err
+	}
+	u := util.UrlJoin(p.repositoryURL(pr.Owner, &result.Repository), "pullRequests", strconv.Itoa(*pr.Number), "labels", url.PathEscape(label))
+	return p.do(http.MethodDelete, u, nil, nil, nil)
+}
+
+func (p *AzureDevOpsProvider) CreateIssueComment(owner string, repo string, number int, comment string) error {
+	log.Warn("Azure DevOps work items are not supported\n")
+	return nil
+}
+
+// UpdateRelease is a no-op as Azure DevOps Repos has no releases
+func (p *AzureDevOpsProvider) UpdateRelease(owner string, repo string, tag string, releaseInfo *GitRelease) error {
+	return nil
+}
+
+// UploadReleaseAsset is not supported as Azure DevOps has no releases
+func (p *AzureDevOpsProvider) UploadReleaseAsset(org string, repo string, tag string, name string, asset *os.File) (*GitReleaseAsset, error) {
+	return nil, fmt.Errorf("release assets are not supported by Azure DevOps")
+}
+
+// ListReleases returns no releases as Azure DevOps Repos has no releases
+func (p *AzureDevOpsProvider) ListReleases(org string, name string) ([]*GitRelease, error) {
+	return []*GitRelease{}, nil
+}
+
+// GetContent returns the base64 encoded content of the file at the ref which can be a branch, tag or commit
+func (p *AzureDevOpsProvider) GetContent(org string, name string, path string, ref string) (*GitFileContent, error) {
+	repo, err := p.findRepository(org, name)
+	if err != nil {
+		return nil, err
+	}
+	params := url.Values{
+		"path":           []string{path},
+		"includeContent": []string{"true"},
+		"$format":        []string{"json"},
+	}
+	if ref != "" {
+		params.Set("versionDescriptor.version", ref)
+		params.Set("versionDescriptor.versionType", azureDevOpsVersionType(ref))
+	}
+	item := azureDevOpsItem{}
+	err = p.do(http.MethodGet, util.UrlJoin(p.repositoryURL(org, repo), "items"), params, nil, &item)
+	if err != nil {
+		return nil, err
+	}
+	i := strings.LastIndex(item.Path, "/")
+	return &GitFileContent{
+		Type:     "file",
+		Encoding: "base64",
+		Name:     item.Path[i+1:],
+		Path:     strings.TrimPrefix(item.Path, "/"),
+		Content:  base64.StdEncoding.EncodeToString([]byte(item.Content)),
+		Size:     len(item.Content),
+		Sha:      item.ObjectID,
+		Url:      item.URL,
+	}, nil
+}
+
+func (p *AzureDevOpsProvider) JenkinsWebHookPath(gitURL string, secret string) string {
+	return ""
+}
+
+func (p *AzureDevOpsProvider) Label() string {
+	return p.Server.Label()
+}
+
+func (p *AzureDevOpsProvider) ServerURL() string {
+	return p.Server.URL
+}
+
+func (p *AzureDevOpsProvider) BranchArchiveURL(org string, name string, branch string) string {
+	repo, err := p.findRepository(org, name)
+	if err != nil {
+		return ""
+	}
+	params := url.Values{
+		"path":                          []string{"/"},
+		"versionDescriptor.version":     []string{branch},
+		"versionDescriptor.versionType": []string{"branch"},
+		"$format":                       []string{"zip"},
+		"download":                      []string{"true"},
+		"api-version":                   []string{azureDevOpsAPIVersion},
+	}
+	return util.UrlJoin(p.repositoryURL(org, repo), "items") + "?" + params.Encode()
+}
+
+func (p *AzureDevOpsProvider) CurrentUsername() string {
+	return p.Username
+}
+
+func (p *AzureDevOpsProvider) UserAuth() auth.UserAuth {
+	return p.User
+}
+
+func (p *AzureDevOpsProvider) UserInfo(username string) *GitUser {
+	return &GitUser{
+		Login: username,
+	}
+}
+
+// AddCollaborator adds a collaborator
+func (p *AzureDevOpsProvider) AddCollaborator(user string, organisation string, repo string) error {
+	log.Infof("Automatically adding the pipeline user as a collaborator is currently not implemented for Azure DevOps. Please add user: %v as a contributor to the project of this repository.\n", user)
+	return nil
+}
+
+// ListInvitations lists pending invites
+func (p *AzureDevOpsProvider) ListInvitations() ([]*github.RepositoryInvitation, *github.Response, error) {
+	return []*github.RepositoryInvitation{}, &github.Response{}, nil
+}
+
+// AcceptInvitation accepts an invitation
+func (p *AzureDevOpsProvider) AcceptInvitation(ID int64) (*github.Response, error) {
+	return &github.Response{}, nil
+}
+
+// ShouldForkForPullRequest returns false as pull requests are created from branches of the repository
+func (p *AzureDevOpsProvider) ShouldForkForPullRequest(originalOwner string, repoName string, username string) bool {
+	return false
+}
+
+// apiURL returns the URL of the API path in the organisation and optional project. Legacy
+// https://org.visualstudio.com servers already include the organisation in their host
+func (p *AzureDevOpsProvider) apiURL(org string, project string, paths ...string) string {
+	parts := []string{p.Server.URL}
+	if !strings.Contains(p.Server.URL, azureDevOpsLegacySuffix) {
+		parts = append(parts, org)
+	}
+	if project != "" {
+		parts = append(parts, url.PathEscape(project))
+	}
+	return util.UrlJoin(append(parts, paths...)...)
+}
+
+func (p *AzureDevOpsProvider) repositoryURL(org string, repo *azureDevOpsRepository) string {
+	return p.apiURL(org, "", "_apis/git/repositories", repo.ID)
+}
+
+func (p *AzureDevOpsProvider) listRepositories(org string) ([]azureDevOpsRepository, error) {
+	repos := []azureDevOpsRepository{}
+	err := p.list(p.apiURL(org, "", "_apis/git/repositories"), nil, &repos)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the repositories of %s due to: %s", org, err)
+	}
+	return repos, nil
+}
+
+// findRepository finds the repository by its name which can be prefixed with the project name
+func (p *AzureDevOpsProvider) findRepository(org string, name string) (*azureDevOpsRepository, error) {
+	repos, err := p.listRepositories(org)
+	if err != nil {
+		return nil, err
+	}
+	repo := findAzureDevOpsRepository(repos, name)
+	if repo == nil {
+		return nil, fmt.Errorf("no repository found with name %s in %s", name, org)
+	}
+	return repo, nil
+}
+
+func (p *AzureDevOpsProvider) getPullRequest(org string, number int) (*azureDevOpsPullRequest, error) {
+	pr := azureDevOpsPullRequest{}
+	err := p.do(http.MethodGet, p.apiURL(org, "", "_apis/git/pullrequests", strconv.Itoa(number)), nil, nil, &pr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request %d due to: %s", number, err)
+	}
+	return &pr, nil
+}
+
+func (p *AzureDevOpsProvider) listSubscriptions(org string, repo *azureDevOpsRepository) ([]azureDevOpsSubscription, error) {
+	subscriptions := []azureDevOpsSubscription{}
+	params := url.Values{
+		"publisherId": []string{azureDevOpsHookPublisher},
+		"consumerId":  []string{azureDevOpsHookConsumer},
+	}
+	err := p.list(p.apiURL(org, "", "_apis/hooks/subscriptions"), params, &subscriptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the webhooks of %s due to: %s", repo.Name, err)
+	}
+	answer := []azureDevOpsSubscription{}
+	for _, s := range subscriptions {
+		if s.PublisherInputs["repository"] == repo.ID {
+			answer = append(answer, s)
+		}
+	}
+	sort.Slice(answer, func(i, j int) bool {
+		return answer[i].ID < answer[j].ID
+	})
+	return answer, nil
+}
+
+// list invokes a GET request of a list resource and unmarshals its values into the result
+func (p *AzureDevOpsProvider) list(u string, params url.Values, result interface{}) error {
+	list := azureDevOpsList{}
+	err := p.do(http.MethodGet, u, params, nil, &list)
+	if err != nil {
+		return err
+	}
+	if len(list.Value) == 0 {
+		return nil
+	}
+	return json.Unmarshal(list.Value, result)
+}
+
+func (p *AzureDevOpsProvider) do(method string, u string, params url.Values, body interface{}, result interface{}) error {
+	if params == nil {
+		params = url.Values{}
+	}
+	params.Set("api-version", azureDevOpsAPIVersion)
+	u += "?" + params.Encode()
+
+	reader := bytes.NewReader(nil)
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, u, reader)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(p.User.Username, p.User.ApiToken)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	client := p.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("error response from %s %s: %s %s", method, u, resp.Status, string(data))
+	}
+	if result != nil && len(data) > 0 {
+		return json.Unmarshal(data, result)
+	}
+	return nil
+}
+
+func findAzureDevOpsRepository(repos []azureDevOpsRepository, name string) *azureDevOpsRepository {
+	project, repoName := splitAzureDevOpsRepoName(name)
+	var answer *azureDevOpsRepository
+	for i := range repos {
+		repo := &repos[i]
+		if !strings.EqualFold(repo.Name, repoName) {
+			continue
+		}
+		if project != "" && !strings.EqualFold(repo.Project.Name, project) {
+			continue
+		}
+		// prefer the repository of the project with the same name
+		if answer == nil || strings.EqualFold(repo.Project.Name, repoName) {
+			answer = repo
+		}
+	}
+	return answer
+}
+
+// splitAzureDevOpsRepoName splits the name of a repository into the optional project name and the repository name
+func splitAzureDevOpsRepoName(name string) (string, string) {
+	i := strings.LastIndex(name, "/")
+	if i < 0 {
+		return "", name
+	}
+	return name[0:i], name[i+1:]
+}
+
+// azureDevOpsRepoName returns the name of the repository prefixed with its project if it is known
+func azureDevOpsRepoName(repo *GitRepository) string {
+	if repo.Project != "" && repo.Project != repo.Organisation {
+		return repo.Project + "/" + repo.Name
+	}
+	return repo.Name
+}
+
+func azureDevOpsRef(branch string) string {
+	if strings.HasPrefix(branch, "refs/") {
+		return branch
+	}
+	return azureDevOpsRefsHeads + branch
+}
+
+func azureDevOpsVersionType(ref string) string {
+	if azureDevOpsCommitSHARegex.MatchString(ref) {
+		return "commit"
+	}
+	return "branch"
+}
+
+func azureDevOpsConsumerInputs(webhookURL string, secret string) map[string]string {
+	inputs := map[string]string{
+		"url": webhookURL,
+	}
+	if secret != "" {
+		inputs["httpHeaders"] = azureDevOpsSecretHeader + ":" + secret
+	}
+	return inputs
+}
+
+// azureDevOpsWebHookID returns a stable numeric ID for the webhook of the URL
+func azureDevOpsWebHookID(webhookURL string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(webhookURL))
+	return int64(h.Sum64() >> 1)
+}
+
+func toAzureDevOpsState(state string) string {
+	switch state {
+	case "success":
+		return "succeeded"
+	case "failure":
+		return "failed"
+	case "error":
+		return "error"
+	default:
+		return "pending"
+	}
+}
+
+func fromAzureDevOpsState(state string) string {
+	switch state {
+	case "succeeded":
+		return "success"
+	case "failed":
+		return "failure"
+	case "error":
+		return "error"
+	default:
+		return "pending"
+	}
+}
+
+func toAzureDevOpsGitRepository(org string, repo *azureDevOpsRepository) *GitRepository {
+	return &GitRepository{
+		Name:         repo.Name,
+		HTMLURL:      repo.WebURL,
+		CloneURL:     repo.RemoteURL,
+		SSHURL:       repo.SSHURL,
+		URL:          repo.WebURL,
+		Fork:         repo.IsFork,
+		Organisation: org,
+		Project:      repo.Project.Name,
+	}
+}
+
+func toAzureDevOpsPullRequest(org string, pr *azureDevOpsPullRequest) *GitPullRequest {
+	number := pr.PullRequestID
+	merged := pr.Status == azureDevOpsPullRequestMerge
+	mergeable := pr.MergeStatus != "conflicts" && pr.MergeStatus != "failure"
+	state := "open"
+	if pr.Status != azureDevOpsPullRequestOpen {
+		state = "closed"
+	}
+	headRef := strings.TrimPrefix(pr.SourceRefName, azureDevOpsRefsHeads)
+	answer := &GitPullRequest{
+		URL:   util.UrlJoin(pr.Repository.WebURL, "pullrequest", strconv.Itoa(number)),
+		Owner: org,
+		Repo:  pr.Repository.Name,
+		Author: &GitUser{
+			Login:     pr.CreatedBy.UniqueName,
+			Name:      pr.CreatedBy.DisplayName,
+			AvatarURL: pr.CreatedBy.ImageURL,
+		},
+		Number:    &number,
+		Mergeable: &mergeable,
+		Merged:    &merged,
+		HeadRef:   &headRef,
+		State:     &state,
+		Title:     pr.Title,
+		Body:      pr.Description,
+		UpdatedAt: pr.CreationDate,
+	}
+	if pr.LastMergeSourceCommit != nil {
+		answer.LastCommitSha = pr.LastMergeSourceCommit.CommitID
+	}
+	if pr.Status != azureDevOpsPullRequestOpen && pr.ClosedDate != nil {
+		answer.ClosedAt = pr.ClosedDate
+		if merged {
+			answer.MergedAt = pr.ClosedDate
+		}
+	}
+	if merged && pr.LastMergeCommit != nil {
+		mergeCommitSHA := pr.LastMergeCommit.CommitID
+		answer.MergeCommitSHA = &mergeCommitSHA
+	}
+	return answer
+}
+
+func toAzureDevOpsCommit(commit *azureDevOpsCommit) *GitCommit {
+	return &GitCommit{
+		SHA:     commit.CommitID,
+		Message: commit.Comment,
+		URL:     commit.RemoteURL,
+		Author: &GitUser{
+			Name:  commit.Author.Name,
+			Email: commit.Author.Email,
+		},
+		Committer: &GitUser{
+			Name:  commit.Committer.Name,
+			Email: commit.Committer.Email,
+		},
+	}
+}
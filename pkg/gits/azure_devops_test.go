@@ -0,0 +1,264 @@
+package gits_test
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/auth"
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/util"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type AzureDevOpsProviderTestSuite struct {
+	suite.Suite
+	mux      *http.ServeMux
+	server   *httptest.Server
+	provider *gits.AzureDevOpsProvider
+}
+
+const (
+	azureDevOpsRepoID = "5febef5a-833d-4e14-b9c0-14cb638f91e6"
+	azureDevOpsSHA    = "b60280bc6e62e2f880f1b63c1e24987664d3bda3"
+	azureDevOpsHook   = "http://hook.jenkins-x.example.com/hook"
+)
+
+var azureDevOpsRouter = util.Router{
+	"/test-org/_apis/git/repositories": util.MethodMap{
+		"GET": "repositories.json",
+	},
+	"/test-org/test-project/_apis/git/repositories": util.MethodMap{
+		"POST": "repository.json",
+	},
+	"/test-org/_apis/git/pullrequests/1": util.MethodMap{
+		"GET": "pull-request.json",
+	},
+	"/test-org/_apis/git/repositories/" + azureDevOpsRepoID + "/pullrequests": util.MethodMap{
+		"GET":  "pull-requests.json",
+		"POST": "pull-request.json",
+	},
+	"/test-org/_apis/git/repositories/" + azureDevOpsRepoID + "/pullrequests/1": util.MethodMap{
+		"PATCH": "pull-request.json",
+	},
+	"/test-org/_apis/git/repositories/" + azureDevOpsRepoID + "/commits": util.MethodMap{
+		"GET": "commits.json",
+	},
+	"/test-org/_apis/git/repositories/" + azureDevOpsRepoID + "/commits/" + azureDevOpsSHA + "/statuses": util.MethodMap{
+		"GET":  "statuses.json",
+		"POST": "status.json",
+	},
+	"/test-org/_apis/git/repositories/" + azureDevOpsRepoID + "/items": util.MethodMap{
+		"GET": "item.json",
+	},
+	"/test-org/_apis/hooks/subscriptions": util.MethodMap{
+		"GET":  "subscriptions.json",
+		"POST": "subscription.json",
+	},
+	"/test-org/_apis/hooks/subscriptions/0a4b5c6d-0000-4000-8000-000000000001": util.MethodMap{
+		"PUT": "subscription.json",
+	},
+	"/test-org/_apis/hooks/subscriptions/0a4b5c6d-0000-4000-8000-000000000002": util.MethodMap{
+		"PUT": "subscription.json",
+	},
+}
+
+func (suite *AzureDevOpsProviderTestSuite) SetupSuite() {
+	suite.mux = http.NewServeMux()
+	suite.server = httptest.NewServer(suite.mux)
+
+	suite.Require().NotNil(suite.server)
+	for path, methodMap := range azureDevOpsRouter {
+		suite.mux.HandleFunc(path, util.GetMockAPIResponseFromFile("test_data/azuredevops", methodMap))
+	}
+
+	as := auth.AuthServer{
+		URL:         suite.server.URL,
+		Name:        "Test Server",
+		Kind:        gits.KindAzureDevOps,
+		CurrentUser: "test-user",
+	}
+	ua := auth.UserAuth{
+		Username: "test-user",
+		ApiToken: "0123456789abdef",
+	}
+
+	gitter := gits.NewGitCLI()
+	provider, err := gits.NewAzureDevOpsProvider(&as, &ua, gitter)
+
+	suite.Require().NotNil(provider)
+	suite.Require().Nil(err)
+
+	var ok bool
+	suite.provider, ok = provider.(*gits.AzureDevOpsProvider)
+	suite.Require().True(ok)
+	suite.Require().NotNil(suite.provider)
+}
+
+func (suite *AzureDevOpsProviderTestSuite) TestListRepositories() {
+	repos, err := suite.provider.ListRepositories("test-org")
+
+	suite.Require().Nil(err)
+	suite.Require().Len(repos, 2)
+	suite.Require().Equal("test-repo", repos[0].Name)
+	suite.Require().Equal("test-project", repos[0].Project)
+	suite.Require().Equal("git@ssh.dev.azure.com:v3/test-org/test-project/test-repo", repos[0].SSHURL)
+	suite.Require().Equal("https://dev.azure.com/test-org/test-project/_git/test-repo", repos[0].HTMLURL)
+}
+
+func (suite *AzureDevOpsProviderTestSuite) TestGetRepository() {
+	repo, err := suite.provider.GetRepository("test-org", "test-project/environment-staging")
+
+	suite.Require().Nil(err)
+	suite.Require().Equal("environment-staging", repo.Name)
+
+	_, err = suite.provider.GetRepository("test-org", "other-project/test-repo")
+	suite.Require().NotNil(err)
+}
+
+func (suite *AzureDevOpsProviderTestSuite) TestCreateRepository() {
+	repo, err := suite.provider.CreateRepository("test-org", "test-project/new-repo", true)
+
+	suite.Require().Nil(err)
+	suite.Require().Equal("new-repo", repo.Name)
+	suite.Require().Equal("https://test-org@dev.azure.com/test-org/test-project/_git/new-repo", repo.CloneURL)
+}
+
+func (suite *AzureDevOpsProviderTestSuite) TestValidateRepositoryName() {
+	suite.Require().NotNil(suite.provider.ValidateRepositoryName("test-org", "test-repo"))
+	suite.Require().Nil(suite.provider.ValidateRepositoryName("test-org", "new-repo"))
+}
+
+func (suite *AzureDevOpsProviderTestSuite) TestCreatePullRequest() {
+	pr, err := suite.provider.CreatePullRequest(&gits.GitPullRequestArguments{
+		GitRepository: &gits.GitRepository{Organisation: "test-org", Project: "test-project", Name: "test-repo"},
+		Title:         "chore: promote myapp to version 0.0.1",
+		Head:          "promote-myapp-0.0.1",
+		Base:          "master",
+	})
+
+	suite.Require().Nil(err)
+	suite.Require().NotNil(pr)
+	suite.Require().Equal(1, *pr.Number)
+	suite.Require().Equal("test-org", pr.Owner)
+	suite.Require().Equal("test-repo", pr.Repo)
+	suite.Require().Equal("open", *pr.State)
+	suite.Require().Equal("promote-myapp-0.0.1", *pr.HeadRef)
+	suite.Require().Equal(azureDevOpsSHA, pr.LastCommitSha)
+	suite.Require().Equal("https://dev.azure.com/test-org/test-project/_git/test-repo/pullrequest/1", pr.URL)
+}
+
+func (suite *AzureDevOpsProviderTestSuite) TestListOpenPullRequests() {
+	prs, err := suite.provider.ListOpenPullRequests("test-org", "test-repo")
+
+	suite.Require().Nil(err)
+	suite.Require().Len(prs, 1)
+	suite.Require().Equal(1, *prs[0].Number)
+}
+
+func (suite *AzureDevOpsProviderTestSuite) TestListCommitStatus() {
+	statuses, err := suite.provider.ListCommitStatus("test-org", "test-repo", azureDevOpsSHA)
+
+	suite.Require().Nil(err)
+	suite.Require().Len(statuses, 2)
+	suite.Require().Equal("jenkins-x", statuses[0].Context)
+	suite.Require().True(statuses[0].IsSuccess())
+	suite.Require().Equal("pending", statuses[1].State)
+}
+
+func (suite *AzureDevOpsProviderTestSuite) TestPullRequestLastCommitStatus() {
+	pr, err := suite.provider.GetPullRequest("test-org", &gits.GitRepository{Name: "test-repo"}, 1)
+	suite.Require().Nil(err)
+
+	status, err := suite.provider.PullRequestLastCommitStatus(pr)
+	suite.Require().Nil(err)
+	suite.Require().Equal("pending", status)
+}
+
+func (suite *AzureDevOpsProviderTestSuite) TestUpdateCommitStatus() {
+	status, err := suite.provider.UpdateCommitStatus("test-org", "test-repo", azureDevOpsSHA, &gits.GitRepoStatus{
+		State:       "success",
+		Context:     "jenkins-x",
+		Description: "the build passed",
+		TargetURL:   "https://jenkins-x.example.com/builds/1",
+	})
+
+	suite.Require().Nil(err)
+	suite.Require().Equal("3", status.ID)
+	suite.Require().True(status.IsSuccess())
+}
+
+func (suite *AzureDevOpsProviderTestSuite) TestMergePullRequest() {
+	pr, err := suite.provider.GetPullRequest("test-org", &gits.GitRepository{Name: "test-repo"}, 1)
+	suite.Require().Nil(err)
+
+	err = suite.provider.MergePullRequest(pr, "jx promote automatically merged promotion PR")
+	suite.Require().Nil(err)
+}
+
+func (suite *AzureDevOpsProviderTestSuite) TestListCommits() {
+	commits, err := suite.provider.ListCommits("test-org", "test-repo", &gits.ListCommitsArguments{
+		SHA:     "master",
+		PerPage: 10,
+	})
+
+	suite.Require().Nil(err)
+	suite.Require().Len(commits, 2)
+	suite.Require().Equal(azureDevOpsSHA, commits[0].SHA)
+	suite.Require().Equal("test-user@example.com", commits[0].Author.Email)
+}
+
+func (suite *AzureDevOpsProviderTestSuite) TestGetContent() {
+	content, err := suite.provider.GetContent("test-org", "test-repo", "jenkins-x.yml", "master")
+
+	suite.Require().Nil(err)
+	suite.Require().Equal("jenkins-x.yml", content.Name)
+	data, err := base64.StdEncoding.DecodeString(content.Content)
+	suite.Require().Nil(err)
+	suite.Require().Equal("buildPack: go\n", string(data))
+}
+
+func (suite *AzureDevOpsProviderTestSuite) TestListWebHooks() {
+	hooks, err := suite.provider.ListWebHooks("test-org", "test-repo")
+
+	suite.Require().Nil(err)
+	suite.Require().Len(hooks, 1)
+	suite.Require().Equal(azureDevOpsHook, hooks[0].URL)
+	suite.Require().NotZero(hooks[0].ID)
+}
+
+func (suite *AzureDevOpsProviderTestSuite) TestCreateWebHook() {
+	err := suite.provider.CreateWebHook(&gits.GitWebHookArguments{
+		Owner:  "test-org",
+		Repo:   &gits.GitRepository{Name: "test-repo"},
+		URL:    "http://hook.jenkins-x.example.com/new",
+		Secret: "secret",
+	})
+
+	suite.Require().Nil(err)
+}
+
+func (suite *AzureDevOpsProviderTestSuite) TestUpdateWebHook() {
+	err := suite.provider.UpdateWebHook(&gits.GitWebHookArguments{
+		Owner:       "test-org",
+		Repo:        &gits.GitRepository{Name: "test-repo"},
+		URL:         "http://hook.jenkins-x.example.com/new",
+		ExistingURL: azureDevOpsHook,
+	})
+
+	suite.Require().Nil(err)
+}
+
+func TestAzureDevOpsProviderTestSuite(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping AzureDevOpsProviderTestSuite in short mode")
+	} else {
+		suite.Run(t, new(AzureDevOpsProviderTestSuite))
+	}
+}
+
+func (suite *AzureDevOpsProviderTestSuite) TearDownSuite() {
+	suite.server.Close()
+}
@@ -3,6 +3,7 @@ package gits
 import (
 	"context"
 	"fmt"
+	"os"
 	"regexp"
 	"strconv"
 	"strings"
@@ -724,6 +725,26 @@ func (b *BitbucketCloudProvider) UpdateWebHook(data *GitWebHookArguments) error
 	return fmt.Errorf("not implemented!")
 }
 
+// GetBranchProtection is not supported on Bitbucket Cloud
+func (b *BitbucketCloudProvider) GetBranchProtection(org string, repo string, branch string) (*GitBranchProtection, error) {
+	return nil, fmt.Errorf("branch protection is not supported by Bitbucket Cloud")
+}
+
+// UpdateBranchProtection is not supported on Bitbucket Cloud
+func (b *BitbucketCloudProvider) UpdateBranchProtection(org string, repo string, branch string, protection *GitBranchProtection) error {
+	return fmt.Errorf("branch protection is not supported by Bitbucket Cloud")
+}
+
+// RemoveBranchProtection is not supported on Bitbucket Cloud
+func (b *BitbucketCloudProvider) RemoveBranchProtection(org string, repo string, branch string) error {
+	return fmt.Errorf("branch protection is not supported by Bitbucket Cloud")
+}
+
+// GetRateLimits returns no rate limits as Bitbucket Cloud does not report them
+func (b *BitbucketCloudProvider) GetRateLimits() ([]*GitRateLimit, error) {
+	return []*GitRateLimit{}, nil
+}
+
 func BitbucketIssueToGitIssue(bIssue bitbucket.Issue) *GitIssue {
 	id := int(bIssue.Id)
 	ownerAndRepo := strings.Split(bIssue.Repository.FullName, "/")
@@ -881,6 +902,102 @@ func (b *BitbucketCloudProvider) AddPRComment(pr *GitPullRequest, comment string
 	return err
 }
 
+// RequestPRReviewers adds the users as reviewers of the pull request
+func (b *BitbucketCloudProvider) RequestPRReviewers(pr *GitPullRequest, reviewers []string) error {
+	if pr.Number == nil {
+		return fmt.Errorf("RequestPRReviewers requires a non-nil PR number")
+	}
+	if len(reviewers) == 0 {
+		return nil
+	}
+	bitbucketPR, _, err := b.Client.PullrequestsApi.RepositoriesUsernameRepoSlugPullrequestsPullRequestIdGet(
+		b.Context,
+		pr.Owner,
+		pr.Repo,
+		int32(*pr.Number),
+	)
+	if err != nil {
+		return err
+	}
+
+	accounts := []bitbucket.Account{}
+	for _, reviewer := range bitbucketPR.Reviewers {
+		accounts = append(accounts, bitbucket.Account{Username: reviewer.Username})
+	}
+	for _, reviewer := range reviewers {
+		if !bitbucketHasReviewer(bitbucketPR.Reviewers, reviewer) {
+			accounts = append(accounts, bitbucket.Account{Username: reviewer})
+		}
+	}
+
+	options := map[string]interface{}{
+		"body": bitbucket.Pullrequest{
+			Title:     bitbucketPR.Title,
+			Reviewers: accounts,
+		},
+	}
+	_, _, err = b.Client.PullrequestsApi.RepositoriesUsernameRepoSlugPullrequestsPullRequestIdPut(
+		b.Context,
+		pr.Owner,
+		pr.Repo,
+		int32(*pr.Number),
+		options,
+	)
+	return err
+}
+
+func bitbucketHasReviewer(reviewers []bitbucket.Account, username string) bool {
+	for _, reviewer := range reviewers {
+		if reviewer.Username == username {
+			return true
+		}
+	}
+	return false
+}
+
+// ListPRReviews returns an approved review for each participant who approved the pull request
+func (b *BitbucketCloudProvider) ListPRReviews(pr *GitPullRequest) ([]*GitReview, error) {
+	if pr.Number == nil {
+		return nil, fmt.Errorf("ListPRReviews requires a non-nil PR number")
+	}
+	bitbucketPR, _, err := b.Client.PullrequestsApi.RepositoriesUsernameRepoSlugPullrequestsPullRequestIdGet(
+		b.Context,
+		pr.Owner,
+		pr.Repo,
+		int32(*pr.Number),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	answer := []*GitReview{}
+	for _, participant := range bitbucketPR.Participants {
+		if !participant.Approved || participant.User == nil {
+			continue
+		}
+		submittedAt := participant.ParticipatedOn
+		answer = append(answer, &GitReview{
+			User: &GitUser{
+				Login: participant.User.Username,
+				Name:  participant.User.DisplayName,
+			},
+			State:       ReviewStateApproved,
+			SubmittedAt: &submittedAt,
+		})
+	}
+	return answer, nil
+}
+
+// AddPRLabels is not supported as Bitbucket has no pull request labels
+func (b *BitbucketCloudProvider) AddPRLabels(pr *GitPullRequest, labels []string) error {
+	return fmt.Errorf("pull request labels are not supported by Bitbucket Cloud")
+}
+
+// RemovePRLabel is not supported as Bitbucket has no pull request labels
+func (b *BitbucketCloudProvider) RemovePRLabel(pr *GitPullRequest, label string) error {
+	return fmt.Errorf("pull request labels are not supported by Bitbucket Cloud")
+}
+
 func (b *BitbucketCloudProvider) CreateIssueComment(owner string, repo string, number int, comment string) error {
 	rawComment := bitbucket.IssueComment{
 		Content: &bitbucket.IssueContent{
@@ -975,6 +1092,11 @@ func (b *BitbucketCloudProvider) UpdateRelease(owner string, repo string, tag st
 	return nil
 }
 
+// UploadReleaseAsset is not supported as Bitbucket Cloud has no releases
+func (b *BitbucketCloudProvider) UploadReleaseAsset(org string, repo string, tag string, name string, asset *os.File) (*GitReleaseAsset, error) {
+	return nil, fmt.Errorf("release assets are not supported by Bitbucket Cloud")
+}
+
 // ListReleases lists the releases
 func (b *BitbucketCloudProvider) ListReleases(org string, name string) ([]*GitRelease, error) {
 	answer := []*GitRelease{}
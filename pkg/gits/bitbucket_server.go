@@ -1,10 +1,16 @@
 package gits
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/pkg/errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
 	"strconv"
 	"strings"
 	"time"
@@ -943,6 +949,104 @@ func (b *BitbucketServerProvider) AddPRComment(pr *GitPullRequest, comment strin
 	return err
 }
 
+// RequestPRReviewers adds the users as reviewers of the pull request
+func (b *BitbucketServerProvider) RequestPRReviewers(pr *GitPullRequest, reviewers []string) error {
+	if pr.Number == nil {
+		return fmt.Errorf("Missing Number for GitPullRequest %#v", pr)
+	}
+	if len(reviewers) == 0 {
+		return nil
+	}
+	var currentPR bitbucket.PullRequest
+	projectKey, repo := parseBitBucketServerURL(pr.URL)
+	apiResponse, err := b.Client.DefaultApi.GetPullRequest(projectKey, repo, *pr.Number)
+	if err != nil {
+		return err
+	}
+	err = mapstructure.Decode(apiResponse.Values, &currentPR)
+	if err != nil {
+		return err
+	}
+
+	names := []string{}
+	for _, reviewer := range currentPR.Reviewers {
+		names = append(names, reviewer.User.Name)
+	}
+	for _, reviewer := range reviewers {
+		if util.StringArrayIndex(names, reviewer) < 0 {
+			names = append(names, reviewer)
+		}
+	}
+	users := []map[string]interface{}{}
+	for _, name := range names {
+		users = append(users, map[string]interface{}{
+			"user": map[string]interface{}{
+				"name": name,
+			},
+		})
+	}
+	options := map[string]interface{}{
+		"version":   currentPR.Version,
+		"title":     currentPR.Title,
+		"reviewers": users,
+	}
+	u := util.UrlJoin("rest/api/1.0/projects", projectKey, "repos", repo, "pull-requests", strconv.Itoa(*pr.Number))
+	err = b.restRequest(http.MethodPut, u, options, nil)
+	if err != nil {
+		return errors.Wrapf(err, "failed to add reviewers to pull request %s", pr.URL)
+	}
+	return nil
+}
+
+// ListPRReviews returns the latest review of each reviewer of the pull request
+func (b *BitbucketServerProvider) ListPRReviews(pr *GitPullRequest) ([]*GitReview, error) {
+	if pr.Number == nil {
+		return nil, fmt.Errorf("Missing Number for GitPullRequest %#v", pr)
+	}
+	var currentPR bitbucket.PullRequest
+	projectKey, repo := parseBitBucketServerURL(pr.URL)
+	apiResponse, err := b.Client.DefaultApi.GetPullRequest(projectKey, repo, *pr.Number)
+	if err != nil {
+		return nil, err
+	}
+	err = mapstructure.Decode(apiResponse.Values, &currentPR)
+	if err != nil {
+		return nil, err
+	}
+
+	answer := []*GitReview{}
+	for _, reviewer := range currentPR.Reviewers {
+		state := ""
+		switch {
+		case reviewer.Approved || reviewer.Status == "APPROVED":
+			state = ReviewStateApproved
+		case reviewer.Status == "NEEDS_WORK":
+			state = ReviewStateChangesRequested
+		default:
+			continue
+		}
+		answer = append(answer, &GitReview{
+			User: &GitUser{
+				Login: reviewer.User.Slug,
+				Name:  reviewer.User.Name,
+				Email: reviewer.User.Email,
+			},
+			State: state,
+		})
+	}
+	return answer, nil
+}
+
+// AddPRLabels is not supported as Bitbucket has no pull request labels
+func (b *BitbucketServerProvider) AddPRLabels(pr *GitPullRequest, labels []string) error {
+	return fmt.Errorf("pull request labels are not supported by Bitbucket Server")
+}
+
+// RemovePRLabel is not supported as Bitbucket has no pull request labels
+func (b *BitbucketServerProvider) RemovePRLabel(pr *GitPullRequest, label string) error {
+	return fmt.Errorf("pull request labels are not supported by Bitbucket Server")
+}
+
 func (b *BitbucketServerProvider) CreateIssueComment(owner string, repo string, number int, comment string) error {
 	log.Warn("Bitbucket Server doesn't support adding issue comments via the REST API")
 	return nil
@@ -1023,6 +1127,11 @@ func (b *BitbucketServerProvider) UpdateRelease(owner string, repo string, tag s
 	return nil
 }
 
+// UploadReleaseAsset is not supported as Bitbucket Server has no releases
+func (b *BitbucketServerProvider) UploadReleaseAsset(org string, repo string, tag string, name string, asset *os.File) (*GitReleaseAsset, error) {
+	return nil, fmt.Errorf("release assets are not supported by Bitbucket Server")
+}
+
 func (b *BitbucketServerProvider) ListReleases(org string, name string) ([]*GitRelease, error) {
 	answer := []*GitRelease{}
 	log.Warn("Bitbucket Server doesn't support releases")
@@ -1066,3 +1175,201 @@ func BitBucketServerAccessTokenURL(url string) string {
 func (p *BitbucketServerProvider) ListCommits(owner, repo string, opt *ListCommitsArguments) ([]*GitCommit, error) {
 	return nil, fmt.Errorf("Listing commits not supported on bitbucket")
 }
+
+// restRequest performs a request against a REST path of the server which is not covered by the API client, encoding
+// the body and decoding the response into result if they are not nil
+func (b *BitbucketServerProvider) restRequest(method string, path string, body interface{}, result interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		requestBody, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(requestBody)
+	}
+	req, err := http.NewRequest(method, util.UrlJoin(b.Server.URL, path), reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+b.User.ApiToken)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s %s", resp.Status, string(data))
+	}
+	if result != nil && len(data) > 0 {
+		return json.Unmarshal(data, result)
+	}
+	return nil
+}
+
+type bitbucketServerRestriction struct {
+	ID      int64  `json:"id"`
+	Type    string `json:"type"`
+	Matcher struct {
+		ID string `json:"id"`
+	} `json:"matcher"`
+	Users []struct {
+		Name string `json:"name"`
+	} `json:"users"`
+	Groups []string `json:"groups"`
+}
+
+type bitbucketServerRestrictionsPage struct {
+	Values        []bitbucketServerRestriction `json:"values"`
+	IsLastPage    bool                         `json:"isLastPage"`
+	NextPageStart int                          `json:"nextPageStart"`
+}
+
+type bitbucketServerPullRequestSettings struct {
+	RequiredApprovers        int `json:"requiredApprovers"`
+	RequiredSuccessfulBuilds int `json:"requiredSuccessfulBuilds"`
+}
+
+const (
+	bitbucketServerRestrictionNoDeletes       = "no-deletes"
+	bitbucketServerRestrictionFastForwardOnly = "fast-forward-only"
+	bitbucketServerRestrictionPullRequestOnly = "pull-request-only"
+)
+
+func (b *BitbucketServerProvider) branchRestrictionsPath(projectKey string, repo string) string {
+	return util.UrlJoin("rest/branch-permissions/2.0/projects", projectKey, "repos", repo, "restrictions")
+}
+
+func (b *BitbucketServerProvider) pullRequestSettingsPath(projectKey string, repo string) string {
+	return util.UrlJoin("rest/api/1.0/projects", projectKey, "repos", repo, "settings/pull-requests")
+}
+
+// listBranchRestrictions returns the restrictions of the branch
+func (b *BitbucketServerProvider) listBranchRestrictions(projectKey string, repo string, branch string) ([]bitbucketServerRestriction, error) {
+	answer := []bitbucketServerRestriction{}
+	ref := "refs/heads/" + branch
+	start := 0
+	for {
+		page := bitbucketServerRestrictionsPage{}
+		u := fmt.Sprintf("%s?matcherType=BRANCH&matcherId=%s&start=%d&limit=%d",
+			b.branchRestrictionsPath(projectKey, repo), url.QueryEscape(ref), start, pageLimit)
+		err := b.restRequest(http.MethodGet, u, nil, &page)
+		if err != nil {
+			return answer, errors.Wrapf(err, "failed to list the restrictions of branch %s of repository %s/%s", branch, projectKey, repo)
+		}
+		for _, restriction := range page.Values {
+			if restriction.Matcher.ID == ref {
+				answer = append(answer, restriction)
+			}
+		}
+		if page.IsLastPage {
+			break
+		}
+		start = page.NextPageStart
+	}
+	return answer, nil
+}
+
+// GetBranchProtection returns the protection of the branch from its restrictions and the pull request settings of
+// the repository. Bitbucket Server requires a number of successful builds rather than named status contexts so
+// AnyStatusContext is reported when builds are required
+func (b *BitbucketServerProvider) GetBranchProtection(org string, repo string, branch string) (*GitBranchProtection, error) {
+	restrictions, err := b.listBranchRestrictions(org, repo, branch)
+	if err != nil {
+		return nil, err
+	}
+	if len(restrictions) == 0 {
+		return nil, nil
+	}
+	answer := &GitBranchProtection{}
+	for _, restriction := range restrictions {
+		if restriction.Type != bitbucketServerRestrictionPullRequestOnly {
+			continue
+		}
+		answer.RestrictPushes = true
+		for _, user := range restriction.Users {
+			answer.PushUsers = append(answer.PushUsers, user.Name)
+		}
+		answer.PushTeams = append(answer.PushTeams, restriction.Groups...)
+	}
+	settings := bitbucketServerPullRequestSettings{}
+	err = b.restRequest(http.MethodGet, b.pullRequestSettingsPath(org, repo), nil, &settings)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the pull request settings of repository %s/%s", org, repo)
+	}
+	answer.RequiredApprovals = settings.RequiredApprovers
+	if settings.RequiredSuccessfulBuilds > 0 {
+		answer.RequiredStatusContexts = []string{AnyStatusContext}
+	}
+	return answer, nil
+}
+
+// UpdateBranchProtection replaces the restrictions of the branch so that it cannot be deleted or rewritten and, if
+// pushes are restricted, can only be changed via pull requests except by the push users and teams.
+// The required approvals and successful builds are applied to all the pull requests of the repository
+func (b *BitbucketServerProvider) UpdateBranchProtection(org string, repo string, branch string, protection *GitBranchProtection) error {
+	if protection.EnforceAdmins {
+		log.Warnf("Enforcing the protection of branch %s of %s/%s for administrators is not supported by Bitbucket Server\n", branch, org, repo)
+	}
+	err := b.RemoveBranchProtection(org, repo, branch)
+	if err != nil {
+		return err
+	}
+	types := []string{bitbucketServerRestrictionNoDeletes, bitbucketServerRestrictionFastForwardOnly}
+	if protection.RestrictPushes {
+		types = append(types, bitbucketServerRestrictionPullRequestOnly)
+	}
+	for _, restrictionType := range types {
+		restriction := map[string]interface{}{
+			"type": restrictionType,
+			"matcher": map[string]interface{}{
+				"id": "refs/heads/" + branch,
+				"type": map[string]interface{}{
+					"id": "BRANCH",
+				},
+			},
+		}
+		if restrictionType == bitbucketServerRestrictionPullRequestOnly {
+			restriction["users"] = append([]string{}, protection.PushUsers...)
+			restriction["groups"] = append([]string{}, protection.PushTeams...)
+		}
+		err = b.restRequest(http.MethodPost, b.branchRestrictionsPath(org, repo), restriction, nil)
+		if err != nil {
+			return errors.Wrapf(err, "failed to add the %s restriction to branch %s of repository %s/%s", restrictionType, branch, org, repo)
+		}
+	}
+	settings := bitbucketServerPullRequestSettings{
+		RequiredApprovers:        protection.RequiredApprovals,
+		RequiredSuccessfulBuilds: len(protection.RequiredStatusContexts),
+	}
+	err = b.restRequest(http.MethodPost, b.pullRequestSettingsPath(org, repo), settings, nil)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update the pull request settings of repository %s/%s", org, repo)
+	}
+	return nil
+}
+
+// RemoveBranchProtection deletes all the restrictions of the branch
+func (b *BitbucketServerProvider) RemoveBranchProtection(org string, repo string, branch string) error {
+	restrictions, err := b.listBranchRestrictions(org, repo, branch)
+	if err != nil {
+		return err
+	}
+	for _, restriction := range restrictions {
+		u := util.UrlJoin(b.branchRestrictionsPath(org, repo), strconv.FormatInt(restriction.ID, 10))
+		err = b.restRequest(http.MethodDelete, u, nil, nil)
+		if err != nil {
+			return errors.Wrapf(err, "failed to delete restriction %d of branch %s of repository %s/%s", restriction.ID, branch, org, repo)
+		}
+	}
+	return nil
+}
+
+// GetRateLimits returns no rate limits as Bitbucket Server only reports them once requests are being rejected
+func (b *BitbucketServerProvider) GetRateLimits() ([]*GitRateLimit, error) {
+	return []*GitRateLimit{}, nil
+}
@@ -70,6 +70,23 @@ var bitbucketServerRouter = util.Router{
 	"/rest/build-status/1.0/commits/d6f24ee03d76a2caf0a4e1975fb43e8f61759b9c": util.MethodMap{
 		"GET": "build-statuses.json",
 	},
+	"/rest/branch-permissions/2.0/projects/TEST-ORG/repos/test-repo/restrictions": util.MethodMap{
+		"GET":  "branch-restrictions.json",
+		"POST": "branch-restriction.json",
+	},
+	"/rest/branch-permissions/2.0/projects/TEST-ORG/repos/test-repo/restrictions/1": util.MethodMap{
+		"DELETE": "branch-restriction.json",
+	},
+	"/rest/branch-permissions/2.0/projects/TEST-ORG/repos/test-repo/restrictions/2": util.MethodMap{
+		"DELETE": "branch-restriction.json",
+	},
+	"/rest/branch-permissions/2.0/projects/TEST-ORG/repos/test-repo/restrictions/3": util.MethodMap{
+		"DELETE": "branch-restriction.json",
+	},
+	"/rest/api/1.0/projects/TEST-ORG/repos/test-repo/settings/pull-requests": util.MethodMap{
+		"GET":  "pull-request-settings.json",
+		"POST": "pull-request-settings.json",
+	},
 }
 
 func (suite *BitbucketServerProviderTestSuite) SetupSuite() {
@@ -370,6 +387,44 @@ func (suite *BitbucketServerProviderTestSuite) TestAddPRComment() {
 	suite.Require().Nil(err)
 }
 
+func (suite *BitbucketServerProviderTestSuite) TestGetBranchProtection() {
+	serverURL := suite.provider.Server.URL
+	suite.provider.Server.URL = suite.server.URL
+	defer func() {
+		suite.provider.Server.URL = serverURL
+	}()
+
+	protection, err := suite.provider.GetBranchProtection("TEST-ORG", "test-repo", "master")
+	suite.Require().Nil(err)
+	suite.Require().Equal(&gits.GitBranchProtection{
+		RequiredStatusContexts: []string{gits.AnyStatusContext},
+		RequiredApprovals:      2,
+		RestrictPushes:         true,
+		PushUsers:              []string{"jenkins-x-bot"},
+		PushTeams:              []string{"release-managers"},
+	}, protection)
+
+	protection, err = suite.provider.GetBranchProtection("TEST-ORG", "test-repo", "develop")
+	suite.Require().Nil(err)
+	suite.Require().Nil(protection)
+}
+
+func (suite *BitbucketServerProviderTestSuite) TestUpdateBranchProtection() {
+	serverURL := suite.provider.Server.URL
+	suite.provider.Server.URL = suite.server.URL
+	defer func() {
+		suite.provider.Server.URL = serverURL
+	}()
+
+	err := suite.provider.UpdateBranchProtection("TEST-ORG", "test-repo", "master", &gits.GitBranchProtection{
+		RequiredStatusContexts: []string{"pr-build"},
+		RequiredApprovals:      1,
+		RestrictPushes:         true,
+		PushUsers:              []string{"jenkins-x-bot"},
+	})
+	suite.Require().Nil(err)
+}
+
 func TestBitbucketServerProviderTestSuite(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping TestBitbucketServerProviderTestSuite in short mode")
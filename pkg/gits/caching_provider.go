@@ -0,0 +1,436 @@
+package gits
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const (
+	// DefaultGitCacheTTL the default time results of a CachingGitProvider are reused
+	DefaultGitCacheTTL = 5 * time.Minute
+	// DefaultRateLimitMinRemaining the default number of requests kept in reserve for other clients
+	DefaultRateLimitMinRemaining = 10
+	// DefaultRateLimitMaxWait the default longest time to wait for a rate limit to be reset
+	DefaultRateLimitMaxWait = 15 * time.Minute
+
+	// GitCacheTTLEnvVar the environment variable overriding the TTL of cached results
+	GitCacheTTLEnvVar = "JX_GIT_CACHE_TTL"
+	// GitCacheDirEnvVar the environment variable enabling the on-disk cache in the given directory
+	GitCacheDirEnvVar = "JX_GIT_CACHE_DIR"
+	// GitRateLimitPolicyEnvVar the environment variable choosing whether to wait or fail when a rate limit is exhausted
+	GitRateLimitPolicyEnvVar = "JX_GIT_RATE_LIMIT_POLICY"
+	// GitRateLimitMaxWaitEnvVar the environment variable overriding the longest time to wait for a rate limit
+	GitRateLimitMaxWaitEnvVar = "JX_GIT_RATE_LIMIT_MAX_WAIT"
+)
+
+// CachingGitProvider decorates a GitProvider with conditional requests, caching of results and handling of rate
+// limits so that bulk operations do not exhaust the API rate limit of the git provider
+type CachingGitProvider struct {
+	GitProvider
+
+	options   CachingOptions
+	cache     *gitCache
+	limits    *rateLimitTracker
+	metrics   *CacheMetrics
+	transport bool
+}
+
+// DefaultCachingOptions returns the default caching options which can be overridden with environment variables
+func DefaultCachingOptions() CachingOptions {
+	options := CachingOptions{
+		TTL:             DefaultGitCacheTTL,
+		Dir:             os.Getenv(GitCacheDirEnvVar),
+		RateLimitPolicy: RateLimitPolicyWait,
+		MinRemaining:    DefaultRateLimitMinRemaining,
+		MaxWait:         DefaultRateLimitMaxWait,
+	}
+	if value := os.Getenv(GitCacheTTLEnvVar); value != "" {
+		ttl, err := time.ParseDuration(value)
+		if err != nil {
+			log.Warnf("Ignoring invalid $%s %s: %s\n", GitCacheTTLEnvVar, value, err)
+		} else {
+			options.TTL = ttl
+		}
+	}
+	if value := os.Getenv(GitRateLimitPolicyEnvVar); value != "" {
+		if util.StringArrayIndex(RateLimitPolicies, value) < 0 {
+			log.Warnf("Ignoring invalid $%s %s. Supported values are: %s\n", GitRateLimitPolicyEnvVar, value,
+				strings.Join(RateLimitPolicies, ", "))
+		} else {
+			options.RateLimitPolicy = RateLimitPolicy(value)
+		}
+	}
+	if value := os.Getenv(GitRateLimitMaxWaitEnvVar); value != "" {
+		maxWait, err := time.ParseDuration(value)
+		if err != nil {
+			log.Warnf("Ignoring invalid $%s %s: %s\n", GitRateLimitMaxWaitEnvVar, value, err)
+		} else {
+			options.MaxWait = maxWait
+		}
+	}
+	return options
+}
+
+// NewCachingGitProvider decorates the git provider with caching and rate limit handling. If the git provider lets
+// its HTTP transport be decorated then conditional requests are used and rate limits are tracked too
+func NewCachingGitProvider(provider GitProvider, options CachingOptions) *CachingGitProvider {
+	if answer, ok := provider.(*CachingGitProvider); ok {
+		return answer
+	}
+	dir := options.Dir
+	if dir != "" {
+		// lets not share cached responses between users
+		dir = filepath.Join(dir, hashText(provider.ServerURL()+"#"+provider.CurrentUsername()))
+	}
+	answer := &CachingGitProvider{
+		GitProvider: provider,
+		options:     options,
+		cache:       newGitCache(dir),
+		limits:      newRateLimitTracker(),
+		metrics:     &CacheMetrics{},
+	}
+	if decorator, ok := provider.(HTTPTransportDecorator); ok {
+		decorator.DecorateTransport(func(base http.RoundTripper) http.RoundTripper {
+			if base == nil {
+				base = http.DefaultTransport
+			}
+			return &cachingTransport{
+				base:    base,
+				options: options,
+				cache:   answer.cache,
+				limits:  answer.limits,
+				metrics: answer.metrics,
+				now:     time.Now,
+				sleep:   time.Sleep,
+			}
+		})
+		answer.transport = true
+	}
+	return answer
+}
+
+// Metrics returns how the requests of the git provider have been served so far
+func (p *CachingGitProvider) Metrics() CacheMetrics {
+	return p.metrics.snapshot()
+}
+
+// GetRateLimits returns the rate limits of the git provider or, if it cannot report them, the latest rate limits
+// received in its responses
+func (p *CachingGitProvider) GetRateLimits() ([]*GitRateLimit, error) {
+	answer, err := p.GitProvider.GetRateLimits()
+	if err != nil || len(answer) > 0 {
+		return answer, err
+	}
+	return p.limits.rateLimits(), nil
+}
+
+// cached populates result from the cache or by calling fetch and caching its result for the TTL
+func (p *CachingGitProvider) cached(scope string, key string, result interface{}, fetch func() (interface{}, error)) error {
+	if p.options.TTL <= 0 {
+		value, err := fetch()
+		if err != nil {
+			return err
+		}
+		return copyResult(value, result)
+	}
+	entry := p.cache.get(scope, key)
+	if entry != nil && !entry.expired(time.Now()) {
+		err := json.Unmarshal(entry.Body, result)
+		if err == nil {
+			atomic.AddInt64(&p.metrics.CacheHits, 1)
+			return nil
+		}
+	}
+	if !p.transport {
+		// lets count the requests of git providers whose transport cannot be decorated
+		atomic.AddInt64(&p.metrics.Requests, 1)
+	}
+	value, err := fetch()
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	p.cache.put(scope, &gitCacheEntry{
+		Key:     key,
+		Expires: time.Now().Add(p.options.TTL),
+		Body:    data,
+	})
+	return json.Unmarshal(data, result)
+}
+
+// invalidate removes the cached results of the repository and its owner
+func (p *CachingGitProvider) invalidate(owner string, repo string) {
+	p.cache.invalidate(ownerScope(owner), repoScope(owner, repo))
+}
+
+func copyResult(value interface{}, result interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, result)
+}
+
+func cacheKey(method string, args ...interface{}) string {
+	return method + fmt.Sprintf("%q", args)
+}
+
+func ownerScope(owner string) string {
+	return "owner/" + owner
+}
+
+func repoScope(owner string, repo string) string {
+	return "repo/" + owner + "/" + repo
+}
+
+func pullRequestRepoScope(pr *GitPullRequest) (string, string) {
+	if pr == nil {
+		return "", ""
+	}
+	return pr.Owner, pr.Repo
+}
+
+// ListOrganisations lists the organisations of the user
+func (p *CachingGitProvider) ListOrganisations() ([]GitOrganisation, error) {
+	answer := []GitOrganisation{}
+	err := p.cached("organisations", cacheKey("ListOrganisations"), &answer, func() (interface{}, error) {
+		return p.GitProvider.ListOrganisations()
+	})
+	return answer, err
+}
+
+// ListRepositories lists the repositories of the organisation
+func (p *CachingGitProvider) ListRepositories(org string) ([]*GitRepository, error) {
+	answer := []*GitRepository{}
+	err := p.cached(ownerScope(org), cacheKey("ListRepositories", org), &answer, func() (interface{}, error) {
+		return p.GitProvider.ListRepositories(org)
+	})
+	return answer, err
+}
+
+// GetRepository gets the repository
+func (p *CachingGitProvider) GetRepository(org string, name string) (*GitRepository, error) {
+	var answer *GitRepository
+	err := p.cached(repoScope(org, name), cacheKey("GetRepository", org, name), &answer, func() (interface{}, error) {
+		return p.GitProvider.GetRepository(org, name)
+	})
+	return answer, err
+}
+
+// ListWebHooks lists the webhooks of the repository
+func (p *CachingGitProvider) ListWebHooks(org string, repo string) ([]*GitWebHookArguments, error) {
+	answer := []*GitWebHookArguments{}
+	err := p.cached(repoScope(org, repo), cacheKey("ListWebHooks", org, repo), &answer, func() (interface{}, error) {
+		return p.GitProvider.ListWebHooks(org, repo)
+	})
+	return answer, err
+}
+
+// GetBranchProtection gets the protection of the branch of the repository
+func (p *CachingGitProvider) GetBranchProtection(org string, repo string, branch string) (*GitBranchProtection, error) {
+	var answer *GitBranchProtection
+	err := p.cached(repoScope(org, repo), cacheKey("GetBranchProtection", org, repo, branch), &answer, func() (interface{}, error) {
+		return p.GitProvider.GetBranchProtection(org, repo, branch)
+	})
+	return answer, err
+}
+
+// ListCommits lists the commits of the repository
+func (p *CachingGitProvider) ListCommits(owner string, repo string, opt *ListCommitsArguments) ([]*GitCommit, error) {
+	answer := []*GitCommit{}
+	err := p.cached(repoScope(owner, repo), cacheKey("ListCommits", owner, repo, opt), &answer, func() (interface{}, error) {
+		return p.GitProvider.ListCommits(owner, repo, opt)
+	})
+	return answer, err
+}
+
+// GetIssue gets the issue of the repository
+func (p *CachingGitProvider) GetIssue(org string, name string, number int) (*GitIssue, error) {
+	var answer *GitIssue
+	err := p.cached(repoScope(org, name), cacheKey("GetIssue", org, name, number), &answer, func() (interface{}, error) {
+		return p.GitProvider.GetIssue(org, name, number)
+	})
+	return answer, err
+}
+
+// SearchIssues searches the issues of the repository
+func (p *CachingGitProvider) SearchIssues(org string, name string, query string) ([]*GitIssue, error) {
+	answer := []*GitIssue{}
+	err := p.cached(repoScope(org, name), cacheKey("SearchIssues", org, name, query), &answer, func() (interface{}, error) {
+		return p.GitProvider.SearchIssues(org, name, query)
+	})
+	return answer, err
+}
+
+// SearchIssuesClosedSince searches the issues of the repository closed since the given time
+func (p *CachingGitProvider) SearchIssuesClosedSince(org string, name string, t time.Time) ([]*GitIssue, error) {
+	answer := []*GitIssue{}
+	err := p.cached(repoScope(org, name), cacheKey("SearchIssuesClosedSince", org, name, t.UTC().Format(time.RFC3339)), &answer, func() (interface{}, error) {
+		return p.GitProvider.SearchIssuesClosedSince(org, name, t)
+	})
+	return answer, err
+}
+
+// ListReleases lists the releases of the repository
+func (p *CachingGitProvider) ListReleases(org string, name string) ([]*GitRelease, error) {
+	answer := []*GitRelease{}
+	err := p.cached(repoScope(org, name), cacheKey("ListReleases", org, name), &answer, func() (interface{}, error) {
+		return p.GitProvider.ListReleases(org, name)
+	})
+	return answer, err
+}
+
+// GetContent gets the content of the file of the repository at the ref
+func (p *CachingGitProvider) GetContent(org string, name string, path string, ref string) (*GitFileContent, error) {
+	var answer *GitFileContent
+	err := p.cached(repoScope(org, name), cacheKey("GetContent", org, name, path, ref), &answer, func() (interface{}, error) {
+		return p.GitProvider.GetContent(org, name, path, ref)
+	})
+	return answer, err
+}
+
+// UserInfo gets the user
+func (p *CachingGitProvider) UserInfo(username string) *GitUser {
+	var answer *GitUser
+	err := p.cached("users", cacheKey("UserInfo", username), &answer, func() (interface{}, error) {
+		return p.GitProvider.UserInfo(username), nil
+	})
+	if err != nil {
+		log.Warnf("Failed to get the user %s: %s\n", username, err)
+	}
+	return answer
+}
+
+// CreateRepository creates the repository
+func (p *CachingGitProvider) CreateRepository(org string, name string, private bool) (*GitRepository, error) {
+	defer p.invalidate(org, name)
+	return p.GitProvider.CreateRepository(org, name, private)
+}
+
+// DeleteRepository deletes the repository
+func (p *CachingGitProvider) DeleteRepository(org string, name string) error {
+	defer p.invalidate(org, name)
+	return p.GitProvider.DeleteRepository(org, name)
+}
+
+// ForkRepository forks the repository into the destination organisation
+func (p *CachingGitProvider) ForkRepository(originalOrg string, name string, destinationOrg string) (*GitRepository, error) {
+	defer p.invalidate(destinationOrg, name)
+	return p.GitProvider.ForkRepository(originalOrg, name, destinationOrg)
+}
+
+// RenameRepository renames the repository
+func (p *CachingGitProvider) RenameRepository(org string, name string, newName string) (*GitRepository, error) {
+	defer p.invalidate(org, name)
+	defer p.invalidate(org, newName)
+	return p.GitProvider.RenameRepository(org, name, newName)
+}
+
+// CreatePullRequest creates the pull request
+func (p *CachingGitProvider) CreatePullRequest(data *GitPullRequestArguments) (*GitPullRequest, error) {
+	if data != nil && data.GitRepository != nil {
+		defer p.invalidate(data.GitRepository.Organisation, data.GitRepository.Name)
+	}
+	return p.GitProvider.CreatePullRequest(data)
+}
+
+// MergePullRequest merges the pull request
+func (p *CachingGitProvider) MergePullRequest(pr *GitPullRequest, message string) error {
+	defer p.invalidate(pullRequestRepoScope(pr))
+	return p.GitProvider.MergePullRequest(pr, message)
+}
+
+// UpdateCommitStatus updates the status of the commit
+func (p *CachingGitProvider) UpdateCommitStatus(org string, repo string, sha string, status *GitRepoStatus) (*GitRepoStatus, error) {
+	defer p.invalidate(org, repo)
+	return p.GitProvider.UpdateCommitStatus(org, repo, sha, status)
+}
+
+// CreateWebHook creates the webhook
+func (p *CachingGitProvider) CreateWebHook(data *GitWebHookArguments) error {
+	defer p.invalidateWebHook(data)
+	return p.GitProvider.CreateWebHook(data)
+}
+
+// UpdateWebHook updates the webhook
+func (p *CachingGitProvider) UpdateWebHook(data *GitWebHookArguments) error {
+	defer p.invalidateWebHook(data)
+	return p.GitProvider.UpdateWebHook(data)
+}
+
+func (p *CachingGitProvider) invalidateWebHook(data *GitWebHookArguments) {
+	if data == nil || data.Repo == nil {
+		return
+	}
+	owner := data.Owner
+	if owner == "" {
+		owner = data.Repo.Organisation
+	}
+	p.invalidate(owner, data.Repo.Name)
+}
+
+// UpdateBranchProtection updates the protection of the branch
+func (p *CachingGitProvider) UpdateBranchProtection(org string, repo string, branch string, protection *GitBranchProtection) error {
+	defer p.invalidate(org, repo)
+	return p.GitProvider.UpdateBranchProtection(org, repo, branch, protection)
+}
+
+// RemoveBranchProtection removes the protection of the branch
+func (p *CachingGitProvider) RemoveBranchProtection(org string, repo string, branch string) error {
+	defer p.invalidate(org, repo)
+	return p.GitProvider.RemoveBranchProtection(org, repo, branch)
+}
+
+// CreateIssue creates the issue
+func (p *CachingGitProvider) CreateIssue(owner string, repo string, issue *GitIssue) (*GitIssue, error) {
+	defer p.invalidate(owner, repo)
+	return p.GitProvider.CreateIssue(owner, repo, issue)
+}
+
+// CreateIssueComment comments on the issue
+func (p *CachingGitProvider) CreateIssueComment(owner string, repo string, number int, comment string) error {
+	defer p.invalidate(owner, repo)
+	return p.GitProvider.CreateIssueComment(owner, repo, number, comment)
+}
+
+// AddPRLabels adds the labels to the pull request
+func (p *CachingGitProvider) AddPRLabels(pr *GitPullRequest, labels []string) error {
+	defer p.invalidate(pullRequestRepoScope(pr))
+	return p.GitProvider.AddPRLabels(pr, labels)
+}
+
+// RemovePRLabel removes the label from the pull request
+func (p *CachingGitProvider) RemovePRLabel(pr *GitPullRequest, label string) error {
+	defer p.invalidate(pullRequestRepoScope(pr))
+	return p.GitProvider.RemovePRLabel(pr, label)
+}
+
+// UpdateRelease updates the release
+func (p *CachingGitProvider) UpdateRelease(owner string, repo string, tag string, releaseInfo *GitRelease) error {
+	defer p.invalidate(owner, repo)
+	return p.GitProvider.UpdateRelease(owner, repo, tag, releaseInfo)
+}
+
+// UploadReleaseAsset uploads the asset to the release
+func (p *CachingGitProvider) UploadReleaseAsset(org string, repo string, tag string, name string, asset *os.File) (*GitReleaseAsset, error) {
+	defer p.invalidate(org, repo)
+	return p.GitProvider.UploadReleaseAsset(org, repo, tag, name, asset)
+}
+
+// AddCollaborator adds the collaborator to the repository
+func (p *CachingGitProvider) AddCollaborator(user string, organisation string, repo string) error {
+	defer p.invalidate(organisation, repo)
+	return p.GitProvider.AddCollaborator(user, organisation, repo)
+}
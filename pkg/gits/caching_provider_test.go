@@ -0,0 +1,180 @@
+package gits_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// httpFakeProvider a fake provider which lists pull requests over HTTP so that its transport can be decorated
+type httpFakeProvider struct {
+	*gits.FakeProvider
+	client    *http.Client
+	serverURL string
+}
+
+func (p *httpFakeProvider) DecorateTransport(decorate func(http.RoundTripper) http.RoundTripper) {
+	p.client.Transport = decorate(p.client.Transport)
+}
+
+func (p *httpFakeProvider) ListOpenPullRequests(owner string, repo string) ([]*gits.GitPullRequest, error) {
+	resp, err := p.client.Get(fmt.Sprintf("%s/repos/%s/%s/pulls", p.serverURL, owner, repo))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	answer := []*gits.GitPullRequest{}
+	err = json.NewDecoder(resp.Body).Decode(&answer)
+	return answer, err
+}
+
+func (p *httpFakeProvider) MergePullRequest(pr *gits.GitPullRequest, message string) error {
+	req, err := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/repos/%s/%s/pulls/%d/merge", p.serverURL, pr.Owner, pr.Repo, *pr.Number), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func TestCachingGitProviderCachesResults(t *testing.T) {
+	t.Parallel()
+	fakeProvider := gits.NewFakeProvider(gits.NewFakeRepository("jstrachan", "cheese"))
+	fakeProvider.WebHooks = []*gits.GitWebHookArguments{{ID: 1, Owner: "jstrachan", URL: "https://hook.jx/1"}}
+	options := gits.CachingOptions{TTL: time.Minute}
+	provider := gits.NewCachingGitProvider(fakeProvider, options)
+
+	hooks, err := provider.ListWebHooks("jstrachan", "cheese")
+	require.NoError(t, err)
+	assert.Len(t, hooks, 1)
+
+	// lets modify the hooks without going through the caching provider
+	fakeProvider.WebHooks = append(fakeProvider.WebHooks, &gits.GitWebHookArguments{ID: 2, Owner: "jstrachan", URL: "https://hook.jx/2"})
+	hooks, err = provider.ListWebHooks("jstrachan", "cheese")
+	require.NoError(t, err)
+	assert.Len(t, hooks, 1, "should use the cached webhooks")
+
+	err = provider.CreateWebHook(&gits.GitWebHookArguments{
+		Owner: "jstrachan",
+		Repo:  &gits.GitRepository{Organisation: "jstrachan", Name: "cheese"},
+		URL:   "https://hook.jx/3",
+	})
+	require.NoError(t, err)
+	hooks, err = provider.ListWebHooks("jstrachan", "cheese")
+	require.NoError(t, err)
+	assert.Len(t, hooks, 3, "creating a webhook should invalidate the cached webhooks")
+
+	metrics := provider.Metrics()
+	assert.Equal(t, int64(1), metrics.CacheHits)
+	assert.Equal(t, int64(2), metrics.Requests)
+	assert.True(t, provider == gits.NewCachingGitProvider(provider, options), "should not decorate a caching provider twice")
+}
+
+func TestCachingGitProviderConditionalRequestsAndRateLimits(t *testing.T) {
+	t.Parallel()
+	requests := 0
+	notModified := 0
+	etag := `"v1"`
+	remaining := 3
+	reset := time.Now().Add(time.Hour).Unix()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		status := http.StatusOK
+		switch {
+		case r.Method == http.MethodPut:
+			etag = `"v2"`
+			remaining--
+		case r.Header.Get("If-None-Match") == etag:
+			notModified++
+			status = http.StatusNotModified
+		default:
+			remaining--
+			w.Header().Set("ETag", etag)
+		}
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset, 10))
+		w.WriteHeader(status)
+		if status == http.StatusOK && r.Method == http.MethodGet {
+			fmt.Fprintf(w, `[{"Title": "pr %s", "Owner": "jstrachan", "Repo": "cheese"}]`, strings.Trim(etag, `"`))
+		}
+	}))
+	defer server.Close()
+
+	fakeProvider := &httpFakeProvider{
+		FakeProvider: gits.NewFakeProvider(),
+		client:       &http.Client{},
+		serverURL:    server.URL,
+	}
+	provider := gits.NewCachingGitProvider(fakeProvider, gits.CachingOptions{
+		TTL:             time.Minute,
+		RateLimitPolicy: gits.RateLimitPolicyFail,
+		MinRemaining:    0,
+	})
+
+	for i := 0; i < 2; i++ {
+		prs, err := provider.ListOpenPullRequests("jstrachan", "cheese")
+		require.NoError(t, err)
+		require.Len(t, prs, 1)
+		assert.Equal(t, "pr v1", prs[0].Title)
+	}
+	assert.Equal(t, 2, requests)
+	assert.Equal(t, 1, notModified, "should revalidate the cached pull requests with a conditional request")
+
+	number := 1
+	err := provider.MergePullRequest(&gits.GitPullRequest{Owner: "jstrachan", Repo: "cheese", Number: &number}, "merge")
+	require.NoError(t, err)
+	prs, err := provider.ListOpenPullRequests("jstrachan", "cheese")
+	require.NoError(t, err)
+	require.Len(t, prs, 1)
+	assert.Equal(t, "pr v2", prs[0].Title, "merging should invalidate the cached pull requests")
+
+	limits, err := provider.GetRateLimits()
+	require.NoError(t, err)
+	require.Len(t, limits, 1)
+	assert.Equal(t, gits.RateLimitResourceCore, limits[0].Resource)
+	assert.Equal(t, 5000, limits[0].Limit)
+	assert.Equal(t, 0, limits[0].Remaining)
+
+	_, err = provider.ListOpenPullRequests("jstrachan", "cheese")
+	require.Error(t, err, "should fail as the rate limit is exhausted")
+	assert.Contains(t, err.Error(), "rate limit")
+	assert.Equal(t, 4, requests)
+
+	metrics := provider.Metrics()
+	assert.Equal(t, int64(1), metrics.NotModified)
+	assert.Equal(t, int64(1), metrics.RateLimitFailures)
+}
+
+func TestParseRateLimitHeaders(t *testing.T) {
+	t.Parallel()
+	header := http.Header{}
+	assert.Nil(t, gits.ParseRateLimitHeaders(header, gits.RateLimitResourceCore))
+
+	header.Set("RateLimit-Limit", "600")
+	header.Set("RateLimit-Remaining", "598")
+	header.Set("RateLimit-Reset", "1546300800")
+	limit := gits.ParseRateLimitHeaders(header, gits.RateLimitResourceCore)
+	require.NotNil(t, limit)
+	assert.Equal(t, gits.GitRateLimit{
+		Resource:  gits.RateLimitResourceCore,
+		Limit:     600,
+		Remaining: 598,
+		Reset:     time.Unix(1546300800, 0),
+	}, *limit)
+
+	assert.Equal(t, gits.RateLimitResourceSearch, gits.RateLimitResource("/api/v3/search/issues"))
+	assert.Equal(t, gits.RateLimitResourceCore, gits.RateLimitResource("/repos/jstrachan/cheese/pulls"))
+}
@@ -0,0 +1,214 @@
+package gits
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/log"
+)
+
+// HTTPTransportDecorator is implemented by the git providers whose HTTP transport can be decorated, for example with
+// the conditional requests, caching and rate limit handling of a CachingGitProvider
+type HTTPTransportDecorator interface {
+	// DecorateTransport replaces the HTTP transport of the git provider with the result of the decorate function
+	DecorateTransport(decorate func(http.RoundTripper) http.RoundTripper)
+}
+
+// cachingTransport an HTTP transport which caches GET responses with an ETag or Last-Modified header, revalidates them
+// using conditional requests and waits or fails when the rate limit of the git provider is exhausted
+type cachingTransport struct {
+	base    http.RoundTripper
+	options CachingOptions
+	cache   *gitCache
+	limits  *rateLimitTracker
+	metrics *CacheMetrics
+	now     func() time.Time
+	sleep   func(time.Duration)
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *cachingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	scope := requestScope(req)
+	key := req.Method + " " + req.URL.String()
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		// the request may modify the resources of the scope
+		t.cache.invalidate(scope)
+		return t.send(req)
+	}
+	// responses are always revalidated as callers poll pull requests and statuses
+	entry := t.cache.get(scope, key)
+	conditional := req
+	if entry != nil {
+		conditional = cloneRequest(req)
+		if entry.ETag != "" {
+			conditional.Header.Set("If-None-Match", entry.ETag)
+		}
+		if entry.LastModified != "" {
+			conditional.Header.Set("If-Modified-Since", entry.LastModified)
+		}
+	}
+	resp, err := t.send(conditional)
+	if err != nil {
+		return resp, err
+	}
+	if resp.StatusCode == http.StatusNotModified && entry != nil {
+		resp.Body.Close()
+		atomic.AddInt64(&t.metrics.NotModified, 1)
+		return entry.response(req, resp.Header), nil
+	}
+	etag := resp.Header.Get("ETag")
+	lastModified := resp.Header.Get("Last-Modified")
+	if resp.StatusCode != http.StatusOK || req.Method != http.MethodGet || (etag == "" && lastModified == "") {
+		return resp, nil
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	t.cache.put(scope, &gitCacheEntry{
+		Key:          key,
+		ETag:         etag,
+		LastModified: lastModified,
+		Header:       withoutRateLimitHeaders(resp.Header),
+		Body:         body,
+	})
+	return resp, nil
+}
+
+// send sends the request once the rate limit allows it, retrying once if the git provider rejects it as the rate
+// limit is exhausted and the policy is to wait
+func (t *cachingTransport) send(req *http.Request) (*http.Response, error) {
+	resource := RateLimitResource(req.URL.Path)
+	err := t.checkRateLimit(resource)
+	if err != nil {
+		return nil, err
+	}
+	atomic.AddInt64(&t.metrics.Requests, 1)
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	limit := ParseRateLimitHeaders(resp.Header, resource)
+	t.limits.update(limit)
+	rejected := limit != nil && limit.Remaining == 0 &&
+		(resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests)
+	if !rejected || (req.Body != nil && req.GetBody == nil) {
+		return resp, nil
+	}
+	err = t.checkRateLimit(resource)
+	if err != nil {
+		// lets return the rejection of the git provider
+		return resp, nil
+	}
+	resp.Body.Close()
+	retry := cloneRequest(req)
+	if req.GetBody != nil {
+		retry.Body, err = req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+	}
+	atomic.AddInt64(&t.metrics.Requests, 1)
+	resp, err = t.base.RoundTrip(retry)
+	if err == nil {
+		t.limits.update(ParseRateLimitHeaders(resp.Header, resource))
+	}
+	return resp, err
+}
+
+// checkRateLimit waits until the rate limit of the resource is reset or fails depending on the policy if the rate
+// limit is exhausted
+func (t *cachingTransport) checkRateLimit(resource string) error {
+	limit := t.limits.exhausted(resource, t.options.MinRemaining)
+	if limit == nil {
+		return nil
+	}
+	wait := limit.Reset.Sub(t.now())
+	if t.options.RateLimitPolicy != RateLimitPolicyWait || wait > t.options.MaxWait {
+		atomic.AddInt64(&t.metrics.RateLimitFailures, 1)
+		return &RateLimitError{RateLimit: *limit}
+	}
+	atomic.AddInt64(&t.metrics.RateLimitWaits, 1)
+	log.Infof("Waiting %s for the %s API rate limit of the git provider to be reset\n", wait.Round(time.Second), resource)
+	t.sleep(wait)
+	return nil
+}
+
+// response returns a new response for the request from the cached entry. The rate limit headers of a conditional
+// request are used as the cached ones are out of date
+func (e *gitCacheEntry) response(req *http.Request, header http.Header) *http.Response {
+	h := http.Header{}
+	for k, v := range e.Header {
+		h[k] = v
+	}
+	for k, v := range header {
+		if isRateLimitHeader(k) {
+			h[k] = v
+		}
+	}
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        h,
+		Body:          ioutil.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
+
+func withoutRateLimitHeaders(header http.Header) http.Header {
+	answer := http.Header{}
+	for k, v := range header {
+		if !isRateLimitHeader(k) {
+			answer[k] = v
+		}
+	}
+	return answer
+}
+
+func isRateLimitHeader(name string) bool {
+	name = http.CanonicalHeaderKey(name)
+	return strings.HasPrefix(name, "X-Ratelimit-") || strings.HasPrefix(name, "Ratelimit-") || name == "Retry-After"
+}
+
+// requestScope returns the repository, project or organisation a request is for so that cached responses can be
+// invalidated when it is modified
+func requestScope(req *http.Request) string {
+	paths := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	for _, name := range []string{"repos", "repositories", "projects", "orgs", "users"} {
+		for i := 0; i < len(paths)-1; i++ {
+			if paths[i] != name {
+				continue
+			}
+			end := i + 2
+			// GitHub and Gitea repositories are named by owner whereas Bitbucket Server ones are within a project
+			if name == "repos" && (i < 2 || paths[i-2] != "projects") {
+				end = i + 3
+			}
+			if end > len(paths) {
+				end = len(paths)
+			}
+			return req.URL.Host + "/" + strings.Join(paths[:end], "/")
+		}
+	}
+	return req.URL.Host
+}
+
+func cloneRequest(req *http.Request) *http.Request {
+	r := new(http.Request)
+	*r = *req
+	r.Header = make(http.Header, len(req.Header))
+	for k, v := range req.Header {
+		r.Header[k] = append([]string(nil), v...)
+	}
+	return r
+}
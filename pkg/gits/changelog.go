@@ -0,0 +1,244 @@
+package gits
+
+import (
+	"bytes"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultChangelogTemplateFile the file in a repository containing the template used to render its changelog
+	DefaultChangelogTemplateFile = "changelog.tmpl"
+
+	// DefaultChangelogFile the changelog file maintained in a repository
+	DefaultChangelogFile = "CHANGELOG.md"
+
+	changelogPreamble = `# Changelog
+
+All notable changes to this project will be documented in this file.
+
+The format is based on [Keep a Changelog](https://keepachangelog.com/en/1.0.0/),
+and this project adheres to [Semantic Versioning](https://semver.org/spec/v2.0.0.html).
+`
+)
+
+var changelogHeadingRegex = regexp.MustCompile(`(?m)^## `)
+
+// ChangelogData is the data available to changelog templates
+type ChangelogData struct {
+	// Release the release including all of its commits, issues and pull requests
+	Release *v1.ReleaseSpec
+	// Groups the commits grouped by their conventional commit type in the order they are usually rendered
+	Groups []*ChangelogGroup
+	// Issues the issues fixed in the release
+	Issues []v1.IssueSummary
+	// PullRequests the pull requests merged in the release
+	PullRequests []v1.IssueSummary
+	// Contributors the authors of the commits of the release
+	Contributors []v1.UserDetails
+	// Breaking the commits which contain breaking changes
+	Breaking []*ChangelogCommit
+}
+
+// ChangelogGroup the commits of a conventional commit type
+type ChangelogGroup struct {
+	// Kind the conventional commit type such as feat or fix
+	Kind string
+	// Title the title of the group such as New Features
+	Title   string
+	Commits []*ChangelogCommit
+}
+
+// ChangelogCommit a commit of the release
+type ChangelogCommit struct {
+	v1.CommitSummary
+
+	// Kind the conventional commit type
+	Kind string
+	// Scope the optional scope of the conventional commit
+	Scope string
+	// Subject the first line of the message without the type and scope
+	Subject string
+	// Breaking is true if the commit contains a breaking change
+	Breaking bool
+	// Issues the issues referenced by the commit
+	Issues []v1.IssueSummary
+}
+
+// NewChangelogData groups the commits, issues, pull requests and contributors of the release for use in templates
+func NewChangelogData(releaseSpec *v1.ReleaseSpec) *ChangelogData {
+	data := &ChangelogData{
+		Release:      releaseSpec,
+		Issues:       releaseSpec.Issues,
+		PullRequests: releaseSpec.PullRequests,
+	}
+	issueMap := map[string]v1.IssueSummary{}
+	for _, issue := range releaseSpec.Issues {
+		issueMap[issue.ID] = issue
+	}
+	groups := map[string]*ChangelogGroup{}
+	contributors := map[string]bool{}
+	for _, cs := range releaseSpec.Commits {
+		if cs.Message == "" {
+			continue
+		}
+		commit := newChangelogCommit(cs, issueMap)
+		group := groups[commit.Kind]
+		if group == nil {
+			group = &ChangelogGroup{
+				Kind:  commit.Kind,
+				Title: ConventionalCommitTypeToTitle(commit.Kind).Title,
+			}
+			if group.Title == "" {
+				group.Title = "Other Changes"
+			}
+			groups[commit.Kind] = group
+			data.Groups = append(data.Groups, group)
+		}
+		group.Commits = append(group.Commits, commit)
+		if commit.Breaking {
+			data.Breaking = append(data.Breaking, commit)
+		}
+
+		user := cs.Author
+		if user == nil {
+			user = cs.Committer
+		}
+		if user != nil {
+			key := user.Login
+			if key == "" {
+				key = user.Email
+			}
+			if key == "" {
+				key = user.Name
+			}
+			if key != "" && !contributors[key] {
+				contributors[key] = true
+				data.Contributors = append(data.Contributors, *user)
+			}
+		}
+	}
+	sort.SliceStable(data.Groups, func(i, j int) bool {
+		return ConventionalCommitTypeToTitle(data.Groups[i].Kind).Order < ConventionalCommitTypeToTitle(data.Groups[j].Kind).Order
+	})
+	return data
+}
+
+func newChangelogCommit(cs v1.CommitSummary, issueMap map[string]v1.IssueSummary) *ChangelogCommit {
+	ci := ParseCommit(cs.Message)
+	commit := &ChangelogCommit{
+		CommitSummary: cs,
+		Kind:          strings.ToLower(ci.Kind),
+		Scope:         ci.Feature,
+		Subject:       strings.TrimSpace(strings.SplitN(strings.TrimSpace(ci.Message), "\n", 2)[0]),
+		Breaking:      ci.Breaking,
+	}
+	// lets handle commits like feat(cheese): something
+	idx := strings.Index(commit.Kind, "(")
+	if idx > 0 && strings.HasSuffix(commit.Kind, ")") {
+		commit.Scope = commit.Kind[idx+1 : len(commit.Kind)-1]
+		commit.Kind = strings.TrimSuffix(commit.Kind[0:idx], "!")
+	}
+	if ConventionalCommitTitles[commit.Kind] == nil && strings.ContainsAny(commit.Kind, " \t") {
+		// not a conventional commit
+		commit.Kind = ""
+		commit.Scope = ""
+		commit.Subject = strings.TrimSpace(strings.SplitN(strings.TrimSpace(cs.Message), "\n", 2)[0])
+	}
+	for _, id := range cs.IssueIDs {
+		if issue, ok := issueMap[id]; ok {
+			commit.Issues = append(commit.Issues, issue)
+		}
+	}
+	return commit
+}
+
+// GenerateChangelog renders the changelog of the release using the go template which can use the fields of
+// ChangelogData and the functions: user, issue, shortSha and indent
+func GenerateChangelog(releaseSpec *v1.ReleaseSpec, gitInfo *GitRepository, templateText string) (string, error) {
+	funcs := template.FuncMap{
+		"user": func(user *v1.UserDetails) string {
+			return strings.TrimSuffix(strings.TrimPrefix(describeUser(gitInfo, user), " ("), ")")
+		},
+		"issue": func(issue v1.IssueSummary) string {
+			return strings.TrimSpace(describeIssueShort(gitInfo, &issue))
+		},
+		"shortSha": func(sha string) string {
+			if len(sha) > 7 {
+				return sha[0:7]
+			}
+			return sha
+		},
+		"indent": func(spaces int, text string) string {
+			pad := strings.Repeat(" ", spaces)
+			return pad + strings.Replace(text, "\n", "\n"+pad, -1)
+		},
+	}
+	tmpl, err := template.New("changelog").Funcs(funcs).Parse(templateText)
+	if err != nil {
+		return "", errors.Wrap(err, "parsing the changelog template")
+	}
+	var buffer bytes.Buffer
+	err = tmpl.Execute(&buffer, NewChangelogData(releaseSpec))
+	if err != nil {
+		return "", errors.Wrap(err, "rendering the changelog template")
+	}
+	return buffer.String(), nil
+}
+
+// UpdateChangelog adds the release notes of the version to the changelog document in Keep a Changelog style, replacing
+// the section of the version if it already exists. The section is added after any Unreleased section and the headings
+// of the notes are nested within the section of the version. If the changelog is empty a new one is created
+// see: https://keepachangelog.com/
+func UpdateChangelog(changelog string, version string, date string, url string, notes string) string {
+	title := "[" + version + "]"
+	if url != "" {
+		title += "(" + url + ")"
+	}
+	if date != "" {
+		title += " - " + date
+	}
+	section := "## " + title + "\n\n" + strings.TrimSpace(nestHeadings(notes)) + "\n\n"
+
+	if strings.TrimSpace(changelog) == "" {
+		return changelogPreamble + "\n" + section
+	}
+	sections := changelogHeadingRegex.FindAllStringIndex(changelog, -1)
+	for i, loc := range sections {
+		if strings.HasPrefix(changelog[loc[1]:], "["+version+"]") {
+			end := len(changelog)
+			if i+1 < len(sections) {
+				end = sections[i+1][0]
+			}
+			return changelog[0:loc[0]] + section + changelog[end:]
+		}
+	}
+	insertAt := len(changelog)
+	for _, loc := range sections {
+		if !strings.HasPrefix(strings.ToLower(changelog[loc[1]:]), "[unreleased]") {
+			insertAt = loc[0]
+			break
+		}
+	}
+	if insertAt == len(changelog) && !strings.HasSuffix(changelog, "\n\n") {
+		changelog = strings.TrimRight(changelog, "\n") + "\n\n"
+		insertAt = len(changelog)
+	}
+	return changelog[0:insertAt] + section + changelog[insertAt:]
+}
+
+// nestHeadings increases the level of the markdown headings so they nest inside the section of a version
+func nestHeadings(markdown string) string {
+	lines := strings.Split(markdown, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, "#") {
+			lines[i] = "#" + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,102 @@
+package gits_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateChangelog(t *testing.T) {
+	t.Parallel()
+	gitInfo, err := gits.ParseGitURL("https://github.com/jenkins-x/jx")
+	require.NoError(t, err)
+
+	james := &v1.UserDetails{Login: "jstrachan"}
+	rawlingsj := &v1.UserDetails{Login: "rawlingsj"}
+	spec := &v1.ReleaseSpec{
+		Version: "1.2.0",
+		Commits: []v1.CommitSummary{
+			{Message: "fix: cheese is not mouldy", SHA: "123456789abc", Author: james, IssueIDs: []string{"12"}},
+			{Message: "feat(wine)!: only red wine\n\nBREAKING CHANGE: no more white", SHA: "abcdef123456", Author: rawlingsj},
+			{Message: "update the readme", SHA: "fedcba987654", Author: james},
+		},
+		Issues: []v1.IssueSummary{
+			{ID: "12", Title: "mouldy cheese", URL: "https://github.com/jenkins-x/jx/issues/12"},
+		},
+	}
+
+	templateText := `# {{ .Release.Version }}
+{{ range .Groups }}
+## {{ .Title }}
+{{ range .Commits }}
+- {{ if .Scope }}**{{ .Scope }}:** {{ end }}{{ .Subject }} {{ shortSha .SHA }}{{ range .Issues }} {{ issue . }}{{ end }}
+{{- end }}
+{{ end }}
+{{- range .Breaking }}
+BREAKING: {{ .Subject }}
+{{- end }}
+Thanks to{{ range .Contributors }} {{ user . }}{{ end }}
+`
+	markdown, err := gits.GenerateChangelog(spec, gitInfo, templateText)
+	require.NoError(t, err)
+
+	expected := `# 1.2.0
+
+## New Features
+
+- **wine:** only red wine abcdef1
+
+## Bug Fixes
+
+- cheese is not mouldy 1234567 [#12](https://github.com/jenkins-x/jx/issues/12)
+
+## Other Changes
+
+- update the readme fedcba9
+
+BREAKING: only red wine
+Thanks to [jstrachan](https://github.com/jstrachan) [rawlingsj](https://github.com/rawlingsj)
+`
+	assert.Equal(t, expected, markdown)
+
+	_, err = gits.GenerateChangelog(spec, gitInfo, "{{ .DoesNotExist }}")
+	assert.Error(t, err)
+}
+
+func TestUpdateChangelog(t *testing.T) {
+	t.Parallel()
+	notes := "## Changes\n\n### Bug Fixes\n\n* cheese\n"
+
+	changelog := gits.UpdateChangelog("", "1.0.0", "2019-05-01", "", notes)
+	assert.Equal(t, `# Changelog
+
+All notable changes to this project will be documented in this file.
+
+The format is based on [Keep a Changelog](https://keepachangelog.com/en/1.0.0/),
+and this project adheres to [Semantic Versioning](https://semver.org/spec/v2.0.0.html).
+
+## [1.0.0] - 2019-05-01
+
+### Changes
+
+#### Bug Fixes
+
+* cheese
+
+`, changelog)
+
+	existing := "# Changelog\n\n## [Unreleased]\n\n- wine\n\n## [1.0.0] - 2019-05-01\n\n- cheese\n"
+	changelog = gits.UpdateChangelog(existing, "1.1.0", "2019-06-01", "https://github.com/jenkins-x/jx/releases/tag/v1.1.0", "* beer\n")
+	assert.Equal(t, "# Changelog\n\n## [Unreleased]\n\n- wine\n\n"+
+		"## [1.1.0](https://github.com/jenkins-x/jx/releases/tag/v1.1.0) - 2019-06-01\n\n* beer\n\n"+
+		"## [1.0.0] - 2019-05-01\n\n- cheese\n", changelog)
+
+	// regenerating the notes of a version replaces its section
+	changelog = gits.UpdateChangelog(changelog, "1.0.0", "2019-05-02", "", "* brie\n")
+	assert.Equal(t, "# Changelog\n\n## [Unreleased]\n\n- wine\n\n"+
+		"## [1.1.0](https://github.com/jenkins-x/jx/releases/tag/v1.1.0) - 2019-06-01\n\n* beer\n\n"+
+		"## [1.0.0] - 2019-05-02\n\n* brie\n\n", changelog)
+}
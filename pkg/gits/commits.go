@@ -12,7 +12,9 @@ type CommitInfo struct {
 	Kind    string
 	Feature string
 	Message string
-	group   *CommitGroup
+	// Breaking is true if the type is followed by ! or the message has a BREAKING CHANGE footer
+	Breaking bool
+	group    *CommitGroup
 }
 
 type CommitGroup struct {
@@ -66,9 +68,14 @@ func ParseCommit(message string) *CommitInfo {
 		Message: message,
 	}
 
+	answer.Breaking = hasBreakingChangeFooter(message)
 	idx := strings.Index(message, ":")
 	if idx > 0 {
 		answer.Kind = message[0:idx]
+		if strings.HasSuffix(answer.Kind, "!") && !strings.Contains(answer.Kind, "\n") {
+			answer.Kind = strings.TrimSuffix(answer.Kind, "!")
+			answer.Breaking = true
+		}
 
 		rest := strings.TrimSpace(message[idx+1:])
 		if strings.HasPrefix(rest, "(") {
@@ -83,6 +90,65 @@ func ParseCommit(message string) *CommitInfo {
 	return answer
 }
 
+// VersionChange the part of a semantic version which is incremented for a commit
+type VersionChange int
+
+const (
+	// VersionChangePatch increments the patch version for fixes and other changes
+	VersionChangePatch VersionChange = iota
+	// VersionChangeMinor increments the minor version for new features
+	VersionChangeMinor
+	// VersionChangeMajor increments the major version for breaking changes
+	VersionChangeMajor
+)
+
+// String returns the name of the part of the version which changes
+func (c VersionChange) String() string {
+	switch c {
+	case VersionChangeMajor:
+		return "major"
+	case VersionChangeMinor:
+		return "minor"
+	default:
+		return "patch"
+	}
+}
+
+// ParseVersionChange returns the part of the semantic version a conventional commit increments. A breaking commit
+// increments the major version, a feat the minor version and anything else the patch
+// see: https://conventionalcommits.org/
+func ParseVersionChange(message string) VersionChange {
+	ci := ParseCommit(strings.TrimSpace(message))
+	if ci.Breaking {
+		return VersionChangeMajor
+	}
+	// lets ignore the scope of commits like feat(cheese): something
+	kind := ci.Kind
+	idx := strings.Index(kind, "(")
+	if idx > 0 {
+		kind = kind[0:idx]
+	}
+	if strings.ToLower(strings.TrimSpace(kind)) == "feat" {
+		return VersionChangeMinor
+	}
+	return VersionChangePatch
+}
+
+// hasBreakingChangeFooter returns true if a footer of the commit message, after the subject and a blank line, is a
+// BREAKING CHANGE
+func hasBreakingChangeFooter(message string) bool {
+	paragraphs := strings.SplitN(strings.TrimSpace(message), "\n\n", 2)
+	if len(paragraphs) < 2 {
+		return false
+	}
+	for _, line := range strings.Split(paragraphs[1], "\n") {
+		if strings.HasPrefix(line, "BREAKING CHANGE:") || strings.HasPrefix(line, "BREAKING-CHANGE:") {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *CommitInfo) Group() *CommitGroup {
 	if c.group == nil {
 		c.group = ConventionalCommitTitles[strings.ToLower(c.Kind)]
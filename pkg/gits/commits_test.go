@@ -26,6 +26,33 @@ func TestParseCommits(t *testing.T) {
 		Feature: "",
 		Message: "wine is good too",
 	})
+	assertParseCommit(t, "feat(beer)!: no more wine", &gits.CommitInfo{
+		Kind:     "feat(beer)",
+		Message:  "no more wine",
+		Breaking: true,
+	})
+}
+
+func TestParseVersionChange(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]gits.VersionChange{
+		"something regular":                                                gits.VersionChangePatch,
+		"fix: cheese":                                                      gits.VersionChangePatch,
+		"chore(deps): upgrade":                                             gits.VersionChangePatch,
+		"feat: cheese":                                                     gits.VersionChangeMinor,
+		"feat(beer): wine is good too":                                     gits.VersionChangeMinor,
+		"feat!: remove the cheese API":                                     gits.VersionChangeMajor,
+		"refactor(api)!: rename everything":                                gits.VersionChangeMajor,
+		"fix: cheese\n\nBREAKING CHANGE: no brie":                          gits.VersionChangeMajor,
+		"docs: explain the feat: prefix in a title":                        gits.VersionChangePatch,
+		"fix: mention BREAKING CHANGE in the subject":                      gits.VersionChangePatch,
+		"fix: cheese\n\nthis is not a BREAKING CHANGE: of anything":        gits.VersionChangePatch,
+		"feat: cheese\n\nsome details\n\nBREAKING-CHANGE: no brie":         gits.VersionChangeMajor,
+		"fix: cheese\n\nCloses #1\nBREAKING CHANGE: the config file moved": gits.VersionChangeMajor,
+	}
+	for message, expected := range testCases {
+		assert.Equal(t, expected, gits.ParseVersionChange(message), "VersionChange for commit %s", message)
+	}
 }
 
 func assertParseCommit(t *testing.T, input string, expected *gits.CommitInfo) {
@@ -11,6 +11,10 @@ const (
 	KindGitlab = "gitlab"
 	// KindGitHub git kind for github
 	KindGitHub = "github"
+	// KindGerrit git kind for gerrit
+	KindGerrit = "gerrit"
+	// KindAzureDevOps git kind for Azure DevOps Services and Server
+	KindAzureDevOps = "azuredevops"
 	// KindGitFake git kind for fake git
 	KindGitFake = "fakegit"
 	// KindUnknown git kind for unknown git
@@ -24,5 +28,5 @@ const (
 )
 
 var (
-	KindGits = []string{KindBitBucketCloud, KindBitBucketServer, KindGitea, KindGitHub, KindGitlab}
+	KindGits = []string{KindAzureDevOps, KindBitBucketCloud, KindBitBucketServer, KindGerrit, KindGitea, KindGitHub, KindGitlab}
 )
@@ -2,14 +2,40 @@ package gits
 
 import (
 	"context"
+	"crypto/sha1"
 	"fmt"
 	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/andygrunwald/go-gerrit"
 	"github.com/google/go-github/github"
 	"github.com/jenkins-x/jx/pkg/auth"
 	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const (
+	// GerritVerifiedLabel is the review label used to report the status of builds on a change
+	GerritVerifiedLabel = "Verified"
+	// GerritCodeReviewLabel is the review label used for code reviews of a change
+	GerritCodeReviewLabel = "Code-Review"
+
+	gerritChangeIDPrefix = "Change-Id: "
+	gerritStatusNew      = "NEW"
+	gerritStatusMerged   = "MERGED"
+)
+
+var (
+	// gerritChangeFields the additional fields needed to map a change onto a pull request
+	gerritChangeFields = []string{"LABELS", "CURRENT_REVISION", "CURRENT_COMMIT", "DETAILED_ACCOUNTS"}
+
+	gerritChangeIDRegex  = regexp.MustCompile(`(?m)^Change-Id: (I[0-9a-f]{40})\s*$`)
+	gerritCommitSHARegex = regexp.MustCompile(`^[0-9a-f]{40}$`)
 )
 
 type GerritProvider struct {
@@ -63,6 +89,60 @@ func buildEncodedProjectName(org, name string) string {
 	return fullNamePathEscaped
 }
 
+// gerritProjectName returns the unescaped name of the project used in change queries
+// gerritFileID encodes a file path as a single URL path segment, escaping the slashes of nested paths as the
+// file IDs of the Gerrit REST API require
+func gerritFileID(path string) string {
+	return strings.Replace(url.PathEscape(path), "/", "%2F", -1)
+}
+
+func gerritProjectName(org, name string) string {
+	if org != "" {
+		return org + "/" + name
+	}
+	return name
+}
+
+// splitGerritProjectName splits the project name into the owner and the repository name
+func splitGerritProjectName(project string) (string, string) {
+	i := strings.LastIndex(project, "/")
+	if i < 0 {
+		return "", project
+	}
+	return project[0:i], project[i+1:]
+}
+
+// GerritRefsFor returns the magic ref to push a commit to so that Gerrit creates or updates a change
+// for the base branch. All the changes of a pull request share the topic so they can be found again
+func GerritRefsFor(base string, topic string) string {
+	ref := "refs/for/" + base
+	if topic != "" {
+		ref += "%topic=" + topic
+	}
+	return ref
+}
+
+// GerritChangeID returns the Change-Id footer of the commit message or blank if there is none
+func GerritChangeID(message string) string {
+	matches := gerritChangeIDRegex.FindStringSubmatch(message)
+	if len(matches) < 2 {
+		return ""
+	}
+	return matches[1]
+}
+
+// AddGerritChangeID appends a Change-Id footer to the commit message if it does not have one already so that the
+// commit can be pushed to Gerrit. If no change ID is specified a new one is generated
+func AddGerritChangeID(message string, changeID string) string {
+	if GerritChangeID(message) != "" {
+		return message
+	}
+	if changeID == "" {
+		changeID = fmt.Sprintf("I%x", sha1.Sum([]byte(fmt.Sprintf("%s\n%d", message, time.Now().UnixNano()))))
+	}
+	return strings.TrimRight(message, "\n") + "\n\n" + gerritChangeIDPrefix + changeID + "\n"
+}
+
 func (p *GerritProvider) projectInfoToGitRepository(project *gerrit.ProjectInfo) *GitRepository {
 	return &GitRepository{
 		Name:     project.Name,
@@ -137,44 +217,296 @@ func (p *GerritProvider) ValidateRepositoryName(org string, name string) error {
 	return nil
 }
 
+// CreatePullRequest returns the change for the pull request.
+//
+// Gerrit creates changes when commits are pushed to the magic refs/for/<base> ref so the commit must already have been
+// pushed using GerritRefsFor with the head branch name as the topic
 func (p *GerritProvider) CreatePullRequest(data *GitPullRequestArguments) (*GitPullRequest, error) {
-	return nil, nil
+	repo := data.GitRepository
+	if repo == nil {
+		return nil, fmt.Errorf("missing property GitRepository")
+	}
+	project := gerritProjectName(repo.Organisation, repo.Name)
+	changes, err := p.queryChanges(fmt.Sprintf("project:%s topic:%s status:open", project, data.Head), 1, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		return nil, fmt.Errorf("no open change found in project %s with topic %s. Please push the commit to %s",
+			project, data.Head, GerritRefsFor(data.Base, data.Head))
+	}
+	return p.changeToPullRequest(&changes[0]), nil
 }
 
+// UpdatePullRequestStatus updates the pull request with the latest state of its change
 func (p *GerritProvider) UpdatePullRequestStatus(pr *GitPullRequest) error {
+	if pr.Number == nil {
+		return fmt.Errorf("missing Number for change %#v", pr)
+	}
+	change, err := p.getChange(*pr.Number)
+	if err != nil {
+		return err
+	}
+	*pr = *p.changeToPullRequest(change)
 	return nil
 }
 
+// GetPullRequest returns the change with the given number
 func (p *GerritProvider) GetPullRequest(owner string, repo *GitRepository, number int) (*GitPullRequest, error) {
-	return nil, nil
+	change, err := p.getChange(number)
+	if err != nil {
+		return nil, err
+	}
+	return p.changeToPullRequest(change), nil
 }
 
-// ListOpenPullRequests lists the open pull requests
+// ListOpenPullRequests lists the open changes of the project
 func (p *GerritProvider) ListOpenPullRequests(owner string, repo string) ([]*GitPullRequest, error) {
-	return nil, nil
+	changes, err := p.queryChanges(fmt.Sprintf("project:%s status:open", gerritProjectName(owner, repo)), 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	answer := []*GitPullRequest{}
+	for i := range changes {
+		answer = append(answer, p.changeToPullRequest(&changes[i]))
+	}
+	return answer, nil
 }
 
+// GetPullRequestCommits returns the commit of the current patch set of the change
 func (p *GerritProvider) GetPullRequestCommits(owner string, repo *GitRepository, number int) ([]*GitCommit, error) {
-	return nil, nil
+	change, err := p.getChange(number)
+	if err != nil {
+		return nil, err
+	}
+	commit := p.changeToCommit(change)
+	if commit == nil {
+		return []*GitCommit{}, nil
+	}
+	return []*GitCommit{commit}, nil
 }
 
+// PullRequestLastCommitStatus returns the status of the change from the votes on its Verified label or on its
+// Code-Review label if the project has no Verified label
 func (p *GerritProvider) PullRequestLastCommitStatus(pr *GitPullRequest) (string, error) {
-	return "", nil
+	if pr.Number == nil {
+		return "", fmt.Errorf("missing Number for change %#v", pr)
+	}
+	change, err := p.getChange(*pr.Number)
+	if err != nil {
+		return "", err
+	}
+	if change.Status == gerritStatusMerged {
+		return "success", nil
+	}
+	for _, name := range []string{GerritVerifiedLabel, GerritCodeReviewLabel} {
+		label, ok := change.Labels[name]
+		if ok {
+			return gerritLabelState(&label), nil
+		}
+	}
+	return "", fmt.Errorf("could not find a %s or %s label on change %d", GerritVerifiedLabel, GerritCodeReviewLabel, change.Number)
 }
 
+// ListCommitStatus returns a status for each review label of the change of the commit
 func (p *GerritProvider) ListCommitStatus(org string, repo string, sha string) ([]*GitRepoStatus, error) {
-	return nil, nil
+	change, err := p.findChangeForCommit(org, repo, sha)
+	if err != nil {
+		return nil, err
+	}
+	link := p.changeURL(change)
+	names := []string{}
+	for name := range change.Labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	statuses := []*GitRepoStatus{}
+	for _, name := range names {
+		label := change.Labels[name]
+		statuses = append(statuses, &GitRepoStatus{
+			ID:          name,
+			Context:     name,
+			URL:         link,
+			TargetURL:   link,
+			State:       gerritLabelState(&label),
+			Description: fmt.Sprintf("%s label of change %d", name, change.Number),
+		})
+	}
+	return statuses, nil
 }
 
-// UpdateCommitStatus updates the status of a specified commit in a specified repo.
+// UpdateCommitStatus reviews the patch set of the commit voting +1 on the Verified label for success, -1 for a failure
+// or error and adding only a message for any other state
 func (p *GerritProvider) UpdateCommitStatus(org, repo, sha string, status *GitRepoStatus) (*GitRepoStatus, error) {
-	return nil, nil
+	change, err := p.findChangeForCommit(org, repo, sha)
+	if err != nil {
+		return nil, err
+	}
+	message := status.Description
+	if status.Context != "" {
+		message = status.Context + ": " + message
+	}
+	if status.TargetURL != "" {
+		message += " " + status.TargetURL
+	}
+	input := &gerrit.ReviewInput{
+		Message: strings.TrimSpace(message),
+	}
+	vote := ""
+	if status.IsSuccess() {
+		vote = "+1"
+	} else if status.IsFailed() {
+		vote = "-1"
+	}
+	if vote != "" {
+		input.Labels = map[string]string{
+			GerritVerifiedLabel: vote,
+		}
+	}
+	_, _, err = p.Client.Changes.SetReview(strconv.Itoa(change.Number), sha, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to review commit %s of change %d due to: %s", sha, change.Number, err)
+	}
+	return status, nil
 }
 
+// MergePullRequest submits the change
 func (p *GerritProvider) MergePullRequest(pr *GitPullRequest, message string) error {
+	if pr.Number == nil {
+		return fmt.Errorf("missing Number for change %#v", pr)
+	}
+	changeID := strconv.Itoa(*pr.Number)
+	if message != "" {
+		_, _, err := p.Client.Changes.SetReview(changeID, "current", &gerrit.ReviewInput{Message: message})
+		if err != nil {
+			return fmt.Errorf("failed to comment on change %s due to: %s", changeID, err)
+		}
+	}
+	_, _, err := p.Client.Changes.SubmitChange(changeID, &gerrit.SubmitInput{})
+	if err != nil {
+		return fmt.Errorf("failed to submit change %s due to: %s", changeID, err)
+	}
 	return nil
 }
 
+func (p *GerritProvider) getChange(number int) (*gerrit.ChangeInfo, error) {
+	change, _, err := p.Client.Changes.GetChange(strconv.Itoa(number), &gerrit.ChangeOptions{
+		AdditionalFields: gerritChangeFields,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get change %d due to: %s", number, err)
+	}
+	return change, nil
+}
+
+// queryChanges returns the changes matching the query. A limit of zero uses the default limit of the server
+func (p *GerritProvider) queryChanges(query string, limit int, skip int) ([]gerrit.ChangeInfo, error) {
+	opt := &gerrit.QueryChangeOptions{}
+	opt.Query = []string{query}
+	opt.Limit = limit
+	opt.Skip = skip
+	opt.AdditionalFields = gerritChangeFields
+	changes, _, err := p.Client.Changes.QueryChanges(opt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query changes %s due to: %s", query, err)
+	}
+	if changes == nil {
+		return nil, nil
+	}
+	return *changes, nil
+}
+
+func (p *GerritProvider) findChangeForCommit(org string, repo string, sha string) (*gerrit.ChangeInfo, error) {
+	project := gerritProjectName(org, repo)
+	changes, err := p.queryChanges(fmt.Sprintf("project:%s commit:%s", project, sha), 1, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		return nil, fmt.Errorf("no change found in project %s for commit %s", project, sha)
+	}
+	return &changes[0], nil
+}
+
+func (p *GerritProvider) changeURL(change *gerrit.ChangeInfo) string {
+	return util.UrlJoin(p.Server.URL, strconv.Itoa(change.Number))
+}
+
+func (p *GerritProvider) changeToPullRequest(change *gerrit.ChangeInfo) *GitPullRequest {
+	owner, repo := splitGerritProjectName(change.Project)
+	number := change.Number
+	mergeable := change.Mergeable
+	merged := change.Status == gerritStatusMerged
+	state := "open"
+	if change.Status != gerritStatusNew {
+		state = "closed"
+	}
+	headRef := change.Topic
+	updated := change.Updated.Time
+	pr := &GitPullRequest{
+		URL:   p.changeURL(change),
+		Owner: owner,
+		Repo:  repo,
+		Author: &GitUser{
+			Login: change.Owner.Username,
+			Name:  change.Owner.Name,
+			Email: change.Owner.Email,
+		},
+		Number:        &number,
+		Mergeable:     &mergeable,
+		Merged:        &merged,
+		HeadRef:       &headRef,
+		State:         &state,
+		LastCommitSha: change.CurrentRevision,
+		Title:         change.Subject,
+		UpdatedAt:     &updated,
+	}
+	if revision, ok := change.Revisions[change.CurrentRevision]; ok {
+		pr.Body = revision.Commit.Message
+	}
+	if change.Status != gerritStatusNew {
+		pr.ClosedAt = &updated
+	}
+	if merged {
+		mergeCommitSHA := change.CurrentRevision
+		pr.MergeCommitSHA = &mergeCommitSHA
+		pr.MergedAt = &updated
+	}
+	return pr
+}
+
+func (p *GerritProvider) changeToCommit(change *gerrit.ChangeInfo) *GitCommit {
+	revision, ok := change.Revisions[change.CurrentRevision]
+	if !ok {
+		return nil
+	}
+	return &GitCommit{
+		SHA:     change.CurrentRevision,
+		Message: revision.Commit.Message,
+		URL:     p.changeURL(change),
+		Branch:  change.Branch,
+		Author: &GitUser{
+			Name:  revision.Commit.Author.Name,
+			Email: revision.Commit.Author.Email,
+		},
+		Committer: &GitUser{
+			Name:  revision.Commit.Committer.Name,
+			Email: revision.Commit.Committer.Email,
+		},
+	}
+}
+
+// gerritLabelState converts the votes on a label into the state of a commit status
+func gerritLabelState(label *gerrit.LabelInfo) string {
+	if label.Rejected.AccountID != 0 {
+		return "failure"
+	}
+	if label.Approved.AccountID != 0 {
+		return "success"
+	}
+	return "pending"
+}
+
 func (p *GerritProvider) CreateWebHook(data *GitWebHookArguments) error {
 	return nil
 }
@@ -184,6 +516,26 @@ func (p *GerritProvider) UpdateWebHook(data *GitWebHookArguments) error {
 	return nil
 }
 
+// GetBranchProtection is not supported on Gerrit
+func (p *GerritProvider) GetBranchProtection(org string, repo string, branch string) (*GitBranchProtection, error) {
+	return nil, fmt.Errorf("branch protection is not supported by Gerrit")
+}
+
+// UpdateBranchProtection is not supported on Gerrit
+func (p *GerritProvider) UpdateBranchProtection(org string, repo string, branch string, protection *GitBranchProtection) error {
+	return fmt.Errorf("branch protection is not supported by Gerrit")
+}
+
+// RemoveBranchProtection is not supported on Gerrit
+func (p *GerritProvider) RemoveBranchProtection(org string, repo string, branch string) error {
+	return fmt.Errorf("branch protection is not supported by Gerrit")
+}
+
+// GetRateLimits returns no rate limits as Gerrit does not report them
+func (p *GerritProvider) GetRateLimits() ([]*GitRateLimit, error) {
+	return []*GitRateLimit{}, nil
+}
+
 // ListWebHooks lists all webhooks for the specified repo.
 func (p *GerritProvider) ListWebHooks(org, repo string) ([]*GitWebHookArguments, error) {
 	return nil, nil
@@ -215,7 +567,7 @@ func (p *GerritProvider) IsGerrit() bool {
 }
 
 func (p *GerritProvider) Kind() string {
-	return "gerrit"
+	return KindGerrit
 }
 
 func (p *GerritProvider) GetIssue(org string, name string, number int) (*GitIssue, error) {
@@ -248,21 +600,111 @@ func (p *GerritProvider) HasIssues() bool {
 	return false
 }
 
+// AddPRComment adds a review message to the current patch set of the change
 func (p *GerritProvider) AddPRComment(pr *GitPullRequest, comment string) error {
+	if pr.Number == nil {
+		return fmt.Errorf("missing Number for change %#v", pr)
+	}
+	_, _, err := p.Client.Changes.SetReview(strconv.Itoa(*pr.Number), "current", &gerrit.ReviewInput{Message: comment})
+	return err
+}
+
+// RequestPRReviewers adds the users as reviewers of the change
+func (p *GerritProvider) RequestPRReviewers(pr *GitPullRequest, reviewers []string) error {
+	if pr.Number == nil {
+		return fmt.Errorf("missing Number for change %#v", pr)
+	}
+	for _, reviewer := range reviewers {
+		_, _, err := p.Client.Changes.AddReviewer(strconv.Itoa(*pr.Number), &gerrit.ReviewerInput{Reviewer: reviewer})
+		if err != nil {
+			return fmt.Errorf("failed to add reviewer %s to change %d due to: %s", reviewer, *pr.Number, err)
+		}
+	}
 	return nil
 }
 
+// ListPRReviews returns the approving and rejecting votes on the Code-Review label of the change as reviews
+func (p *GerritProvider) ListPRReviews(pr *GitPullRequest) ([]*GitReview, error) {
+	if pr.Number == nil {
+		return nil, fmt.Errorf("missing Number for change %#v", pr)
+	}
+	change, err := p.getChange(*pr.Number)
+	if err != nil {
+		return nil, err
+	}
+	answer := []*GitReview{}
+	label, ok := change.Labels[GerritCodeReviewLabel]
+	if !ok {
+		return answer, nil
+	}
+	if label.Approved.AccountID != 0 {
+		answer = append(answer, &GitReview{
+			User:  gerritAccountToGitUser(&label.Approved),
+			State: ReviewStateApproved,
+		})
+	}
+	if label.Rejected.AccountID != 0 {
+		answer = append(answer, &GitReview{
+			User:  gerritAccountToGitUser(&label.Rejected),
+			State: ReviewStateChangesRequested,
+		})
+	}
+	return answer, nil
+}
+
+func gerritAccountToGitUser(account *gerrit.AccountInfo) *GitUser {
+	return &GitUser{
+		Login: account.Username,
+		Name:  account.Name,
+		Email: account.Email,
+	}
+}
+
+// AddPRLabels is not supported as Gerrit changes have no labels other than review labels
+func (p *GerritProvider) AddPRLabels(pr *GitPullRequest, labels []string) error {
+	return fmt.Errorf("pull request labels are not supported by Gerrit")
+}
+
+// RemovePRLabel is not supported as Gerrit changes have no labels other than review labels
+func (p *GerritProvider) RemovePRLabel(pr *GitPullRequest, label string) error {
+	return fmt.Errorf("pull request labels are not supported by Gerrit")
+}
+
 func (p *GerritProvider) CreateIssueComment(owner string, repo string, number int, comment string) error {
 	log.Warn("Gerrit does not support issue tracking")
 	return nil
 }
 
+// UpdateRelease is not supported as Gerrit has no releases to hold the release notes of a tag
 func (p *GerritProvider) UpdateRelease(owner string, repo string, tag string, releaseInfo *GitRelease) error {
-	return nil
+	return fmt.Errorf("releases are not supported by Gerrit")
+}
+
+// UploadReleaseAsset is not supported as Gerrit has no releases
+func (p *GerritProvider) UploadReleaseAsset(org string, repo string, tag string, name string, asset *os.File) (*GitReleaseAsset, error) {
+	return nil, fmt.Errorf("release assets are not supported by Gerrit")
 }
 
+// ListReleases returns a release for each tag of the project as Gerrit has no releases. The body of the release is
+// the message of an annotated tag
 func (p *GerritProvider) ListReleases(org string, name string) ([]*GitRelease, error) {
-	return nil, nil
+	tags, _, err := p.Client.Projects.ListTags(gerritProjectName(org, name), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the tags of project %s due to: %s", gerritProjectName(org, name), err)
+	}
+	answer := []*GitRelease{}
+	if tags == nil {
+		return answer, nil
+	}
+	for _, tag := range *tags {
+		tagName := strings.TrimPrefix(tag.Ref, "refs/tags/")
+		answer = append(answer, &GitRelease{
+			Name:    tagName,
+			TagName: tagName,
+			Body:    tag.Message,
+		})
+	}
+	return answer, nil
 }
 
 func (p *GerritProvider) JenkinsWebHookPath(gitURL string, secret string) string {
@@ -270,11 +712,11 @@ func (p *GerritProvider) JenkinsWebHookPath(gitURL string, secret string) string
 }
 
 func (p *GerritProvider) Label() string {
-	return ""
+	return p.Server.Label()
 }
 
 func (p *GerritProvider) ServerURL() string {
-	return ""
+	return p.Server.URL
 }
 
 func (p *GerritProvider) BranchArchiveURL(org string, name string, branch string) string {
@@ -282,19 +724,21 @@ func (p *GerritProvider) BranchArchiveURL(org string, name string, branch string
 }
 
 func (p *GerritProvider) CurrentUsername() string {
-	return ""
+	return p.Username
 }
 
 func (p *GerritProvider) UserAuth() auth.UserAuth {
-	return auth.UserAuth{}
+	return p.User
 }
 
 func (p *GerritProvider) UserInfo(username string) *GitUser {
 	return nil
 }
 
+// AddCollaborator is not supported as access to Gerrit projects is granted to groups by the access rights of the project
 func (p *GerritProvider) AddCollaborator(user string, organisation string, repo string) error {
-	log.Infof("Automatically adding the pipeline user as a collaborator is currently not implemented for gerrit. Please add user: %v as a collaborator to this project.\n", user)
+	log.Warnf("Automatically adding the pipeline user as a collaborator is currently not implemented for gerrit. Please grant user %s access to the project %s by adding them to a group with access to it\n",
+		user, gerritProjectName(organisation, repo))
 	return nil
 }
 
@@ -308,16 +752,84 @@ func (p *GerritProvider) AcceptInvitation(ID int64) (*github.Response, error) {
 	return &github.Response{}, nil
 }
 
+// GetContent returns the base64 encoded content of a file on a branch. The ref must be a branch name
 func (p *GerritProvider) GetContent(org string, name string, path string, ref string) (*GitFileContent, error) {
-	return nil, fmt.Errorf("Getting content not supported on gerrit")
+	if ref == "" {
+		ref = "master"
+	}
+	// the client escapes the project name itself but passes the branch and file IDs as they are
+	content, _, err := p.Client.Projects.GetBranchContent(gerritProjectName(org, name), url.PathEscape(ref), gerritFileID(path))
+	if err != nil {
+		return nil, err
+	}
+	i := strings.LastIndex(path, "/")
+	return &GitFileContent{
+		Type:     "file",
+		Encoding: "base64",
+		Name:     path[i+1:],
+		Path:     path,
+		Content:  content,
+		Size:     len(content),
+	}, nil
 }
 
 // ShouldForkForPullReques treturns true if we should create a personal fork of this repository
 // before creating a pull request
 func (p *GerritProvider) ShouldForkForPullRequest(originalOwner string, repoName string, username string) bool {
-	return originalOwner != username
+	// changes are pushed to the magic refs/for/<branch> refs of the repository itself
+	return false
 }
 
+// ListCommits lists the commits of the merged changes of the project as Gerrit has no API to list the commit log
 func (p *GerritProvider) ListCommits(owner, repo string, opt *ListCommitsArguments) ([]*GitCommit, error) {
-	return nil, fmt.Errorf("Listing commits not supported on gerrit")
+	changes, err := p.queryChanges(GerritCommitsQuery(owner, repo, opt), opt.PerPage, gerritSkip(opt))
+	if err != nil {
+		return nil, err
+	}
+	var commits []*GitCommit
+	for i := range changes {
+		commit := p.changeToCommit(&changes[i])
+		if commit != nil {
+			commits = append(commits, commit)
+		}
+	}
+	return commits, nil
+}
+
+// GerritCommitsQuery returns the query of the merged changes of the project matching the arguments. A path matches
+// the file itself or any file in the directory of that name
+func GerritCommitsQuery(owner, repo string, opt *ListCommitsArguments) string {
+	terms := []string{"project:" + gerritProjectName(owner, repo), "status:merged"}
+	if opt.SHA != "" {
+		if gerritCommitSHARegex.MatchString(opt.SHA) {
+			terms = append(terms, "commit:"+opt.SHA)
+		} else {
+			terms = append(terms, "branch:"+opt.SHA)
+		}
+	}
+	if opt.Path != "" {
+		terms = append(terms, fmt.Sprintf("file:^%s(/.*)?$", regexp.QuoteMeta(strings.TrimSuffix(opt.Path, "/"))))
+	}
+	if opt.Author != "" {
+		terms = append(terms, "owner:"+opt.Author)
+	}
+	if !opt.Since.IsZero() {
+		terms = append(terms, fmt.Sprintf("after:\"%s\"", opt.Since.UTC().Format("2006-01-02 15:04:05")))
+	}
+	if !opt.Until.IsZero() {
+		terms = append(terms, fmt.Sprintf("before:\"%s\"", opt.Until.UTC().Format("2006-01-02 15:04:05")))
+	}
+	return strings.Join(terms, " ")
+}
+
+func gerritSkip(opt *ListCommitsArguments) int {
+	if opt.Page > 1 {
+		return (opt.Page - 1) * opt.PerPage
+	}
+	return 0
+}
+
+// GerritAccessTokenURL returns the URL to click on to generate an HTTP password for the Git provider
+func GerritAccessTokenURL(url string) string {
+	return util.UrlJoin(url, "/settings/#HTTPCredentials")
 }
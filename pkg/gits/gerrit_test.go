@@ -4,13 +4,17 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"regexp"
 	"sort"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/jenkins-x/jx/pkg/auth"
 	"github.com/jenkins-x/jx/pkg/gits"
 	"github.com/jenkins-x/jx/pkg/util"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -28,8 +32,28 @@ var gerritRouter = util.Router{
 	"/a/projects/test-org%2Ftest-user/": util.MethodMap{
 		"PUT": "create-project.json",
 	},
+	"/a/projects/test-org/test-repo/tags/": util.MethodMap{
+		"GET": "list-tags.json",
+	},
+	"/a/changes/": util.MethodMap{
+		"GET": "changes.json",
+	},
+	"/a/changes/42": util.MethodMap{
+		"GET": "change.json",
+	},
+	"/a/changes/42/submit": util.MethodMap{
+		"POST": "submit-change.json",
+	},
+	"/a/changes/42/revisions/current/review": util.MethodMap{
+		"POST": "set-review.json",
+	},
+	"/a/changes/42/revisions/184ebe53805e102605d11f6b143486d15c23a09c/review": util.MethodMap{
+		"POST": "set-review.json",
+	},
 }
 
+const gerritChangeSHA = "184ebe53805e102605d11f6b143486d15c23a09c"
+
 func (suite *GerritProviderTestSuite) SetupSuite() {
 	suite.mux = http.NewServeMux()
 	suite.server = httptest.NewServer(suite.mux)
@@ -93,6 +117,135 @@ func (suite *GerritProviderTestSuite) TestCreateRepository() {
 	suite.Require().Equal(fmt.Sprintf("%s:test-org/test-repo", suite.server.URL), repo.SSHURL)
 }
 
+func (suite *GerritProviderTestSuite) TestCreatePullRequest() {
+	pr, err := suite.provider.CreatePullRequest(&gits.GitPullRequestArguments{
+		GitRepository: &gits.GitRepository{Organisation: "test-org", Name: "test-repo"},
+		Title:         "chore: promote myapp to version 0.0.1",
+		Head:          "promote-myapp-0.0.1",
+		Base:          "master",
+	})
+
+	suite.Require().Nil(err)
+	suite.Require().NotNil(pr)
+	suite.Require().Equal(42, *pr.Number)
+	suite.Require().Equal("test-org", pr.Owner)
+	suite.Require().Equal("test-repo", pr.Repo)
+	suite.Require().Equal("open", *pr.State)
+	suite.Require().Equal(gerritChangeSHA, pr.LastCommitSha)
+	suite.Require().Equal(fmt.Sprintf("%s/42", suite.server.URL), pr.URL)
+	suite.Require().Equal("I8473b95934b5732ac55d26311a706c9c2bde9940", gits.GerritChangeID(pr.Body))
+}
+
+func (suite *GerritProviderTestSuite) TestPullRequestLastCommitStatus() {
+	pr, err := suite.provider.GetPullRequest("test-org", &gits.GitRepository{Name: "test-repo"}, 42)
+	suite.Require().Nil(err)
+
+	status, err := suite.provider.PullRequestLastCommitStatus(pr)
+	suite.Require().Nil(err)
+	suite.Require().Equal("success", status)
+}
+
+func (suite *GerritProviderTestSuite) TestListCommitStatus() {
+	statuses, err := suite.provider.ListCommitStatus("test-org", "test-repo", gerritChangeSHA)
+
+	suite.Require().Nil(err)
+	suite.Require().Len(statuses, 2)
+	suite.Require().Equal("Code-Review", statuses[0].Context)
+	suite.Require().Equal("Verified", statuses[1].Context)
+	suite.Require().True(statuses[1].IsSuccess())
+}
+
+func (suite *GerritProviderTestSuite) TestUpdateCommitStatus() {
+	status, err := suite.provider.UpdateCommitStatus("test-org", "test-repo", gerritChangeSHA, &gits.GitRepoStatus{
+		State:       "success",
+		Context:     "jenkins-x",
+		Description: "the build passed",
+		TargetURL:   "https://jenkins-x.example.com/builds/1",
+	})
+
+	suite.Require().Nil(err)
+	suite.Require().NotNil(status)
+}
+
+func (suite *GerritProviderTestSuite) TestMergePullRequest() {
+	pr, err := suite.provider.GetPullRequest("test-org", &gits.GitRepository{Name: "test-repo"}, 42)
+	suite.Require().Nil(err)
+
+	err = suite.provider.MergePullRequest(pr, "jx promote automatically merged promotion PR")
+	suite.Require().Nil(err)
+}
+
+func (suite *GerritProviderTestSuite) TestListReleases() {
+	releases, err := suite.provider.ListReleases("test-org", "test-repo")
+
+	suite.Require().Nil(err)
+	suite.Require().Len(releases, 2)
+	suite.Require().Equal("v1.0.0", releases[0].TagName)
+	suite.Require().Equal("release 1.0.0", releases[0].Body)
+	suite.Require().Equal("v1.0.1", releases[1].Name)
+}
+
+func TestGerritCommitsQuery(t *testing.T) {
+	t.Parallel()
+	since := time.Date(2019, 3, 1, 12, 0, 0, 0, time.UTC)
+	query := gits.GerritCommitsQuery("test-org", "test-repo", &gits.ListCommitsArguments{SHA: "master", Since: since})
+	assert.Equal(t, `project:test-org/test-repo status:merged branch:master after:"2019-03-01 12:00:00"`, query)
+
+	query = gits.GerritCommitsQuery("test-org", "test-repo", &gits.ListCommitsArguments{Path: "charts/myapp/"})
+	assert.Equal(t, `project:test-org/test-repo status:merged file:^charts/myapp(/.*)?$`, query)
+
+	query = gits.GerritCommitsQuery("test-org", "test-repo", &gits.ListCommitsArguments{Path: "env/requirements.yaml"})
+	assert.Equal(t, `project:test-org/test-repo status:merged file:^env/requirements\.yaml(/.*)?$`, query)
+	pattern := regexp.MustCompile(strings.TrimPrefix(strings.Fields(query)[2], "file:"))
+	assert.True(t, pattern.MatchString("env/requirements.yaml"))
+	assert.False(t, pattern.MatchString("env/requirements.yaml.bak"))
+	assert.False(t, pattern.MatchString("env/requirementsXyaml"))
+}
+
+func TestGerritChangeID(t *testing.T) {
+	t.Parallel()
+	message := gits.AddGerritChangeID("chore: promote myapp", "")
+	changeID := gits.GerritChangeID(message)
+	assert.Len(t, changeID, 41)
+	assert.Equal(t, message, gits.AddGerritChangeID(message, ""), "an existing Change-Id should be kept")
+
+	message = gits.AddGerritChangeID("chore: promote myapp\n", "I8473b95934b5732ac55d26311a706c9c2bde9940")
+	assert.Equal(t, "chore: promote myapp\n\nChange-Id: I8473b95934b5732ac55d26311a706c9c2bde9940\n", message)
+	assert.Equal(t, "refs/for/master%topic=promote-myapp", gits.GerritRefsFor("master", "promote-myapp"))
+}
+
+func TestGerritGetContentOfNestedFile(t *testing.T) {
+	t.Parallel()
+	requestPath := ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestPath = r.URL.EscapedPath()
+		fmt.Fprint(w, ")]}'\n\"ZGVwZW5kZW5jaWVzOiBbXQo=\"")
+	}))
+	defer server.Close()
+
+	as := auth.AuthServer{
+		URL:         server.URL,
+		Name:        "Test Server",
+		Kind:        "Oauth2",
+		CurrentUser: "test-user",
+	}
+	ua := auth.UserAuth{
+		Username: "test-user",
+		ApiToken: "0123456789abdef",
+	}
+	provider, err := gits.NewGerritProvider(&as, &ua, gits.NewGitCLI())
+	assert.NoError(t, err)
+
+	content, err := provider.GetContent("test-org", "test-repo", "env/requirements.yaml", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "/a/projects/test-org%2Ftest-repo/branches/master/files/env%2Frequirements.yaml/content", requestPath)
+	if assert.NotNil(t, content) {
+		assert.Equal(t, "requirements.yaml", content.Name)
+		assert.Equal(t, "env/requirements.yaml", content.Path)
+		assert.Equal(t, "ZGVwZW5kZW5jaWVzOiBbXQo=", content.Content)
+	}
+}
+
 func TestGerritProviderTestSuite(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping GerritProviderTestSuite in short mode")
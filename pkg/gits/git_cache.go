@@ -0,0 +1,185 @@
+package gits
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+// CachingOptions configures the caching and rate limit handling of a CachingGitProvider
+type CachingOptions struct {
+	// TTL how long the results of listing or getting repositories, webhooks, issues, releases, content and users are
+	// reused without asking the git provider. Other responses with an ETag are always revalidated with a conditional
+	// request which does not count against the rate limit of GitHub
+	TTL time.Duration
+	// Dir the optional directory in which responses and results are cached so that they are reused across processes
+	Dir string
+	// RateLimitPolicy whether to wait for the rate limit to be reset or to fail once no more than MinRemaining
+	// requests are left
+	RateLimitPolicy RateLimitPolicy
+	// MinRemaining the number of requests to keep in reserve for other clients of the same user
+	MinRemaining int
+	// MaxWait the longest time to wait for a rate limit to be reset before failing
+	MaxWait time.Duration
+}
+
+// CacheMetrics counts how the requests of a CachingGitProvider have been served
+type CacheMetrics struct {
+	// Requests the number of requests sent to the git provider
+	Requests int64
+	// CacheHits the number of results served from the cache without a request
+	CacheHits int64
+	// NotModified the number of conditional requests answered by reusing the cached response
+	NotModified int64
+	// RateLimitWaits the number of times a request waited for the rate limit to be reset
+	RateLimitWaits int64
+	// RateLimitFailures the number of requests which failed as the rate limit was exhausted
+	RateLimitFailures int64
+}
+
+// String returns a summary of the metrics
+func (m CacheMetrics) String() string {
+	return fmt.Sprintf("requests: %d, cache hits: %d, not modified: %d, rate limit waits: %d, rate limit failures: %d",
+		m.Requests, m.CacheHits, m.NotModified, m.RateLimitWaits, m.RateLimitFailures)
+}
+
+func (m *CacheMetrics) snapshot() CacheMetrics {
+	return CacheMetrics{
+		Requests:          atomic.LoadInt64(&m.Requests),
+		CacheHits:         atomic.LoadInt64(&m.CacheHits),
+		NotModified:       atomic.LoadInt64(&m.NotModified),
+		RateLimitWaits:    atomic.LoadInt64(&m.RateLimitWaits),
+		RateLimitFailures: atomic.LoadInt64(&m.RateLimitFailures),
+	}
+}
+
+// gitCacheEntry a cached response or result
+type gitCacheEntry struct {
+	Key          string      `json:"key"`
+	Expires      time.Time   `json:"expires"`
+	ETag         string      `json:"etag,omitempty"`
+	LastModified string      `json:"lastModified,omitempty"`
+	Header       http.Header `json:"header,omitempty"`
+	Body         []byte      `json:"body"`
+}
+
+func (e *gitCacheEntry) expired(now time.Time) bool {
+	return !now.Before(e.Expires)
+}
+
+// gitCache caches entries in memory and optionally on disk. Entries are grouped into scopes, typically a repository,
+// so that they can be invalidated together when the scope is modified
+type gitCache struct {
+	dir     string
+	lock    sync.Mutex
+	entries map[string]*gitCacheEntry
+	scopes  map[string]map[string]bool
+}
+
+func newGitCache(dir string) *gitCache {
+	if dir != "" {
+		err := os.MkdirAll(dir, util.DefaultWritePermissions)
+		if err != nil {
+			log.Warnf("Not caching git provider responses on disk as %s could not be created: %s\n", dir, err)
+			dir = ""
+		}
+	}
+	return &gitCache{
+		dir:     dir,
+		entries: map[string]*gitCacheEntry{},
+		scopes:  map[string]map[string]bool{},
+	}
+}
+
+// get returns the entry of the key in the scope or nil if there is none
+func (c *gitCache) get(scope string, key string) *gitCacheEntry {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	entry := c.entries[key]
+	if entry != nil || c.dir == "" {
+		return entry
+	}
+	data, err := ioutil.ReadFile(c.fileName(scope, key))
+	if err != nil {
+		return nil
+	}
+	entry = &gitCacheEntry{}
+	err = json.Unmarshal(data, entry)
+	if err != nil || entry.Key != key {
+		return nil
+	}
+	c.add(scope, entry)
+	return entry
+}
+
+// put adds or replaces the entry in the scope
+func (c *gitCache) put(scope string, entry *gitCacheEntry) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.add(scope, entry)
+	if c.dir == "" {
+		return
+	}
+	data, err := json.Marshal(entry)
+	if err == nil {
+		err = ioutil.WriteFile(c.fileName(scope, entry.Key), data, util.DefaultWritePermissions)
+	}
+	if err != nil {
+		log.Warnf("Failed to cache git provider response on disk: %s\n", err)
+	}
+}
+
+// invalidate removes all the entries of the scopes
+func (c *gitCache) invalidate(scopes ...string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for _, scope := range scopes {
+		for key := range c.scopes[scope] {
+			delete(c.entries, key)
+		}
+		delete(c.scopes, scope)
+		if c.dir == "" {
+			continue
+		}
+		files, err := filepath.Glob(filepath.Join(c.dir, hashText(scope)+"-*.json"))
+		if err != nil {
+			continue
+		}
+		for _, file := range files {
+			err = os.Remove(file)
+			if err != nil && !os.IsNotExist(err) {
+				log.Warnf("Failed to remove cached git provider response %s: %s\n", file, err)
+			}
+		}
+	}
+}
+
+func (c *gitCache) add(scope string, entry *gitCacheEntry) {
+	c.entries[entry.Key] = entry
+	keys := c.scopes[scope]
+	if keys == nil {
+		keys = map[string]bool{}
+		c.scopes[scope] = keys
+	}
+	keys[entry.Key] = true
+}
+
+func (c *gitCache) fileName(scope string, key string) string {
+	return filepath.Join(c.dir, hashText(scope)+"-"+hashText(key)+".json")
+}
+
+func hashText(text string) string {
+	hash := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(hash[:])
+}
@@ -18,7 +18,10 @@ import (
 	"github.com/jenkins-x/jx/pkg/log"
 	"github.com/jenkins-x/jx/pkg/util"
 
+	"golang.org/x/crypto/openpgp"
+	"gopkg.in/src-d/go-git.v4"
 	gitcfg "gopkg.in/src-d/go-git.v4/config"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
 )
 
 const (
@@ -408,7 +411,11 @@ func (g *GitCLI) CreatePushURL(cloneURL string, userAuth *auth.UserAuth) (string
 		return cloneURL, nil
 	}
 	if userAuth.Username != "" || userAuth.ApiToken != "" {
-		u.User = url.UserPassword(userAuth.Username, userAuth.ApiToken)
+		token, err := userAuth.GitToken()
+		if err != nil {
+			return "", errors.Wrapf(err, "getting the git token of user %s", userAuth.Username)
+		}
+		u.User = url.UserPassword(userAuth.Username, token)
 		return u.String(), nil
 	}
 	return cloneURL, nil
@@ -678,6 +685,37 @@ func (g *GitCLI) GetRevisionBeforeDateText(dir string, dateText string) (string,
 	return g.gitCmdWithOutput(dir, "rev-list", "-1", "--before=\""+dateText+"\"", "--max-count=1", branch)
 }
 
+// GetFileRevisions returns the SHAs of the commits which changed the file in the repository at the given directory
+// with the latest commit first
+func (g *GitCLI) GetFileRevisions(dir string, file string) ([]string, error) {
+	out, err := g.gitCmdWithOutput(dir, "log", "--format=%H", "--", file)
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(out), nil
+}
+
+// GetCommitMessagesSince returns the messages of the commits of HEAD which are not reachable from the revision, or of
+// all the commits if the revision is empty, in the repository at the given directory with the latest commit first
+func (g *GitCLI) GetCommitMessagesSince(dir string, rev string) ([]string, error) {
+	revisions := "HEAD"
+	if rev != "" {
+		revisions = rev + "..HEAD"
+	}
+	out, err := g.gitCmdWithOutput(dir, "log", "--format=%B%x00", revisions)
+	if err != nil {
+		return nil, err
+	}
+	messages := []string{}
+	for _, message := range strings.Split(out, "\x00") {
+		message = strings.TrimSpace(message)
+		if message != "" {
+			messages = append(messages, message)
+		}
+	}
+	return messages, nil
+}
+
 // GetCurrentGitTagSHA return the SHA of the current git tag from the repository at the given directory
 func (g *GitCLI) GetCurrentGitTagSHA(dir string) (string, error) {
 	return g.gitCmdWithOutput(dir, "rev-list", "--tags", "--max-count=1")
@@ -709,6 +747,53 @@ func (g *GitCLI) CreateTag(dir string, tag string, msg string) error {
 	return g.gitCmd("", "tag", "-fa", tag, "-m", msg)
 }
 
+// CreateSignedTag creates a tag with the given name and message signed with the GPG key in the repository at the given
+// directory. The tag is written with go-git so that the key does not need to be imported into a GPG keyring
+func (g *GitCLI) CreateSignedTag(dir string, tag string, msg string, signer *openpgp.Entity) error {
+	repo, err := g.openRepository(dir)
+	if err != nil {
+		return err
+	}
+	name, _ := g.gitCmdWithOutput(dir, "config", "user.name")
+	email, _ := g.gitCmdWithOutput(dir, "config", "user.email")
+	tagger := &object.Signature{
+		Name:  name,
+		Email: email,
+		When:  time.Now(),
+	}
+	if name == "" || email == "" {
+		tagger = taggerForKey(signer)
+		if tagger == nil {
+			return fmt.Errorf("no git user name and email are configured")
+		}
+	}
+	return writeTag(repo, tag, msg, tagger, signer)
+}
+
+// VerifyTag checks the GPG signature of the tag against the keyring returning the key which signed it
+func (g *GitCLI) VerifyTag(dir string, tag string, keyring openpgp.EntityList) (*openpgp.Entity, error) {
+	repo, err := g.openRepository(dir)
+	if err != nil {
+		return nil, err
+	}
+	return verifyTag(repo, tag, keyring)
+}
+
+func (g *GitCLI) openRepository(dir string) (*git.Repository, error) {
+	root, _, err := g.FindGitConfigDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	if root == "" {
+		return nil, fmt.Errorf("no git repository could be found from %s", dir)
+	}
+	repo, err := git.PlainOpen(root)
+	if err != nil {
+		return nil, errors.Wrapf(err, "opening the git repository %s", root)
+	}
+	return repo, nil
+}
+
 // PrintCreateRepositoryGenerateAccessToken prints the access token URL of a Git repository
 func (g *GitCLI) PrintCreateRepositoryGenerateAccessToken(server *auth.AuthServer, username string, o io.Writer) {
 	tokenUrl := ProviderAccessTokenURL(server.Kind, server.URL, username)
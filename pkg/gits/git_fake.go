@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/jenkins-x/jx/pkg/auth"
+	"golang.org/x/crypto/openpgp"
 	gitcfg "gopkg.in/src-d/go-git.v4/config"
 )
 
@@ -23,6 +24,8 @@ type GitRemote struct {
 type GitTag struct {
 	Name    string
 	Message string
+	// Signer the name of the GPG key which signed the tag if it is signed
+	Signer string
 }
 
 // GitFake provides a fake Gitter
@@ -482,6 +485,36 @@ func (g *GitFake) CreateTag(dir string, tag string, msg string) error {
 	return nil
 }
 
+// CreateSignedTag creates a tag signed by the key
+func (g *GitFake) CreateSignedTag(dir string, tag string, msg string, signer *openpgp.Entity) error {
+	t := GitTag{
+		Name:    tag,
+		Message: msg,
+		Signer:  KeyName(signer),
+	}
+	g.GitTags = append(g.GitTags, t)
+	return nil
+}
+
+// VerifyTag returns the key of the keyring whose name is the signer of the tag
+func (g *GitFake) VerifyTag(dir string, tag string, keyring openpgp.EntityList) (*openpgp.Entity, error) {
+	for _, t := range g.GitTags {
+		if t.Name != tag {
+			continue
+		}
+		if t.Signer == "" {
+			return nil, ErrTagNotSigned
+		}
+		for _, key := range keyring {
+			if KeyName(key) == t.Signer {
+				return key, nil
+			}
+		}
+		return nil, fmt.Errorf("tag %s is signed by an untrusted key %s", tag, t.Signer)
+	}
+	return nil, fmt.Errorf("tag %s not found", tag)
+}
+
 // GetRevisionBeforeDate get the revision before the date
 func (g *GitFake) GetRevisionBeforeDate(dir string, t time.Time) (string, error) {
 	return g.Revision, nil
@@ -492,6 +525,20 @@ func (g *GitFake) GetRevisionBeforeDateText(dir string, dateText string) (string
 	return g.Revision, nil
 }
 
+// GetFileRevisions returns the SHAs of the commits which changed the file
+func (g *GitFake) GetFileRevisions(dir string, file string) ([]string, error) {
+	return []string{}, nil
+}
+
+// GetCommitMessagesSince returns the messages of all the fake commits with the latest commit first
+func (g *GitFake) GetCommitMessagesSince(dir string, rev string) ([]string, error) {
+	messages := []string{}
+	for i := len(g.Commits) - 1; i >= 0; i-- {
+		messages = append(messages, g.Commits[i].Message)
+	}
+	return messages, nil
+}
+
 // Diff performs a git diff
 func (g *GitFake) Diff(dir string) (string, error) {
 	return "", nil
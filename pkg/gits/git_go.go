@@ -0,0 +1,1823 @@
+package gits
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/jenkins-x/jx/pkg/auth"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"golang.org/x/crypto/openpgp"
+	"gopkg.in/src-d/go-git.v4"
+	gitcfg "gopkg.in/src-d/go-git.v4/config"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/filemode"
+	formatcfg "gopkg.in/src-d/go-git.v4/plumbing/format/config"
+	"gopkg.in/src-d/go-git.v4/plumbing/format/diff"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/plumbing/storer"
+	"gopkg.in/src-d/go-git.v4/plumbing/transport"
+	githttp "gopkg.in/src-d/go-git.v4/plumbing/transport/http"
+	diffutil "gopkg.in/src-d/go-git.v4/utils/diff"
+	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
+)
+
+const (
+	// GitClientEnvVar the environment variable which selects the Gitter implementation: cli or go
+	GitClientEnvVar = "JX_GIT_CLIENT"
+	// GitClientCLI the Gitter which runs the git binary
+	GitClientCLI = "cli"
+	// GitClientGo the Gitter which uses go-git
+	GitClientGo = "go"
+
+	originRemote   = "origin"
+	upstreamRemote = "upstream"
+)
+
+var shaRegex = regexp.MustCompile(`^[0-9a-fA-F]{7,40}$`)
+
+// NewGitter creates the Gitter selected by $JX_GIT_CLIENT. If it is not set the GitCLI is used unless there is no git
+// binary on the PATH, such as in minimal containers, in which case the GoGitter is used
+func NewGitter() Gitter {
+	switch os.Getenv(GitClientEnvVar) {
+	case GitClientGo:
+		return NewGoGitter()
+	case GitClientCLI:
+		return NewGitCLI()
+	}
+	_, err := exec.LookPath("git")
+	if err != nil {
+		return NewGoGitter()
+	}
+	return NewGitCLI()
+}
+
+// GoGitter implements the Gitter interface using go-git so that no git binary is required. The few operations which
+// go-git does not support fall back to the git CLI and are marked with the comment "Falls back to the git CLI"
+type GoGitter struct {
+	// GitCLI is used for the operations go-git does not support
+	GitCLI *GitCLI
+	// UserAuth is the optional user and API token used to authenticate with HTTP(S) remotes. If it is not specified
+	// the credentials of the remote URL or of the git credentials store are used
+	UserAuth *auth.UserAuth
+}
+
+// NewGoGitter creates a new GoGitter instance
+func NewGoGitter() *GoGitter {
+	return &GoGitter{
+		GitCLI: NewGitCLI(),
+	}
+}
+
+// FindGitConfigDir tries to find the `.git` directory either in the current directory or in parent directories
+func (g *GoGitter) FindGitConfigDir(dir string) (string, string, error) {
+	return g.GitCLI.FindGitConfigDir(dir)
+}
+
+// PrintCreateRepositoryGenerateAccessToken prints the access token URL of a Git repository
+func (g *GoGitter) PrintCreateRepositoryGenerateAccessToken(server *auth.AuthServer, username string, o io.Writer) {
+	g.GitCLI.PrintCreateRepositoryGenerateAccessToken(server, username, o)
+}
+
+// Status returns an error if the status of the git repository at the given directory cannot be determined
+func (g *GoGitter) Status(dir string) error {
+	_, wt, err := g.worktree(dir)
+	if err != nil {
+		return err
+	}
+	_, err = wt.Status()
+	return err
+}
+
+// Server returns the Git server of the repository at the given directory
+func (g *GoGitter) Server(dir string) (string, error) {
+	repo, err := g.Info(dir)
+	if err != nil {
+		return "", err
+	}
+	return repo.HostURL(), err
+}
+
+// Info returns the git info of the repository at the given directory
+func (g *GoGitter) Info(dir string) (*GitRepository, error) {
+	var rURL string
+	repo, err := g.open(dir)
+	if errors.Cause(err) == git.ErrRepositoryNotExists {
+		rURL = os.Getenv("SOURCE_URL")
+		if rURL == "" {
+			// Relevant in a Jenkins pipeline triggered by a PR
+			rURL = os.Getenv("CHANGE_URL")
+			if rURL == "" {
+				return nil, fmt.Errorf("you are not in a Git repository - promotion command should be executed from an application directory")
+			}
+		}
+	} else if err != nil {
+		return nil, err
+	} else {
+		rURL, err = remoteURL(repo, originRemote)
+		if err != nil {
+			return nil, err
+		}
+	}
+	answer, err := ParseGitURL(rURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Git URL %s due to %s", rURL, err)
+	}
+	return answer, nil
+}
+
+// IsFork indicates if the repository at the given directory is a fork
+func (g *GoGitter) IsFork(dir string) (bool, error) {
+	// lets ignore errors as that just means there's no config
+	repo, _ := g.open(dir)
+	if repo != nil {
+		originURL, _ := remoteURL(repo, originRemote)
+		upstreamURL, _ := remoteURL(repo, upstreamRemote)
+		if originURL != upstreamURL && originURL != "" && upstreamURL != "" {
+			return true, nil
+		}
+	}
+	return false, fmt.Errorf("could not confirm the repo is a fork")
+}
+
+// Version returns the version of the git client
+func (g *GoGitter) Version() (string, error) {
+	return "go-git v4", nil
+}
+
+// RepoName formats the repository names based on the organization
+func (g *GoGitter) RepoName(org, repoName string) string {
+	return g.GitCLI.RepoName(org, repoName)
+}
+
+// Username return the username from the global git configuration
+func (g *GoGitter) Username(dir string) (string, error) {
+	return globalGitConfigOption("user", "name")
+}
+
+// SetUsername sets the username in the global git configuration if it is not already set
+func (g *GoGitter) SetUsername(dir string, username string) error {
+	return setGlobalGitConfigOption("user", "name", username)
+}
+
+// Email returns the email from the global git configuration
+func (g *GoGitter) Email(dir string) (string, error) {
+	return globalGitConfigOption("user", "email")
+}
+
+// SetEmail sets the email in the global git configuration if it is not already set
+func (g *GoGitter) SetEmail(dir string, email string) error {
+	return setGlobalGitConfigOption("user", "email", email)
+}
+
+// GetAuthorEmailForCommit returns the author email from commit message with the given SHA
+func (g *GoGitter) GetAuthorEmailForCommit(dir string, sha string) (string, error) {
+	repo, err := g.open(dir)
+	if err != nil {
+		return "", err
+	}
+	commit, err := resolveCommit(repo, sha)
+	if err != nil {
+		return "", err
+	}
+	return commit.Author.Email, nil
+}
+
+// Init inits a git repository into the given directory
+func (g *GoGitter) Init(dir string) error {
+	_, err := git.PlainInit(dir, false)
+	if err == git.ErrRepositoryAlreadyExists {
+		return nil
+	}
+	return errors.Wrapf(err, "initialising a git repository in %s", dir)
+}
+
+// Clone clones the given git URL into the given directory
+func (g *GoGitter) Clone(url string, dir string) error {
+	_, err := git.PlainClone(dir, false, &git.CloneOptions{
+		URL:  url,
+		Auth: g.auth(url),
+	})
+	return errors.Wrapf(err, "cloning %s into %s", url, dir)
+}
+
+// ShallowCloneBranch clones a single branch of the given git URL into the given directory
+func (g *GoGitter) ShallowCloneBranch(url string, branch string, dir string) error {
+	_, err := git.PlainClone(dir, false, &git.CloneOptions{
+		URL:           url,
+		Auth:          g.auth(url),
+		ReferenceName: branchReferenceName(branch),
+		SingleBranch:  true,
+		Depth:         1,
+	})
+	return errors.Wrapf(err, "cloning branch %s of %s into %s", branch, url, dir)
+}
+
+// ShallowClone shallow clones the repo at url from the specified commitish or pull request to a local master branch.
+// Falls back to the git CLI if the commitish is a SHA as go-git can only fetch references
+func (g *GoGitter) ShallowClone(dir string, url string, commitish string, pullRequest string) error {
+	if commitish != "" && pullRequest != "" {
+		return errors.Errorf("cannot specify both pull request and commitish")
+	}
+	if shaRegex.MatchString(commitish) {
+		return g.GitCLI.ShallowClone(dir, url, commitish, pullRequest)
+	}
+	refName := branchReferenceName("master")
+	if pullRequest != "" {
+		pullRequestNumber, err := strconv.Atoi(strings.TrimPrefix(pullRequest, "PR-"))
+		if err != nil {
+			return errors.Wrapf(err, "converting %s to a pull request number", pullRequest)
+		}
+		refName = plumbing.ReferenceName(fmt.Sprintf("refs/pull/%d/head", pullRequestNumber))
+	} else if commitish != "" {
+		refName = expandRefName(commitish)
+	}
+	repo, err := git.PlainClone(dir, false, &git.CloneOptions{
+		URL:           url,
+		Auth:          g.auth(url),
+		ReferenceName: refName,
+		SingleBranch:  true,
+		Depth:         1,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "cloning %s of %s into %s", refName, url, dir)
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return err
+	}
+	master := branchReferenceName("master")
+	if head.Name() == master {
+		return nil
+	}
+	err = repo.Storer.SetReference(plumbing.NewHashReference(master, head.Hash()))
+	if err != nil {
+		return err
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		return err
+	}
+	return wt.Checkout(&git.CheckoutOptions{Branch: master, Force: true})
+}
+
+// FetchUnshallow fetches the full history of a shallow repository.
+// Falls back to the git CLI as go-git cannot deepen a shallow repository
+func (g *GoGitter) FetchUnshallow(dir string) error {
+	return g.GitCLI.FetchUnshallow(dir)
+}
+
+// IsShallow returns true if the repository at the given directory is shallow
+func (g *GoGitter) IsShallow(dir string) (bool, error) {
+	repo, err := g.open(dir)
+	if err != nil {
+		return false, err
+	}
+	shallow, err := repo.Storer.Shallow()
+	if err != nil {
+		return false, errors.Wrapf(err, "reading the shallow commits of %s", dir)
+	}
+	return len(shallow) > 0, nil
+}
+
+// Push pushes the current branch of the repository at the given directory to the branch of the same name in origin
+func (g *GoGitter) Push(dir string) error {
+	repo, err := g.open(dir)
+	if err != nil {
+		return err
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return err
+	}
+	if !head.Name().IsBranch() {
+		return errors.Errorf("cannot push %s as HEAD is not a branch", dir)
+	}
+	return g.push(repo, originRemote, fmt.Sprintf("%s:%s", head.Name(), head.Name()))
+}
+
+// PushMaster pushes the master branch into the origin and tracks it
+func (g *GoGitter) PushMaster(dir string) error {
+	repo, err := g.open(dir)
+	if err != nil {
+		return err
+	}
+	master := branchReferenceName("master")
+	err = g.push(repo, originRemote, fmt.Sprintf("%s:%s", master, master))
+	if err != nil {
+		return err
+	}
+	return setUpstream(repo, "master", originRemote, master)
+}
+
+// PushTag pushes the given tag into the origin
+func (g *GoGitter) PushTag(dir string, tag string) error {
+	repo, err := g.open(dir)
+	if err != nil {
+		return err
+	}
+	ref := tagReferenceName(tag)
+	return g.push(repo, originRemote, fmt.Sprintf("%s:%s", ref, ref))
+}
+
+// CreatePushURL creates the Git repository URL with the username and password encoded for HTTPS based URLs
+func (g *GoGitter) CreatePushURL(cloneURL string, userAuth *auth.UserAuth) (string, error) {
+	return g.GitCLI.CreatePushURL(cloneURL, userAuth)
+}
+
+// ForcePushBranch does a force push of the local branch into the remote branch of the repository at the given directory.
+// The remote branch can be a full reference name such as refs/for/master. Falls back to the git CLI for references with
+// push options such as refs/for/master%topic=foo as go-git cannot send push options
+func (g *GoGitter) ForcePushBranch(dir string, localBranch string, remoteBranch string) error {
+	if strings.Contains(remoteBranch, "%") {
+		return g.GitCLI.ForcePushBranch(dir, localBranch, remoteBranch)
+	}
+	repo, err := g.open(dir)
+	if err != nil {
+		return err
+	}
+	local := expandRefName(localBranch)
+	if local == plumbing.HEAD {
+		// go-git only pushes hash references so lets push the branch HEAD refers to
+		head, err := repo.Head()
+		if err != nil {
+			return err
+		}
+		if !head.Name().IsBranch() {
+			return errors.Errorf("cannot push HEAD of %s as it is not a branch", dir)
+		}
+		local = head.Name()
+	}
+	return g.push(repo, originRemote, fmt.Sprintf("+%s:%s", local, expandRefName(remoteBranch)))
+}
+
+// CloneOrPull clones  the given git URL or pull if it already exists
+func (g *GoGitter) CloneOrPull(url string, dir string) error {
+	empty, err := util.IsEmpty(dir)
+	if err != nil {
+		return err
+	}
+	if !empty {
+		return g.Pull(dir)
+	}
+	return g.Clone(url, dir)
+}
+
+// Pull pulls the Git repository in the given directory.
+// Falls back to the git CLI if the current branch cannot be fast forwarded as go-git cannot merge
+func (g *GoGitter) Pull(dir string) error {
+	repo, wt, err := g.worktree(dir)
+	if err != nil {
+		return err
+	}
+	remoteName := originRemote
+	head, err := repo.Head()
+	if err == nil && head.Name().IsBranch() {
+		cfg, err := repo.Config()
+		if err == nil && cfg.Raw != nil {
+			if remote := cfg.Raw.Section("branch").Subsection(head.Name().Short()).Option("remote"); remote != "" {
+				remoteName = remote
+			}
+		}
+	}
+	gitURL, err := remoteURL(repo, remoteName)
+	if err != nil {
+		return err
+	}
+	err = wt.Pull(&git.PullOptions{
+		RemoteName: remoteName,
+		Auth:       g.auth(gitURL),
+	})
+	switch {
+	case err == nil || err == git.NoErrAlreadyUpToDate:
+		return nil
+	case isNonFastForward(err):
+		return g.GitCLI.Pull(dir)
+	default:
+		return errors.Wrapf(err, "pulling %s", dir)
+	}
+}
+
+// isNonFastForward returns true if go-git refused to pull as the branches have diverged. go-git v4.5.0 returns a plain
+// error from the worktree rather than a sentinel error so the message is matched too
+func isNonFastForward(err error) bool {
+	return err == git.ErrForceNeeded || strings.HasPrefix(err.Error(), "non-fast-forward update")
+}
+
+// PullRemoteBranches fetches all the remotes and pulls the current branch of the repository in the given directory
+func (g *GoGitter) PullRemoteBranches(dir string) error {
+	err := g.RemoteUpdate(dir)
+	if err != nil {
+		return err
+	}
+	return g.Pull(dir)
+}
+
+// PullUpstream pulls the remote upstream branch into master branch into the given directory.
+// Falls back to the git CLI as go-git cannot rebase
+func (g *GoGitter) PullUpstream(dir string) error {
+	return g.GitCLI.PullUpstream(dir)
+}
+
+// ResetToUpstream resets the given branch to the upstream version
+func (g *GoGitter) ResetToUpstream(dir string, branch string) error {
+	repo, wt, err := g.worktree(dir)
+	if err != nil {
+		return err
+	}
+	err = g.fetch(repo, upstreamRemote, nil, 0, git.TagFollowing)
+	if err != nil {
+		return err
+	}
+	ref, err := repo.Reference(remoteReferenceName(upstreamRemote, branch), true)
+	if err != nil {
+		return errors.Wrapf(err, "resolving %s/%s", upstreamRemote, branch)
+	}
+	return wt.Reset(&git.ResetOptions{Commit: ref.Hash(), Mode: git.HardReset})
+}
+
+// AddRemote adds a remote repository at the given URL and with the given name
+func (g *GoGitter) AddRemote(dir string, name string, url string) error {
+	repo, err := g.open(dir)
+	if err != nil {
+		return err
+	}
+	_, err = repo.CreateRemote(&gitcfg.RemoteConfig{
+		Name: name,
+		URLs: []string{url},
+	})
+	return errors.Wrapf(err, "adding remote %s with URL %s", name, url)
+}
+
+// SetRemoteURL sets the remote URL of the remote with the given name
+func (g *GoGitter) SetRemoteURL(dir string, name string, gitURL string) error {
+	repo, err := g.open(dir)
+	if err != nil {
+		return err
+	}
+	cfg, err := repo.Config()
+	if err != nil {
+		return err
+	}
+	remote := cfg.Remotes[name]
+	if remote == nil {
+		_, err = repo.CreateRemote(&gitcfg.RemoteConfig{
+			Name: name,
+			URLs: []string{gitURL},
+		})
+		return errors.Wrapf(err, "adding remote %s with URL %s", name, gitURL)
+	}
+	remote.URLs = []string{gitURL}
+	return repo.Storer.SetConfig(cfg)
+}
+
+// UpdateRemote updates the URL of the origin remote repository
+func (g *GoGitter) UpdateRemote(dir, url string) error {
+	return g.SetRemoteURL(dir, originRemote, url)
+}
+
+// DiscoverRemoteGitURL discovers the remote git URL from the given git configuration
+func (g *GoGitter) DiscoverRemoteGitURL(gitConf string) (string, error) {
+	return g.GitCLI.DiscoverRemoteGitURL(gitConf)
+}
+
+// DiscoverUpstreamGitURL discovers the upstream git URL from the given git configuration
+func (g *GoGitter) DiscoverUpstreamGitURL(gitConf string) (string, error) {
+	return g.GitCLI.DiscoverUpstreamGitURL(gitConf)
+}
+
+// RemoteBranches returns the remote branches such as origin/master
+func (g *GoGitter) RemoteBranches(dir string) ([]string, error) {
+	answer := []string{}
+	repo, err := g.open(dir)
+	if err != nil {
+		return answer, err
+	}
+	err = forEachReference(repo, func(ref *plumbing.Reference) {
+		if ref.Name().IsRemote() && ref.Type() == plumbing.HashReference {
+			answer = append(answer, ref.Name().Short())
+		}
+	})
+	return answer, err
+}
+
+// RemoteBranchNames returns all the local branches and the remote branches, prefixed with remotes/, with the given
+// prefix removed
+func (g *GoGitter) RemoteBranchNames(dir string, prefix string) ([]string, error) {
+	answer := []string{}
+	repo, err := g.open(dir)
+	if err != nil {
+		return answer, err
+	}
+	err = forEachReference(repo, func(ref *plumbing.Reference) {
+		name := ""
+		switch {
+		case ref.Name().IsBranch():
+			name = ref.Name().Short()
+		case ref.Name().IsRemote() && ref.Type() == plumbing.HashReference:
+			name = "remotes/" + ref.Name().Short()
+		default:
+			return
+		}
+		if prefix != "" {
+			name = strings.TrimPrefix(name, prefix)
+		}
+		answer = append(answer, name)
+	})
+	return answer, err
+}
+
+// GetRemoteUrl returns the remote URL from the given git config
+func (g *GoGitter) GetRemoteUrl(config *gitcfg.Config, name string) string {
+	return g.GitCLI.GetRemoteUrl(config, name)
+}
+
+// RemoteUpdate fetches all the remotes
+func (g *GoGitter) RemoteUpdate(dir string) error {
+	repo, err := g.open(dir)
+	if err != nil {
+		return err
+	}
+	remotes, err := repo.Remotes()
+	if err != nil {
+		return err
+	}
+	for _, remote := range remotes {
+		err = g.fetch(repo, remote.Config().Name, nil, 0, git.TagFollowing)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LocalBranches will list all local branches
+func (g *GoGitter) LocalBranches(dir string) ([]string, error) {
+	repo, err := g.open(dir)
+	if err != nil {
+		return nil, err
+	}
+	answer := make([]string, 0)
+	err = forEachReference(repo, func(ref *plumbing.Reference) {
+		if ref.Name().IsBranch() {
+			answer = append(answer, ref.Name().Short())
+		}
+	})
+	return answer, err
+}
+
+// Branch returns the current branch of the repository located at the given directory or HEAD if it is detached
+func (g *GoGitter) Branch(dir string) (string, error) {
+	repo, err := g.open(dir)
+	if err != nil {
+		return "", err
+	}
+	head, err := repo.Storer.Reference(plumbing.HEAD)
+	if err != nil {
+		return "", errors.Wrapf(err, "reading HEAD of %s", dir)
+	}
+	if head.Type() == plumbing.SymbolicReference && head.Target().IsBranch() {
+		return head.Target().Short(), nil
+	}
+	return "HEAD", nil
+}
+
+// CreateBranchFrom creates a new branch called branchName from startPoint
+func (g *GoGitter) CreateBranchFrom(dir string, branchName string, startPoint string) error {
+	repo, err := g.open(dir)
+	if err != nil {
+		return err
+	}
+	hash, err := repo.ResolveRevision(plumbing.Revision(startPoint))
+	if err != nil {
+		return errors.Wrapf(err, "resolving %s", startPoint)
+	}
+	return createBranch(repo, branchName, *hash)
+}
+
+// CreateBranch creates a branch with the given name in the Git repository from the given directory
+func (g *GoGitter) CreateBranch(dir string, branch string) error {
+	repo, err := g.open(dir)
+	if err != nil {
+		return err
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return errors.Wrapf(err, "resolving HEAD of %s", dir)
+	}
+	return createBranch(repo, branch, head.Hash())
+}
+
+// CheckoutRemoteBranch checks out the given branch creating it from the origin remote tracking branch if there is no
+// local branch
+func (g *GoGitter) CheckoutRemoteBranch(dir string, branch string) error {
+	cur, err := g.Branch(dir)
+	if err != nil {
+		return err
+	}
+	if cur == branch {
+		return nil
+	}
+	return g.Checkout(dir, branch)
+}
+
+// Checkout checks out the given branch. If there is no such local branch but there is an origin remote tracking
+// branch a local branch tracking it is created, otherwise the revision is checked out with a detached HEAD
+func (g *GoGitter) Checkout(dir string, branch string) error {
+	repo, wt, err := g.worktree(dir)
+	if err != nil {
+		return err
+	}
+	refName := branchReferenceName(branch)
+	_, err = repo.Reference(refName, false)
+	if err == plumbing.ErrReferenceNotFound {
+		remoteRef, remoteErr := repo.Reference(remoteReferenceName(originRemote, branch), true)
+		if remoteErr != nil {
+			hash, revErr := repo.ResolveRevision(plumbing.Revision(branch))
+			if revErr != nil {
+				return errors.Wrapf(err, "checking out %s", branch)
+			}
+			return wt.Checkout(&git.CheckoutOptions{Hash: *hash})
+		}
+		err = createBranch(repo, branch, remoteRef.Hash())
+		if err != nil {
+			return err
+		}
+		err = setUpstream(repo, branch, originRemote, refName)
+	}
+	if err != nil {
+		return err
+	}
+	return errors.Wrapf(wt.Checkout(&git.CheckoutOptions{Branch: refName}), "checking out %s", branch)
+}
+
+// CheckoutOrphan checks out a new branch which has no commits
+func (g *GoGitter) CheckoutOrphan(dir string, branch string) error {
+	repo, err := g.open(dir)
+	if err != nil {
+		return err
+	}
+	return repo.Storer.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, branchReferenceName(branch)))
+}
+
+// ConvertToValidBranchName converts the given branch name into a valid git branch string
+// replacing any dodgy characters
+func (g *GoGitter) ConvertToValidBranchName(name string) string {
+	return g.GitCLI.ConvertToValidBranchName(name)
+}
+
+// FetchBranch fetches the refspecs from the repo.
+// Falls back to the git CLI for SHAs or when fetching from a URL as go-git can only fetch references into a remote
+func (g *GoGitter) FetchBranch(dir string, repo string, refspecs ...string) error {
+	return g.fetchBranch(dir, repo, 0, refspecs, g.GitCLI.FetchBranch)
+}
+
+// FetchBranchShallow fetches the refspecs from the repo with a depth of 1.
+// Falls back to the git CLI for SHAs or when fetching from a URL as go-git can only fetch references into a remote
+func (g *GoGitter) FetchBranchShallow(dir string, repo string, refspecs ...string) error {
+	return g.fetchBranch(dir, repo, 1, refspecs, g.GitCLI.FetchBranchShallow)
+}
+
+// FetchBranchUnshallow fetches the refspecs from the repo and converts a shallow repository to a complete one.
+// Falls back to the git CLI as go-git cannot deepen a shallow repository
+func (g *GoGitter) FetchBranchUnshallow(dir string, repo string, refspecs ...string) error {
+	return g.GitCLI.FetchBranchUnshallow(dir, repo, refspecs...)
+}
+
+// Merge merges the commitish into the current branch.
+// Falls back to the git CLI as go-git cannot merge
+func (g *GoGitter) Merge(dir string, commitish string) error {
+	return g.GitCLI.Merge(dir, commitish)
+}
+
+// ResetHard performs a git reset --hard back to the commitish specified
+func (g *GoGitter) ResetHard(dir string, commitish string) error {
+	repo, wt, err := g.worktree(dir)
+	if err != nil {
+		return err
+	}
+	hash, err := repo.ResolveRevision(plumbing.Revision(commitish))
+	if err != nil {
+		return errors.Wrapf(err, "resolving %s", commitish)
+	}
+	return wt.Reset(&git.ResetOptions{Commit: *hash, Mode: git.HardReset})
+}
+
+// Stash stashes the current changes from the given directory.
+// Falls back to the git CLI as go-git cannot stash
+func (g *GoGitter) Stash(dir string) error {
+	return g.GitCLI.Stash(dir)
+}
+
+// Remove removes the given file or directory from a Git repository located at the given directory
+func (g *GoGitter) Remove(dir, fileName string) error {
+	_, wt, err := g.worktree(dir)
+	if err != nil {
+		return err
+	}
+	_, err = wt.Remove(fileName)
+	return errors.Wrapf(err, "removing %s from %s", fileName, dir)
+}
+
+// RemoveForce removes the given file or directory from a git repository located at the given directory
+func (g *GoGitter) RemoveForce(dir, fileName string) error {
+	return g.Remove(dir, fileName)
+}
+
+// CleanForce removes the untracked files within the given path of a git repository located at a given directory
+func (g *GoGitter) CleanForce(dir, fileName string) error {
+	root, wt, err := g.worktreeRoot(dir)
+	if err != nil {
+		return err
+	}
+	status, err := wt.Status()
+	if err != nil {
+		return err
+	}
+	for path, fileStatus := range status {
+		if fileStatus.Worktree == git.Untracked && pathspecMatches(fileName, path) {
+			err = os.RemoveAll(filepath.Join(root, path))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Add adds the files matching the given paths or globs, including deletions, to the index
+func (g *GoGitter) Add(dir string, args ...string) error {
+	_, wt, err := g.worktree(dir)
+	if err != nil {
+		return err
+	}
+	status, err := wt.Status()
+	if err != nil {
+		return err
+	}
+	for path, fileStatus := range status {
+		if fileStatus.Worktree == git.Unmodified || !pathspecsMatch(args, path) {
+			continue
+		}
+		if fileStatus.Worktree == git.Deleted {
+			_, err = wt.Remove(path)
+		} else {
+			_, err = wt.Add(path)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "adding %s", path)
+		}
+	}
+	return nil
+}
+
+// CommitIfChanges does a commit if there are any changes in the repository at the given directory
+func (g *GoGitter) CommitIfChanges(dir string, message string) error {
+	changed, err := g.HasChanges(dir)
+	if err != nil {
+		return err
+	}
+	if !changed {
+		return nil
+	}
+	return g.CommitDir(dir, message)
+}
+
+// CommitDir commits the changes in the index of the repository at the given directory
+func (g *GoGitter) CommitDir(dir string, message string) error {
+	return g.commit(dir, message, false)
+}
+
+// AddCommit commits all the changes of tracked files in the repository at the given directory, even if there are
+// none, with the given message
+func (g *GoGitter) AddCommit(dir string, msg string) error {
+	return g.commit(dir, msg, true)
+}
+
+// HasChanges indicates if there are any changes in the repository from the given directory
+func (g *GoGitter) HasChanges(dir string) (bool, error) {
+	_, wt, err := g.worktree(dir)
+	if err != nil {
+		return false, err
+	}
+	status, err := wt.Status()
+	if err != nil {
+		return false, err
+	}
+	return !status.IsClean(), nil
+}
+
+// Diff returns the unified diff of the changes in the working tree which have not been added to the index
+func (g *GoGitter) Diff(dir string) (string, error) {
+	repo, err := g.open(dir)
+	if err != nil {
+		return "", err
+	}
+	root, wt, err := g.worktreeRoot(dir)
+	if err != nil {
+		return "", err
+	}
+	status, err := wt.Status()
+	if err != nil {
+		return "", err
+	}
+	idx, err := repo.Storer.Index()
+	if err != nil {
+		return "", err
+	}
+	paths := []string{}
+	for path, fileStatus := range status {
+		if fileStatus.Worktree == git.Modified || fileStatus.Worktree == git.Deleted {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	patch := &worktreePatch{}
+	for _, path := range paths {
+		entry, err := idx.Entry(path)
+		if err != nil {
+			return "", errors.Wrapf(err, "finding %s in the index", path)
+		}
+		blob, err := repo.BlobObject(entry.Hash)
+		if err != nil {
+			return "", err
+		}
+		from, err := blobContents(blob)
+		if err != nil {
+			return "", err
+		}
+		filePatch := &worktreeFilePatch{
+			from: &worktreeFile{hash: entry.Hash, mode: entry.Mode, path: path},
+		}
+		to := ""
+		if status[path].Worktree != git.Deleted {
+			data, err := ioutil.ReadFile(filepath.Join(root, path))
+			if err != nil {
+				return "", err
+			}
+			to = string(data)
+			filePatch.to = &worktreeFile{
+				hash: plumbing.ComputeHash(plumbing.BlobObject, data),
+				mode: entry.Mode,
+				path: path,
+			}
+		}
+		filePatch.binary = isBinary(from) || isBinary(to)
+		if !filePatch.binary {
+			filePatch.chunks = diffChunks(from, to)
+		}
+		patch.filePatches = append(patch.filePatches, filePatch)
+	}
+	var buffer bytes.Buffer
+	err = diff.NewUnifiedEncoder(&buffer, diff.DefaultContextLines).Encode(patch)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buffer.String(), "\n"), nil
+}
+
+// ListChangedFilesFromBranch lists the files which differ between the branch and the working tree in the format of
+// git diff --name-status
+func (g *GoGitter) ListChangedFilesFromBranch(dir string, branch string) (string, error) {
+	repo, wt, err := g.worktree(dir)
+	if err != nil {
+		return "", err
+	}
+	from, err := resolveCommit(repo, branch)
+	if err != nil {
+		return "", err
+	}
+	changes := map[string]string{}
+	head, err := repo.Head()
+	if err != nil {
+		return "", err
+	}
+	to, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		return "", err
+	}
+	fromTree, err := from.Tree()
+	if err != nil {
+		return "", err
+	}
+	toTree, err := to.Tree()
+	if err != nil {
+		return "", err
+	}
+	treeChanges, err := object.DiffTree(fromTree, toTree)
+	if err != nil {
+		return "", err
+	}
+	for _, change := range treeChanges {
+		action, err := change.Action()
+		if err != nil {
+			return "", err
+		}
+		switch action {
+		case merkletrie.Insert:
+			changes[change.To.Name] = "A"
+		case merkletrie.Delete:
+			changes[change.From.Name] = "D"
+		default:
+			changes[change.To.Name] = "M"
+		}
+	}
+	// lets include the uncommitted changes to tracked files like git diff does
+	status, err := wt.Status()
+	if err != nil {
+		return "", err
+	}
+	for path, fileStatus := range status {
+		if fileStatus.Worktree == git.Untracked || (fileStatus.Worktree == git.Unmodified && fileStatus.Staging == git.Unmodified) {
+			continue
+		}
+		_, err := from.File(path)
+		inBranch := err == nil
+		deleted := fileStatus.Worktree == git.Deleted || fileStatus.Staging == git.Deleted
+		switch {
+		case deleted && inBranch:
+			changes[path] = "D"
+		case deleted:
+			delete(changes, path)
+		case inBranch:
+			changes[path] = "M"
+		default:
+			changes[path] = "A"
+		}
+	}
+	paths := []string{}
+	for path := range changes {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	lines := []string{}
+	for _, path := range paths {
+		lines = append(lines, changes[path]+"\t"+path)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// LoadFileFromBranch returns a files's contents from a branch
+func (g *GoGitter) LoadFileFromBranch(dir string, branch string, file string) (string, error) {
+	repo, err := g.open(dir)
+	if err != nil {
+		return "", err
+	}
+	commit, err := resolveCommit(repo, branch)
+	if err != nil {
+		return "", err
+	}
+	f, err := commit.File(file)
+	if err != nil {
+		return "", errors.Wrapf(err, "finding %s in %s", file, branch)
+	}
+	return f.Contents()
+}
+
+// GetLatestCommitMessage returns the latest git commit message
+func (g *GoGitter) GetLatestCommitMessage(dir string) (string, error) {
+	repo, err := g.open(dir)
+	if err != nil {
+		return "", err
+	}
+	commit, err := resolveCommit(repo, "HEAD")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(commit.Message), nil
+}
+
+// GetPreviousGitTagSHA returns the tag before the latest tag reachable from HEAD in the repository at the given
+// directory or the abbreviated SHA of the commit before the latest tag if there is no previous tag
+func (g *GoGitter) GetPreviousGitTagSHA(dir string) (string, error) {
+	repo, err := g.open(dir)
+	if err != nil {
+		return "", err
+	}
+	tags, err := tagsByCommit(repo)
+	if err != nil {
+		return "", err
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return "", err
+	}
+	latest, latestCommit, err := describe(repo, tags, head.Hash())
+	if err != nil {
+		return "", fmt.Errorf("failed to find latest tag for project in %s : %s", dir, err)
+	}
+	commit, err := repo.CommitObject(latestCommit)
+	if err != nil {
+		return "", err
+	}
+	if commit.NumParents() == 0 {
+		return "", fmt.Errorf("failed to find previous tag for project in %s : %s has no parent", dir, latest)
+	}
+	previous, _, err := describe(repo, tags, commit.ParentHashes[0])
+	if err != nil {
+		return "", fmt.Errorf("failed to find previous tag for project in %s : %s", dir, err)
+	}
+	return previous, nil
+}
+
+// GetCurrentGitTagSHA return the SHA of the most recent commit which is tagged in the repository at the given directory
+func (g *GoGitter) GetCurrentGitTagSHA(dir string) (string, error) {
+	repo, err := g.open(dir)
+	if err != nil {
+		return "", err
+	}
+	tags, err := tagsByCommit(repo)
+	if err != nil {
+		return "", err
+	}
+	var latest *object.Commit
+	for hash := range tags {
+		commit, err := repo.CommitObject(hash)
+		if err != nil {
+			return "", err
+		}
+		if latest == nil || commit.Committer.When.After(latest.Committer.When) {
+			latest = commit
+		}
+	}
+	if latest == nil {
+		return "", nil
+	}
+	return latest.Hash.String(), nil
+}
+
+// FetchTags fetches all the tags from origin
+func (g *GoGitter) FetchTags(dir string) error {
+	repo, err := g.open(dir)
+	if err != nil {
+		return err
+	}
+	return g.fetch(repo, originRemote, []gitcfg.RefSpec{"+refs/tags/*:refs/tags/*"}, 0, git.AllTags)
+}
+
+// Tags returns all tags from the repository at the given directory
+func (g *GoGitter) Tags(dir string) ([]string, error) {
+	tags := []string{}
+	repo, err := g.open(dir)
+	if err != nil {
+		return tags, err
+	}
+	err = forEachReference(repo, func(ref *plumbing.Reference) {
+		if ref.Name().IsTag() {
+			tags = append(tags, ref.Name().Short())
+		}
+	})
+	sort.Strings(tags)
+	return tags, err
+}
+
+// CreateTag creates an annotated tag of HEAD with the given name and message in the repository at the given
+// directory replacing any existing tag with the same name
+func (g *GoGitter) CreateTag(dir string, tag string, msg string) error {
+	repo, err := g.open(dir)
+	if err != nil {
+		return err
+	}
+	tagger, err := signature(repo)
+	if err != nil {
+		return err
+	}
+	return writeTag(repo, tag, msg, tagger, nil)
+}
+
+// CreateSignedTag creates an annotated tag of HEAD signed with the GPG key in the repository at the given directory
+// replacing any existing tag with the same name
+func (g *GoGitter) CreateSignedTag(dir string, tag string, msg string, signer *openpgp.Entity) error {
+	repo, err := g.open(dir)
+	if err != nil {
+		return err
+	}
+	tagger, err := signature(repo)
+	if err != nil {
+		tagger = taggerForKey(signer)
+		if tagger == nil {
+			return err
+		}
+	}
+	return writeTag(repo, tag, msg, tagger, signer)
+}
+
+// VerifyTag checks the GPG signature of the tag against the keyring returning the key which signed it
+func (g *GoGitter) VerifyTag(dir string, tag string, keyring openpgp.EntityList) (*openpgp.Entity, error) {
+	repo, err := g.open(dir)
+	if err != nil {
+		return nil, err
+	}
+	return verifyTag(repo, tag, keyring)
+}
+
+// GetLatestCommitSha returns the sha of the last commit
+func (g *GoGitter) GetLatestCommitSha(dir string) (string, error) {
+	repo, err := g.open(dir)
+	if err != nil {
+		return "", err
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return "", err
+	}
+	return head.Hash().String(), nil
+}
+
+// GetRevisionBeforeDate returns the revision before the given date
+func (g *GoGitter) GetRevisionBeforeDate(dir string, t time.Time) (string, error) {
+	repo, err := g.open(dir)
+	if err != nil {
+		return "", err
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return "", err
+	}
+	iter, err := repo.Log(&git.LogOptions{From: head.Hash()})
+	if err != nil {
+		return "", err
+	}
+	answer := ""
+	err = iter.ForEach(func(commit *object.Commit) error {
+		if commit.Committer.When.Before(t) {
+			answer = commit.Hash.String()
+			return storer.ErrStop
+		}
+		return nil
+	})
+	return answer, err
+}
+
+// GetFileRevisions returns the SHAs of the commits which changed the file in the repository at the given directory
+// with the latest commit first
+func (g *GoGitter) GetFileRevisions(dir string, file string) ([]string, error) {
+	repo, err := g.open(dir)
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.ToSlash(file)
+	head, err := repo.Head()
+	if err != nil {
+		return nil, err
+	}
+	iter, err := repo.Log(&git.LogOptions{From: head.Hash()})
+	if err != nil {
+		return nil, err
+	}
+	answer := []string{}
+	err = iter.ForEach(func(commit *object.Commit) error {
+		hash := fileHash(commit, path)
+		parentHash := plumbing.ZeroHash
+		if parent, err := commit.Parent(0); err == nil {
+			parentHash = fileHash(parent, path)
+		}
+		if hash != parentHash {
+			answer = append(answer, commit.Hash.String())
+		}
+		return nil
+	})
+	return answer, err
+}
+
+// GetCommitMessagesSince returns the messages of the commits of HEAD which are not reachable from the revision, or of
+// all the commits if the revision is empty, in the repository at the given directory with the latest commit first
+func (g *GoGitter) GetCommitMessagesSince(dir string, rev string) ([]string, error) {
+	repo, err := g.open(dir)
+	if err != nil {
+		return nil, err
+	}
+	reachable := map[plumbing.Hash]bool{}
+	if rev != "" {
+		from, err := resolveCommitish(repo, rev)
+		if err != nil {
+			return nil, err
+		}
+		iter, err := repo.Log(&git.LogOptions{From: from.Hash})
+		if err != nil {
+			return nil, err
+		}
+		err = iter.ForEach(func(commit *object.Commit) error {
+			reachable[commit.Hash] = true
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return nil, err
+	}
+	iter, err := repo.Log(&git.LogOptions{From: head.Hash()})
+	if err != nil {
+		return nil, err
+	}
+	messages := []string{}
+	err = iter.ForEach(func(commit *object.Commit) error {
+		if !reachable[commit.Hash] {
+			messages = append(messages, strings.TrimSpace(commit.Message))
+		}
+		return nil
+	})
+	return messages, err
+}
+
+// fileHash returns the blob hash of the file in the commit or the zero hash if it does not exist
+func fileHash(commit *object.Commit, path string) plumbing.Hash {
+	f, err := commit.File(path)
+	if err != nil {
+		return plumbing.ZeroHash
+	}
+	return f.Hash
+}
+
+// GetRevisionBeforeDateText returns the revision before the given date in format "MonthName dayNumber year"
+func (g *GoGitter) GetRevisionBeforeDateText(dir string, dateText string) (string, error) {
+	t, err := util.ParseDate(dateText)
+	if err != nil {
+		return "", errors.Wrapf(err, "parsing date %s", dateText)
+	}
+	return g.GetRevisionBeforeDate(dir, t)
+}
+
+// DeleteRemoteBranch deletes the remote branch in the given given directory
+func (g *GoGitter) DeleteRemoteBranch(dir string, remoteName string, branch string) error {
+	repo, err := g.open(dir)
+	if err != nil {
+		return err
+	}
+	return g.push(repo, remoteName, ":"+string(branchReferenceName(branch)))
+}
+
+func (g *GoGitter) open(dir string) (*git.Repository, error) {
+	if dir == "" {
+		dir = "."
+	}
+	repo, err := git.PlainOpenWithOptions(dir, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return nil, errors.Wrapf(err, "opening the git repository in %s", dir)
+	}
+	return repo, nil
+}
+
+func (g *GoGitter) worktree(dir string) (*git.Repository, *git.Worktree, error) {
+	repo, err := g.open(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "opening the working tree of %s", dir)
+	}
+	return repo, wt, nil
+}
+
+// worktreeRoot returns the directory of the working tree containing the given directory
+func (g *GoGitter) worktreeRoot(dir string) (string, *git.Worktree, error) {
+	_, wt, err := g.worktree(dir)
+	if err != nil {
+		return "", nil, err
+	}
+	root, _, err := g.FindGitConfigDir(dir)
+	if err != nil {
+		return "", nil, err
+	}
+	if root == "" {
+		root = dir
+	}
+	return root, wt, nil
+}
+
+func (g *GoGitter) commit(dir string, message string, all bool) error {
+	repo, wt, err := g.worktree(dir)
+	if err != nil {
+		return err
+	}
+	author, err := signature(repo)
+	if err != nil {
+		return err
+	}
+	_, err = wt.Commit(message, &git.CommitOptions{
+		All:    all,
+		Author: author,
+	})
+	return errors.Wrapf(err, "committing in %s", dir)
+}
+
+// fetchBranch fetches the refspecs from the remote falling back to the CLI for what go-git cannot fetch
+func (g *GoGitter) fetchBranch(dir string, remote string, depth int, refspecs []string,
+	fallback func(dir string, repo string, refspecs ...string) error) error {
+	repo, err := g.open(dir)
+	if err != nil {
+		return err
+	}
+	cfg, err := repo.Config()
+	if err != nil {
+		return err
+	}
+	if cfg.Remotes[remote] == nil {
+		return fallback(dir, remote, refspecs...)
+	}
+	specs := []gitcfg.RefSpec{}
+	for _, refspec := range refspecs {
+		spec, ok := fetchRefSpec(remote, refspec)
+		if !ok {
+			return fallback(dir, remote, refspecs...)
+		}
+		specs = append(specs, spec)
+	}
+	return g.fetch(repo, remote, specs, depth, git.TagFollowing)
+}
+
+func (g *GoGitter) fetch(repo *git.Repository, remote string, refspecs []gitcfg.RefSpec, depth int, tags git.TagMode) error {
+	gitURL, err := remoteURL(repo, remote)
+	if err != nil {
+		return err
+	}
+	if len(refspecs) == 0 {
+		refspecs = nil
+	}
+	err = repo.Fetch(&git.FetchOptions{
+		RemoteName: remote,
+		RefSpecs:   refspecs,
+		Depth:      depth,
+		Auth:       g.auth(gitURL),
+		Tags:       tags,
+	})
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		return errors.Wrapf(err, "fetching %v from %s", refspecs, remote)
+	}
+	return nil
+}
+
+func (g *GoGitter) push(repo *git.Repository, remote string, refspec string) error {
+	gitURL, err := remoteURL(repo, remote)
+	if err != nil {
+		return err
+	}
+	err = repo.Push(&git.PushOptions{
+		RemoteName: remote,
+		RefSpecs:   []gitcfg.RefSpec{gitcfg.RefSpec(refspec)},
+		Auth:       g.auth(gitURL),
+	})
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		return errors.Wrapf(err, "pushing %s to %s", refspec, remote)
+	}
+	return nil
+}
+
+// auth returns the credentials to use for the git URL. SSH URLs use the SSH agent
+func (g *GoGitter) auth(gitURL string) transport.AuthMethod {
+	u, err := url.Parse(gitURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil
+	}
+	if u.User != nil {
+		password, _ := u.User.Password()
+		return &githttp.BasicAuth{Username: u.User.Username(), Password: password}
+	}
+	if g.UserAuth != nil && !g.UserAuth.IsInvalid() {
+		token, err := g.UserAuth.GitToken()
+		if err != nil {
+			log.Warnf("Failed to get the git token of user %s: %s\n", g.UserAuth.Username, err)
+			token = g.UserAuth.ApiToken
+		}
+		return &githttp.BasicAuth{Username: g.UserAuth.Username, Password: token}
+	}
+	return credentialsStoreAuth(u)
+}
+
+// credentialsStoreAuth returns the credentials for the URL from the git credentials store files which are written by
+// jx step git credentials
+func credentialsStoreAuth(u *url.URL) transport.AuthMethod {
+	files := []string{}
+	cfgHome := os.Getenv("XDG_CONFIG_HOME")
+	home := util.HomeDir()
+	if cfgHome != "" {
+		files = append(files, filepath.Join(cfgHome, "git", "credentials"))
+	}
+	files = append(files, filepath.Join(home, ".config", "git", "credentials"), filepath.Join(home, ".git-credentials"),
+		filepath.Join(home, "git", "credentials"))
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		for scanner.Scan() {
+			credential, err := url.Parse(strings.TrimSpace(scanner.Text()))
+			if err != nil || credential.User == nil || credential.Scheme != u.Scheme || credential.Host != u.Host {
+				continue
+			}
+			if !strings.HasPrefix(u.Path, credential.Path) {
+				continue
+			}
+			password, _ := credential.User.Password()
+			return &githttp.BasicAuth{Username: credential.User.Username(), Password: password}
+		}
+	}
+	return nil
+}
+
+// signature returns the author of commits and tags from the git configuration or the environment
+func signature(repo *git.Repository) (*object.Signature, error) {
+	name := os.Getenv("GIT_AUTHOR_NAME")
+	email := os.Getenv("GIT_AUTHOR_EMAIL")
+	cfg, err := repo.Config()
+	if err == nil && cfg.Raw != nil {
+		if name == "" {
+			name = cfg.Raw.Section("user").Option("name")
+		}
+		if email == "" {
+			email = cfg.Raw.Section("user").Option("email")
+		}
+	}
+	if name == "" {
+		name, _ = globalGitConfigOption("user", "name")
+	}
+	if email == "" {
+		email, _ = globalGitConfigOption("user", "email")
+	}
+	if name == "" || email == "" {
+		return nil, errors.Errorf("no git user name and email are configured")
+	}
+	return &object.Signature{
+		Name:  name,
+		Email: email,
+		When:  time.Now(),
+	}, nil
+}
+
+// globalGitConfigFiles returns the global git configuration files in order of precedence
+func globalGitConfigFiles() []string {
+	cfgHome := os.Getenv("XDG_CONFIG_HOME")
+	if cfgHome == "" {
+		cfgHome = filepath.Join(util.HomeDir(), ".config")
+	}
+	return []string{filepath.Join(util.HomeDir(), ".gitconfig"), filepath.Join(cfgHome, "git", "config")}
+}
+
+func loadGitConfigFile(fileName string) (*formatcfg.Config, error) {
+	cfg := formatcfg.New()
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cfg, nil
+		}
+		return nil, err
+	}
+	err = formatcfg.NewDecoder(bytes.NewReader(data)).Decode(cfg)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing %s", fileName)
+	}
+	return cfg, nil
+}
+
+func globalGitConfigOption(section string, key string) (string, error) {
+	for _, fileName := range globalGitConfigFiles() {
+		cfg, err := loadGitConfigFile(fileName)
+		if err != nil {
+			return "", err
+		}
+		value := cfg.Section(section).Option(key)
+		if value != "" {
+			return value, nil
+		}
+	}
+	return "", nil
+}
+
+// setGlobalGitConfigOption sets the option in ~/.gitconfig if it is not already set
+func setGlobalGitConfigOption(section string, key string, value string) error {
+	current, err := globalGitConfigOption(section, key)
+	if err != nil || current != "" {
+		return err
+	}
+	fileName := globalGitConfigFiles()[0]
+	cfg, err := loadGitConfigFile(fileName)
+	if err != nil {
+		return err
+	}
+	cfg.Section(section).SetOption(key, value)
+	var buffer bytes.Buffer
+	err = formatcfg.NewEncoder(&buffer).Encode(cfg)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(fileName, buffer.Bytes(), util.DefaultWritePermissions)
+}
+
+func remoteURL(repo *git.Repository, name string) (string, error) {
+	remote, err := repo.Remote(name)
+	if err != nil {
+		return "", errors.Wrapf(err, "finding remote %s", name)
+	}
+	urls := remote.Config().URLs
+	if len(urls) == 0 {
+		return "", errors.Errorf("remote %s has no URL", name)
+	}
+	return urls[0], nil
+}
+
+// setUpstream makes the local branch track the branch of the remote
+func setUpstream(repo *git.Repository, branch string, remote string, merge plumbing.ReferenceName) error {
+	cfg, err := repo.Config()
+	if err != nil {
+		return err
+	}
+	subsection := cfg.Raw.Section("branch").Subsection(branch)
+	subsection.SetOption("remote", remote)
+	subsection.SetOption("merge", string(merge))
+	return repo.Storer.SetConfig(cfg)
+}
+
+func createBranch(repo *git.Repository, branch string, hash plumbing.Hash) error {
+	refName := branchReferenceName(branch)
+	_, err := repo.Reference(refName, false)
+	if err == nil {
+		return errors.Errorf("a branch named '%s' already exists", branch)
+	}
+	return repo.Storer.SetReference(plumbing.NewHashReference(refName, hash))
+}
+
+func resolveCommit(repo *git.Repository, revision string) (*object.Commit, error) {
+	hash, err := repo.ResolveRevision(plumbing.Revision(revision))
+	if err != nil {
+		return nil, errors.Wrapf(err, "resolving %s", revision)
+	}
+	return repo.CommitObject(*hash)
+}
+
+// resolveCommitish resolves the revision to a commit, peeling annotated tags which go-git does not resolve to commits
+func resolveCommitish(repo *git.Repository, revision string) (*object.Commit, error) {
+	ref, err := repo.Reference(tagReferenceName(revision), true)
+	if err == nil {
+		tag, err := repo.TagObject(ref.Hash())
+		if err == nil {
+			return tag.Commit()
+		}
+		if err != plumbing.ErrObjectNotFound {
+			return nil, err
+		}
+		return repo.CommitObject(ref.Hash())
+	}
+	return resolveCommit(repo, revision)
+}
+
+func forEachReference(repo *git.Repository, fn func(ref *plumbing.Reference)) error {
+	refs, err := repo.References()
+	if err != nil {
+		return err
+	}
+	return refs.ForEach(func(ref *plumbing.Reference) error {
+		fn(ref)
+		return nil
+	})
+}
+
+// tagsByCommit returns the names of the tags of each tagged commit
+func tagsByCommit(repo *git.Repository) (map[plumbing.Hash][]string, error) {
+	answer := map[plumbing.Hash][]string{}
+	var err error
+	iterErr := forEachReference(repo, func(ref *plumbing.Reference) {
+		if err != nil || !ref.Name().IsTag() {
+			return
+		}
+		hash := ref.Hash()
+		tag, tagErr := repo.TagObject(hash)
+		if tagErr == nil {
+			commit, commitErr := tag.Commit()
+			if commitErr != nil {
+				// lets ignore tags of trees and blobs
+				return
+			}
+			hash = commit.Hash
+		} else if tagErr != plumbing.ErrObjectNotFound {
+			err = tagErr
+			return
+		}
+		answer[hash] = append(answer[hash], ref.Name().Short())
+	})
+	if iterErr != nil {
+		return nil, iterErr
+	}
+	return answer, err
+}
+
+// describe returns the name and commit of the nearest tag reachable from the commit like git describe --abbrev=0
+// --tags --always, which returns the abbreviated commit SHA if there is no tag
+func describe(repo *git.Repository, tags map[plumbing.Hash][]string, from plumbing.Hash) (string, plumbing.Hash, error) {
+	iter, err := repo.Log(&git.LogOptions{From: from})
+	if err != nil {
+		return "", from, err
+	}
+	name := ""
+	found := from
+	err = iter.ForEach(func(commit *object.Commit) error {
+		names := tags[commit.Hash]
+		if len(names) == 0 {
+			return nil
+		}
+		sort.Strings(names)
+		name = names[len(names)-1]
+		found = commit.Hash
+		return storer.ErrStop
+	})
+	if err != nil {
+		return "", from, err
+	}
+	if name == "" {
+		return from.String()[:7], from, nil
+	}
+	return name, found, nil
+}
+
+func branchReferenceName(branch string) plumbing.ReferenceName {
+	return plumbing.ReferenceName("refs/heads/" + strings.TrimPrefix(branch, "refs/heads/"))
+}
+
+func tagReferenceName(tag string) plumbing.ReferenceName {
+	return plumbing.ReferenceName("refs/tags/" + strings.TrimPrefix(tag, "refs/tags/"))
+}
+
+func remoteReferenceName(remote string, branch string) plumbing.ReferenceName {
+	return plumbing.ReferenceName(fmt.Sprintf("refs/remotes/%s/%s", remote, branch))
+}
+
+// expandRefName expands a short name such as master or pull/1/head into a full reference name
+func expandRefName(name string) plumbing.ReferenceName {
+	switch {
+	case name == "HEAD" || strings.HasPrefix(name, "refs/"):
+		return plumbing.ReferenceName(name)
+	case strings.HasPrefix(name, "pull/") || strings.HasPrefix(name, "merge-requests/") ||
+		strings.HasPrefix(name, "tags/") || strings.HasPrefix(name, "heads/"):
+		return plumbing.ReferenceName("refs/" + name)
+	default:
+		return branchReferenceName(name)
+	}
+}
+
+// fetchRefSpec converts a refspec of git fetch, such as master or pull/1/head:PR-1, into a go-git refspec. It returns
+// false if go-git cannot fetch the refspec such as a SHA
+func fetchRefSpec(remote string, refspec string) (gitcfg.RefSpec, bool) {
+	refspec = strings.TrimPrefix(refspec, "+")
+	src, dst := refspec, ""
+	if i := strings.Index(refspec, ":"); i >= 0 {
+		src, dst = refspec[:i], refspec[i+1:]
+	}
+	if src == "" || shaRegex.MatchString(src) {
+		return "", false
+	}
+	srcRef := expandRefName(src)
+	dstRef := srcRef
+	if dst != "" {
+		dstRef = expandRefName(dst)
+	} else if srcRef.IsBranch() {
+		dstRef = remoteReferenceName(remote, srcRef.Short())
+	}
+	return gitcfg.RefSpec(fmt.Sprintf("+%s:%s", srcRef, dstRef)), true
+}
+
+// pathspecsMatch returns true if the path matches any of the pathspecs of git add or if there are none
+func pathspecsMatch(pathspecs []string, path string) bool {
+	if len(pathspecs) == 0 {
+		return true
+	}
+	for _, pathspec := range pathspecs {
+		if pathspecMatches(pathspec, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// pathspecMatches returns true if the path is, or is within, the pathspec or matches its glob where * also matches /
+func pathspecMatches(pathspec string, path string) bool {
+	if pathspec == "-A" || pathspec == "--all" {
+		return true
+	}
+	pathspec = strings.TrimSuffix(filepath.ToSlash(filepath.Clean(pathspec)), "/")
+	if pathspec == "." || pathspec == "" {
+		return true
+	}
+	if path == pathspec || strings.HasPrefix(path, pathspec+"/") {
+		return true
+	}
+	if !strings.ContainsAny(pathspec, "*?[") {
+		return false
+	}
+	pattern := regexp.QuoteMeta(pathspec)
+	pattern = strings.Replace(pattern, `\*`, ".*", -1)
+	pattern = strings.Replace(pattern, `\?`, ".", -1)
+	re, err := regexp.Compile("^" + pattern + "(/.*)?$")
+	return err == nil && re.MatchString(path)
+}
+
+func blobContents(blob *object.Blob) (string, error) {
+	reader, err := blob.Reader()
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+	data, err := ioutil.ReadAll(reader)
+	return string(data), err
+}
+
+func isBinary(text string) bool {
+	return strings.IndexByte(text, 0) >= 0
+}
+
+func diffChunks(from string, to string) []diff.Chunk {
+	chunks := []diff.Chunk{}
+	for _, d := range diffutil.Do(from, to) {
+		op := diff.Equal
+		switch d.Type {
+		case diffmatchpatch.DiffInsert:
+			op = diff.Add
+		case diffmatchpatch.DiffDelete:
+			op = diff.Delete
+		}
+		chunks = append(chunks, &worktreeChunk{content: d.Text, op: op})
+	}
+	return chunks
+}
+
+// worktreePatch the changes in the working tree which can be encoded as a unified diff
+type worktreePatch struct {
+	filePatches []diff.FilePatch
+}
+
+func (p *worktreePatch) FilePatches() []diff.FilePatch {
+	return p.filePatches
+}
+
+func (p *worktreePatch) Message() string {
+	return ""
+}
+
+type worktreeFilePatch struct {
+	from   diff.File
+	to     diff.File
+	binary bool
+	chunks []diff.Chunk
+}
+
+func (p *worktreeFilePatch) IsBinary() bool {
+	return p.binary
+}
+
+func (p *worktreeFilePatch) Files() (diff.File, diff.File) {
+	return p.from, p.to
+}
+
+func (p *worktreeFilePatch) Chunks() []diff.Chunk {
+	return p.chunks
+}
+
+type worktreeFile struct {
+	hash plumbing.Hash
+	mode filemode.FileMode
+	path string
+}
+
+func (f *worktreeFile) Hash() plumbing.Hash {
+	return f.hash
+}
+
+func (f *worktreeFile) Mode() filemode.FileMode {
+	return f.mode
+}
+
+func (f *worktreeFile) Path() string {
+	return f.path
+}
+
+type worktreeChunk struct {
+	content string
+	op      diff.Operation
+}
+
+func (c *worktreeChunk) Content() string {
+	return c.content
+}
+
+func (c *worktreeChunk) Type() diff.Operation {
+	return c.op
+}
@@ -0,0 +1,278 @@
+package gits_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/openpgp"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+)
+
+func TestGoGitterCommitBranchTagAndDiff(t *testing.T) {
+	os.Setenv("GIT_AUTHOR_NAME", "jx-bot")
+	os.Setenv("GIT_AUTHOR_EMAIL", "jx-bot@jenkins-x.io")
+	defer os.Unsetenv("GIT_AUTHOR_NAME")
+	defer os.Unsetenv("GIT_AUTHOR_EMAIL")
+
+	dir, err := ioutil.TempDir("", "test-go-gitter-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	gitter := gits.NewGoGitter()
+	require.NoError(t, gitter.Init(dir))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "README.md"), []byte("hello\n"), 0644))
+	changed, err := gitter.HasChanges(dir)
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	require.NoError(t, gitter.Add(dir, "."))
+	require.NoError(t, gitter.CommitDir(dir, "initial import"))
+	changed, err = gitter.HasChanges(dir)
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	message, err := gitter.GetLatestCommitMessage(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "initial import", message)
+	sha, err := gitter.GetLatestCommitSha(dir)
+	require.NoError(t, err)
+	email, err := gitter.GetAuthorEmailForCommit(dir, sha)
+	require.NoError(t, err)
+	assert.Equal(t, "jx-bot@jenkins-x.io", email)
+
+	require.NoError(t, gitter.CreateTag(dir, "v1.0.0", "release 1.0.0"))
+	tags, err := gitter.Tags(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"v1.0.0"}, tags)
+	tagSHA, err := gitter.GetCurrentGitTagSHA(dir)
+	require.NoError(t, err)
+	assert.Equal(t, sha, tagSHA)
+
+	require.NoError(t, gitter.CreateBranch(dir, "feature"))
+	require.NoError(t, gitter.Checkout(dir, "feature"))
+	branch, err := gitter.Branch(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "feature", branch)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "README.md"), []byte("hello\nworld\n"), 0644))
+	diff, err := gitter.Diff(dir)
+	require.NoError(t, err)
+	assert.Contains(t, diff, "+world")
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "CHANGELOG.md"), []byte("changes\n"), 0644))
+	require.NoError(t, gitter.Add(dir, "*.md"))
+	require.NoError(t, gitter.CommitIfChanges(dir, "add a changelog"))
+
+	changes, err := gitter.ListChangedFilesFromBranch(dir, "master")
+	require.NoError(t, err)
+	assert.Equal(t, "A\tCHANGELOG.md\nM\tREADME.md", changes)
+	readme, err := gitter.LoadFileFromBranch(dir, "master", "README.md")
+	require.NoError(t, err)
+	assert.Equal(t, "hello\n", readme)
+
+	branches, err := gitter.LocalBranches(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"feature", "master"}, branches)
+
+	require.NoError(t, gitter.CreateTag(dir, "v1.1.0", "release 1.1.0"))
+	previous, err := gitter.GetPreviousGitTagSHA(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "v1.0.0", previous)
+}
+
+func TestGoGitterFileRevisions(t *testing.T) {
+	os.Setenv("GIT_AUTHOR_NAME", "jx-bot")
+	os.Setenv("GIT_AUTHOR_EMAIL", "jx-bot@jenkins-x.io")
+	defer os.Unsetenv("GIT_AUTHOR_NAME")
+	defer os.Unsetenv("GIT_AUTHOR_EMAIL")
+
+	dir, err := ioutil.TempDir("", "test-go-gitter-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	gitter := gits.NewGoGitter()
+	require.NoError(t, gitter.Init(dir))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "env"), 0755))
+	requirements := filepath.Join(dir, "env", "requirements.yaml")
+	commit := func(fileName string, text string, message string) string {
+		require.NoError(t, ioutil.WriteFile(fileName, []byte(text), 0644))
+		require.NoError(t, gitter.Add(dir, "."))
+		require.NoError(t, gitter.CommitDir(dir, message))
+		sha, err := gitter.GetLatestCommitSha(dir)
+		require.NoError(t, err)
+		return sha
+	}
+	first := commit(requirements, "version: 1.0.0\n", "promote 1.0.0")
+	commit(filepath.Join(dir, "README.md"), "hello\n", "add a readme")
+	second := commit(requirements, "version: 1.0.1\n", "promote 1.0.1")
+
+	revisions, err := gitter.GetFileRevisions(dir, "env/requirements.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, []string{second, first}, revisions)
+
+	text, err := gitter.LoadFileFromBranch(dir, first, "env/requirements.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "version: 1.0.0\n", text)
+}
+
+func TestGoGitterCommitMessagesSince(t *testing.T) {
+	os.Setenv("GIT_AUTHOR_NAME", "jx-bot")
+	os.Setenv("GIT_AUTHOR_EMAIL", "jx-bot@jenkins-x.io")
+	defer os.Unsetenv("GIT_AUTHOR_NAME")
+	defer os.Unsetenv("GIT_AUTHOR_EMAIL")
+
+	dir, err := ioutil.TempDir("", "test-go-gitter-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	gitter := gits.NewGoGitter()
+	require.NoError(t, gitter.Init(dir))
+	commit := func(text string, message string) {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "README.md"), []byte(text), 0644))
+		require.NoError(t, gitter.Add(dir, "."))
+		require.NoError(t, gitter.CommitDir(dir, message))
+	}
+	commit("hello\n", "initial import")
+	require.NoError(t, gitter.CreateTag(dir, "v1.0.0", "release 1.0.0"))
+
+	messages, err := gitter.GetCommitMessagesSince(dir, "v1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, []string{}, messages)
+
+	commit("hello\nworld\n", "fix: say hello to the world")
+	commit("hello\nworld\n!\n", "feat: shout")
+	messages, err = gitter.GetCommitMessagesSince(dir, "v1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"feat: shout", "fix: say hello to the world"}, messages)
+
+	messages, err = gitter.GetCommitMessagesSince(dir, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"feat: shout", "fix: say hello to the world", "initial import"}, messages)
+}
+
+func TestGoGitterCloneFetchAndPush(t *testing.T) {
+	os.Setenv("GIT_AUTHOR_NAME", "jx-bot")
+	os.Setenv("GIT_AUTHOR_EMAIL", "jx-bot@jenkins-x.io")
+	defer os.Unsetenv("GIT_AUTHOR_NAME")
+	defer os.Unsetenv("GIT_AUTHOR_EMAIL")
+
+	dir, err := ioutil.TempDir("", "test-go-gitter-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	origin := filepath.Join(dir, "origin")
+	clone := filepath.Join(dir, "clone")
+
+	gitter := gits.NewGoGitter()
+	require.NoError(t, gitter.Init(origin))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(origin, "README.md"), []byte("hello\n"), 0644))
+	require.NoError(t, gitter.Add(origin, "README.md"))
+	require.NoError(t, gitter.CommitDir(origin, "initial import"))
+
+	require.NoError(t, gitter.Clone(origin, clone))
+	remoteBranches, err := gitter.RemoteBranches(clone)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"origin/master"}, remoteBranches)
+
+	// lets push a new branch and fetch it back
+	require.NoError(t, gitter.CreateBranch(clone, "feature"))
+	require.NoError(t, gitter.Checkout(clone, "feature"))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(clone, "feature.txt"), []byte("feature\n"), 0644))
+	require.NoError(t, gitter.Add(clone, "."))
+	require.NoError(t, gitter.CommitDir(clone, "add a feature"))
+	require.NoError(t, gitter.Push(clone))
+	originBranches, err := gitter.LocalBranches(origin)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"feature", "master"}, originBranches)
+
+	require.NoError(t, gitter.FetchBranch(clone, "origin", "feature"))
+	remoteBranches, err = gitter.RemoteBranches(clone)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"origin/feature", "origin/master"}, remoteBranches)
+
+	require.NoError(t, gitter.DeleteRemoteBranch(clone, "origin", "feature"))
+	originBranches, err = gitter.LocalBranches(origin)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"master"}, originBranches)
+}
+
+func TestGoGitterForcePushBranchToReference(t *testing.T) {
+	os.Setenv("GIT_AUTHOR_NAME", "jx-bot")
+	os.Setenv("GIT_AUTHOR_EMAIL", "jx-bot@jenkins-x.io")
+	defer os.Unsetenv("GIT_AUTHOR_NAME")
+	defer os.Unsetenv("GIT_AUTHOR_EMAIL")
+
+	dir, err := ioutil.TempDir("", "test-go-gitter-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	origin := filepath.Join(dir, "origin")
+	clone := filepath.Join(dir, "clone")
+
+	gitter := gits.NewGoGitter()
+	require.NoError(t, gitter.Init(origin))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(origin, "README.md"), []byte("hello\n"), 0644))
+	require.NoError(t, gitter.Add(origin, "README.md"))
+	require.NoError(t, gitter.CommitDir(origin, "initial import"))
+	require.NoError(t, gitter.Clone(origin, clone))
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(clone, "change.txt"), []byte("change\n"), 0644))
+	require.NoError(t, gitter.Add(clone, "."))
+	require.NoError(t, gitter.CommitDir(clone, "add a change"))
+	sha, err := gitter.GetLatestCommitSha(clone)
+	require.NoError(t, err)
+
+	// a full reference name is pushed as is rather than as a branch
+	require.NoError(t, gitter.ForcePushBranch(clone, "HEAD", "refs/changes/for-review"))
+	repo, err := git.PlainOpen(origin)
+	require.NoError(t, err)
+	ref, err := repo.Reference(plumbing.ReferenceName("refs/changes/for-review"), false)
+	require.NoError(t, err)
+	assert.Equal(t, sha, ref.Hash().String())
+	_, err = repo.Reference(plumbing.ReferenceName("refs/heads/refs/changes/for-review"), false)
+	assert.Equal(t, plumbing.ErrReferenceNotFound, err)
+
+	// a short name is pushed as a branch
+	require.NoError(t, gitter.ForcePushBranch(clone, "HEAD", "review"))
+	originBranches, err := gitter.LocalBranches(origin)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"master", "review"}, originBranches)
+}
+
+func TestGoGitterSignedTags(t *testing.T) {
+	os.Setenv("GIT_AUTHOR_NAME", "jx-bot")
+	os.Setenv("GIT_AUTHOR_EMAIL", "jx-bot@jenkins-x.io")
+	defer os.Unsetenv("GIT_AUTHOR_NAME")
+	defer os.Unsetenv("GIT_AUTHOR_EMAIL")
+
+	dir, err := ioutil.TempDir("", "test-go-gitter-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	signer, err := openpgp.NewEntity("jx-release", "", "jx-release@jenkins-x.io", nil)
+	require.NoError(t, err)
+	other, err := openpgp.NewEntity("someone-else", "", "someone-else@jenkins-x.io", nil)
+	require.NoError(t, err)
+
+	gitter := gits.NewGoGitter()
+	require.NoError(t, gitter.Init(dir))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "README.md"), []byte("hello\n"), 0644))
+	require.NoError(t, gitter.Add(dir, "."))
+	require.NoError(t, gitter.CommitDir(dir, "initial import"))
+
+	require.NoError(t, gitter.CreateSignedTag(dir, "v1.0.0", "release 1.0.0", signer))
+	key, err := gitter.VerifyTag(dir, "v1.0.0", openpgp.EntityList{other, signer})
+	require.NoError(t, err)
+	assert.Equal(t, gits.KeyName(signer), gits.KeyName(key))
+
+	_, err = gitter.VerifyTag(dir, "v1.0.0", openpgp.EntityList{other})
+	assert.Error(t, err)
+
+	require.NoError(t, gitter.CreateTag(dir, "v1.0.1", "release 1.0.1"))
+	_, err = gitter.VerifyTag(dir, "v1.0.1", openpgp.EntityList{signer})
+	assert.Equal(t, gits.ErrTagNotSigned, errors.Cause(err))
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/jenkins-x/jx/pkg/auth"
 
+	"golang.org/x/crypto/openpgp"
 	gitcfg "gopkg.in/src-d/go-git.v4/config"
 )
 
@@ -288,6 +289,18 @@ func (g *GitLocal) GetRevisionBeforeDateText(dir string, dateText string) (strin
 	return g.GitCLI.GetRevisionBeforeDateText(dir, dateText)
 }
 
+// GetFileRevisions returns the SHAs of the commits which changed the file in the repository at the given directory
+// with the latest commit first
+func (g *GitLocal) GetFileRevisions(dir string, file string) ([]string, error) {
+	return g.GitCLI.GetFileRevisions(dir, file)
+}
+
+// GetCommitMessagesSince returns the messages of the commits of HEAD which are not reachable from the revision, or of
+// all the commits if the revision is empty, with the latest commit first
+func (g *GitLocal) GetCommitMessagesSince(dir string, rev string) ([]string, error) {
+	return g.GitCLI.GetCommitMessagesSince(dir, rev)
+}
+
 // GetCurrentGitTagSHA return the SHA of the current git tag from the repository at the given directory
 func (g *GitLocal) GetCurrentGitTagSHA(dir string) (string, error) {
 	return g.GitCLI.GetCurrentGitTagSHA(dir)
@@ -314,6 +327,17 @@ func (g *GitLocal) CreateTag(dir string, tag string, msg string) error {
 	return g.GitCLI.CreateTag(dir, tag, msg)
 }
 
+// CreateSignedTag creates a tag with the given name and message signed with the GPG key in the repository at the given
+// directory
+func (g *GitLocal) CreateSignedTag(dir string, tag string, msg string, signer *openpgp.Entity) error {
+	return g.GitCLI.CreateSignedTag(dir, tag, msg, signer)
+}
+
+// VerifyTag checks the GPG signature of the tag against the keyring returning the key which signed it
+func (g *GitLocal) VerifyTag(dir string, tag string, keyring openpgp.EntityList) (*openpgp.Entity, error) {
+	return g.GitCLI.VerifyTag(dir, tag, keyring)
+}
+
 // PrintCreateRepositoryGenerateAccessToken prints the access token URL of a Git repository
 func (g *GitLocal) PrintCreateRepositoryGenerateAccessToken(server *auth.AuthServer, username string, o io.Writer) {
 	g.GitCLI.PrintCreateRepositoryGenerateAccessToken(server, username, o)
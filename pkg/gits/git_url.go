@@ -12,20 +12,43 @@ const (
 	GitHubHost = "github.com"
 	GitHubURL  = "https://github.com"
 
+	// AzureDevOpsHost the host of Azure DevOps Services
+	AzureDevOpsHost = "dev.azure.com"
+	// AzureDevOpsURL the URL of Azure DevOps Services
+	AzureDevOpsURL = "https://dev.azure.com"
+
 	gitPrefix = "git@"
+
+	azureDevOpsGitPath      = "_git"
+	azureDevOpsSSHHost      = "ssh.dev.azure.com"
+	azureDevOpsSSHVersion   = "v3"
+	azureDevOpsLegacySuffix = ".visualstudio.com"
 )
 
 func (i *GitRepository) IsGitHub() bool {
 	return GitHubHost == i.Host || strings.HasSuffix(i.URL, "https://github.com")
 }
 
+// IsAzureDevOps returns true if the repository is hosted on Azure DevOps Services or Server
+func (i *GitRepository) IsAzureDevOps() bool {
+	return i.Host == AzureDevOpsHost || strings.HasSuffix(i.Host, azureDevOpsLegacySuffix) ||
+		strings.Contains(i.URL, "/"+azureDevOpsGitPath+"/")
+}
+
 // PullRequestURL returns the URL of a pull request of the given name/number
 func (i *GitRepository) PullRequestURL(prName string) string {
+	if i.IsAzureDevOps() {
+		return util.UrlJoin(i.HttpsURL(), "pullrequest", prName)
+	}
 	return util.UrlJoin("https://"+i.Host, i.Organisation, i.Name, "pull", prName)
 }
 
 // HttpCloneURL returns the HTTPS git URL this repository
 func (i *GitRepository) HttpCloneURL() string {
+	if i.IsAzureDevOps() {
+		// Azure DevOps clone URLs have no .git suffix
+		return i.HttpsURL()
+	}
 	return i.HttpsURL() + ".git"
 }
 
@@ -44,6 +67,13 @@ func (i *GitRepository) HttpsURL() string {
 	if !strings.Contains(host, ":/") {
 		host = "https://" + host
 	}
+	if i.IsAzureDevOps() {
+		if strings.HasSuffix(i.Host, azureDevOpsLegacySuffix) {
+			// the organisation is the sub domain of the legacy hosts
+			return util.UrlJoin(host, i.Project, azureDevOpsGitPath, i.Name)
+		}
+		return util.UrlJoin(host, i.Organisation, i.Project, azureDevOpsGitPath, i.Name)
+	}
 	return util.UrlJoin(host, i.Organisation, i.Name)
 }
 
@@ -126,6 +156,15 @@ func ParseGitURL(text string) (*GitRepository, error) {
 		t = strings.TrimSuffix(t, ".git")
 
 		arr := util.RegexpSplit(t, ":|/")
+		if len(arr) >= 5 && arr[0] == azureDevOpsSSHHost && arr[1] == azureDevOpsSSHVersion {
+			// Azure DevOps uses git@ssh.dev.azure.com:v3/org/project/repo
+			answer.Scheme = "git"
+			answer.Host = AzureDevOpsHost
+			answer.Organisation = arr[2]
+			answer.Project = arr[3]
+			answer.Name = arr[4]
+			return &answer, nil
+		}
 		if len(arr) >= 3 {
 			answer.Scheme = "git"
 			answer.Host = arr[0]
@@ -138,6 +177,9 @@ func ParseGitURL(text string) (*GitRepository, error) {
 }
 
 func parsePath(path string, info *GitRepository) (*GitRepository, error) {
+	if strings.Contains(path, "/"+azureDevOpsGitPath+"/") {
+		return parseAzureDevOpsPath(path, info)
+	}
 
 	// This is necessary for Bitbucket Server in some cases.
 	trimPath := strings.TrimPrefix(path, "/scm")
@@ -166,6 +208,27 @@ func parsePath(path string, info *GitRepository) (*GitRepository, error) {
 	return info, fmt.Errorf("Invalid path %s could not determine organisation and repository name", path)
 }
 
+// parseAzureDevOpsPath parses the org/project/_git/repo layout of Azure DevOps. On Azure DevOps Server the
+// organisation is the path of the collection such as tfs/DefaultCollection. On the legacy visualstudio.com
+// hosts the organisation is the sub domain
+func parseAzureDevOpsPath(path string, info *GitRepository) (*GitRepository, error) {
+	arr := strings.Split(strings.Trim(path, "/"), "/")
+	i := util.StringArrayIndex(arr, azureDevOpsGitPath)
+	if i < 1 || i+1 >= len(arr) {
+		return info, fmt.Errorf("Invalid path %s could not determine the Azure DevOps project and repository name", path)
+	}
+	info.Project = arr[i-1]
+	info.Name = strings.TrimSuffix(arr[i+1], ".git")
+	if i >= 2 {
+		info.Organisation = strings.Join(arr[0:i-1], "/")
+	} else if strings.HasSuffix(info.Host, azureDevOpsLegacySuffix) {
+		info.Organisation = strings.TrimSuffix(info.Host, azureDevOpsLegacySuffix)
+	} else {
+		return info, fmt.Errorf("Invalid path %s could not determine the Azure DevOps organisation", path)
+	}
+	return info, nil
+}
+
 // SaasGitKind returns the kind for SaaS Git providers or "" if the URL could not be deduced
 func SaasGitKind(gitServiceUrl string) string {
 	gitServiceUrl = strings.TrimSuffix(gitServiceUrl, "/")
@@ -182,10 +245,15 @@ func SaasGitKind(gitServiceUrl string) string {
 		return KindBitBucketCloud
 	case "http://fake.git", FakeGitURL:
 		return KindGitFake
+	case AzureDevOpsURL:
+		return KindAzureDevOps
 	default:
 		if strings.HasPrefix(gitServiceUrl, "https://github") {
 			return KindGitHub
 		}
+		if strings.HasPrefix(gitServiceUrl, AzureDevOpsURL+"/") || strings.HasSuffix(gitServiceUrl, azureDevOpsLegacySuffix) {
+			return KindAzureDevOps
+		}
 		return ""
 	}
 }
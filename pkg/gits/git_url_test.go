@@ -54,6 +54,21 @@ func TestParseGitURL(t *testing.T) {
 		{
 			"https://bitbucketserver.com/projects/myproject/repos/foo/pull-requests/1", "bitbucketserver.com", "myproject", "foo",
 		},
+		{
+			"https://dev.azure.com/myorg/myproject/_git/myrepo", "dev.azure.com", "myorg", "myrepo",
+		},
+		{
+			"https://myorg@dev.azure.com/myorg/myproject/_git/myrepo", "dev.azure.com", "myorg", "myrepo",
+		},
+		{
+			"git@ssh.dev.azure.com:v3/myorg/myproject/myrepo", "dev.azure.com", "myorg", "myrepo",
+		},
+		{
+			"https://myorg.visualstudio.com/myproject/_git/myrepo", "myorg.visualstudio.com", "myorg", "myrepo",
+		},
+		{
+			"https://tfs.acme.com/tfs/DefaultCollection/myproject/_git/myrepo", "tfs.acme.com", "tfs/DefaultCollection", "myrepo",
+		},
 	}
 	for _, data := range testCases {
 		info, err := gits.ParseGitURL(data.url)
@@ -79,6 +94,14 @@ func TestSaasKind(t *testing.T) {
 			gitURL: "https://github.test.com",
 			kind:   gits.KindGitHub,
 		},
+		"Azure DevOps": {
+			gitURL: "https://dev.azure.com/myorg",
+			kind:   gits.KindAzureDevOps,
+		},
+		"Azure DevOps legacy": {
+			gitURL: "https://myorg.visualstudio.com",
+			kind:   gits.KindAzureDevOps,
+		},
 	}
 
 	for name, tc := range tests {
@@ -89,6 +112,22 @@ func TestSaasKind(t *testing.T) {
 	}
 }
 
+func TestParseAzureDevOpsGitURL(t *testing.T) {
+	t.Parallel()
+	info, err := gits.ParseGitURL("git@ssh.dev.azure.com:v3/myorg/myproject/myrepo")
+	require.NoError(t, err)
+	assert.Equal(t, "myproject", info.Project)
+	assert.Equal(t, "https://dev.azure.com/myorg/myproject/_git/myrepo", info.HttpsURL())
+	assert.Equal(t, "https://dev.azure.com/myorg/myproject/_git/myrepo", info.HttpCloneURL())
+	assert.Equal(t, "https://dev.azure.com/myorg/myproject/_git/myrepo/pullrequest/1", info.PullRequestURL("1"))
+	assert.True(t, info.IsAzureDevOps())
+
+	info, err = gits.ParseGitURL("https://tfs.acme.com/tfs/DefaultCollection/myproject/_git/myrepo")
+	require.NoError(t, err)
+	assert.Equal(t, "myproject", info.Project)
+	assert.Equal(t, "https://tfs.acme.com/tfs/DefaultCollection/myproject/_git/myrepo", info.HttpsURL())
+}
+
 func TestGitInfoProviderURL(t *testing.T) {
 	for _, u := range []string{"https://github.com/jenkins-x/x.git", "git@github.com:jenkins-x/jx.git"} {
 		info, err := gits.ParseGitURL(u)
@@ -3,6 +3,7 @@ package gits
 import (
 	"errors"
 	"fmt"
+	"os"
 	"strconv"
 	"strings"
 	"time"
@@ -249,6 +250,26 @@ func (p *GiteaProvider) UpdateWebHook(data *GitWebHookArguments) error {
 	return fmt.Errorf("not implemented!")
 }
 
+// GetBranchProtection is not supported on Gitea
+func (p *GiteaProvider) GetBranchProtection(org string, repo string, branch string) (*GitBranchProtection, error) {
+	return nil, fmt.Errorf("branch protection is not supported by Gitea")
+}
+
+// UpdateBranchProtection is not supported on Gitea
+func (p *GiteaProvider) UpdateBranchProtection(org string, repo string, branch string, protection *GitBranchProtection) error {
+	return fmt.Errorf("branch protection is not supported by Gitea")
+}
+
+// RemoveBranchProtection is not supported on Gitea
+func (p *GiteaProvider) RemoveBranchProtection(org string, repo string, branch string) error {
+	return fmt.Errorf("branch protection is not supported by Gitea")
+}
+
+// GetRateLimits returns no rate limits as Gitea does not limit the rate of API requests
+func (p *GiteaProvider) GetRateLimits() ([]*GitRateLimit, error) {
+	return []*GitRateLimit{}, nil
+}
+
 func (p *GiteaProvider) CreatePullRequest(data *GitPullRequestArguments) (*GitPullRequest, error) {
 	owner := data.GitRepository.Organisation
 	repo := data.GitRepository.Name
@@ -536,6 +557,72 @@ func (p *GiteaProvider) AddPRComment(pr *GitPullRequest, comment string) error {
 	return err
 }
 
+// RequestPRReviewers assigns the reviewers to the pull request as Gitea has no review requests
+func (p *GiteaProvider) RequestPRReviewers(pr *GitPullRequest, reviewers []string) error {
+	if pr.Number == nil {
+		return fmt.Errorf("Missing Number for GitPullRequest %#v", pr)
+	}
+	if len(reviewers) == 0 {
+		return nil
+	}
+	_, err := p.Client.EditIssue(pr.Owner, pr.Repo, int64(*pr.Number), gitea.EditIssueOption{
+		Assignees: reviewers,
+	})
+	return err
+}
+
+// ListPRReviews is not supported as Gitea has no API for pull request reviews
+func (p *GiteaProvider) ListPRReviews(pr *GitPullRequest) ([]*GitReview, error) {
+	return nil, fmt.Errorf("pull request reviews are not supported by Gitea")
+}
+
+// AddPRLabels adds the labels to the pull request. The labels must already exist in the repository
+func (p *GiteaProvider) AddPRLabels(pr *GitPullRequest, labels []string) error {
+	if pr.Number == nil {
+		return fmt.Errorf("Missing Number for GitPullRequest %#v", pr)
+	}
+	if len(labels) == 0 {
+		return nil
+	}
+	ids := []int64{}
+	for _, label := range labels {
+		id, err := p.findLabelID(pr.Owner, pr.Repo, label)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, id)
+	}
+	_, err := p.Client.AddIssueLabels(pr.Owner, pr.Repo, int64(*pr.Number), gitea.IssueLabelsOption{
+		Labels: ids,
+	})
+	return err
+}
+
+// RemovePRLabel removes the label from the pull request
+func (p *GiteaProvider) RemovePRLabel(pr *GitPullRequest, label string) error {
+	if pr.Number == nil {
+		return fmt.Errorf("Missing Number for GitPullRequest %#v", pr)
+	}
+	id, err := p.findLabelID(pr.Owner, pr.Repo, label)
+	if err != nil {
+		return err
+	}
+	return p.Client.DeleteIssueLabel(pr.Owner, pr.Repo, int64(*pr.Number), id)
+}
+
+func (p *GiteaProvider) findLabelID(owner string, repo string, name string) (int64, error) {
+	labels, err := p.Client.ListRepoLabels(owner, repo)
+	if err != nil {
+		return 0, err
+	}
+	for _, label := range labels {
+		if label.Name == name {
+			return label.ID, nil
+		}
+	}
+	return 0, fmt.Errorf("no label %s found in repository %s/%s", name, owner, repo)
+}
+
 func (p *GiteaProvider) CreateIssueComment(owner string, repo string, number int, comment string) error {
 	issueComment := gitea.CreateIssueCommentOption{
 		Body: comment,
@@ -638,6 +725,45 @@ func (p *GiteaProvider) UpdateRelease(owner string, repo string, tag string, rel
 	return err
 }
 
+// UploadReleaseAsset uploads the file as an attachment of the release of the tag replacing any existing attachment
+// with the same name
+func (p *GiteaProvider) UploadReleaseAsset(org string, repo string, tag string, name string, asset *os.File) (*GitReleaseAsset, error) {
+	owner := org
+	if owner == "" {
+		owner = p.Username
+	}
+	releases, err := p.Client.ListReleases(owner, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the releases of repository %s/%s due to: %s", owner, repo, err)
+	}
+	var release *gitea.Release
+	for _, rel := range releases {
+		if rel.TagName == tag {
+			release = rel
+			break
+		}
+	}
+	if release == nil {
+		return nil, fmt.Errorf("no release found for tag %s of repository %s/%s", tag, owner, repo)
+	}
+	for _, existing := range release.Attachments {
+		if existing.Name == name {
+			err = p.Client.DeleteReleaseAttachment(owner, repo, release.ID, existing.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to delete the existing attachment %s of release %s of repository %s/%s due to: %s", name, tag, owner, repo, err)
+			}
+		}
+	}
+	attachment, err := p.Client.CreateReleaseAttachment(owner, repo, release.ID, asset, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload the attachment %s to release %s of repository %s/%s due to: %s", name, tag, owner, repo, err)
+	}
+	return &GitReleaseAsset{
+		Name:               attachment.Name,
+		BrowserDownloadURL: attachment.DownloadURL,
+	}, nil
+}
+
 func (p *GiteaProvider) HasIssues() bool {
 	return true
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/pkg/errors"
 	"io/ioutil"
+	"net/http"
 	"os"
 	"strconv"
 	"strings"
@@ -29,6 +30,8 @@ type GitHubProvider struct {
 	Server auth.AuthServer
 	User   auth.UserAuth
 	Git    Gitter
+
+	httpClient *http.Client
 }
 
 func NewGitHubProvider(server *auth.AuthServer, user *auth.UserAuth, git Gitter) (GitProvider, error) {
@@ -42,10 +45,30 @@ func NewGitHubProvider(server *auth.AuthServer, user *auth.UserAuth, git Gitter)
 		Git:      git,
 	}
 
-	ts := oauth2.StaticTokenSource(
-		&oauth2.Token{AccessToken: user.ApiToken},
-	)
-	tc := oauth2.NewClient(ctx, ts)
+	var tc *http.Client
+	if user.IsGitHubApp() {
+		apiURL := GitHubAPIURL(server.URL)
+		tokens, err := auth.GitHubAppTokensFor(apiURL, user)
+		if err != nil {
+			return nil, err
+		}
+		tc = &http.Client{Transport: tokens.Transport(nil, user.GitHubAppOwner)}
+		if provider.Username == "" {
+			provider.Username = user.GitHubAppOwner
+		}
+		// lets resolve the installation token on the user auth so that git operations use a current one via GitToken
+		err = provider.User.ResolveGitHubAppToken(apiURL, "")
+		if err != nil {
+			log.Warnf("Failed to create an installation token of GitHub App %d: %s\n", user.GitHubAppID, err)
+		}
+	} else {
+		ts := oauth2.StaticTokenSource(
+			&oauth2.Token{AccessToken: user.ApiToken},
+		)
+		tc = oauth2.NewClient(ctx, ts)
+	}
+
+	provider.httpClient = tc
 
 	var err error
 	u := server.URL
@@ -69,6 +92,14 @@ func GitHubEnterpriseApiEndpointURL(u string) string {
 	return u
 }
 
+// GitHubAPIURL returns the URL of the GitHub API for the given GitHub or GitHub Enterprise server URL
+func GitHubAPIURL(u string) string {
+	if IsGitHubServerURL(u) {
+		return "https://api.github.com/"
+	}
+	return GitHubEnterpriseApiEndpointURL(u)
+}
+
 // GetEnterpriseApiURL returns the github enterprise API URL or blank if this
 // provider is for the https://github.com service
 func (p *GitHubProvider) GetEnterpriseApiURL() string {
@@ -445,6 +476,105 @@ func (p *GitHubProvider) UpdateWebHook(data *GitWebHookArguments) error {
 	return err
 }
 
+// GetBranchProtection returns the protection of the branch or nil if the branch is not protected
+func (p *GitHubProvider) GetBranchProtection(org string, repo string, branch string) (*GitBranchProtection, error) {
+	protection, r, err := p.Client.Repositories.GetBranchProtection(p.Context, org, repo, branch)
+	if r != nil && r.StatusCode == 404 {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	answer := &GitBranchProtection{}
+	if protection.RequiredStatusChecks != nil {
+		answer.RequiredStatusContexts = protection.RequiredStatusChecks.Contexts
+	}
+	if protection.RequiredPullRequestReviews != nil {
+		answer.RequiredApprovals = protection.RequiredPullRequestReviews.RequiredApprovingReviewCount
+	}
+	if protection.EnforceAdmins != nil {
+		answer.EnforceAdmins = protection.EnforceAdmins.Enabled
+	}
+	if protection.Restrictions != nil {
+		answer.RestrictPushes = true
+		for _, user := range protection.Restrictions.Users {
+			answer.PushUsers = append(answer.PushUsers, user.GetLogin())
+		}
+		for _, team := range protection.Restrictions.Teams {
+			answer.PushTeams = append(answer.PushTeams, team.GetSlug())
+		}
+	}
+	return answer, nil
+}
+
+// UpdateBranchProtection replaces the protection of the branch. Status checks are required to be up to date with the
+// branch before merging
+func (p *GitHubProvider) UpdateBranchProtection(org string, repo string, branch string, protection *GitBranchProtection) error {
+	request := &github.ProtectionRequest{
+		EnforceAdmins: protection.EnforceAdmins,
+	}
+	if len(protection.RequiredStatusContexts) > 0 {
+		request.RequiredStatusChecks = &github.RequiredStatusChecks{
+			Strict:   true,
+			Contexts: protection.RequiredStatusContexts,
+		}
+	}
+	if protection.RequiredApprovals > 0 {
+		request.RequiredPullRequestReviews = &github.PullRequestReviewsEnforcementRequest{
+			RequiredApprovingReviewCount: protection.RequiredApprovals,
+		}
+	}
+	if protection.RestrictPushes {
+		request.Restrictions = &github.BranchRestrictionsRequest{
+			Users: append([]string{}, protection.PushUsers...),
+			Teams: append([]string{}, protection.PushTeams...),
+		}
+	}
+	_, _, err := p.Client.Repositories.UpdateBranchProtection(p.Context, org, repo, branch, request)
+	return err
+}
+
+// RemoveBranchProtection removes the protection of the branch, ignoring branches which are not protected
+func (p *GitHubProvider) RemoveBranchProtection(org string, repo string, branch string) error {
+	r, err := p.Client.Repositories.RemoveBranchProtection(p.Context, org, repo, branch)
+	if r != nil && r.StatusCode == 404 {
+		return nil
+	}
+	return err
+}
+
+// GetRateLimits returns the core and search API rate limits of the user
+func (p *GitHubProvider) GetRateLimits() ([]*GitRateLimit, error) {
+	limits, _, err := p.Client.RateLimits(p.Context)
+	if err != nil {
+		return nil, err
+	}
+	answer := []*GitRateLimit{}
+	if limits.Core != nil {
+		answer = append(answer, toGitRateLimit(RateLimitResourceCore, limits.Core))
+	}
+	if limits.Search != nil {
+		answer = append(answer, toGitRateLimit(RateLimitResourceSearch, limits.Search))
+	}
+	return answer, nil
+}
+
+func toGitRateLimit(resource string, rate *github.Rate) *GitRateLimit {
+	return &GitRateLimit{
+		Resource:  resource,
+		Limit:     rate.Limit,
+		Remaining: rate.Remaining,
+		Reset:     rate.Reset.Time,
+	}
+}
+
+// DecorateTransport decorates the HTTP transport used to access the GitHub API
+func (p *GitHubProvider) DecorateTransport(decorate func(http.RoundTripper) http.RoundTripper) {
+	if p.httpClient != nil {
+		p.httpClient.Transport = decorate(p.httpClient.Transport)
+	}
+}
+
 func (p *GitHubProvider) CreatePullRequest(data *GitPullRequestArguments) (*GitPullRequest, error) {
 	owner := data.GitRepository.Organisation
 	repo := data.GitRepository.Name
@@ -722,6 +852,73 @@ func (p *GitHubProvider) AddPRComment(pr *GitPullRequest, comment string) error
 	return nil
 }
 
+// RequestPRReviewers requests reviews of the pull request from the users
+func (p *GitHubProvider) RequestPRReviewers(pr *GitPullRequest, reviewers []string) error {
+	if pr.Number == nil {
+		return fmt.Errorf("Missing Number for GitPullRequest %#v", pr)
+	}
+	if len(reviewers) == 0 {
+		return nil
+	}
+	_, _, err := p.Client.PullRequests.RequestReviewers(p.Context, pr.Owner, pr.Repo, *pr.Number, github.ReviewersRequest{
+		Reviewers: reviewers,
+	})
+	return err
+}
+
+// ListPRReviews lists the reviews of the pull request
+func (p *GitHubProvider) ListPRReviews(pr *GitPullRequest) ([]*GitReview, error) {
+	if pr.Number == nil {
+		return nil, fmt.Errorf("Missing Number for GitPullRequest %#v", pr)
+	}
+	opt := &github.ListOptions{
+		Page:    0,
+		PerPage: pageSize,
+	}
+	answer := []*GitReview{}
+	for {
+		reviews, _, err := p.Client.PullRequests.ListReviews(p.Context, pr.Owner, pr.Repo, *pr.Number, opt)
+		if err != nil {
+			return answer, err
+		}
+		for _, review := range reviews {
+			answer = append(answer, &GitReview{
+				ID:          review.GetID(),
+				User:        toGitHubUser(review.User),
+				State:       review.GetState(),
+				Body:        review.GetBody(),
+				SubmittedAt: review.SubmittedAt,
+			})
+		}
+		if len(reviews) < pageSize || len(reviews) == 0 {
+			break
+		}
+		opt.Page++
+	}
+	return answer, nil
+}
+
+// AddPRLabels adds the labels to the pull request
+func (p *GitHubProvider) AddPRLabels(pr *GitPullRequest, labels []string) error {
+	if pr.Number == nil {
+		return fmt.Errorf("Missing Number for GitPullRequest %#v", pr)
+	}
+	if len(labels) == 0 {
+		return nil
+	}
+	_, _, err := p.Client.Issues.AddLabelsToIssue(p.Context, pr.Owner, pr.Repo, *pr.Number, labels)
+	return err
+}
+
+// RemovePRLabel removes the label from the pull request
+func (p *GitHubProvider) RemovePRLabel(pr *GitPullRequest, label string) error {
+	if pr.Number == nil {
+		return fmt.Errorf("Missing Number for GitPullRequest %#v", pr)
+	}
+	_, err := p.Client.Issues.RemoveLabelForIssue(p.Context, pr.Owner, pr.Repo, *pr.Number, label)
+	return err
+}
+
 func (p *GitHubProvider) CreateIssueComment(owner string, repo string, number int, comment string) error {
 	issueComment := &github.IssueComment{
 		Body: &comment,
@@ -929,6 +1126,36 @@ func (p *GitHubProvider) UpdateRelease(owner string, repo string, tag string, re
 	return err
 }
 
+// UploadReleaseAsset uploads the file as an asset of the release of the tag replacing any existing asset with the same name
+func (p *GitHubProvider) UploadReleaseAsset(org string, repo string, tag string, name string, asset *os.File) (*GitReleaseAsset, error) {
+	owner := org
+	if owner == "" {
+		owner = p.Username
+	}
+	release, _, err := p.Client.Repositories.GetReleaseByTag(p.Context, owner, repo, tag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the release of tag %s of repository %s/%s due to: %s", tag, owner, repo, err)
+	}
+	// GitHub fails to upload an asset with the same name as an existing asset
+	for _, existing := range release.Assets {
+		if asText(existing.Name) == name && existing.ID != nil {
+			_, err = p.Client.Repositories.DeleteReleaseAsset(p.Context, owner, repo, *existing.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to delete the existing asset %s of release %s of repository %s/%s due to: %s", name, tag, owner, repo, err)
+			}
+		}
+	}
+	uploaded, _, err := p.Client.Repositories.UploadReleaseAsset(p.Context, owner, repo, release.GetID(), &github.UploadOptions{Name: name}, asset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload the asset %s to release %s of repository %s/%s due to: %s", name, tag, owner, repo, err)
+	}
+	return &GitReleaseAsset{
+		Name:               asText(uploaded.Name),
+		BrowserDownloadURL: asText(uploaded.BrowserDownloadURL),
+		ContentType:        asText(uploaded.ContentType),
+	}, nil
+}
+
 func (p *GitHubProvider) GetIssue(org string, name string, number int) (*GitIssue, error) {
 	i, r, err := p.Client.Issues.Get(p.Context, org, name, number)
 	if r != nil && r.StatusCode == 404 {
@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
+	"os"
 	"strconv"
 	"strings"
 	"time"
@@ -23,17 +26,25 @@ type GitlabProvider struct {
 	Server auth.AuthServer
 	User   auth.UserAuth
 	Git    Gitter
+
+	httpClient *http.Client
 }
 
 func NewGitlabProvider(server *auth.AuthServer, user *auth.UserAuth, git Gitter) (GitProvider, error) {
 	u := server.URL
-	c := gitlab.NewClient(nil, user.ApiToken)
+	httpClient := &http.Client{}
+	c := gitlab.NewClient(httpClient, user.ApiToken)
 	if !IsGitLabServerURL(u) {
 		if err := c.SetBaseURL(u); err != nil {
 			return nil, err
 		}
 	}
-	return WithGitlabClient(server, user, c, git)
+	provider, err := WithGitlabClient(server, user, c, git)
+	if err != nil {
+		return nil, err
+	}
+	provider.(*GitlabProvider).httpClient = httpClient
+	return provider, nil
 }
 
 func IsGitLabServerURL(u string) bool {
@@ -240,6 +251,11 @@ func fromMergeRequest(mr *gitlab.MergeRequest, owner, repo string) *GitPullReque
 	if mr.MergedAt != nil {
 		merged = true
 	}
+	labels := []*Label{}
+	for _, l := range mr.Labels {
+		name := l
+		labels = append(labels, &Label{Name: &name})
+	}
 	return &GitPullRequest{
 		Author: &GitUser{
 			Login: mr.Author.Username,
@@ -256,6 +272,7 @@ func fromMergeRequest(mr *gitlab.MergeRequest, owner, repo string) *GitPullReque
 		LastCommitSha:  mr.SHA,
 		MergedAt:       mr.MergedAt,
 		ClosedAt:       mr.ClosedAt,
+		Labels:         labels,
 	}
 }
 
@@ -438,11 +455,200 @@ func (g *GitlabProvider) CreateWebHook(data *GitWebHookArguments) error {
 // ListWebHooks lists the webhooks
 func (g *GitlabProvider) ListWebHooks(owner string, repo string) ([]*GitWebHookArguments, error) {
 	webHooks := []*GitWebHookArguments{}
-	return webHooks, fmt.Errorf("not implemented!")
+	if repo == "" {
+		return webHooks, fmt.Errorf("Missing property Repo")
+	}
+	pid, err := g.projectId(owner, g.Username, repo)
+	if err != nil {
+		return webHooks, err
+	}
+	hooks, _, err := g.Client.Projects.ListProjectHooks(pid, nil)
+	if err != nil {
+		return webHooks, fmt.Errorf("failed to list webhooks of repository %s/%s due to: %s", owner, repo, err)
+	}
+	for _, hook := range hooks {
+		webHooks = append(webHooks, &GitWebHookArguments{
+			ID:    int64(hook.ID),
+			Owner: owner,
+			Repo:  nil,
+			URL:   hook.URL,
+		})
+	}
+	return webHooks, nil
 }
 
+// UpdateWebHook updates the URL and secret token of a webhook. If no ID is specified the webhook is found by its existing URL
 func (g *GitlabProvider) UpdateWebHook(data *GitWebHookArguments) error {
-	return fmt.Errorf("not implemented!")
+	if data.Repo == nil || data.Repo.Name == "" {
+		return fmt.Errorf("Missing property Repo")
+	}
+	if data.URL == "" {
+		return fmt.Errorf("Missing property URL")
+	}
+	repo := data.Repo.Name
+	pid, err := g.projectId(data.Owner, g.Username, repo)
+	if err != nil {
+		return err
+	}
+	id := int(data.ID)
+	if id == 0 {
+		hooks, _, err := g.Client.Projects.ListProjectHooks(pid, nil)
+		if err != nil {
+			return fmt.Errorf("failed to list webhooks of repository %s/%s due to: %s", data.Owner, repo, err)
+		}
+		for _, hook := range hooks {
+			if hook.URL == data.ExistingURL {
+				log.Warnf("Found existing webhook for url %s\n", data.ExistingURL)
+				id = hook.ID
+			}
+		}
+	}
+	if id == 0 {
+		log.Warnf("No webhooks found to update for %s/%s\n", data.Owner, repo)
+		return nil
+	}
+	opt := &gitlab.EditProjectHookOptions{
+		URL:        &data.URL,
+		PushEvents: gitlab.Bool(true),
+	}
+	if data.Secret != "" {
+		opt.Token = &data.Secret
+	}
+	log.Infof("Updating GitLab webhook for %s/%s for url %s\n", util.ColorInfo(data.Owner), util.ColorInfo(repo), util.ColorInfo(data.URL))
+	_, _, err = g.Client.Projects.EditProjectHook(pid, id, opt)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook %d of repository %s/%s due to: %s", id, data.Owner, repo, err)
+	}
+	return nil
+}
+
+// gitlabMergeSettings are the project settings which control when merge requests can be merged
+type gitlabMergeSettings struct {
+	OnlyAllowMergeIfPipelineSucceeds *bool `json:"only_allow_merge_if_pipeline_succeeds,omitempty"`
+	ApprovalsBeforeMerge             *int  `json:"approvals_before_merge,omitempty"`
+}
+
+// GetBranchProtection returns the protection of a protected branch. GitLab requires the pipeline of a merge request
+// to succeed rather than named status contexts so AnyStatusContext is reported when pipelines must succeed
+func (g *GitlabProvider) GetBranchProtection(org string, repo string, branch string) (*GitBranchProtection, error) {
+	pid, err := g.projectId(org, g.Username, repo)
+	if err != nil {
+		return nil, err
+	}
+	protected, r, err := g.Client.ProtectedBranches.GetProtectedBranch(pid, branch)
+	if r != nil && r.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	answer := &GitBranchProtection{
+		RestrictPushes: true,
+	}
+	for _, level := range protected.PushAccessLevels {
+		if level != nil && level.AccessLevel != gitlab.NoPermissions {
+			answer.RestrictPushes = false
+		}
+	}
+	req, err := g.Client.NewRequest(http.MethodGet, "projects/"+pid, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	settings := &gitlabMergeSettings{}
+	_, err = g.Client.Do(req, settings)
+	if err != nil {
+		return nil, err
+	}
+	if settings.OnlyAllowMergeIfPipelineSucceeds != nil && *settings.OnlyAllowMergeIfPipelineSucceeds {
+		answer.RequiredStatusContexts = []string{AnyStatusContext}
+	}
+	if settings.ApprovalsBeforeMerge != nil {
+		answer.RequiredApprovals = *settings.ApprovalsBeforeMerge
+	}
+	return answer, nil
+}
+
+// UpdateBranchProtection protects the branch so that it can only be pushed to by maintainers, or only merged into if
+// pushes are restricted. The required status contexts and approvals are applied to all the merge requests of the project
+func (g *GitlabProvider) UpdateBranchProtection(org string, repo string, branch string, protection *GitBranchProtection) error {
+	pid, err := g.projectId(org, g.Username, repo)
+	if err != nil {
+		return err
+	}
+	if len(protection.PushUsers) > 0 || len(protection.PushTeams) > 0 {
+		log.Warnf("Allowing specific users or teams to push to branch %s of %s/%s is not supported by GitLab\n", branch, org, repo)
+	}
+	if protection.EnforceAdmins {
+		log.Warnf("Enforcing the protection of branch %s of %s/%s for administrators is not supported by GitLab\n", branch, org, repo)
+	}
+	// GitLab fails to protect a branch which is already protected
+	err = g.RemoveBranchProtection(org, repo, branch)
+	if err != nil {
+		return err
+	}
+	pushLevel := gitlab.MasterPermissions
+	if protection.RestrictPushes {
+		pushLevel = gitlab.NoPermissions
+	}
+	opt := &gitlab.ProtectRepositoryBranchesOptions{
+		Name:             gitlab.String(branch),
+		PushAccessLevel:  gitlab.AccessLevel(pushLevel),
+		MergeAccessLevel: gitlab.AccessLevel(gitlab.DeveloperPermissions),
+	}
+	_, _, err = g.Client.ProtectedBranches.ProtectRepositoryBranches(pid, opt)
+	if err != nil {
+		return fmt.Errorf("failed to protect branch %s of repository %s/%s due to: %s", branch, org, repo, err)
+	}
+	settings := &gitlabMergeSettings{
+		OnlyAllowMergeIfPipelineSucceeds: gitlab.Bool(len(protection.RequiredStatusContexts) > 0),
+		ApprovalsBeforeMerge:             gitlab.Int(protection.RequiredApprovals),
+	}
+	req, err := g.Client.NewRequest(http.MethodPut, "projects/"+pid, settings, nil)
+	if err != nil {
+		return err
+	}
+	_, err = g.Client.Do(req, nil)
+	if err != nil {
+		return fmt.Errorf("failed to update the merge settings of repository %s/%s due to: %s", org, repo, err)
+	}
+	return nil
+}
+
+// RemoveBranchProtection unprotects the branch
+func (g *GitlabProvider) RemoveBranchProtection(org string, repo string, branch string) error {
+	pid, err := g.projectId(org, g.Username, repo)
+	if err != nil {
+		return err
+	}
+	r, err := g.Client.ProtectedBranches.UnprotectRepositoryBranches(pid, branch)
+	if r != nil && r.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	return err
+}
+
+// GetRateLimits returns the API rate limit of the user from the RateLimit headers of GitLab, which are only sent when
+// rate limiting is enabled on the server
+func (g *GitlabProvider) GetRateLimits() ([]*GitRateLimit, error) {
+	_, resp, err := g.Client.Users.CurrentUser()
+	if err != nil {
+		return nil, err
+	}
+	answer := []*GitRateLimit{}
+	if resp != nil && resp.Response != nil {
+		limit := ParseRateLimitHeaders(resp.Header, RateLimitResourceCore)
+		if limit != nil {
+			answer = append(answer, limit)
+		}
+	}
+	return answer, nil
+}
+
+// DecorateTransport decorates the HTTP transport used to access the GitLab API
+func (g *GitlabProvider) DecorateTransport(decorate func(http.RoundTripper) http.RoundTripper) {
+	if g.httpClient != nil {
+		g.httpClient.Transport = decorate(g.httpClient.Transport)
+	}
 }
 
 func (g *GitlabProvider) SearchIssues(org, repo, query string) ([]*GitIssue, error) {
@@ -556,6 +762,126 @@ func (g *GitlabProvider) AddPRComment(pr *GitPullRequest, comment string) error
 	return err
 }
 
+// RequestPRReviewers assigns the merge request to the first reviewer as merge requests only have a single assignee
+func (g *GitlabProvider) RequestPRReviewers(pr *GitPullRequest, reviewers []string) error {
+	if len(reviewers) == 0 {
+		return nil
+	}
+	pid, err := g.projectId(pr.Owner, g.Username, pr.Repo)
+	if err != nil {
+		return err
+	}
+	reviewer := reviewers[0]
+	users, _, err := g.Client.Users.ListUsers(&gitlab.ListUsersOptions{Username: &reviewer})
+	if err != nil {
+		return err
+	}
+	if len(users) == 0 {
+		return fmt.Errorf("no GitLab user found with username %s", reviewer)
+	}
+	if len(reviewers) > 1 {
+		log.Warnf("Merge requests only have a single assignee so only %s is assigned to %s\n", reviewer, pr.URL)
+	}
+	opt := &gitlab.UpdateMergeRequestOptions{AssigneeID: &users[0].ID}
+	_, _, err = g.Client.MergeRequests.UpdateMergeRequest(pid, *pr.Number, opt)
+	return err
+}
+
+// gitlabApprover is a user who approved a merge request
+type gitlabApprover struct {
+	ID        int    `json:"id"`
+	Username  string `json:"username"`
+	Name      string `json:"name"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+type gitlabApprovals struct {
+	ApprovedBy []struct {
+		User *gitlabApprover `json:"user"`
+	} `json:"approved_by"`
+}
+
+// ListPRReviews returns an approved review for each user who approved the merge request
+func (g *GitlabProvider) ListPRReviews(pr *GitPullRequest) ([]*GitReview, error) {
+	pid, err := g.projectId(pr.Owner, g.Username, pr.Repo)
+	if err != nil {
+		return nil, err
+	}
+	u := fmt.Sprintf("projects/%s/merge_requests/%d/approvals", pid, *pr.Number)
+	req, err := g.Client.NewRequest(http.MethodGet, u, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	approvals := &gitlabApprovals{}
+	_, err = g.Client.Do(req, approvals)
+	if err != nil {
+		return nil, err
+	}
+	answer := []*GitReview{}
+	for _, approval := range approvals.ApprovedBy {
+		if approval.User == nil {
+			continue
+		}
+		answer = append(answer, &GitReview{
+			ID: int64(approval.User.ID),
+			User: &GitUser{
+				Login:     approval.User.Username,
+				Name:      approval.User.Name,
+				AvatarURL: approval.User.AvatarURL,
+			},
+			State: ReviewStateApproved,
+		})
+	}
+	return answer, nil
+}
+
+// AddPRLabels adds the labels to the merge request
+func (g *GitlabProvider) AddPRLabels(pr *GitPullRequest, labels []string) error {
+	if len(labels) == 0 {
+		return nil
+	}
+	return g.updateMergeRequestLabels(pr, func(existing []string) []string {
+		answer := existing
+		for _, label := range labels {
+			if util.StringArrayIndex(answer, label) < 0 {
+				answer = append(answer, label)
+			}
+		}
+		return answer
+	})
+}
+
+// RemovePRLabel removes the label from the merge request
+func (g *GitlabProvider) RemovePRLabel(pr *GitPullRequest, label string) error {
+	return g.updateMergeRequestLabels(pr, func(existing []string) []string {
+		answer := []string{}
+		for _, l := range existing {
+			if l != label {
+				answer = append(answer, l)
+			}
+		}
+		return answer
+	})
+}
+
+func (g *GitlabProvider) updateMergeRequestLabels(pr *GitPullRequest, fn func([]string) []string) error {
+	pid, err := g.projectId(pr.Owner, g.Username, pr.Repo)
+	if err != nil {
+		return err
+	}
+	mr, _, err := g.Client.MergeRequests.GetMergeRequest(pid, *pr.Number)
+	if err != nil {
+		return err
+	}
+	existing := []string{}
+	for _, l := range mr.Labels {
+		existing = append(existing, l)
+	}
+	opt := &gitlab.UpdateMergeRequestOptions{Labels: fn(existing)}
+	_, _, err = g.Client.MergeRequests.UpdateMergeRequest(pid, *pr.Number, opt)
+	return err
+}
+
 func (g *GitlabProvider) CreateIssueComment(owner string, repo string, number int, comment string) error {
 	opt := &gitlab.CreateIssueNoteOptions{Body: &comment}
 
@@ -637,36 +963,159 @@ func (g *GitlabProvider) UserInfo(username string) *GitUser {
 	}
 }
 
-func (g *GitlabProvider) UpdateRelease(owner string, repo string, tag string, releaseInfo *GitRelease) error {
+// UpdateRelease creates or updates the release notes of the tag
+func (g *GitlabProvider) UpdateRelease(org string, repo string, tag string, releaseInfo *GitRelease) error {
+	pid, err := g.projectId(org, g.Username, repo)
+	if err != nil {
+		return err
+	}
+	gitlabTag, r, err := g.Client.Tags.GetTag(pid, tag)
+	if r != nil && r.StatusCode == http.StatusNotFound && !strings.HasPrefix(tag, "v") {
+		// sometimes we prepend a v for example when using gh-release
+		// so lets make sure we update the release notes of the right tag
+		vtag := "v" + tag
+		vGitlabTag, _, verr := g.Client.Tags.GetTag(pid, vtag)
+		if verr == nil {
+			gitlabTag = vGitlabTag
+			err = nil
+			tag = vtag
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find tag %s of repository %s/%s due to: %s", tag, org, repo, err)
+	}
+	description := releaseInfo.Body
+	if gitlabTag.Release == nil {
+		log.Warnf("No release found for %s/%s and tag %s so creating a new release\n", org, repo, tag)
+		_, _, err = g.Client.Tags.CreateRelease(pid, tag, &gitlab.CreateReleaseOptions{Description: &description})
+	} else {
+		_, _, err = g.Client.Tags.UpdateRelease(pid, tag, &gitlab.UpdateReleaseOptions{Description: &description})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update the release of tag %s of repository %s/%s due to: %s", tag, org, repo, err)
+	}
+	releaseInfo.HTMLURL = util.UrlJoin(g.ServerURL(), owner(org, g.Username), repo, "tags", tag)
+	releaseInfo.URL = releaseInfo.HTMLURL
 	return nil
 }
 
+type gitlabReleaseLink struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// UploadReleaseAsset uploads the file to the generic package of the repository whose version is the tag and links it
+// to the release of the tag
+func (g *GitlabProvider) UploadReleaseAsset(org string, repo string, tag string, name string, asset *os.File) (*GitReleaseAsset, error) {
+	pid, err := g.projectId(org, g.Username, repo)
+	if err != nil {
+		return nil, err
+	}
+	info, err := asset.Stat()
+	if err != nil {
+		return nil, err
+	}
+	packagePath := fmt.Sprintf("projects/%s/packages/generic/%s/%s/%s", pid, url.PathEscape(repo), url.PathEscape(tag), url.PathEscape(name))
+	req, err := g.Client.NewRequest(http.MethodPut, packagePath, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Body = asset
+	req.ContentLength = info.Size()
+	req.Header.Set("Content-Type", "application/octet-stream")
+	_, err = g.Client.Do(req, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload %s to the generic package %s %s of repository %s/%s due to: %s", name, repo, tag, org, repo, err)
+	}
+
+	link := &gitlabReleaseLink{
+		Name: name,
+		URL:  util.UrlJoin(g.Client.BaseURL().String(), packagePath),
+	}
+	req, err = g.Client.NewRequest(http.MethodPost, fmt.Sprintf("projects/%s/releases/%s/assets/links", pid, url.PathEscape(tag)), link, nil)
+	if err != nil {
+		return nil, err
+	}
+	_, err = g.Client.Do(req, link)
+	if err != nil {
+		return nil, fmt.Errorf("failed to link %s to the release of tag %s of repository %s/%s due to: %s", name, tag, org, repo, err)
+	}
+	return &GitReleaseAsset{
+		Name:               link.Name,
+		BrowserDownloadURL: link.URL,
+		ContentType:        "application/octet-stream",
+	}, nil
+}
+
 // IssueURL returns the URL of the issue
 func (g *GitlabProvider) IssueURL(org string, name string, number int, isPull bool) string {
 	return ""
 }
 
-// AddCollaborator adds a collaborator
+// AddCollaborator adds the user as a developer of the project. GitLab adds members directly so there is no invitation to accept
 func (g *GitlabProvider) AddCollaborator(user string, organisation string, repo string) error {
-	log.Infof("Automatically adding the pipeline user as a collaborator is currently not implemented for gitlab. Please add user: %v as a collaborator to this project.\n", user)
+	pid, err := g.projectId(organisation, g.Username, repo)
+	if err != nil {
+		return err
+	}
+	users, _, err := g.Client.Users.ListUsers(&gitlab.ListUsersOptions{Username: &user})
+	if err != nil {
+		return fmt.Errorf("failed to find GitLab user %s due to: %s", user, err)
+	}
+	if len(users) == 0 {
+		return fmt.Errorf("no GitLab user found with username %s", user)
+	}
+	opt := &gitlab.AddProjectMemberOptions{
+		UserID:      &users[0].ID,
+		AccessLevel: gitlab.AccessLevel(gitlab.DeveloperPermissions),
+	}
+	_, r, err := g.Client.ProjectMembers.AddProjectMember(pid, opt)
+	if r != nil && r.StatusCode == http.StatusConflict {
+		log.Infof("User %s is already a member of %s/%s\n", util.ColorInfo(user), organisation, repo)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to add user %s as a member of %s/%s due to: %s", user, organisation, repo, err)
+	}
+	log.Infof("Added user %s as a developer of %s/%s\n", util.ColorInfo(user), organisation, repo)
 	return nil
 }
 
-// ListInvitations lists pending invites
+// ListInvitations lists pending invites. GitLab adds project members directly so there are never any invitations
 func (g *GitlabProvider) ListInvitations() ([]*github.RepositoryInvitation, *github.Response, error) {
-	log.Infof("Automatically adding the pipeline user as a collaborator is currently not implemented for gitlab.\n")
 	return []*github.RepositoryInvitation{}, &github.Response{}, nil
 }
 
-// AcceptInvitation accepts an invitation
+// AcceptInvitation accepts an invitation. GitLab adds project members directly so there is nothing to accept
 func (g *GitlabProvider) AcceptInvitation(ID int64) (*github.Response, error) {
-	log.Infof("Automatically adding the pipeline user as a collaborator is currently not implemented for gitlab.\n")
 	return &github.Response{}, nil
 }
 
 // GetContent returns the content of a file
 func (g *GitlabProvider) GetContent(org string, name string, path string, ref string) (*GitFileContent, error) {
-	return nil, fmt.Errorf("Getting content not supported on gitlab")
+	pid, err := g.projectId(org, g.Username, name)
+	if err != nil {
+		return nil, err
+	}
+	if ref == "" {
+		ref = "master"
+	}
+	file, _, err := g.Client.RepositoryFiles.GetFile(pid, path, &gitlab.GetFileOptions{Ref: &ref})
+	if err != nil {
+		return nil, err
+	}
+	htmlURL := util.UrlJoin(g.ServerURL(), owner(org, g.Username), name, "blob", ref, file.FilePath)
+	return &GitFileContent{
+		Type:        "file",
+		Name:        file.FileName,
+		Path:        file.FilePath,
+		Encoding:    file.Encoding,
+		Content:     file.Content,
+		Size:        file.Size,
+		Sha:         file.BlobID,
+		HtmlUrl:     htmlURL,
+		DownloadUrl: util.UrlJoin(g.ServerURL(), owner(org, g.Username), name, "raw", ref, file.FilePath),
+	}, nil
 }
 
 // ShouldForkForPullReques treturns true if we should create a personal fork of this repository
@@ -681,6 +1130,53 @@ func (g *GitlabProvider) ShouldForkForPullRequest(originalOwner string, repoName
 func GitlabAccessTokenURL(url string) string {
 	return util.UrlJoin(url, "/profile/personal_access_tokens")
 }
-func (p *GitlabProvider) ListCommits(owner, repo string, opt *ListCommitsArguments) ([]*GitCommit, error) {
-	return nil, fmt.Errorf("Listing commits not supported on gitlab")
+
+// ListCommits lists the commits of the repository
+func (g *GitlabProvider) ListCommits(org, repo string, opt *ListCommitsArguments) ([]*GitCommit, error) {
+	pid, err := g.projectId(org, g.Username, repo)
+	if err != nil {
+		return nil, err
+	}
+	gitlabOpt := &gitlab.ListCommitsOptions{
+		ListOptions: gitlab.ListOptions{
+			Page:    opt.Page,
+			PerPage: opt.PerPage,
+		},
+	}
+	if opt.SHA != "" {
+		gitlabOpt.RefName = &opt.SHA
+	}
+	if opt.Path != "" {
+		gitlabOpt.Path = &opt.Path
+	}
+	if !opt.Since.IsZero() {
+		gitlabOpt.Since = &opt.Since
+	}
+	if !opt.Until.IsZero() {
+		gitlabOpt.Until = &opt.Until
+	}
+	gitlabCommits, _, err := g.Client.Commits.ListCommits(pid, gitlabOpt)
+	if err != nil {
+		return nil, fmt.Errorf("could not find commits for repository %s/%s due to: %s", org, repo, err)
+	}
+	var commits []*GitCommit
+	for _, commit := range gitlabCommits {
+		if commit == nil {
+			continue
+		}
+		commits = append(commits, &GitCommit{
+			SHA:     commit.ID,
+			Message: commit.Message,
+			URL:     util.UrlJoin(g.ServerURL(), owner(org, g.Username), repo, "commit", commit.ID),
+			Author: &GitUser{
+				Name:  commit.AuthorName,
+				Email: commit.AuthorEmail,
+			},
+			Committer: &GitUser{
+				Name:  commit.CommitterName,
+				Email: commit.CommitterEmail,
+			},
+		})
+	}
+	return commits, nil
 }
@@ -7,6 +7,8 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 
 	"github.com/jenkins-x/jx/pkg/auth"
 	"github.com/jenkins-x/jx/pkg/gits"
@@ -93,10 +95,47 @@ func configureGitlabMock(suite *GitlabProviderSuite, mux *http.ServeMux) {
 		w.Write(src)
 	})
 
+	mux.HandleFunc(fmt.Sprintf("/api/v4/projects/%s/packages/generic/%s/v1.0.0/app.tar.gz", gitlabProjectID, gitlabProjectName), func(w http.ResponseWriter, r *http.Request) {
+		suite.Require().Equal(http.MethodPut, r.Method)
+		body, err := ioutil.ReadAll(r.Body)
+
+		suite.Require().Nil(err)
+		suite.Require().Equal("binary", string(body))
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"message":"201 Created"}`))
+	})
+
 	gitlabRouter := util.Router{
 		fmt.Sprintf("/api/v4/projects/%s", gitlabProjectID): util.MethodMap{
 			"GET": "project.json",
 		},
+		fmt.Sprintf("/api/v4/projects/%s/hooks", gitlabProjectID): util.MethodMap{
+			"GET": "project-hooks.json",
+		},
+		fmt.Sprintf("/api/v4/projects/%s/hooks/1", gitlabProjectID): util.MethodMap{
+			"PUT": "project-hook.json",
+		},
+		fmt.Sprintf("/api/v4/projects/%s/repository/tags/v1.0.0", gitlabProjectID): util.MethodMap{
+			"GET": "tag.json",
+		},
+		fmt.Sprintf("/api/v4/projects/%s/repository/tags/v1.0.0/release", gitlabProjectID): util.MethodMap{
+			"PUT": "release.json",
+		},
+		fmt.Sprintf("/api/v4/projects/%s/releases/v1.0.0/assets/links", gitlabProjectID): util.MethodMap{
+			"POST": "release-link.json",
+		},
+		fmt.Sprintf("/api/v4/projects/%s/repository/files/jenkins-x.yml", gitlabProjectID): util.MethodMap{
+			"GET": "file.json",
+		},
+		fmt.Sprintf("/api/v4/projects/%s/repository/commits", gitlabProjectID): util.MethodMap{
+			"GET": "commits.json",
+		},
+		fmt.Sprintf("/api/v4/projects/%s/members", gitlabProjectID): util.MethodMap{
+			"POST": "project-member.json",
+		},
+		"/api/v4/users": util.MethodMap{
+			"GET": "users.json",
+		},
 	}
 	for path, methodMap := range gitlabRouter {
 		mux.HandleFunc(path, util.GetMockAPIResponseFromFile("test_data/gitlab", methodMap))
@@ -155,8 +194,79 @@ func (suite *GitlabProviderSuite) TestGetRepository() {
 }
 
 func (suite *GitlabProviderSuite) TestAddCollaborator() {
-	err := suite.provider.AddCollaborator("derek", orgName, "repo")
+	err := suite.provider.AddCollaborator("derek", gitlabUserName, gitlabProjectName)
+	suite.Require().Nil(err)
+}
+
+func (suite *GitlabProviderSuite) TestListWebHooks() {
+	hooks, err := suite.provider.ListWebHooks(gitlabUserName, gitlabProjectName)
+
+	suite.Require().Nil(err)
+	suite.Require().Len(hooks, 1)
+	suite.Require().Equal(int64(1), hooks[0].ID)
+	suite.Require().Equal("http://hook.jx.example.com/hook", hooks[0].URL)
+}
+
+func (suite *GitlabProviderSuite) TestUpdateWebHook() {
+	err := suite.provider.UpdateWebHook(&gits.GitWebHookArguments{
+		Owner:       gitlabUserName,
+		Repo:        &gits.GitRepository{Name: gitlabProjectName},
+		URL:         "http://hook.jx.example.com/hook",
+		ExistingURL: "http://hook.jx.example.com/hook",
+		Secret:      "secret",
+	})
+	suite.Require().Nil(err)
+}
+
+func (suite *GitlabProviderSuite) TestUpdateRelease() {
+	release := &gits.GitRelease{
+		TagName: "v1.0.0",
+		Body:    "## Changes",
+	}
+	err := suite.provider.UpdateRelease(gitlabUserName, gitlabProjectName, "1.0.0", release)
+
+	suite.Require().Nil(err)
+	suite.Require().Equal(util.UrlJoin(suite.server.URL, gitlabUserName, gitlabProjectName, "tags", "v1.0.0"), release.HTMLURL)
+}
+
+func (suite *GitlabProviderSuite) TestUploadReleaseAsset() {
+	dir, err := ioutil.TempDir("", "test-gitlab-release-asset-")
+	suite.Require().Nil(err)
+	defer os.RemoveAll(dir)
+	fileName := filepath.Join(dir, "app.tar.gz")
+	suite.Require().Nil(ioutil.WriteFile(fileName, []byte("binary"), 0644))
+	file, err := os.Open(fileName)
+	suite.Require().Nil(err)
+	defer file.Close()
+
+	asset, err := suite.provider.UploadReleaseAsset(gitlabUserName, gitlabProjectName, "v1.0.0", "app.tar.gz", file)
+
+	suite.Require().Nil(err)
+	suite.Require().Equal("app.tar.gz", asset.Name)
+	suite.Require().Equal("https://gitlab.com/api/v4/projects/5690870/packages/generic/test-project/v1.0.0/app.tar.gz", asset.BrowserDownloadURL)
+}
+
+func (suite *GitlabProviderSuite) TestGetContent() {
+	content, err := suite.provider.GetContent(gitlabUserName, gitlabProjectName, "jenkins-x.yml", "master")
+
+	suite.Require().Nil(err)
+	suite.Require().Equal("jenkins-x.yml", content.Path)
+	suite.Require().Equal("base64", content.Encoding)
+	suite.Require().Equal("YnVpbGRQYWNrOiBtYXZlbgo=", content.Content)
+}
+
+func (suite *GitlabProviderSuite) TestListCommits() {
+	commits, err := suite.provider.ListCommits(gitlabUserName, gitlabProjectName, &gits.ListCommitsArguments{
+		SHA:     "master",
+		Page:    1,
+		PerPage: 1,
+	})
+
 	suite.Require().Nil(err)
+	suite.Require().Len(commits, 1)
+	suite.Require().Equal("ed899a2f4b50b4370feeea94676502b42383c746", commits[0].SHA)
+	suite.Require().Equal("fix: the build", commits[0].Message)
+	suite.Require().Equal("testperson@example.com", commits[0].Author.Email)
 }
 
 func (suite *GitlabProviderSuite) TestListInvitations() {
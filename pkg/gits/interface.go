@@ -2,10 +2,12 @@ package gits
 
 import (
 	"io"
+	"os"
 	"time"
 
 	"github.com/google/go-github/github"
 	"github.com/jenkins-x/jx/pkg/auth"
+	"golang.org/x/crypto/openpgp"
 	gitcfg "gopkg.in/src-d/go-git.v4/config"
 )
 
@@ -66,6 +68,18 @@ type GitProvider interface {
 
 	UpdateWebHook(data *GitWebHookArguments) error
 
+	// GetBranchProtection returns the protection of the branch or nil if the branch is not protected
+	GetBranchProtection(org string, repo string, branch string) (*GitBranchProtection, error)
+
+	// UpdateBranchProtection protects the branch, replacing any existing protection
+	UpdateBranchProtection(org string, repo string, branch string, protection *GitBranchProtection) error
+
+	// RemoveBranchProtection removes any protection from the branch
+	RemoveBranchProtection(org string, repo string, branch string) error
+
+	// GetRateLimits returns the API rate limits of the user or an empty slice if the git server does not report them
+	GetRateLimits() ([]*GitRateLimit, error)
+
 	IsGitHub() bool
 
 	IsGitea() bool
@@ -92,12 +106,27 @@ type GitProvider interface {
 
 	AddPRComment(pr *GitPullRequest, comment string) error
 
+	// RequestPRReviewers requests reviews of the pull request from the given users
+	RequestPRReviewers(pr *GitPullRequest, reviewers []string) error
+
+	// ListPRReviews returns the reviews of the pull request in the order they were submitted
+	ListPRReviews(pr *GitPullRequest) ([]*GitReview, error)
+
+	// AddPRLabels adds the labels to the pull request
+	AddPRLabels(pr *GitPullRequest, labels []string) error
+
+	// RemovePRLabel removes the label from the pull request
+	RemovePRLabel(pr *GitPullRequest, label string) error
+
 	CreateIssueComment(owner string, repo string, number int, comment string) error
 
 	UpdateRelease(owner string, repo string, tag string, releaseInfo *GitRelease) error
 
 	ListReleases(org string, name string) ([]*GitRelease, error)
 
+	// UploadReleaseAsset uploads the file as an asset with the given name of the release of the tag
+	UploadReleaseAsset(org string, repo string, tag string, name string, asset *os.File) (*GitReleaseAsset, error)
+
 	GetContent(org string, name string, path string, ref string) (*GitFileContent, error)
 
 	// returns the path relative to the Jenkins URL to trigger webhooks on this kind of repository
@@ -236,9 +265,13 @@ type Gitter interface {
 	FetchTags(dir string) error
 	Tags(dir string) ([]string, error)
 	CreateTag(dir string, tag string, msg string) error
+	CreateSignedTag(dir string, tag string, msg string, signer *openpgp.Entity) error
+	VerifyTag(dir string, tag string, keyring openpgp.EntityList) (*openpgp.Entity, error)
 	GetLatestCommitSha(dir string) (string, error)
 
 	GetRevisionBeforeDate(dir string, t time.Time) (string, error)
 	GetRevisionBeforeDateText(dir string, dateText string) (string, error)
+	GetFileRevisions(dir string, file string) ([]string, error)
+	GetCommitMessagesSince(dir string, rev string) ([]string, error)
 	DeleteRemoteBranch(dir string, remoteName string, branch string) error
 }
@@ -8,6 +8,7 @@ import (
 	auth "github.com/jenkins-x/jx/pkg/auth"
 	gits "github.com/jenkins-x/jx/pkg/gits"
 	pegomock "github.com/petergtz/pegomock"
+	"os"
 	"reflect"
 	"time"
 )
@@ -76,6 +77,21 @@ func (mock *MockGitProvider) AddPRComment(_param0 *gits.GitPullRequest, _param1
 	return ret0
 }
 
+func (mock *MockGitProvider) AddPRLabels(_param0 *gits.GitPullRequest, _param1 []string) error {
+	if mock == nil {
+		panic("mock must not be nil. Use myMock := NewMockGitProvider().")
+	}
+	params := []pegomock.Param{_param0, _param1}
+	result := pegomock.GetGenericMockFrom(mock).Invoke("AddPRLabels", params, []reflect.Type{reflect.TypeOf((*error)(nil)).Elem()})
+	var ret0 error
+	if len(result) != 0 {
+		if result[0] != nil {
+			ret0 = result[0].(error)
+		}
+	}
+	return ret0
+}
+
 func (mock *MockGitProvider) BranchArchiveURL(_param0 string, _param1 string, _param2 string) string {
 	if mock == nil {
 		panic("mock must not be nil. Use myMock := NewMockGitProvider().")
@@ -227,6 +243,25 @@ func (mock *MockGitProvider) ForkRepository(_param0 string, _param1 string, _par
 	return ret0, ret1
 }
 
+func (mock *MockGitProvider) GetBranchProtection(_param0 string, _param1 string, _param2 string) (*gits.GitBranchProtection, error) {
+	if mock == nil {
+		panic("mock must not be nil. Use myMock := NewMockGitProvider().")
+	}
+	params := []pegomock.Param{_param0, _param1, _param2}
+	result := pegomock.GetGenericMockFrom(mock).Invoke("GetBranchProtection", params, []reflect.Type{reflect.TypeOf((**gits.GitBranchProtection)(nil)).Elem(), reflect.TypeOf((*error)(nil)).Elem()})
+	var ret0 *gits.GitBranchProtection
+	var ret1 error
+	if len(result) != 0 {
+		if result[0] != nil {
+			ret0 = result[0].(*gits.GitBranchProtection)
+		}
+		if result[1] != nil {
+			ret1 = result[1].(error)
+		}
+	}
+	return ret0, ret1
+}
+
 func (mock *MockGitProvider) GetContent(_param0 string, _param1 string, _param2 string, _param3 string) (*gits.GitFileContent, error) {
 	if mock == nil {
 		panic("mock must not be nil. Use myMock := NewMockGitProvider().")
@@ -303,6 +338,25 @@ func (mock *MockGitProvider) GetPullRequestCommits(_param0 string, _param1 *gits
 	return ret0, ret1
 }
 
+func (mock *MockGitProvider) GetRateLimits() ([]*gits.GitRateLimit, error) {
+	if mock == nil {
+		panic("mock must not be nil. Use myMock := NewMockGitProvider().")
+	}
+	params := []pegomock.Param{}
+	result := pegomock.GetGenericMockFrom(mock).Invoke("GetRateLimits", params, []reflect.Type{reflect.TypeOf((*[]*gits.GitRateLimit)(nil)).Elem(), reflect.TypeOf((*error)(nil)).Elem()})
+	var ret0 []*gits.GitRateLimit
+	var ret1 error
+	if len(result) != 0 {
+		if result[0] != nil {
+			ret0 = result[0].([]*gits.GitRateLimit)
+		}
+		if result[1] != nil {
+			ret1 = result[1].(error)
+		}
+	}
+	return ret0, ret1
+}
+
 func (mock *MockGitProvider) GetRepository(_param0 string, _param1 string) (*gits.GitRepository, error) {
 	if mock == nil {
 		panic("mock must not be nil. Use myMock := NewMockGitProvider().")
@@ -571,6 +625,25 @@ func (mock *MockGitProvider) ListOrganisations() ([]gits.GitOrganisation, error)
 	return ret0, ret1
 }
 
+func (mock *MockGitProvider) ListPRReviews(_param0 *gits.GitPullRequest) ([]*gits.GitReview, error) {
+	if mock == nil {
+		panic("mock must not be nil. Use myMock := NewMockGitProvider().")
+	}
+	params := []pegomock.Param{_param0}
+	result := pegomock.GetGenericMockFrom(mock).Invoke("ListPRReviews", params, []reflect.Type{reflect.TypeOf((*[]*gits.GitReview)(nil)).Elem(), reflect.TypeOf((*error)(nil)).Elem()})
+	var ret0 []*gits.GitReview
+	var ret1 error
+	if len(result) != 0 {
+		if result[0] != nil {
+			ret0 = result[0].([]*gits.GitReview)
+		}
+		if result[1] != nil {
+			ret1 = result[1].(error)
+		}
+	}
+	return ret0, ret1
+}
+
 func (mock *MockGitProvider) ListReleases(_param0 string, _param1 string) ([]*gits.GitRelease, error) {
 	if mock == nil {
 		panic("mock must not be nil. Use myMock := NewMockGitProvider().")
@@ -662,6 +735,36 @@ func (mock *MockGitProvider) PullRequestLastCommitStatus(_param0 *gits.GitPullRe
 	return ret0, ret1
 }
 
+func (mock *MockGitProvider) RemoveBranchProtection(_param0 string, _param1 string, _param2 string) error {
+	if mock == nil {
+		panic("mock must not be nil. Use myMock := NewMockGitProvider().")
+	}
+	params := []pegomock.Param{_param0, _param1, _param2}
+	result := pegomock.GetGenericMockFrom(mock).Invoke("RemoveBranchProtection", params, []reflect.Type{reflect.TypeOf((*error)(nil)).Elem()})
+	var ret0 error
+	if len(result) != 0 {
+		if result[0] != nil {
+			ret0 = result[0].(error)
+		}
+	}
+	return ret0
+}
+
+func (mock *MockGitProvider) RemovePRLabel(_param0 *gits.GitPullRequest, _param1 string) error {
+	if mock == nil {
+		panic("mock must not be nil. Use myMock := NewMockGitProvider().")
+	}
+	params := []pegomock.Param{_param0, _param1}
+	result := pegomock.GetGenericMockFrom(mock).Invoke("RemovePRLabel", params, []reflect.Type{reflect.TypeOf((*error)(nil)).Elem()})
+	var ret0 error
+	if len(result) != 0 {
+		if result[0] != nil {
+			ret0 = result[0].(error)
+		}
+	}
+	return ret0
+}
+
 func (mock *MockGitProvider) RenameRepository(_param0 string, _param1 string, _param2 string) (*gits.GitRepository, error) {
 	if mock == nil {
 		panic("mock must not be nil. Use myMock := NewMockGitProvider().")
@@ -681,6 +784,21 @@ func (mock *MockGitProvider) RenameRepository(_param0 string, _param1 string, _p
 	return ret0, ret1
 }
 
+func (mock *MockGitProvider) RequestPRReviewers(_param0 *gits.GitPullRequest, _param1 []string) error {
+	if mock == nil {
+		panic("mock must not be nil. Use myMock := NewMockGitProvider().")
+	}
+	params := []pegomock.Param{_param0, _param1}
+	result := pegomock.GetGenericMockFrom(mock).Invoke("RequestPRReviewers", params, []reflect.Type{reflect.TypeOf((*error)(nil)).Elem()})
+	var ret0 error
+	if len(result) != 0 {
+		if result[0] != nil {
+			ret0 = result[0].(error)
+		}
+	}
+	return ret0
+}
+
 func (mock *MockGitProvider) SearchIssues(_param0 string, _param1 string, _param2 string) ([]*gits.GitIssue, error) {
 	if mock == nil {
 		panic("mock must not be nil. Use myMock := NewMockGitProvider().")
@@ -749,6 +867,21 @@ func (mock *MockGitProvider) ShouldForkForPullRequest(_param0 string, _param1 st
 	return ret0
 }
 
+func (mock *MockGitProvider) UpdateBranchProtection(_param0 string, _param1 string, _param2 string, _param3 *gits.GitBranchProtection) error {
+	if mock == nil {
+		panic("mock must not be nil. Use myMock := NewMockGitProvider().")
+	}
+	params := []pegomock.Param{_param0, _param1, _param2, _param3}
+	result := pegomock.GetGenericMockFrom(mock).Invoke("UpdateBranchProtection", params, []reflect.Type{reflect.TypeOf((*error)(nil)).Elem()})
+	var ret0 error
+	if len(result) != 0 {
+		if result[0] != nil {
+			ret0 = result[0].(error)
+		}
+	}
+	return ret0
+}
+
 func (mock *MockGitProvider) UpdateCommitStatus(_param0 string, _param1 string, _param2 string, _param3 *gits.GitRepoStatus) (*gits.GitRepoStatus, error) {
 	if mock == nil {
 		panic("mock must not be nil. Use myMock := NewMockGitProvider().")
@@ -813,6 +946,25 @@ func (mock *MockGitProvider) UpdateWebHook(_param0 *gits.GitWebHookArguments) er
 	return ret0
 }
 
+func (mock *MockGitProvider) UploadReleaseAsset(_param0 string, _param1 string, _param2 string, _param3 string, _param4 *os.File) (*gits.GitReleaseAsset, error) {
+	if mock == nil {
+		panic("mock must not be nil. Use myMock := NewMockGitProvider().")
+	}
+	params := []pegomock.Param{_param0, _param1, _param2, _param3, _param4}
+	result := pegomock.GetGenericMockFrom(mock).Invoke("UploadReleaseAsset", params, []reflect.Type{reflect.TypeOf((**gits.GitReleaseAsset)(nil)).Elem(), reflect.TypeOf((*error)(nil)).Elem()})
+	var ret0 *gits.GitReleaseAsset
+	var ret1 error
+	if len(result) != 0 {
+		if result[0] != nil {
+			ret0 = result[0].(*gits.GitReleaseAsset)
+		}
+		if result[1] != nil {
+			ret1 = result[1].(error)
+		}
+	}
+	return ret0, ret1
+}
+
 func (mock *MockGitProvider) UserAuth() auth.UserAuth {
 	if mock == nil {
 		panic("mock must not be nil. Use myMock := NewMockGitProvider().")
@@ -988,6 +1140,37 @@ func (c *MockGitProvider_AddPRComment_OngoingVerification) GetAllCapturedArgumen
 	return
 }
 
+func (verifier *VerifierMockGitProvider) AddPRLabels(_param0 *gits.GitPullRequest, _param1 []string) *MockGitProvider_AddPRLabels_OngoingVerification {
+	params := []pegomock.Param{_param0, _param1}
+	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "AddPRLabels", params, verifier.timeout)
+	return &MockGitProvider_AddPRLabels_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
+}
+
+type MockGitProvider_AddPRLabels_OngoingVerification struct {
+	mock              *MockGitProvider
+	methodInvocations []pegomock.MethodInvocation
+}
+
+func (c *MockGitProvider_AddPRLabels_OngoingVerification) GetCapturedArguments() (*gits.GitPullRequest, []string) {
+	_param0, _param1 := c.GetAllCapturedArguments()
+	return _param0[len(_param0)-1], _param1[len(_param1)-1]
+}
+
+func (c *MockGitProvider_AddPRLabels_OngoingVerification) GetAllCapturedArguments() (_param0 []*gits.GitPullRequest, _param1 [][]string) {
+	params := pegomock.GetGenericMockFrom(c.mock).GetInvocationParams(c.methodInvocations)
+	if len(params) > 0 {
+		_param0 = make([]*gits.GitPullRequest, len(params[0]))
+		for u, param := range params[0] {
+			_param0[u] = param.(*gits.GitPullRequest)
+		}
+		_param1 = make([][]string, len(params[1]))
+		for u, param := range params[1] {
+			_param1[u] = param.([]string)
+		}
+	}
+	return
+}
+
 func (verifier *VerifierMockGitProvider) BranchArchiveURL(_param0 string, _param1 string, _param2 string) *MockGitProvider_BranchArchiveURL_OngoingVerification {
 	params := []pegomock.Param{_param0, _param1, _param2}
 	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "BranchArchiveURL", params, verifier.timeout)
@@ -1269,6 +1452,41 @@ func (c *MockGitProvider_ForkRepository_OngoingVerification) GetAllCapturedArgum
 	return
 }
 
+func (verifier *VerifierMockGitProvider) GetBranchProtection(_param0 string, _param1 string, _param2 string) *MockGitProvider_GetBranchProtection_OngoingVerification {
+	params := []pegomock.Param{_param0, _param1, _param2}
+	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "GetBranchProtection", params, verifier.timeout)
+	return &MockGitProvider_GetBranchProtection_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
+}
+
+type MockGitProvider_GetBranchProtection_OngoingVerification struct {
+	mock              *MockGitProvider
+	methodInvocations []pegomock.MethodInvocation
+}
+
+func (c *MockGitProvider_GetBranchProtection_OngoingVerification) GetCapturedArguments() (string, string, string) {
+	_param0, _param1, _param2 := c.GetAllCapturedArguments()
+	return _param0[len(_param0)-1], _param1[len(_param1)-1], _param2[len(_param2)-1]
+}
+
+func (c *MockGitProvider_GetBranchProtection_OngoingVerification) GetAllCapturedArguments() (_param0 []string, _param1 []string, _param2 []string) {
+	params := pegomock.GetGenericMockFrom(c.mock).GetInvocationParams(c.methodInvocations)
+	if len(params) > 0 {
+		_param0 = make([]string, len(params[0]))
+		for u, param := range params[0] {
+			_param0[u] = param.(string)
+		}
+		_param1 = make([]string, len(params[1]))
+		for u, param := range params[1] {
+			_param1[u] = param.(string)
+		}
+		_param2 = make([]string, len(params[2]))
+		for u, param := range params[2] {
+			_param2[u] = param.(string)
+		}
+	}
+	return
+}
+
 func (verifier *VerifierMockGitProvider) GetContent(_param0 string, _param1 string, _param2 string, _param3 string) *MockGitProvider_GetContent_OngoingVerification {
 	params := []pegomock.Param{_param0, _param1, _param2, _param3}
 	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "GetContent", params, verifier.timeout)
@@ -1413,6 +1631,23 @@ func (c *MockGitProvider_GetPullRequestCommits_OngoingVerification) GetAllCaptur
 	return
 }
 
+func (verifier *VerifierMockGitProvider) GetRateLimits() *MockGitProvider_GetRateLimits_OngoingVerification {
+	params := []pegomock.Param{}
+	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "GetRateLimits", params, verifier.timeout)
+	return &MockGitProvider_GetRateLimits_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
+}
+
+type MockGitProvider_GetRateLimits_OngoingVerification struct {
+	mock              *MockGitProvider
+	methodInvocations []pegomock.MethodInvocation
+}
+
+func (c *MockGitProvider_GetRateLimits_OngoingVerification) GetCapturedArguments() {
+}
+
+func (c *MockGitProvider_GetRateLimits_OngoingVerification) GetAllCapturedArguments() {
+}
+
 func (verifier *VerifierMockGitProvider) GetRepository(_param0 string, _param1 string) *MockGitProvider_GetRepository_OngoingVerification {
 	params := []pegomock.Param{_param0, _param1}
 	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "GetRepository", params, verifier.timeout)
@@ -1785,6 +2020,33 @@ func (c *MockGitProvider_ListOrganisations_OngoingVerification) GetCapturedArgum
 func (c *MockGitProvider_ListOrganisations_OngoingVerification) GetAllCapturedArguments() {
 }
 
+func (verifier *VerifierMockGitProvider) ListPRReviews(_param0 *gits.GitPullRequest) *MockGitProvider_ListPRReviews_OngoingVerification {
+	params := []pegomock.Param{_param0}
+	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "ListPRReviews", params, verifier.timeout)
+	return &MockGitProvider_ListPRReviews_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
+}
+
+type MockGitProvider_ListPRReviews_OngoingVerification struct {
+	mock              *MockGitProvider
+	methodInvocations []pegomock.MethodInvocation
+}
+
+func (c *MockGitProvider_ListPRReviews_OngoingVerification) GetCapturedArguments() *gits.GitPullRequest {
+	_param0 := c.GetAllCapturedArguments()
+	return _param0[len(_param0)-1]
+}
+
+func (c *MockGitProvider_ListPRReviews_OngoingVerification) GetAllCapturedArguments() (_param0 []*gits.GitPullRequest) {
+	params := pegomock.GetGenericMockFrom(c.mock).GetInvocationParams(c.methodInvocations)
+	if len(params) > 0 {
+		_param0 = make([]*gits.GitPullRequest, len(params[0]))
+		for u, param := range params[0] {
+			_param0[u] = param.(*gits.GitPullRequest)
+		}
+	}
+	return
+}
+
 func (verifier *VerifierMockGitProvider) ListReleases(_param0 string, _param1 string) *MockGitProvider_ListReleases_OngoingVerification {
 	params := []pegomock.Param{_param0, _param1}
 	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "ListReleases", params, verifier.timeout)
@@ -1932,6 +2194,72 @@ func (c *MockGitProvider_PullRequestLastCommitStatus_OngoingVerification) GetAll
 	return
 }
 
+func (verifier *VerifierMockGitProvider) RemoveBranchProtection(_param0 string, _param1 string, _param2 string) *MockGitProvider_RemoveBranchProtection_OngoingVerification {
+	params := []pegomock.Param{_param0, _param1, _param2}
+	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "RemoveBranchProtection", params, verifier.timeout)
+	return &MockGitProvider_RemoveBranchProtection_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
+}
+
+type MockGitProvider_RemoveBranchProtection_OngoingVerification struct {
+	mock              *MockGitProvider
+	methodInvocations []pegomock.MethodInvocation
+}
+
+func (c *MockGitProvider_RemoveBranchProtection_OngoingVerification) GetCapturedArguments() (string, string, string) {
+	_param0, _param1, _param2 := c.GetAllCapturedArguments()
+	return _param0[len(_param0)-1], _param1[len(_param1)-1], _param2[len(_param2)-1]
+}
+
+func (c *MockGitProvider_RemoveBranchProtection_OngoingVerification) GetAllCapturedArguments() (_param0 []string, _param1 []string, _param2 []string) {
+	params := pegomock.GetGenericMockFrom(c.mock).GetInvocationParams(c.methodInvocations)
+	if len(params) > 0 {
+		_param0 = make([]string, len(params[0]))
+		for u, param := range params[0] {
+			_param0[u] = param.(string)
+		}
+		_param1 = make([]string, len(params[1]))
+		for u, param := range params[1] {
+			_param1[u] = param.(string)
+		}
+		_param2 = make([]string, len(params[2]))
+		for u, param := range params[2] {
+			_param2[u] = param.(string)
+		}
+	}
+	return
+}
+
+func (verifier *VerifierMockGitProvider) RemovePRLabel(_param0 *gits.GitPullRequest, _param1 string) *MockGitProvider_RemovePRLabel_OngoingVerification {
+	params := []pegomock.Param{_param0, _param1}
+	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "RemovePRLabel", params, verifier.timeout)
+	return &MockGitProvider_RemovePRLabel_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
+}
+
+type MockGitProvider_RemovePRLabel_OngoingVerification struct {
+	mock              *MockGitProvider
+	methodInvocations []pegomock.MethodInvocation
+}
+
+func (c *MockGitProvider_RemovePRLabel_OngoingVerification) GetCapturedArguments() (*gits.GitPullRequest, string) {
+	_param0, _param1 := c.GetAllCapturedArguments()
+	return _param0[len(_param0)-1], _param1[len(_param1)-1]
+}
+
+func (c *MockGitProvider_RemovePRLabel_OngoingVerification) GetAllCapturedArguments() (_param0 []*gits.GitPullRequest, _param1 []string) {
+	params := pegomock.GetGenericMockFrom(c.mock).GetInvocationParams(c.methodInvocations)
+	if len(params) > 0 {
+		_param0 = make([]*gits.GitPullRequest, len(params[0]))
+		for u, param := range params[0] {
+			_param0[u] = param.(*gits.GitPullRequest)
+		}
+		_param1 = make([]string, len(params[1]))
+		for u, param := range params[1] {
+			_param1[u] = param.(string)
+		}
+	}
+	return
+}
+
 func (verifier *VerifierMockGitProvider) RenameRepository(_param0 string, _param1 string, _param2 string) *MockGitProvider_RenameRepository_OngoingVerification {
 	params := []pegomock.Param{_param0, _param1, _param2}
 	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "RenameRepository", params, verifier.timeout)
@@ -1967,6 +2295,37 @@ func (c *MockGitProvider_RenameRepository_OngoingVerification) GetAllCapturedArg
 	return
 }
 
+func (verifier *VerifierMockGitProvider) RequestPRReviewers(_param0 *gits.GitPullRequest, _param1 []string) *MockGitProvider_RequestPRReviewers_OngoingVerification {
+	params := []pegomock.Param{_param0, _param1}
+	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "RequestPRReviewers", params, verifier.timeout)
+	return &MockGitProvider_RequestPRReviewers_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
+}
+
+type MockGitProvider_RequestPRReviewers_OngoingVerification struct {
+	mock              *MockGitProvider
+	methodInvocations []pegomock.MethodInvocation
+}
+
+func (c *MockGitProvider_RequestPRReviewers_OngoingVerification) GetCapturedArguments() (*gits.GitPullRequest, []string) {
+	_param0, _param1 := c.GetAllCapturedArguments()
+	return _param0[len(_param0)-1], _param1[len(_param1)-1]
+}
+
+func (c *MockGitProvider_RequestPRReviewers_OngoingVerification) GetAllCapturedArguments() (_param0 []*gits.GitPullRequest, _param1 [][]string) {
+	params := pegomock.GetGenericMockFrom(c.mock).GetInvocationParams(c.methodInvocations)
+	if len(params) > 0 {
+		_param0 = make([]*gits.GitPullRequest, len(params[0]))
+		for u, param := range params[0] {
+			_param0[u] = param.(*gits.GitPullRequest)
+		}
+		_param1 = make([][]string, len(params[1]))
+		for u, param := range params[1] {
+			_param1[u] = param.([]string)
+		}
+	}
+	return
+}
+
 func (verifier *VerifierMockGitProvider) SearchIssues(_param0 string, _param1 string, _param2 string) *MockGitProvider_SearchIssues_OngoingVerification {
 	params := []pegomock.Param{_param0, _param1, _param2}
 	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "SearchIssues", params, verifier.timeout)
@@ -2089,6 +2448,45 @@ func (c *MockGitProvider_ShouldForkForPullRequest_OngoingVerification) GetAllCap
 	return
 }
 
+func (verifier *VerifierMockGitProvider) UpdateBranchProtection(_param0 string, _param1 string, _param2 string, _param3 *gits.GitBranchProtection) *MockGitProvider_UpdateBranchProtection_OngoingVerification {
+	params := []pegomock.Param{_param0, _param1, _param2, _param3}
+	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "UpdateBranchProtection", params, verifier.timeout)
+	return &MockGitProvider_UpdateBranchProtection_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
+}
+
+type MockGitProvider_UpdateBranchProtection_OngoingVerification struct {
+	mock              *MockGitProvider
+	methodInvocations []pegomock.MethodInvocation
+}
+
+func (c *MockGitProvider_UpdateBranchProtection_OngoingVerification) GetCapturedArguments() (string, string, string, *gits.GitBranchProtection) {
+	_param0, _param1, _param2, _param3 := c.GetAllCapturedArguments()
+	return _param0[len(_param0)-1], _param1[len(_param1)-1], _param2[len(_param2)-1], _param3[len(_param3)-1]
+}
+
+func (c *MockGitProvider_UpdateBranchProtection_OngoingVerification) GetAllCapturedArguments() (_param0 []string, _param1 []string, _param2 []string, _param3 []*gits.GitBranchProtection) {
+	params := pegomock.GetGenericMockFrom(c.mock).GetInvocationParams(c.methodInvocations)
+	if len(params) > 0 {
+		_param0 = make([]string, len(params[0]))
+		for u, param := range params[0] {
+			_param0[u] = param.(string)
+		}
+		_param1 = make([]string, len(params[1]))
+		for u, param := range params[1] {
+			_param1[u] = param.(string)
+		}
+		_param2 = make([]string, len(params[2]))
+		for u, param := range params[2] {
+			_param2[u] = param.(string)
+		}
+		_param3 = make([]*gits.GitBranchProtection, len(params[3]))
+		for u, param := range params[3] {
+			_param3[u] = param.(*gits.GitBranchProtection)
+		}
+	}
+	return
+}
+
 func (verifier *VerifierMockGitProvider) UpdateCommitStatus(_param0 string, _param1 string, _param2 string, _param3 *gits.GitRepoStatus) *MockGitProvider_UpdateCommitStatus_OngoingVerification {
 	params := []pegomock.Param{_param0, _param1, _param2, _param3}
 	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "UpdateCommitStatus", params, verifier.timeout)
@@ -2221,6 +2619,49 @@ func (c *MockGitProvider_UpdateWebHook_OngoingVerification) GetAllCapturedArgume
 	return
 }
 
+func (verifier *VerifierMockGitProvider) UploadReleaseAsset(_param0 string, _param1 string, _param2 string, _param3 string, _param4 *os.File) *MockGitProvider_UploadReleaseAsset_OngoingVerification {
+	params := []pegomock.Param{_param0, _param1, _param2, _param3, _param4}
+	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "UploadReleaseAsset", params, verifier.timeout)
+	return &MockGitProvider_UploadReleaseAsset_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
+}
+
+type MockGitProvider_UploadReleaseAsset_OngoingVerification struct {
+	mock              *MockGitProvider
+	methodInvocations []pegomock.MethodInvocation
+}
+
+func (c *MockGitProvider_UploadReleaseAsset_OngoingVerification) GetCapturedArguments() (string, string, string, string, *os.File) {
+	_param0, _param1, _param2, _param3, _param4 := c.GetAllCapturedArguments()
+	return _param0[len(_param0)-1], _param1[len(_param1)-1], _param2[len(_param2)-1], _param3[len(_param3)-1], _param4[len(_param4)-1]
+}
+
+func (c *MockGitProvider_UploadReleaseAsset_OngoingVerification) GetAllCapturedArguments() (_param0 []string, _param1 []string, _param2 []string, _param3 []string, _param4 []*os.File) {
+	params := pegomock.GetGenericMockFrom(c.mock).GetInvocationParams(c.methodInvocations)
+	if len(params) > 0 {
+		_param0 = make([]string, len(params[0]))
+		for u, param := range params[0] {
+			_param0[u] = param.(string)
+		}
+		_param1 = make([]string, len(params[1]))
+		for u, param := range params[1] {
+			_param1[u] = param.(string)
+		}
+		_param2 = make([]string, len(params[2]))
+		for u, param := range params[2] {
+			_param2[u] = param.(string)
+		}
+		_param3 = make([]string, len(params[3]))
+		for u, param := range params[3] {
+			_param3[u] = param.(string)
+		}
+		_param4 = make([]*os.File, len(params[4]))
+		for u, param := range params[4] {
+			_param4[u] = param.(*os.File)
+		}
+	}
+	return
+}
+
 func (verifier *VerifierMockGitProvider) UserAuth() *MockGitProvider_UserAuth_OngoingVerification {
 	params := []pegomock.Param{}
 	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "UserAuth", params, verifier.timeout)
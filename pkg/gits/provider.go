@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/jenkins-x/jx/pkg/auth"
+	"github.com/jenkins-x/jx/pkg/util"
 	"gopkg.in/AlecAivazis/survey.v1"
 	"gopkg.in/AlecAivazis/survey.v1/terminal"
 )
@@ -70,6 +71,42 @@ type Label struct {
 	Default     *bool
 }
 
+// GitReview represents a review of a pull request
+type GitReview struct {
+	ID          int64
+	User        *GitUser
+	State       string
+	Body        string
+	SubmittedAt *time.Time
+}
+
+const (
+	// ReviewStateApproved the state of a review approving a pull request
+	ReviewStateApproved = "APPROVED"
+	// ReviewStateChangesRequested the state of a review requesting changes to a pull request
+	ReviewStateChangesRequested = "CHANGES_REQUESTED"
+	// ReviewStateCommented the state of a review which only comments on a pull request
+	ReviewStateCommented = "COMMENTED"
+)
+
+// GitBranchProtection represents the protection of a branch of a repository
+type GitBranchProtection struct {
+	// RequiredStatusContexts the commit status contexts which must pass before a pull request can be merged
+	RequiredStatusContexts []string
+	// RequiredApprovals the number of approving reviews a pull request needs before it can be merged
+	RequiredApprovals int
+	// RestrictPushes only allows the PushUsers and PushTeams to push to the branch
+	RestrictPushes bool
+	PushUsers      []string
+	PushTeams      []string
+	// EnforceAdmins applies the protection to administrators too
+	EnforceAdmins bool
+}
+
+// AnyStatusContext is reported as the required status context by git providers which require all the builds of a
+// pull request to pass rather than named status contexts
+const AnyStatusContext = "*"
+
 type GitCommit struct {
 	SHA       string
 	Message   string
@@ -221,6 +258,10 @@ func CreateProvider(server *auth.AuthServer, user *auth.UserAuth, git Gitter) (G
 		return NewGiteaProvider(server, user, git)
 	} else if server.Kind == KindGitlab {
 		return NewGitlabProvider(server, user, git)
+	} else if server.Kind == KindGerrit {
+		return NewGerritProvider(server, user, git)
+	} else if server.Kind == KindAzureDevOps {
+		return NewAzureDevOpsProvider(server, user, git)
 	} else if server.Kind == KindGitFake {
 		return NewFakeProvider(), nil
 	} else {
@@ -255,6 +296,10 @@ func ProviderAccessTokenURL(kind string, url string, username string) string {
 		return GiteaAccessTokenURL(url)
 	case KindGitlab:
 		return GitlabAccessTokenURL(url)
+	case KindGerrit:
+		return GerritAccessTokenURL(url)
+	case KindAzureDevOps:
+		return AzureDevOpsAccessTokenURL(url)
 	default:
 		return GitHubAccessTokenURL(url)
 	}
@@ -367,6 +412,111 @@ func (s *GitRepoStatus) IsFailed() bool {
 	return s.State == "error" || s.State == "failure"
 }
 
+// IsApproved returns true if the review approves the pull request
+func (r *GitReview) IsApproved() bool {
+	return r.State == ReviewStateApproved
+}
+
+// IsChangesRequested returns true if the review requests changes to the pull request
+func (r *GitReview) IsChangesRequested() bool {
+	return r.State == ReviewStateChangesRequested
+}
+
+// LatestReviews returns the latest review of each reviewer ignoring reviews which only comment.
+// The reviews are expected in the order they were submitted
+func LatestReviews(reviews []*GitReview) map[string]*GitReview {
+	answer := map[string]*GitReview{}
+	for _, review := range reviews {
+		if review.User == nil || review.User.Login == "" || review.State == ReviewStateCommented {
+			continue
+		}
+		answer[review.User.Login] = review
+	}
+	return answer
+}
+
+// ApprovedReviewers returns the sorted logins of the reviewers whose latest review approves the pull request
+func ApprovedReviewers(reviews []*GitReview) []string {
+	return reviewersInState(reviews, ReviewStateApproved)
+}
+
+// ChangesRequestedReviewers returns the sorted logins of the reviewers whose latest review requests changes
+func ChangesRequestedReviewers(reviews []*GitReview) []string {
+	return reviewersInState(reviews, ReviewStateChangesRequested)
+}
+
+func reviewersInState(reviews []*GitReview, state string) []string {
+	answer := []string{}
+	for login, review := range LatestReviews(reviews) {
+		if review.State == state {
+			answer = append(answer, login)
+		}
+	}
+	sort.Strings(answer)
+	return answer
+}
+
+// HasLabel returns true if the pull request has a label with the given name
+func (pr *GitPullRequest) HasLabel(name string) bool {
+	for _, label := range pr.Labels {
+		if label != nil && label.Name != nil && *label.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// BranchProtectionDrift returns a description of each difference between the desired protection of a branch and
+// its actual protection. A nil protection is treated as an unprotected branch
+func BranchProtectionDrift(desired *GitBranchProtection, actual *GitBranchProtection) []string {
+	if desired == nil {
+		desired = &GitBranchProtection{}
+	}
+	if actual == nil {
+		actual = &GitBranchProtection{}
+	}
+	answer := []string{}
+	if util.StringArrayIndex(actual.RequiredStatusContexts, AnyStatusContext) < 0 {
+		for _, context := range desired.RequiredStatusContexts {
+			if util.StringArrayIndex(actual.RequiredStatusContexts, context) < 0 {
+				answer = append(answer, fmt.Sprintf("status context %s is not required", context))
+			}
+		}
+	} else if len(desired.RequiredStatusContexts) == 0 {
+		answer = append(answer, "status checks are required")
+	}
+	for _, context := range actual.RequiredStatusContexts {
+		if context != AnyStatusContext && util.StringArrayIndex(desired.RequiredStatusContexts, context) < 0 {
+			answer = append(answer, fmt.Sprintf("status context %s is required", context))
+		}
+	}
+	if desired.RequiredApprovals != actual.RequiredApprovals {
+		answer = append(answer, fmt.Sprintf("%d approvals are required rather than %d", actual.RequiredApprovals, desired.RequiredApprovals))
+	}
+	if desired.RestrictPushes != actual.RestrictPushes {
+		answer = append(answer, fmt.Sprintf("push restriction is %t rather than %t", actual.RestrictPushes, desired.RestrictPushes))
+	} else if desired.RestrictPushes {
+		if !util.StringArraysEqual(sortedCopy(desired.PushUsers), sortedCopy(actual.PushUsers)) {
+			answer = append(answer, fmt.Sprintf("users allowed to push are [%s] rather than [%s]",
+				strings.Join(actual.PushUsers, ", "), strings.Join(desired.PushUsers, ", ")))
+		}
+		if !util.StringArraysEqual(sortedCopy(desired.PushTeams), sortedCopy(actual.PushTeams)) {
+			answer = append(answer, fmt.Sprintf("teams allowed to push are [%s] rather than [%s]",
+				strings.Join(actual.PushTeams, ", "), strings.Join(desired.PushTeams, ", ")))
+		}
+	}
+	if desired.EnforceAdmins != actual.EnforceAdmins {
+		answer = append(answer, fmt.Sprintf("enforce admins is %t rather than %t", actual.EnforceAdmins, desired.EnforceAdmins))
+	}
+	return answer
+}
+
+func sortedCopy(values []string) []string {
+	answer := append([]string{}, values...)
+	sort.Strings(answer)
+	return answer
+}
+
 func (i *GitRepository) PickOrCreateProvider(authConfigSvc auth.ConfigService, message string, batchMode bool, gitKind string, git Gitter, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) (GitProvider, error) {
 	config := authConfigSvc.Config()
 	hostUrl := i.HostURLWithoutUser()
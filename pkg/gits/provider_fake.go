@@ -3,6 +3,7 @@ package gits
 import (
 	"errors"
 	"fmt"
+	"os"
 	"strconv"
 	"strings"
 	"time"
@@ -45,6 +46,7 @@ type FakePullRequest struct {
 	PullRequest *GitPullRequest
 	Commits     []*FakeCommit
 	Comment     string
+	Reviews     []*GitReview
 }
 
 type FakeIssue struct {
@@ -61,6 +63,7 @@ type FakeRepository struct {
 	issueCount         int
 	Releases           map[string]*GitRelease
 	PullRequestCounter int
+	BranchProtections  map[string]*GitBranchProtection
 }
 
 type FakeProvider struct {
@@ -419,6 +422,53 @@ func (p *FakeProvider) UpdateWebHook(data *GitWebHookArguments) error {
 	return fmt.Errorf("not implemented!")
 }
 
+func (f *FakeProvider) GetBranchProtection(org string, repo string, branch string) (*GitBranchProtection, error) {
+	fakeRepo, err := f.findRepository(org, repo)
+	if err != nil {
+		return nil, err
+	}
+	return fakeRepo.BranchProtections[branch], nil
+}
+
+func (f *FakeProvider) UpdateBranchProtection(org string, repo string, branch string, protection *GitBranchProtection) error {
+	fakeRepo, err := f.findRepository(org, repo)
+	if err != nil {
+		return err
+	}
+	if fakeRepo.BranchProtections == nil {
+		fakeRepo.BranchProtections = map[string]*GitBranchProtection{}
+	}
+	copy := *protection
+	fakeRepo.BranchProtections[branch] = &copy
+	return nil
+}
+
+func (f *FakeProvider) RemoveBranchProtection(org string, repo string, branch string) error {
+	fakeRepo, err := f.findRepository(org, repo)
+	if err != nil {
+		return err
+	}
+	delete(fakeRepo.BranchProtections, branch)
+	return nil
+}
+
+func (f *FakeProvider) GetRateLimits() ([]*GitRateLimit, error) {
+	return []*GitRateLimit{}, nil
+}
+
+func (f *FakeProvider) findRepository(org string, name string) (*FakeRepository, error) {
+	repos, ok := f.Repositories[org]
+	if !ok {
+		return nil, fmt.Errorf("organization '%s' not found", org)
+	}
+	for _, repo := range repos {
+		if repo.GitRepo.Name == name {
+			return repo, nil
+		}
+	}
+	return nil, fmt.Errorf("repository '%s' not found within the organization '%s'", name, org)
+}
+
 func (f *FakeProvider) IsGitHub() bool {
 	return f.Type == GitHub
 }
@@ -567,6 +617,75 @@ func (f *FakeProvider) AddPRComment(pr *GitPullRequest, comment string) error {
 	return fmt.Errorf("repository with name '%s' not found", repoName)
 }
 
+func (f *FakeProvider) RequestPRReviewers(pr *GitPullRequest, reviewers []string) error {
+	fakePR, err := f.findPullRequest(pr)
+	if err != nil {
+		return err
+	}
+	for _, reviewer := range reviewers {
+		fakePR.PullRequest.RequestedReviewers = append(fakePR.PullRequest.RequestedReviewers, &GitUser{Login: reviewer})
+	}
+	return nil
+}
+
+func (f *FakeProvider) ListPRReviews(pr *GitPullRequest) ([]*GitReview, error) {
+	fakePR, err := f.findPullRequest(pr)
+	if err != nil {
+		return nil, err
+	}
+	return fakePR.Reviews, nil
+}
+
+func (f *FakeProvider) AddPRLabels(pr *GitPullRequest, labels []string) error {
+	fakePR, err := f.findPullRequest(pr)
+	if err != nil {
+		return err
+	}
+	for _, label := range labels {
+		if !fakePR.PullRequest.HasLabel(label) {
+			name := label
+			fakePR.PullRequest.Labels = append(fakePR.PullRequest.Labels, &Label{Name: &name})
+		}
+	}
+	return nil
+}
+
+func (f *FakeProvider) RemovePRLabel(pr *GitPullRequest, label string) error {
+	fakePR, err := f.findPullRequest(pr)
+	if err != nil {
+		return err
+	}
+	labels := []*Label{}
+	for _, l := range fakePR.PullRequest.Labels {
+		if l.Name == nil || *l.Name != label {
+			labels = append(labels, l)
+		}
+	}
+	fakePR.PullRequest.Labels = labels
+	return nil
+}
+
+func (f *FakeProvider) findPullRequest(pr *GitPullRequest) (*FakePullRequest, error) {
+	repos, ok := f.Repositories[pr.Owner]
+	if !ok {
+		return nil, fmt.Errorf("no repositories found for '%s'", pr.Owner)
+	}
+	if pr.Number == nil {
+		return nil, fmt.Errorf("missing Number for pull request %#v", pr)
+	}
+	number := *pr.Number
+	for _, r := range repos {
+		if r.GitRepo.Name == pr.Repo {
+			fakePR, ok := r.PullRequests[number]
+			if !ok {
+				return nil, fmt.Errorf("pull request with id '%d' not found", number)
+			}
+			return fakePR, nil
+		}
+	}
+	return nil, fmt.Errorf("repository with name '%s' not found", pr.Repo)
+}
+
 func (f *FakeProvider) CreateIssueComment(owner string, repoName string, number int, comment string) error {
 	repos, ok := f.Repositories[owner]
 	if !ok {
@@ -600,6 +719,38 @@ func (f *FakeProvider) UpdateRelease(owner string, repoName string, tag string,
 	return fmt.Errorf("repository with name '%s' not found", repoName)
 }
 
+// UploadReleaseAsset adds an asset with the name of the file to the release of the tag
+func (f *FakeProvider) UploadReleaseAsset(org string, repoName string, tag string, name string, asset *os.File) (*GitReleaseAsset, error) {
+	repos, ok := f.Repositories[org]
+	if !ok {
+		return nil, fmt.Errorf("organization '%s' not found", org)
+	}
+	for _, repo := range repos {
+		if repo.GitRepo.Name == repoName {
+			release, ok := repo.Releases[tag]
+			if !ok {
+				return nil, fmt.Errorf("release with tag '%s' not found", tag)
+			}
+			answer := GitReleaseAsset{
+				Name:               name,
+				BrowserDownloadURL: util.UrlJoin(repo.GitRepo.HTMLURL, "releases/download", tag, name),
+			}
+			assets := []GitReleaseAsset{}
+			if release.Assets != nil {
+				for _, existing := range *release.Assets {
+					if existing.Name != name {
+						assets = append(assets, existing)
+					}
+				}
+			}
+			assets = append(assets, answer)
+			release.Assets = &assets
+			return &answer, nil
+		}
+	}
+	return nil, fmt.Errorf("repository with name '%s' not found", repoName)
+}
+
 func (f *FakeProvider) ListReleases(org string, name string) ([]*GitRelease, error) {
 	repos, ok := f.Repositories[org]
 	if !ok {
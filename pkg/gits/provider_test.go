@@ -831,3 +831,49 @@ func assertProvider(t *testing.T, want gits.GitProvider, result gits.GitProvider
 	assert.Equal(t, want.ServerURL(), result.ServerURL())
 	assert.Equal(t, want.UserAuth(), result.UserAuth())
 }
+
+func TestBranchProtectionDrift(t *testing.T) {
+	t.Parallel()
+	desired := &gits.GitBranchProtection{
+		RequiredStatusContexts: []string{"pr-build", "integration"},
+		RequiredApprovals:      1,
+		RestrictPushes:         true,
+		PushUsers:              []string{"jenkins-x-bot"},
+		EnforceAdmins:          true,
+	}
+
+	assert.Empty(t, gits.BranchProtectionDrift(desired, &gits.GitBranchProtection{
+		RequiredStatusContexts: []string{"integration", "pr-build"},
+		RequiredApprovals:      1,
+		RestrictPushes:         true,
+		PushUsers:              []string{"jenkins-x-bot"},
+		EnforceAdmins:          true,
+	}))
+
+	assert.Empty(t, gits.BranchProtectionDrift(desired, &gits.GitBranchProtection{
+		RequiredStatusContexts: []string{gits.AnyStatusContext},
+		RequiredApprovals:      1,
+		RestrictPushes:         true,
+		PushUsers:              []string{"jenkins-x-bot"},
+		EnforceAdmins:          true,
+	}), "any status context satisfies the required contexts")
+
+	assert.Equal(t, []string{
+		"status context integration is not required",
+		"status context lint is required",
+		"0 approvals are required rather than 1",
+		"users allowed to push are [] rather than [jenkins-x-bot]",
+		"enforce admins is false rather than true",
+	}, gits.BranchProtectionDrift(desired, &gits.GitBranchProtection{
+		RequiredStatusContexts: []string{"pr-build", "lint"},
+		RestrictPushes:         true,
+	}))
+
+	assert.Equal(t, []string{
+		"status context pr-build is not required",
+		"status context integration is not required",
+		"0 approvals are required rather than 1",
+		"push restriction is false rather than true",
+		"enforce admins is false rather than true",
+	}, gits.BranchProtectionDrift(desired, nil))
+}
@@ -0,0 +1,149 @@
+package gits
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// RateLimitResourceCore the rate limit of regular API requests
+	RateLimitResourceCore = "core"
+	// RateLimitResourceSearch the rate limit of search API requests
+	RateLimitResourceSearch = "search"
+	// RateLimitResourceGraphQL the rate limit of GraphQL API requests
+	RateLimitResourceGraphQL = "graphql"
+)
+
+// RateLimitPolicy what to do when the API rate limit of a git provider is about to be exhausted
+type RateLimitPolicy string
+
+const (
+	// RateLimitPolicyWait waits until the rate limit is reset before making the request
+	RateLimitPolicyWait RateLimitPolicy = "wait"
+	// RateLimitPolicyFail fails the request straight away
+	RateLimitPolicyFail RateLimitPolicy = "fail"
+)
+
+// RateLimitPolicies the supported rate limit policies
+var RateLimitPolicies = []string{string(RateLimitPolicyWait), string(RateLimitPolicyFail)}
+
+// GitRateLimit the API rate limit of a git provider for a kind of request
+type GitRateLimit struct {
+	Resource  string
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// RateLimitError the error returned when a request is not made as the rate limit of the git provider is exhausted
+type RateLimitError struct {
+	RateLimit GitRateLimit
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("the %s API rate limit of %d requests is exhausted until %s", e.RateLimit.Resource, e.RateLimit.Limit,
+		e.RateLimit.Reset.Format(time.RFC3339))
+}
+
+// ParseRateLimitHeaders returns the rate limit from the headers of a response of a git provider or nil if the headers
+// contain no rate limit. Both the X-RateLimit-* headers of GitHub and Azure DevOps and the RateLimit-* headers of
+// GitLab are supported along with Retry-After
+func ParseRateLimitHeaders(header http.Header, resource string) *GitRateLimit {
+	for _, prefix := range []string{"X-RateLimit-", "RateLimit-"} {
+		remaining := header.Get(prefix + "Remaining")
+		if remaining == "" {
+			continue
+		}
+		answer := &GitRateLimit{
+			Resource: resource,
+		}
+		answer.Remaining, _ = strconv.Atoi(remaining)
+		answer.Limit, _ = strconv.Atoi(header.Get(prefix + "Limit"))
+		reset, err := strconv.ParseInt(header.Get(prefix+"Reset"), 10, 64)
+		if err == nil && reset > 0 {
+			answer.Reset = time.Unix(reset, 0)
+		}
+		if r := header.Get(prefix + "Resource"); r != "" {
+			answer.Resource = r
+		}
+		return answer
+	}
+	retryAfter, err := strconv.Atoi(header.Get("Retry-After"))
+	if err == nil && retryAfter >= 0 {
+		return &GitRateLimit{
+			Resource: resource,
+			Reset:    time.Now().Add(time.Duration(retryAfter) * time.Second),
+		}
+	}
+	return nil
+}
+
+// RateLimitResource returns the rate limit resource which a request of the given URL path counts against
+func RateLimitResource(path string) string {
+	path = strings.TrimSuffix(path, "/")
+	if strings.HasSuffix(path, "/graphql") {
+		return RateLimitResourceGraphQL
+	}
+	if strings.Contains(path, "/search/") {
+		return RateLimitResourceSearch
+	}
+	return RateLimitResourceCore
+}
+
+// rateLimitTracker tracks the rate limits reported by the responses of a git provider
+type rateLimitTracker struct {
+	lock   sync.Mutex
+	limits map[string]*GitRateLimit
+	now    func() time.Time
+}
+
+func newRateLimitTracker() *rateLimitTracker {
+	return &rateLimitTracker{
+		limits: map[string]*GitRateLimit{},
+		now:    time.Now,
+	}
+}
+
+// update records the latest rate limit of its resource
+func (t *rateLimitTracker) update(limit *GitRateLimit) {
+	if limit == nil {
+		return
+	}
+	value := *limit
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.limits[limit.Resource] = &value
+}
+
+// exhausted returns the rate limit of the resource if there are no more than minRemaining requests left before it
+// is reset or nil if requests can be made
+func (t *rateLimitTracker) exhausted(resource string, minRemaining int) *GitRateLimit {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	limit := t.limits[resource]
+	if limit == nil || limit.Remaining > minRemaining || !t.now().Before(limit.Reset) {
+		return nil
+	}
+	value := *limit
+	return &value
+}
+
+// rateLimits returns the latest rate limits sorted by resource
+func (t *rateLimitTracker) rateLimits() []*GitRateLimit {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	answer := []*GitRateLimit{}
+	for _, limit := range t.limits {
+		value := *limit
+		answer = append(answer, &value)
+	}
+	sort.Slice(answer, func(i, j int) bool {
+		return answer[i].Resource < answer[j].Resource
+	})
+	return answer
+}
@@ -0,0 +1,208 @@
+package gits
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+// ErrTagNotSigned is returned when verifying a tag which has no signature
+var ErrTagNotSigned = errors.New("tag is not signed")
+
+// ReadKeyRing reads an armored or binary GPG keyring such as the pubring.gpg of a GPG home directory
+func ReadKeyRing(data []byte) (openpgp.EntityList, error) {
+	if _, err := armor.Decode(bytes.NewReader(data)); err == nil {
+		return openpgp.ReadArmoredKeyRing(bytes.NewReader(data))
+	}
+	return openpgp.ReadKeyRing(bytes.NewReader(data))
+}
+
+// ReadSigningKey reads the private key used to sign tags from an armored or binary keyring, decrypting it with the
+// passphrase if it is encrypted. If the keyID is specified the key is the one whose ID or identity matches it,
+// otherwise it is the first private key of the keyring
+func ReadSigningKey(data []byte, passphrase string, keyID string) (*openpgp.Entity, error) {
+	keyring, err := ReadKeyRing(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading the GPG signing key")
+	}
+	for _, key := range keyring {
+		if key.PrivateKey == nil || (keyID != "" && !keyMatches(key, keyID)) {
+			continue
+		}
+		err = decryptKey(key, passphrase)
+		if err != nil {
+			return nil, err
+		}
+		return key, nil
+	}
+	if keyID != "" {
+		return nil, errors.Errorf("no GPG private key %s could be found", keyID)
+	}
+	return nil, errors.New("no GPG private key could be found")
+}
+
+// KeyName returns the name of the key, which is the name of its first identity or its key ID
+func KeyName(key *openpgp.Entity) string {
+	if key == nil {
+		return ""
+	}
+	for name := range key.Identities {
+		return name
+	}
+	return key.PrimaryKey.KeyIdString()
+}
+
+func keyMatches(key *openpgp.Entity, keyID string) bool {
+	keyID = strings.ToUpper(strings.TrimPrefix(keyID, "0x"))
+	if key.PrimaryKey.KeyIdString() == keyID || key.PrimaryKey.KeyIdShortString() == keyID {
+		return true
+	}
+	for name := range key.Identities {
+		if strings.Contains(strings.ToUpper(name), keyID) {
+			return true
+		}
+	}
+	return false
+}
+
+func decryptKey(key *openpgp.Entity, passphrase string) error {
+	if key.PrivateKey.Encrypted {
+		err := key.PrivateKey.Decrypt([]byte(passphrase))
+		if err != nil {
+			return errors.Wrapf(err, "decrypting the GPG key %s", KeyName(key))
+		}
+	}
+	for _, subkey := range key.Subkeys {
+		if subkey.PrivateKey != nil && subkey.PrivateKey.Encrypted {
+			err := subkey.PrivateKey.Decrypt([]byte(passphrase))
+			if err != nil {
+				return errors.Wrapf(err, "decrypting a subkey of the GPG key %s", KeyName(key))
+			}
+		}
+	}
+	return nil
+}
+
+// taggerForKey returns the tagger of the signing key for when there is no git user configured
+func taggerForKey(signer *openpgp.Entity) *object.Signature {
+	for _, identity := range signer.Identities {
+		if identity.UserId != nil && identity.UserId.Email != "" {
+			return &object.Signature{
+				Name:  identity.UserId.Name,
+				Email: identity.UserId.Email,
+				When:  time.Now(),
+			}
+		}
+	}
+	return nil
+}
+
+// writeTag writes an annotated tag of HEAD, signing it if there is a signer, replacing any existing tag with the
+// same name. Like git the signature is appended to the encoded tag so the object is built from raw bytes rather than
+// with Tag.Encode whose handling of signatures differs between go-git versions
+func writeTag(repo *git.Repository, name string, msg string, tagger *object.Signature, signer *openpgp.Entity) error {
+	head, err := repo.Head()
+	if err != nil {
+		return err
+	}
+	if !strings.HasSuffix(msg, "\n") {
+		// the signature is appended to the message
+		msg += "\n"
+	}
+	tag := &object.Tag{
+		Name:       name,
+		Tagger:     *tagger,
+		Message:    msg,
+		TargetType: plumbing.CommitObject,
+		Target:     head.Hash(),
+	}
+	payload := &plumbing.MemoryObject{}
+	err = tag.Encode(payload)
+	if err != nil {
+		return errors.Wrapf(err, "encoding tag %s", name)
+	}
+	data, err := readObject(payload)
+	if err != nil {
+		return errors.Wrapf(err, "encoding tag %s", name)
+	}
+	if signer != nil {
+		var signature bytes.Buffer
+		err = openpgp.ArmoredDetachSign(&signature, signer, bytes.NewReader(data), nil)
+		if err != nil {
+			return errors.Wrapf(err, "signing tag %s with GPG key %s", name, KeyName(signer))
+		}
+		data = append(data, signature.Bytes()...)
+		if !bytes.HasSuffix(data, []byte("\n")) {
+			data = append(data, '\n')
+		}
+	}
+	encoded := repo.Storer.NewEncodedObject()
+	encoded.SetType(plumbing.TagObject)
+	w, err := encoded.Writer()
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	if err == nil {
+		err = w.Close()
+	}
+	if err != nil {
+		return errors.Wrapf(err, "encoding tag %s", name)
+	}
+	hash, err := repo.Storer.SetEncodedObject(encoded)
+	if err != nil {
+		return errors.Wrapf(err, "storing tag %s", name)
+	}
+	return repo.Storer.SetReference(plumbing.NewHashReference(tagReferenceName(name), hash))
+}
+
+// verifyTag checks the signature of the annotated tag against the keyring returning the key which signed it. As git
+// does, the signed payload is the raw tag object up to the signature
+func verifyTag(repo *git.Repository, name string, keyring openpgp.EntityList) (*openpgp.Entity, error) {
+	ref, err := repo.Reference(tagReferenceName(name), false)
+	if err != nil {
+		return nil, errors.Wrapf(err, "finding tag %s", name)
+	}
+	obj, err := repo.Storer.EncodedObject(plumbing.TagObject, ref.Hash())
+	if err == plumbing.ErrObjectNotFound {
+		// lightweight tags cannot be signed
+		return nil, errors.Wrapf(ErrTagNotSigned, "tag %s", name)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading tag %s", name)
+	}
+	data, err := readObject(obj)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading tag %s", name)
+	}
+	// the signature starts on its own line after the message
+	idx := bytes.Index(data, []byte("\n"+pgpSignatureBegin))
+	if idx < 0 {
+		return nil, errors.Wrapf(ErrTagNotSigned, "tag %s", name)
+	}
+	idx++
+	signer, err := openpgp.CheckArmoredDetachedSignature(keyring, bytes.NewReader(data[0:idx]), bytes.NewReader(data[idx:]))
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid signature of tag %s", name)
+	}
+	return signer, nil
+}
+
+const pgpSignatureBegin = "-----BEGIN PGP SIGNATURE-----"
+
+func readObject(obj plumbing.EncodedObject) ([]byte, error) {
+	reader, err := obj.Reader()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return ioutil.ReadAll(reader)
+}
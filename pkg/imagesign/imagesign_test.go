@@ -0,0 +1,216 @@
+package imagesign_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/imagesign"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/openpgp"
+)
+
+// fakeRegistry an in memory registry which requires a bearer token
+type fakeRegistry struct {
+	sync.Mutex
+	server    *httptest.Server
+	blobs     map[string][]byte
+	manifests map[string][]byte
+	uploads   int
+}
+
+func newFakeRegistry() *fakeRegistry {
+	r := &fakeRegistry{
+		blobs:     map[string][]byte{},
+		manifests: map[string][]byte{},
+	}
+	r.server = httptest.NewServer(r)
+	return r
+}
+
+func (r *fakeRegistry) host() string {
+	u, _ := url.Parse(r.server.URL)
+	return u.Host
+}
+
+func (r *fakeRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.Lock()
+	defer r.Unlock()
+	if req.URL.Path == "/token" {
+		user, password, _ := req.BasicAuth()
+		if user != "jenkins" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"token":"abc"}`)
+		return
+	}
+	if req.Header.Get("Authorization") != "Bearer abc" {
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="fake"`, r.server.URL))
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	body, _ := ioutil.ReadAll(req.Body)
+	path := strings.TrimPrefix(req.URL.Path, "/v2/")
+	switch {
+	case strings.HasSuffix(path, "/blobs/uploads/") && req.Method == http.MethodPost:
+		r.uploads++
+		w.Header().Set("Location", fmt.Sprintf("/upload/%d?state=x", r.uploads))
+		w.WriteHeader(http.StatusAccepted)
+	case strings.HasPrefix(req.URL.Path, "/upload/") && req.Method == http.MethodPut:
+		digest := req.URL.Query().Get("digest")
+		if digest != imagesign.Digest(body) || req.URL.Query().Get("state") != "x" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		r.blobs[digest] = body
+		w.WriteHeader(http.StatusCreated)
+	case strings.Contains(path, "/blobs/"):
+		data, ok := r.blobs[path[strings.LastIndex(path, "/")+1:]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if req.Method == http.MethodGet {
+			w.Write(data)
+		}
+	case strings.Contains(path, "/manifests/"):
+		if req.Method == http.MethodPut {
+			r.putManifest(path, body)
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		data, ok := r.manifests[path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", imagesign.MediaTypeOCIManifest)
+		w.Header().Set("Docker-Content-Digest", imagesign.Digest(data))
+		if req.Method == http.MethodGet {
+			w.Write(data)
+		}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (r *fakeRegistry) putManifest(path string, data []byte) {
+	r.manifests[path] = data
+	r.manifests[path[0:strings.LastIndex(path, "/")+1]+imagesign.Digest(data)] = data
+}
+
+func TestSignAndVerify(t *testing.T) {
+	t.Parallel()
+	fake := newFakeRegistry()
+	defer fake.server.Close()
+	fake.putManifest("myorg/myapp/manifests/1.0.0", []byte(`{"schemaVersion":2,"layers":[]}`))
+
+	signer, err := openpgp.NewEntity("Jenkins X", "", "jenkins-x@googlegroups.com", nil)
+	require.NoError(t, err)
+	other, err := openpgp.NewEntity("Someone Else", "", "someone@example.com", nil)
+	require.NoError(t, err)
+
+	registry := &imagesign.Registry{
+		HTTPClient: fake.server.Client(),
+		Credentials: func(registry string) (string, string) {
+			return "jenkins", "secret"
+		},
+		InsecureRegistries: []string{fake.host()},
+	}
+	ref, err := imagesign.ParseReference(fake.host() + "/myorg/myapp:1.0.0")
+	require.NoError(t, err)
+
+	_, err = imagesign.Verify(registry, ref, openpgp.EntityList{signer})
+	assert.Equal(t, imagesign.ErrNotSigned, err)
+
+	_, err = imagesign.Sign(registry, ref, other, nil)
+	require.NoError(t, err)
+	_, err = imagesign.Sign(registry, ref, signer, map[string]string{"pipeline": "myorg/myapp/master"})
+	require.NoError(t, err)
+	sigRef, err := imagesign.Sign(registry, ref, signer, map[string]string{"pipeline": "myorg/myapp/master"})
+	require.NoError(t, err)
+
+	digest := imagesign.Digest([]byte(`{"schemaVersion":2,"layers":[]}`))
+	assert.Equal(t, imagesign.SignatureTag(digest), sigRef.Tag)
+	manifest := &imagesign.Manifest{}
+	require.NoError(t, json.Unmarshal(fake.manifests["myorg/myapp/manifests/"+sigRef.Tag], manifest))
+	assert.Len(t, manifest.Layers, 2, "re-signing replaces the signature of the same key")
+
+	signature, err := imagesign.Verify(registry, ref, openpgp.EntityList{signer})
+	require.NoError(t, err)
+	assert.Equal(t, signer.PrimaryKey.KeyId, signature.Signer.PrimaryKey.KeyId)
+	assert.Equal(t, digest, signature.Digest)
+	assert.Equal(t, "myorg/myapp/master", signature.Payload.Optional["pipeline"])
+
+	ref.Tag = ""
+	ref.Digest = digest
+	_, err = imagesign.Verify(registry, ref, openpgp.EntityList{signer})
+	require.NoError(t, err, "verify by digest")
+
+	untrusted, err := openpgp.NewEntity("Untrusted", "", "untrusted@example.com", nil)
+	require.NoError(t, err)
+	_, err = imagesign.Verify(registry, ref, openpgp.EntityList{untrusted})
+	assert.Error(t, err)
+	assert.NotEqual(t, imagesign.ErrNotSigned, err)
+
+	// a signature copied to another repository is not valid for it
+	otherRef, err := imagesign.ParseReference(fake.host() + "/myorg/other@" + digest)
+	require.NoError(t, err)
+	fake.putManifest("myorg/other/manifests/"+sigRef.Tag, fake.manifests["myorg/myapp/manifests/"+sigRef.Tag])
+	_, err = imagesign.Verify(registry, otherRef, openpgp.EntityList{signer})
+	assert.Error(t, err)
+
+	anonymous := &imagesign.Registry{
+		HTTPClient:         fake.server.Client(),
+		InsecureRegistries: []string{fake.host()},
+	}
+	_, err = imagesign.Verify(anonymous, ref, openpgp.EntityList{signer})
+	assert.Error(t, err, "no credentials")
+}
+
+func TestParseReference(t *testing.T) {
+	t.Parallel()
+	digest := "sha256:1111111111111111111111111111111111111111111111111111111111111111"
+	testCases := []struct {
+		image    string
+		expected imagesign.Reference
+		name     string
+	}{
+		{"nginx", imagesign.Reference{Registry: imagesign.DockerHubRegistry, Repository: "library/nginx", Tag: "latest"}, "index.docker.io/library/nginx"},
+		{"jenkinsxio/jx:1.2.3", imagesign.Reference{Registry: imagesign.DockerHubRegistry, Repository: "jenkinsxio/jx", Tag: "1.2.3"}, "index.docker.io/jenkinsxio/jx"},
+		{"gcr.io/myorg/myapp:0.0.1@" + digest, imagesign.Reference{Registry: "gcr.io", Repository: "myorg/myapp", Tag: "0.0.1", Digest: digest}, "gcr.io/myorg/myapp"},
+		{"10.0.0.1:5000/myorg/myapp@" + digest, imagesign.Reference{Registry: "10.0.0.1:5000", Repository: "myorg/myapp", Digest: digest}, "10.0.0.1:5000/myorg/myapp"},
+		{"localhost/myapp", imagesign.Reference{Registry: "localhost", Repository: "myapp", Tag: "latest"}, "localhost/myapp"},
+	}
+	for _, tc := range testCases {
+		ref, err := imagesign.ParseReference(tc.image)
+		require.NoError(t, err, tc.image)
+		assert.Equal(t, tc.expected, *ref, tc.image)
+		assert.Equal(t, tc.name, ref.Name(), tc.image)
+	}
+	for _, image := range []string{"", "myorg/MyApp", "myapp@md5:1234", "myapp@sha256:"} {
+		_, err := imagesign.ParseReference(image)
+		assert.Error(t, err, image)
+	}
+	assert.Equal(t, "sha256-1234.sig", imagesign.SignatureTag("sha256:1234"))
+}
+
+func TestManifestImages(t *testing.T) {
+	t.Parallel()
+	images, err := imagesign.DirManifestImages("test_data")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"busybox:1.30",
+		"gcr.io/myorg/myapp:0.0.1",
+		"gcr.io/myorg/worker:0.0.1",
+		"nginx",
+	}, images)
+}
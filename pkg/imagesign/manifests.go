@@ -0,0 +1,98 @@
+package imagesign
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// ManifestImages returns the sorted images of the containers and init containers of the pod templates of the
+// Kubernetes resources in the YAML documents
+func ManifestImages(data []byte) ([]string, error) {
+	images := map[string]bool{}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var doc interface{}
+		err := decoder.Decode(&doc)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse the YAML")
+		}
+		addContainerImages(doc, images)
+	}
+	return sortedKeys(images), nil
+}
+
+// DirManifestImages returns the sorted images of the Kubernetes resources in the YAML files of the directory tree
+// such as the output of 'helm template'
+func DirManifestImages(dir string) ([]string, error) {
+	images := map[string]bool{}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !(strings.HasSuffix(path, ".yaml") || strings.HasSuffix(path, ".yml")) {
+			return nil
+		}
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		fileImages, err := ManifestImages(data)
+		if err != nil {
+			return errors.Wrapf(err, "in file %s", path)
+		}
+		for _, image := range fileImages {
+			images[image] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sortedKeys(images), nil
+}
+
+// addContainerImages walks the YAML tree adding the images of any containers or init containers
+func addContainerImages(node interface{}, images map[string]bool) {
+	switch n := node.(type) {
+	case map[interface{}]interface{}:
+		for k, v := range n {
+			key, _ := k.(string)
+			if key == "containers" || key == "initContainers" {
+				containers, _ := v.([]interface{})
+				for _, c := range containers {
+					container, _ := c.(map[interface{}]interface{})
+					image, _ := container["image"].(string)
+					if strings.TrimSpace(image) != "" {
+						images[strings.TrimSpace(image)] = true
+					}
+				}
+				continue
+			}
+			addContainerImages(v, images)
+		}
+	case []interface{}:
+		for _, v := range n {
+			addContainerImages(v, images)
+		}
+	}
+}
+
+func sortedKeys(m map[string]bool) []string {
+	answer := []string{}
+	for k := range m {
+		answer = append(answer, k)
+	}
+	sort.Strings(answer)
+	return answer
+}
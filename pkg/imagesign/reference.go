@@ -0,0 +1,104 @@
+package imagesign
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// DockerHubRegistry the name of the Docker Hub registry
+	DockerHubRegistry = "index.docker.io"
+
+	dockerHubHost      = "registry-1.docker.io"
+	dockerHubAuthKey   = "https://index.docker.io/v1/"
+	dockerHubLibrary   = "library/"
+	digestAlgorithm    = "sha256"
+	defaultTag         = "latest"
+	signatureTagSuffix = ".sig"
+)
+
+// Reference a reference to an image in a registry such as 'gcr.io/org/app:1.0.0@sha256:abc...'
+type Reference struct {
+	Registry   string
+	Repository string
+	Tag        string
+	// Digest the digest of the image manifest including the algorithm such as 'sha256:abc...'
+	Digest string
+}
+
+// ParseReference parses an image reference defaulting the registry to Docker Hub
+func ParseReference(image string) (*Reference, error) {
+	text := strings.TrimSpace(image)
+	if text == "" {
+		return nil, fmt.Errorf("no image specified")
+	}
+	ref := &Reference{}
+	if idx := strings.Index(text, "@"); idx >= 0 {
+		ref.Digest = text[idx+1:]
+		text = text[0:idx]
+		if !strings.HasPrefix(ref.Digest, digestAlgorithm+":") || len(ref.Digest) == len(digestAlgorithm)+1 {
+			return nil, fmt.Errorf("invalid digest in image %s. Only %s digests are supported", image, digestAlgorithm)
+		}
+	}
+	// the tag follows the last path element so lets not confuse the port of a registry with a tag
+	if idx := strings.LastIndex(text, ":"); idx > strings.LastIndex(text, "/") {
+		ref.Tag = text[idx+1:]
+		text = text[0:idx]
+	}
+	parts := strings.SplitN(text, "/", 2)
+	if len(parts) == 2 && (strings.ContainsAny(parts[0], ".:") || parts[0] == "localhost") {
+		ref.Registry = parts[0]
+		ref.Repository = parts[1]
+	} else {
+		ref.Registry = DockerHubRegistry
+		ref.Repository = text
+	}
+	if ref.Registry == DockerHubRegistry && !strings.Contains(ref.Repository, "/") {
+		ref.Repository = dockerHubLibrary + ref.Repository
+	}
+	if ref.Repository == "" || ref.Repository != strings.ToLower(ref.Repository) {
+		return nil, fmt.Errorf("invalid repository in image %s", image)
+	}
+	if ref.Tag == "" && ref.Digest == "" {
+		ref.Tag = defaultTag
+	}
+	return ref, nil
+}
+
+// Name returns the registry and repository of the image without the tag or digest
+func (r *Reference) Name() string {
+	return r.Registry + "/" + r.Repository
+}
+
+// String returns the full reference of the image
+func (r *Reference) String() string {
+	answer := r.Name()
+	if r.Tag != "" {
+		answer += ":" + r.Tag
+	}
+	if r.Digest != "" {
+		answer += "@" + r.Digest
+	}
+	return answer
+}
+
+// Host returns the host of the registry API
+func (r *Reference) Host() string {
+	if r.Registry == DockerHubRegistry {
+		return dockerHubHost
+	}
+	return r.Registry
+}
+
+// manifestReference returns the digest or tag used to look up the image manifest
+func (r *Reference) manifestReference() string {
+	if r.Digest != "" {
+		return r.Digest
+	}
+	return r.Tag
+}
+
+// SignatureTag returns the tag of the signatures of the image with the digest such as 'sha256-abc....sig'
+func SignatureTag(digest string) string {
+	return strings.Replace(digest, ":", "-", 1) + signatureTagSuffix
+}
@@ -0,0 +1,405 @@
+package imagesign
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+)
+
+const (
+	// MediaTypeOCIManifest the media type of OCI image manifests
+	MediaTypeOCIManifest = "application/vnd.oci.image.manifest.v1+json"
+	// MediaTypeOCIIndex the media type of OCI image indexes
+	MediaTypeOCIIndex = "application/vnd.oci.image.index.v1+json"
+	// MediaTypeDockerManifest the media type of Docker image manifests
+	MediaTypeDockerManifest = "application/vnd.docker.distribution.manifest.v2+json"
+	// MediaTypeDockerManifestList the media type of Docker manifest lists
+	MediaTypeDockerManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+
+	headerContentDigest = "Docker-Content-Digest"
+)
+
+var (
+	// ErrNotFound is returned when a manifest or blob does not exist in the registry
+	ErrNotFound = errors.New("not found")
+
+	manifestMediaTypes = []string{MediaTypeOCIManifest, MediaTypeOCIIndex, MediaTypeDockerManifest, MediaTypeDockerManifestList}
+)
+
+// Credentials returns the user name and password of a registry host or empty strings for anonymous access
+type Credentials func(registry string) (string, string)
+
+// Registry a client of the Docker Registry HTTP API V2 which is implemented by most container registries
+type Registry struct {
+	HTTPClient  *http.Client
+	Credentials Credentials
+	// InsecureRegistries the registries which are accessed using plain HTTP
+	InsecureRegistries []string
+
+	tokens map[string]string
+}
+
+// NewRegistry creates a registry client which uses the credentials of the Docker config file
+func NewRegistry(insecureRegistries []string) *Registry {
+	return &Registry{
+		HTTPClient:         &http.Client{Timeout: 2 * time.Minute},
+		Credentials:        DockerConfigCredentials(""),
+		InsecureRegistries: insecureRegistries,
+	}
+}
+
+// Descriptor describes a blob or manifest in a registry
+type Descriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Size        int64             `json:"size"`
+	Digest      string            `json:"digest"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Manifest an OCI image manifest
+type Manifest struct {
+	SchemaVersion int               `json:"schemaVersion"`
+	MediaType     string            `json:"mediaType,omitempty"`
+	Config        Descriptor        `json:"config"`
+	Layers        []Descriptor      `json:"layers"`
+	Annotations   map[string]string `json:"annotations,omitempty"`
+}
+
+// Digest returns the sha256 digest of the data such as 'sha256:abc...'
+func Digest(data []byte) string {
+	hash := sha256.Sum256(data)
+	return digestAlgorithm + ":" + hex.EncodeToString(hash[:])
+}
+
+// ResolveDigest returns the digest of the manifest of the image, which is the digest of the reference if it has one
+func (r *Registry) ResolveDigest(ref *Reference) (string, error) {
+	if ref.Digest != "" {
+		return ref.Digest, nil
+	}
+	u := r.url(ref, "manifests", ref.Tag)
+	resp, err := r.do(ref, http.MethodHead, u, map[string]string{"Accept": strings.Join(manifestMediaTypes, ", ")}, nil)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to find the digest of image %s", ref.String())
+	}
+	resp.Body.Close()
+	digest := resp.Header.Get(headerContentDigest)
+	if digest != "" {
+		return digest, nil
+	}
+	// some registries only return the digest for GET requests so lets calculate it
+	data, _, err := r.GetManifest(ref, ref.Tag)
+	if err != nil {
+		return "", err
+	}
+	return Digest(data), nil
+}
+
+// GetManifest returns the manifest with the tag or digest in the repository of the image and its media type
+func (r *Registry) GetManifest(ref *Reference, tagOrDigest string) ([]byte, string, error) {
+	u := r.url(ref, "manifests", tagOrDigest)
+	resp, err := r.do(ref, http.MethodGet, u, map[string]string{"Accept": strings.Join(manifestMediaTypes, ", ")}, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "failed to read the manifest %s of %s", tagOrDigest, ref.Name())
+	}
+	if strings.HasPrefix(tagOrDigest, digestAlgorithm+":") && Digest(data) != tagOrDigest {
+		return nil, "", fmt.Errorf("the manifest of %s does not match its digest %s", ref.Name(), tagOrDigest)
+	}
+	return data, resp.Header.Get("Content-Type"), nil
+}
+
+// PutManifest pushes the manifest to the tag in the repository of the image returning its digest
+func (r *Registry) PutManifest(ref *Reference, tag string, mediaType string, data []byte) (string, error) {
+	u := r.url(ref, "manifests", tag)
+	resp, err := r.do(ref, http.MethodPut, u, map[string]string{"Content-Type": mediaType}, data)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to push the manifest %s of %s", tag, ref.Name())
+	}
+	resp.Body.Close()
+	return Digest(data), nil
+}
+
+// GetBlob returns the blob with the digest in the repository of the image
+func (r *Registry) GetBlob(ref *Reference, digest string) ([]byte, error) {
+	u := r.url(ref, "blobs", digest)
+	resp, err := r.do(ref, http.MethodGet, u, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read the blob %s of %s", digest, ref.Name())
+	}
+	if Digest(data) != digest {
+		return nil, fmt.Errorf("the blob of %s does not match its digest %s", ref.Name(), digest)
+	}
+	return data, nil
+}
+
+// PutBlob uploads the data to the repository of the image, unless it already exists, returning its descriptor
+func (r *Registry) PutBlob(ref *Reference, mediaType string, data []byte) (*Descriptor, error) {
+	descriptor := &Descriptor{
+		MediaType: mediaType,
+		Size:      int64(len(data)),
+		Digest:    Digest(data),
+	}
+	resp, err := r.do(ref, http.MethodHead, r.url(ref, "blobs", descriptor.Digest), nil, nil)
+	if err == nil {
+		resp.Body.Close()
+		return descriptor, nil
+	}
+	if err != ErrNotFound {
+		return nil, err
+	}
+
+	resp, err = r.do(ref, http.MethodPost, r.url(ref, "blobs", "uploads/"), nil, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to start the upload of a blob to %s", ref.Name())
+	}
+	resp.Body.Close()
+	location, err := resp.Request.URL.Parse(resp.Header.Get("Location"))
+	if err != nil || resp.Header.Get("Location") == "" {
+		return nil, fmt.Errorf("no valid upload location returned by %s", ref.Registry)
+	}
+	values := location.Query()
+	values.Set("digest", descriptor.Digest)
+	location.RawQuery = values.Encode()
+	resp, err = r.do(ref, http.MethodPut, location.String(), map[string]string{"Content-Type": "application/octet-stream"}, data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to upload the blob %s to %s", descriptor.Digest, ref.Name())
+	}
+	resp.Body.Close()
+	return descriptor, nil
+}
+
+func (r *Registry) url(ref *Reference, kind string, name string) string {
+	scheme := "https"
+	if util.StringArrayIndex(r.InsecureRegistries, ref.Registry) >= 0 {
+		scheme = "http"
+	}
+	return fmt.Sprintf("%s://%s/v2/%s/%s/%s", scheme, ref.Host(), ref.Repository, kind, name)
+}
+
+// do performs the request authenticating with the registry if it is challenged. The response body must be closed
+// by the caller if there is no error
+func (r *Registry) do(ref *Reference, method string, u string, headers map[string]string, body []byte) (*http.Response, error) {
+	resp, err := r.send(method, u, headers, body, r.tokens[ref.Name()])
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+		authorization, err := r.authorize(ref, challenge)
+		if err != nil {
+			return nil, err
+		}
+		if r.tokens == nil {
+			r.tokens = map[string]string{}
+		}
+		r.tokens[ref.Name()] = authorization
+		resp, err = r.send(method, u, headers, body, authorization)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		data, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, fmt.Errorf("%s %s returned status %s: %s", method, u, resp.Status, strings.TrimSpace(string(data)))
+	}
+	return resp, nil
+}
+
+func (r *Registry) send(method string, u string, headers map[string]string, body []byte, authorization string) (*http.Response, error) {
+	req, err := http.NewRequest(method, u, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	client := r.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return client.Do(req)
+}
+
+// authorize returns the authorization header answering the challenge of the registry
+func (r *Registry) authorize(ref *Reference, challenge string) (string, error) {
+	username, password := "", ""
+	if r.Credentials != nil {
+		username, password = r.Credentials(ref.Registry)
+	}
+	scheme, params := parseChallenge(challenge)
+	switch scheme {
+	case "basic":
+		if username == "" {
+			return "", fmt.Errorf("no credentials found for registry %s", ref.Registry)
+		}
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password)), nil
+	case "bearer":
+		realm, err := url.Parse(params["realm"])
+		if err != nil || params["realm"] == "" {
+			return "", fmt.Errorf("invalid authentication challenge from registry %s: %s", ref.Registry, challenge)
+		}
+		values := realm.Query()
+		if params["service"] != "" {
+			values.Set("service", params["service"])
+		}
+		scope := params["scope"]
+		if scope == "" {
+			scope = "repository:" + ref.Repository + ":pull"
+		}
+		values.Set("scope", scope)
+		realm.RawQuery = values.Encode()
+		req, err := http.NewRequest(http.MethodGet, realm.String(), nil)
+		if err != nil {
+			return "", err
+		}
+		if username != "" {
+			req.SetBasicAuth(username, password)
+		}
+		client := r.HTTPClient
+		if client == nil {
+			client = http.DefaultClient
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to get a token for registry %s", ref.Registry)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return "", fmt.Errorf("failed to get a token for registry %s: %s", ref.Registry, resp.Status)
+		}
+		token := struct {
+			Token       string `json:"token"`
+			AccessToken string `json:"access_token"`
+		}{}
+		err = json.NewDecoder(resp.Body).Decode(&token)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to parse the token of registry %s", ref.Registry)
+		}
+		if token.Token == "" {
+			token.Token = token.AccessToken
+		}
+		return "Bearer " + token.Token, nil
+	}
+	return "", fmt.Errorf("unsupported authentication challenge from registry %s: %s", ref.Registry, challenge)
+}
+
+// parseChallenge parses a WWW-Authenticate header such as 'Bearer realm="https://auth.docker.io/token",service="registry.docker.io"'
+func parseChallenge(challenge string) (string, map[string]string) {
+	params := map[string]string{}
+	parts := strings.SplitN(strings.TrimSpace(challenge), " ", 2)
+	scheme := strings.ToLower(parts[0])
+	if len(parts) < 2 {
+		return scheme, params
+	}
+	text := parts[1]
+	for text != "" {
+		idx := strings.Index(text, "=")
+		if idx < 0 {
+			break
+		}
+		key := strings.ToLower(strings.TrimSpace(text[0:idx]))
+		text = text[idx+1:]
+		value := ""
+		if strings.HasPrefix(text, "\"") {
+			end := strings.Index(text[1:], "\"")
+			if end < 0 {
+				value = text[1:]
+				text = ""
+			} else {
+				value = text[1 : end+1]
+				text = text[end+2:]
+			}
+		} else {
+			end := strings.Index(text, ",")
+			if end < 0 {
+				end = len(text)
+			}
+			value = text[0:end]
+			text = text[end:]
+		}
+		params[key] = value
+		text = strings.TrimPrefix(strings.TrimSpace(text), ",")
+	}
+	return scheme, params
+}
+
+// DockerConfigCredentials returns the credentials of the auths of the Docker config file which defaults to
+// '$DOCKER_CONFIG/config.json' or '~/.docker/config.json'
+func DockerConfigCredentials(configFile string) Credentials {
+	if configFile == "" {
+		dir := os.Getenv("DOCKER_CONFIG")
+		if dir == "" {
+			dir = filepath.Join(util.HomeDir(), ".docker")
+		}
+		configFile = filepath.Join(dir, "config.json")
+	}
+	return func(registry string) (string, string) {
+		data, err := ioutil.ReadFile(configFile)
+		if err != nil {
+			return "", ""
+		}
+		config := struct {
+			Auths map[string]struct {
+				Auth     string `json:"auth"`
+				Username string `json:"username"`
+				Password string `json:"password"`
+			} `json:"auths"`
+		}{}
+		err = json.Unmarshal(data, &config)
+		if err != nil {
+			return "", ""
+		}
+		keys := []string{registry, "https://" + registry, "http://" + registry, "https://" + registry + "/v1/"}
+		if registry == DockerHubRegistry {
+			keys = append(keys, dockerHubAuthKey)
+		}
+		for _, key := range keys {
+			auth, ok := config.Auths[key]
+			if !ok {
+				continue
+			}
+			if auth.Auth != "" {
+				decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+				if err == nil {
+					parts := strings.SplitN(string(decoded), ":", 2)
+					if len(parts) == 2 {
+						return parts[0], parts[1]
+					}
+				}
+			}
+			return auth.Username, auth.Password
+		}
+		return "", ""
+	}
+}
@@ -0,0 +1,246 @@
+package imagesign
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+	"golang.org/x/crypto/openpgp/packet"
+)
+
+const (
+	// SignatureType the type of the simple signing payload of container image signatures
+	SignatureType = "atomic container signature"
+	// MediaTypePayload the media type of the layers of signature manifests which contain the signed payload
+	MediaTypePayload = "application/vnd.dev.cosign.simplesigning.v1+json"
+	// MediaTypeConfig the media type of the config of signature manifests
+	MediaTypeConfig = "application/vnd.oci.image.config.v1+json"
+	// AnnotationSignature the annotation of a payload layer which contains the base64 encoded armored GPG signature
+	AnnotationSignature = "jenkins.io/gpg-signature"
+)
+
+var (
+	// ErrNotSigned is returned when an image has no signatures
+	ErrNotSigned = errors.New("the image has no signatures")
+)
+
+// Payload the simple signing payload which is signed to sign an image digest
+type Payload struct {
+	Critical Critical          `json:"critical"`
+	Optional map[string]string `json:"optional,omitempty"`
+}
+
+// Critical the signed identity and digest of the image
+type Critical struct {
+	Identity Identity `json:"identity"`
+	Image    Image    `json:"image"`
+	Type     string   `json:"type"`
+}
+
+// Identity the name of the signed image
+type Identity struct {
+	DockerReference string `json:"docker-reference"`
+}
+
+// Image the digest of the signed image manifest
+type Image struct {
+	DockerManifestDigest string `json:"docker-manifest-digest"`
+}
+
+// Signature a valid signature of an image
+type Signature struct {
+	Digest  string
+	Payload *Payload
+	Signer  *openpgp.Entity
+}
+
+// Sign signs the digest of the image and pushes the signature to the signature tag of the image repository, keeping
+// any existing signatures of other keys. The optional annotations are included in the signed payload
+func Sign(registry *Registry, ref *Reference, signer *openpgp.Entity, annotations map[string]string) (*Reference, error) {
+	digest, err := registry.ResolveDigest(ref)
+	if err != nil {
+		return nil, err
+	}
+	payload := &Payload{
+		Critical: Critical{
+			Identity: Identity{
+				DockerReference: ref.Name(),
+			},
+			Image: Image{
+				DockerManifestDigest: digest,
+			},
+			Type: SignatureType,
+		},
+		Optional: annotations,
+	}
+	payloadData, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	var buffer bytes.Buffer
+	err = openpgp.ArmoredDetachSign(&buffer, signer, bytes.NewReader(payloadData), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign the image digest")
+	}
+
+	sigRef := &Reference{
+		Registry:   ref.Registry,
+		Repository: ref.Repository,
+		Tag:        SignatureTag(digest),
+	}
+	manifest, err := getSignatureManifest(registry, sigRef)
+	if err != nil && err != ErrNotFound {
+		return nil, err
+	}
+	if manifest == nil {
+		config, err := registry.PutBlob(sigRef, MediaTypeConfig, []byte("{}"))
+		if err != nil {
+			return nil, err
+		}
+		manifest = &Manifest{
+			SchemaVersion: 2,
+			MediaType:     MediaTypeOCIManifest,
+			Config:        *config,
+		}
+	}
+	layer, err := registry.PutBlob(sigRef, MediaTypePayload, payloadData)
+	if err != nil {
+		return nil, err
+	}
+	layer.Annotations = map[string]string{
+		AnnotationSignature: base64.StdEncoding.EncodeToString(buffer.Bytes()),
+	}
+	manifest.Layers = append(removeSignaturesOf(manifest.Layers, signer), *layer)
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, err
+	}
+	sigRef.Digest, err = registry.PutManifest(sigRef, sigRef.Tag, MediaTypeOCIManifest, data)
+	if err != nil {
+		return nil, err
+	}
+	return sigRef, nil
+}
+
+// Verify returns the first signature of the image by one of the keys of the keyring whose payload matches the
+// repository and digest of the image. ErrNotSigned is returned if the image has no signatures
+func Verify(registry *Registry, ref *Reference, keyring openpgp.EntityList) (*Signature, error) {
+	digest, err := registry.ResolveDigest(ref)
+	if err != nil {
+		return nil, err
+	}
+	sigRef := &Reference{
+		Registry:   ref.Registry,
+		Repository: ref.Repository,
+		Tag:        SignatureTag(digest),
+	}
+	manifest, err := getSignatureManifest(registry, sigRef)
+	if err == ErrNotFound {
+		return nil, ErrNotSigned
+	}
+	if err != nil {
+		return nil, err
+	}
+	failures := []string{}
+	for _, layer := range manifest.Layers {
+		encoded := layer.Annotations[AnnotationSignature]
+		if layer.MediaType != MediaTypePayload || encoded == "" {
+			continue
+		}
+		signature, err := verifyLayer(registry, sigRef, layer, encoded, keyring)
+		if err == nil {
+			if signature.Payload.Critical.Image.DockerManifestDigest != digest {
+				err = fmt.Errorf("signed digest %s is not %s", signature.Payload.Critical.Image.DockerManifestDigest, digest)
+			} else if signature.Payload.Critical.Identity.DockerReference != ref.Name() {
+				err = fmt.Errorf("signed image %s is not %s", signature.Payload.Critical.Identity.DockerReference, ref.Name())
+			} else {
+				signature.Digest = digest
+				return signature, nil
+			}
+		}
+		failures = append(failures, err.Error())
+	}
+	if len(failures) == 0 {
+		return nil, ErrNotSigned
+	}
+	return nil, fmt.Errorf("no valid signature of %s@%s: %s", ref.Name(), digest, strings.Join(failures, ", "))
+}
+
+func verifyLayer(registry *Registry, sigRef *Reference, layer Descriptor, encoded string, keyring openpgp.EntityList) (*Signature, error) {
+	armored, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid signature encoding")
+	}
+	data, err := registry.GetBlob(sigRef, layer.Digest)
+	if err != nil {
+		return nil, err
+	}
+	signer, err := openpgp.CheckArmoredDetachedSignature(keyring, bytes.NewReader(data), bytes.NewReader(armored))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid signature")
+	}
+	payload := &Payload{}
+	err = json.Unmarshal(data, payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid signed payload")
+	}
+	if payload.Critical.Type != SignatureType {
+		return nil, fmt.Errorf("unsupported signature type %s", payload.Critical.Type)
+	}
+	return &Signature{
+		Payload: payload,
+		Signer:  signer,
+	}, nil
+}
+
+func getSignatureManifest(registry *Registry, sigRef *Reference) (*Manifest, error) {
+	data, _, err := registry.GetManifest(sigRef, sigRef.Tag)
+	if err != nil {
+		return nil, err
+	}
+	manifest := &Manifest{}
+	err = json.Unmarshal(data, manifest)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the signature manifest %s", sigRef.String())
+	}
+	return manifest, nil
+}
+
+// removeSignaturesOf removes the layers signed by the key so that re-signing an image replaces its signature
+func removeSignaturesOf(layers []Descriptor, signer *openpgp.Entity) []Descriptor {
+	keyring := openpgp.EntityList{signer}
+	answer := []Descriptor{}
+	for _, layer := range layers {
+		armored, err := base64.StdEncoding.DecodeString(layer.Annotations[AnnotationSignature])
+		if err == nil && len(armored) > 0 {
+			issuer, err := signatureIssuer(armored)
+			if err == nil && len(keyring.KeysById(issuer)) > 0 {
+				continue
+			}
+		}
+		answer = append(answer, layer)
+	}
+	return answer
+}
+
+// signatureIssuer returns the ID of the key which made the armored signature
+func signatureIssuer(armored []byte) (uint64, error) {
+	block, err := armor.Decode(bytes.NewReader(armored))
+	if err != nil {
+		return 0, err
+	}
+	p, err := packet.Read(block.Body)
+	if err != nil {
+		return 0, err
+	}
+	sig, ok := p.(*packet.Signature)
+	if !ok || sig.IssuerKeyId == nil {
+		return 0, fmt.Errorf("no issuer found in the signature")
+	}
+	return *sig.IssuerKeyId, nil
+}
@@ -322,7 +322,7 @@ func (f *factory) AuthMergePipelineSecrets(config *auth.AuthConfig, secrets *cor
 	if config == nil || secrets == nil {
 		return nil
 	}
-	for _, secret := range secrets.Items {
+	for i, secret := range secrets.Items {
 		labels := secret.Labels
 		annotations := secret.Annotations
 		data := secret.Data
@@ -340,6 +340,19 @@ func (f *factory) AuthMergePipelineSecrets(config *auth.AuthConfig, secrets *cor
 					if name != "" {
 						server.Name = name
 					}
+					if data != nil && isCDPipeline {
+						appAuth, err := kube.GitHubAppSecretUserAuth(&secrets.Items[i])
+						if err != nil {
+							log.Warnf("Ignoring the GitHub App in pipeline credential %s: %s\n", secret.Name, err)
+						} else if appAuth != nil {
+							if appAuth.Username == "" {
+								appAuth.Username = appAuth.GitHubAppOwner
+							}
+							config.SetUserAuth(u, appAuth)
+							config.UpdatePipelineServer(server, appAuth)
+							continue
+						}
+					}
 					if data != nil {
 						username := data[kube.SecretDataUsername]
 						pwd := data[kube.SecretDataPassword]
@@ -106,6 +106,7 @@ func NewJXCommand(f clients.Factory, in terminal.FileReader, out terminal.FileWr
 	environmentsCommands := []*cobra.Command{
 		NewCmdPreview(commonOpts),
 		NewCmdPromote(commonOpts),
+		NewCmdRollback(commonOpts),
 	}
 	environmentsCommands = append(environmentsCommands, findCommands("environment", createCommands, deleteCommands, editCommands, getCommands)...)
 
@@ -44,7 +44,10 @@ func NewCmdController(commonOpts *opts.CommonOptions) *cobra.Command {
 	cmd.AddCommand(NewCmdControllerBackup(commonOpts))
 	cmd.AddCommand(NewCmdControllerBuild(commonOpts))
 	cmd.AddCommand(NewCmdControllerBuildNumbers(commonOpts))
+	cmd.AddCommand(NewCmdControllerChatNotifier(commonOpts))
 	cmd.AddCommand(NewCmdControllerEnvironment(commonOpts))
+	cmd.AddCommand(NewCmdControllerEventsServer(commonOpts))
+	cmd.AddCommand(NewCmdControllerMetricsExporter(commonOpts))
 	cmd.AddCommand(NewCmdControllerPipelineRunner(commonOpts))
 	cmd.AddCommand(NewCmdControllerRole(commonOpts))
 	cmd.AddCommand(NewCmdControllerTeam(commonOpts))
@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sync"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/chats"
+	"github.com/jenkins-x/jx/pkg/client/clientset/versioned"
+	"github.com/jenkins-x/jx/pkg/config"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/opts"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/notifications"
+	"github.com/jenkins-x/jx/pkg/users"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+)
+
+// ControllerChatNotifierOptions holds the options for the chat notification controller
+type ControllerChatNotifierOptions struct {
+	ControllerOptions
+
+	Branches []string
+
+	providersLock sync.Mutex
+	providers     map[string]chats.ChatProvider
+}
+
+var (
+	controllerChatNotifierLong = templates.LongDesc(`
+		Runs a controller which sends chat messages when pipelines fail or recover and when promotions are raised or merged.
+
+		The chat service and channels are configured in the 'chat' section of the jenkins-x.yml file of each repository.
+		Pipeline failures and recoveries are sent to the developer channel mentioning the authors of the commits.
+		Promotions are sent to the user channel if there is one, otherwise the developer channel.
+`)
+
+	controllerChatNotifierExample = templates.Examples(`
+		jx controller chat-notifier
+
+		# notify failures of the master and release branches
+		jx controller chat-notifier --branch master --branch release
+`)
+)
+
+// NewCmdControllerChatNotifier creates the command
+func NewCmdControllerChatNotifier(commonOpts *opts.CommonOptions) *cobra.Command {
+	options := ControllerChatNotifierOptions{
+		ControllerOptions: ControllerOptions{
+			CommonOptions: commonOpts,
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "chat-notifier",
+		Short:   "Runs the controller which sends chat messages about pipeline failures, recoveries and promotions",
+		Long:    controllerChatNotifierLong,
+		Example: controllerChatNotifierExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringArrayVarP(&options.Branches, "branch", "", []string{"master"}, "The branches whose pipeline failures and recoveries are notified")
+	return cmd
+}
+
+// Run implements this command
+func (o *ControllerChatNotifierOptions) Run() error {
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	err = o.RegisterPipelineActivityCRD()
+	if err != nil {
+		return err
+	}
+	o.providers = map[string]chats.ChatProvider{}
+
+	notifier := notifications.NewNotifier(o.loadChatConfig, o.chatProvider, func(event *notifications.Event, chatKind string) ([]chats.Mention, error) {
+		return o.resolveMentions(jxClient, ns, event, chatKind)
+	})
+	notifier.Branches = o.Branches
+
+	pipelineCache := kube.NewPipelineCache(jxClient, ns)
+	defer pipelineCache.Stop()
+
+	pipelineCache.AddListener(func(oldActivity *v1.PipelineActivity, newActivity *v1.PipelineActivity) {
+		if newActivity == nil {
+			return
+		}
+		previous := notifications.PreviousActivity(pipelineCache.Pipelines(), newActivity)
+		for _, event := range notifications.Events(oldActivity, newActivity, previous) {
+			e := event
+			err := notifier.Notify(&e)
+			if err != nil {
+				log.Warnf("Failed to send %s chat notification for %s: %s\n", e.Kind, newActivity.Name, err)
+			}
+		}
+	})
+
+	log.Infof("Watching for pipeline activities in namespace %s to send chat notifications\n", ns)
+	select {}
+}
+
+// loadChatConfig loads the chat configuration from the jenkins-x.yml file of the repository of the activity
+func (o *ControllerChatNotifierOptions) loadChatConfig(activity *v1.PipelineActivity) (*config.ChatConfig, error) {
+	gitURL := activity.Spec.GitURL
+	if gitURL == "" {
+		return nil, nil
+	}
+	gitProvider, gitInfo, err := o.CreateGitProviderForURLWithoutKind(gitURL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create the git provider for %s", gitURL)
+	}
+	ref := activity.Spec.LastCommitSHA
+	if ref == "" {
+		ref = activity.BranchName()
+	}
+	content, err := gitProvider.GetContent(gitInfo.Organisation, gitInfo.Name, config.ProjectConfigFileName, ref)
+	if err != nil || content == nil {
+		// no project configuration so chat is not enabled
+		return nil, nil
+	}
+	data, err := base64.StdEncoding.DecodeString(content.Content)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode %s of %s", config.ProjectConfigFileName, gitURL)
+	}
+	projectConfig := config.ProjectConfig{}
+	err = yaml.Unmarshal(data, &projectConfig)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to unmarshal %s of %s due to %s", config.ProjectConfigFileName, gitURL, err)
+	}
+	return projectConfig.Chat, nil
+}
+
+// chatProvider returns the cached chat provider for the configuration
+func (o *ControllerChatNotifierOptions) chatProvider(chatConfig *config.ChatConfig) (chats.ChatProvider, error) {
+	o.providersLock.Lock()
+	defer o.providersLock.Unlock()
+	provider := o.providers[chatConfig.URL]
+	if provider != nil {
+		return provider, nil
+	}
+	provider, err := o.CreateChatProvider(chatConfig)
+	if err != nil {
+		return nil, err
+	}
+	if provider != nil {
+		o.providers[chatConfig.URL] = provider
+	}
+	return provider, nil
+}
+
+// resolveMentions returns the authors of the commits of the pipeline to mention in a chat message
+func (o *ControllerChatNotifierOptions) resolveMentions(jxClient versioned.Interface, ns string, event *notifications.Event, chatKind string) ([]chats.Mention, error) {
+	gitURL := event.Activity.Spec.GitURL
+	if gitURL == "" {
+		return nil, nil
+	}
+	gitProvider, _, err := o.CreateGitProviderForURLWithoutKind(gitURL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create the git provider for %s", gitURL)
+	}
+	authors, err := notifications.CommitAuthors(gitProvider, event.Activity, event.Previous)
+	if err != nil {
+		log.Warnf("Failed to find the commit authors of %s: %s\n", event.Activity.Name, err)
+	}
+	resolver := &users.GitUserResolver{
+		GitProvider: gitProvider,
+		JXClient:    jxClient,
+		Namespace:   ns,
+	}
+	return notifications.ResolveMentions(resolver, chatKind, authors)
+}
@@ -42,6 +42,8 @@ import (
 // ControllerCommitStatusOptions the options for the controller
 type ControllerCommitStatusOptions struct {
 	ControllerOptions
+
+	gitProviders map[string]gits.GitProvider
 }
 
 // NewCmdControllerCommitStatus creates a command object for the "create" command
@@ -479,6 +481,11 @@ func (o *ControllerCommitStatusOptions) getGitProvider(url string) (gits.GitProv
 	if err != nil {
 		return nil, nil, err
 	}
+	// lets reuse the caching git provider of each server so that we don't exhaust its rate limit
+	hostURL := gitInfo.HostURL()
+	if provider := o.gitProviders[hostURL]; provider != nil {
+		return provider, gitInfo, nil
+	}
 	authConfigSvc, err := o.CreateGitAuthConfigService()
 	if err != nil {
 		return nil, nil, err
@@ -503,7 +510,16 @@ func (o *ControllerCommitStatusOptions) getGitProvider(url string) (gits.GitProv
 			break
 		}
 	}
-	return o.CreateGitProviderForURLWithoutKind(url)
+	provider, gitInfo, err := o.CreateGitProviderForURLWithoutKind(url)
+	if err != nil {
+		return provider, gitInfo, err
+	}
+	provider = o.CachingGitProvider(provider)
+	if o.gitProviders == nil {
+		o.gitProviders = map[string]gits.GitProvider{}
+	}
+	o.gitProviders[hostURL] = provider
+	return provider, gitInfo, nil
 }
 
 func getBuildNumber(pipelineActName string) string {
@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/eventsserver"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/opts"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	pe "github.com/jenkins-x/jx/pkg/pipeline_events"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const pipelineEventsBufferSize = 100
+
+// ControllerEventsServerOptions holds the options for the pipeline events server
+type ControllerEventsServerOptions struct {
+	ControllerOptions
+
+	BindAddress    string
+	Port           int
+	DeadLetterFile string
+	AllowedOrigins []string
+}
+
+var (
+	controllerEventsServerLong = templates.LongDesc(`
+		Runs a server which streams the status changes of pipeline activities, their stages and steps as JSON.
+
+		Clients can connect using Server-Sent Events on the /events path or WebSockets on the /ws path. Web pages can
+		only connect to the WebSockets from the host of the server or the origins given by '--allowed-origin'.
+		The events can be filtered using the 'owner', 'repo' and 'branch' query parameters.
+
+		If a pipeline events sink has been registered with 'jx create addon pipeline-events' the changed pipeline
+		activities are also sent to the sink.
+`)
+
+	controllerEventsServerExample = templates.Examples(`
+		jx controller events-server
+
+		# then stream the events of a repository
+		curl -N http://localhost:8080/events?owner=myorg&repo=myapp
+
+		# allow a dashboard served from another host to connect to the WebSockets
+		jx controller events-server --allowed-origin https://dashboard.example.com
+
+		# keep the pipeline events which could not be delivered to the CloudEvents or webhook sink
+		jx controller events-server --dead-letter-file /var/lib/jx/pipeline-events.jsonl
+`)
+)
+
+// NewCmdControllerEventsServer creates the command
+func NewCmdControllerEventsServer(commonOpts *opts.CommonOptions) *cobra.Command {
+	options := ControllerEventsServerOptions{
+		ControllerOptions: ControllerOptions{
+			CommonOptions: commonOpts,
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "events-server",
+		Short:   "Runs the server which streams pipeline activity changes over Server-Sent Events or WebSockets",
+		Long:    controllerEventsServerLong,
+		Example: controllerEventsServerExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().IntVarP(&options.Port, optionPort, "", 8080, "The TCP port to listen on.")
+	cmd.Flags().StringVarP(&options.BindAddress, optionBind, "", "",
+		"The interface address to bind to (by default, will listen on all interfaces/addresses).")
+	cmd.Flags().StringVarP(&options.DeadLetterFile, "dead-letter-file", "", "",
+		"The file to append the pipeline events which could not be delivered to a CloudEvents or webhook sink")
+	cmd.Flags().StringArrayVarP(&options.AllowedOrigins, "allowed-origin", "", []string{},
+		"The origins of the web pages from other hosts which may connect to the WebSockets, such as https://dashboard.example.com or * for any origin")
+	return cmd
+}
+
+// Run implements this command
+func (o *ControllerEventsServerOptions) Run() error {
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	err = o.RegisterPipelineActivityCRD()
+	if err != nil {
+		return err
+	}
+
+	pipelineCache := kube.NewPipelineCache(jxClient, ns)
+	defer pipelineCache.Stop()
+
+	server := eventsserver.NewServer(o.BindAddress, o.Port, pipelineCache.Ready, o.AllowedOrigins)
+	pipelineCache.AddListener(server.OnPipelineActivity)
+
+	provider, err := o.createPipelineEventsProvider()
+	if err != nil {
+		return errors.Wrap(err, "creating the pipeline events provider")
+	}
+	if provider != nil {
+		pipelineCache.AddListener(sendPipelineEvents(provider, o.DeadLetterFile, time.Now()))
+	}
+	return server.Start()
+}
+
+// createPipelineEventsProvider creates the provider of the sink registered with 'jx create addon pipeline-events'
+// or returns nil if no sink has been registered
+func (o *ControllerEventsServerOptions) createPipelineEventsProvider() (pe.PipelineEventsProvider, error) {
+	authConfigSvc, err := o.CreateAddonAuthConfigService()
+	if err != nil {
+		return nil, err
+	}
+	config := authConfigSvc.Config()
+	server := config.GetServerByKind(kube.ValueKindPipelineEvent)
+	if server == nil {
+		return nil, nil
+	}
+	log.Infof("Sending pipeline events to %s\n", server.URL)
+	return pe.CreateProvider(server, server.CurrentAuth(), o.DeadLetterFile)
+}
+
+// sendPipelineEvents returns a listener which sends the changed pipeline activities to the provider in the order
+// they changed. Activities created before the controller started are only sent when they change again.
+// The listener never blocks the pipeline cache: if the sink falls too far behind the activities are written to the
+// dead letter file instead
+func sendPipelineEvents(provider pe.PipelineEventsProvider, deadLetterFile string, started time.Time) kube.PipelineActivityListener {
+	activities := make(chan *v1.PipelineActivity, pipelineEventsBufferSize)
+	go func() {
+		for activity := range activities {
+			err := provider.SendActivity(activity)
+			if err != nil {
+				log.Warnf("Failed to send the pipeline event for activity %s: %s\n", activity.Name, err)
+			}
+		}
+	}()
+	return func(oldActivity *v1.PipelineActivity, newActivity *v1.PipelineActivity) {
+		if !pipelineActivityChanged(oldActivity, newActivity, started) {
+			return
+		}
+		select {
+		case activities <- newActivity:
+		default:
+			log.Warnf("Dropping the pipeline event for activity %s as %d events are waiting to be sent\n", newActivity.Name, pipelineEventsBufferSize)
+			dropPipelineEvent(deadLetterFile, newActivity)
+		}
+	}
+}
+
+// dropPipelineEvent writes the activity which could not be queued to the dead letter file
+func dropPipelineEvent(deadLetterFile string, activity *v1.PipelineActivity) {
+	data, err := json.Marshal(activity)
+	if err == nil {
+		err = pe.WriteDeadLetter(deadLetterFile, "", nil, data, fmt.Errorf("more than %d pipeline events are waiting to be sent", pipelineEventsBufferSize))
+	}
+	if err != nil {
+		log.Warnf("Failed to write the dead letter for activity %s: %s\n", activity.Name, err)
+	}
+}
+
+// pipelineActivityChanged returns true if the activity was created after the controller started or has been updated.
+// Deleted activities and resyncs of unchanged activities are ignored
+func pipelineActivityChanged(oldActivity *v1.PipelineActivity, newActivity *v1.PipelineActivity, started time.Time) bool {
+	if newActivity == nil {
+		return false
+	}
+	if oldActivity == nil {
+		return !newActivity.CreationTimestamp.Time.Before(started)
+	}
+	return !reflect.DeepEqual(oldActivity.Spec, newActivity.Spec)
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	v1 "github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPipelineActivityChanged(t *testing.T) {
+	t.Parallel()
+	started := time.Now()
+	oldActivity := &v1.PipelineActivity{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "myorg-myapp-master-1",
+			CreationTimestamp: metav1.NewTime(started.Add(-time.Hour)),
+		},
+		Spec: v1.PipelineActivitySpec{
+			Status: v1.ActivityStatusTypeRunning,
+		},
+	}
+	newActivity := oldActivity.DeepCopy()
+	newActivity.Spec.Status = v1.ActivityStatusTypeSucceeded
+	createdActivity := oldActivity.DeepCopy()
+	createdActivity.CreationTimestamp = metav1.NewTime(started.Add(time.Second))
+
+	assert.True(t, pipelineActivityChanged(oldActivity, newActivity, started))
+	assert.True(t, pipelineActivityChanged(nil, createdActivity, started))
+	assert.False(t, pipelineActivityChanged(nil, oldActivity, started),
+		"activities created before the controller started should not be sent on startup")
+	assert.False(t, pipelineActivityChanged(oldActivity, oldActivity.DeepCopy(), started),
+		"resyncs of unchanged activities should not be sent")
+	assert.False(t, pipelineActivityChanged(oldActivity, nil, started))
+}
+
+// blockingPipelineEventsProvider blocks sending activities until it is released
+type blockingPipelineEventsProvider struct {
+	release chan struct{}
+}
+
+func (p *blockingPipelineEventsProvider) SendActivity(a *v1.PipelineActivity) error {
+	<-p.release
+	return nil
+}
+
+func (p *blockingPipelineEventsProvider) SendRelease(r *v1.Release) error {
+	<-p.release
+	return nil
+}
+
+func TestSendPipelineEventsDoesNotBlockWhenTheSinkIsSlow(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "test-pipeline-events-dead-letter")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	deadLetterFile := filepath.Join(dir, "dead-letters.jsonl")
+
+	provider := &blockingPipelineEventsProvider{release: make(chan struct{})}
+	defer close(provider.release)
+	started := time.Now()
+	listener := sendPipelineEvents(provider, deadLetterFile, started)
+
+	// one activity is being sent and the buffer is full so the remaining activities are dropped
+	count := pipelineEventsBufferSize + 5
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < count; i++ {
+			listener(nil, &v1.PipelineActivity{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              fmt.Sprintf("myorg-myapp-master-%d", i),
+					CreationTimestamp: metav1.NewTime(started.Add(time.Second)),
+				},
+			})
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		require.Fail(t, "the listener blocked on the slow sink")
+	}
+
+	f, err := os.Open(deadLetterFile)
+	require.NoError(t, err)
+	defer f.Close()
+	lines := 0
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lines++
+	}
+	assert.True(t, lines >= count-pipelineEventsBufferSize-1, "expected at least %d dead letters but got %d", count-pipelineEventsBufferSize-1, lines)
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/client/informers/externalversions"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/opts"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/pipelinemetrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/spf13/cobra"
+)
+
+const (
+	metricsPath = "/metrics"
+)
+
+// ControllerMetricsExporterOptions holds the options for the pipeline metrics exporter
+type ControllerMetricsExporterOptions struct {
+	ControllerOptions
+
+	BindAddress string
+	Port        int
+	Resync      time.Duration
+}
+
+var (
+	controllerMetricsExporterLong = templates.LongDesc(`
+		Runs a server which exposes Prometheus metrics about the pipelines, stages, releases and promotions of the team.
+
+		The metrics are calculated from the PipelineActivity, Release and Environment resources and include pipeline
+		and stage durations, queue wait times and the lead time from a release pipeline starting to its promotion.
+`)
+
+	controllerMetricsExporterExample = templates.Examples(`
+		jx controller metrics-exporter
+
+		# then scrape the metrics
+		curl http://localhost:8080/metrics
+`)
+)
+
+// NewCmdControllerMetricsExporter creates the command
+func NewCmdControllerMetricsExporter(commonOpts *opts.CommonOptions) *cobra.Command {
+	options := ControllerMetricsExporterOptions{
+		ControllerOptions: ControllerOptions{
+			CommonOptions: commonOpts,
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "metrics-exporter",
+		Short:   "Runs the server which exposes Prometheus metrics about pipelines and promotions",
+		Long:    controllerMetricsExporterLong,
+		Example: controllerMetricsExporterExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().IntVarP(&options.Port, optionPort, "", 8080, "The TCP port to listen on.")
+	cmd.Flags().StringVarP(&options.BindAddress, optionBind, "", "",
+		"The interface address to bind to (by default, will listen on all interfaces/addresses).")
+	cmd.Flags().DurationVarP(&options.Resync, "resync", "", 10*time.Minute, "The resync period of the resource caches")
+	return cmd
+}
+
+// Run implements this command
+func (o *ControllerMetricsExporterOptions) Run() error {
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	err = o.RegisterPipelineActivityCRD()
+	if err != nil {
+		return err
+	}
+	err = o.RegisterReleaseCRD()
+	if err != nil {
+		return err
+	}
+
+	factory := externalversions.NewSharedInformerFactoryWithOptions(jxClient, o.Resync, externalversions.WithNamespace(ns))
+	informers := factory.Jenkins().V1()
+	collector := &pipelinemetrics.Collector{
+		Activities:   informers.PipelineActivities().Lister().PipelineActivities(ns),
+		Releases:     informers.Releases().Lister().Releases(ns),
+		Environments: informers.Environments().Lister().Environments(ns),
+	}
+
+	stop := make(chan struct{})
+	defer close(stop)
+	factory.Start(stop)
+	for informerType, synced := range factory.WaitForCacheSync(stop) {
+		if !synced {
+			return fmt.Errorf("failed to sync the cache of %s", informerType.String())
+		}
+	}
+
+	registry := prometheus.NewRegistry()
+	err = registry.Register(collector)
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(metricsPath, promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	mux.HandleFunc(HealthPath, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	address := fmt.Sprintf("%s:%d", o.BindAddress, o.Port)
+	log.Infof("Exposing pipeline metrics on %s%s\n", address, metricsPath)
+	return http.ListenAndServe(address, mux)
+}
@@ -5,11 +5,15 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"os"
 	"strconv"
 	"time"
 
 	"github.com/jenkins-x/jx/pkg/jenkinsfile"
 	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/pipelinescheduler"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/jenkins-x/jx/pkg/webhooks"
 
 	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/pkg/errors"
@@ -18,6 +22,7 @@ import (
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	"github.com/spf13/cobra"
 	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	prowapi "k8s.io/test-infra/prow/apis/prowjobs/v1"
 	"k8s.io/test-infra/prow/pod-utils/downwardapi"
 )
@@ -36,6 +41,15 @@ type ControllerPipelineRunnerOptions struct {
 	Path                  string
 	Port                  int
 	NoGitCredeentialsInit bool
+	WebHookPath           string
+	HMACToken             string
+
+	hmacToken []byte
+}
+
+// WebHookResponse the jobs triggered by a webhook
+type WebHookResponse struct {
+	Jobs []string `json:"jobs,omitempty"`
 }
 
 // PipelineRunRequest the request to trigger a pipeline run
@@ -61,11 +75,19 @@ type ObjectReference struct {
 }
 
 var (
-	controllerPipelineRunnersLong = templates.LongDesc(`Runs the service to generate Tekton PipelineRun resources from source code webhooks such as from Prow`)
+	controllerPipelineRunnersLong = templates.LongDesc(`Runs the service to generate Tekton PipelineRun resources from source code webhooks such as from Prow
+
+		The service also receives the push and pull request webhooks of GitHub, GitLab, Gitea and Bitbucket directly so
+		that pipelines can be triggered without Prow. The webhooks are verified with the HMAC token and the jobs to run
+		are chosen from the team's pipeline schedulers: the Scheduler referenced by the team settings is applied to all the
+		repositories and the Scheduler referenced by the SourceRepository of a repository is applied on top of it.`)
 
 	controllerPipelineRunnersExample = templates.Examples(`
 			# run the pipeline runner controller
 			jx controller pipelinerunner
+
+			# run the pipeline runner controller receiving the webhooks of the git providers on a different path
+			jx controller pipelinerunner --webhook-path /webhook
 		`)
 )
 
@@ -94,6 +116,10 @@ func NewCmdControllerPipelineRunner(commonOpts *opts.CommonOptions) *cobra.Comma
 		"The path to listen on for requests to trigger a pipeline run.")
 	cmd.Flags().StringVarP(&options.ServiceAccount, "service-account", "", "tekton-bot", "The Kubernetes ServiceAccount to use to run the pipeline")
 	cmd.Flags().BoolVarP(&options.NoGitCredeentialsInit, "no-git-init", "", false, "Disables checking we have setup git credentials on startup")
+	cmd.Flags().StringVarP(&options.WebHookPath, "webhook-path", "", "/hook",
+		"The path to listen on for the webhooks of git providers which trigger pipeline runs without Prow. Disabled if empty")
+	cmd.Flags().StringVarP(&options.HMACToken, "hmac", "", "",
+		"The secret used to verify webhooks. If not specified defaults to $HMAC_TOKEN or the hmac-token Secret")
 	return cmd
 }
 
@@ -109,6 +135,19 @@ func (o *ControllerPipelineRunnerOptions) Run() error {
 	mux.Handle(o.Path, http.HandlerFunc(o.pipelineRunMethods))
 	mux.Handle(HealthPath, http.HandlerFunc(o.health))
 	mux.Handle(ReadyPath, http.HandlerFunc(o.ready))
+	if o.WebHookPath != "" && o.WebHookPath != o.Path {
+		token, err := o.loadHMACToken()
+		if err != nil {
+			return err
+		}
+		if len(token) == 0 {
+			log.Warnf("Not receiving webhooks at %s as no HMAC token was found to verify them\n", o.WebHookPath)
+		} else {
+			o.hmacToken = token
+			mux.Handle(o.WebHookPath, http.HandlerFunc(o.handleWebHookRequests))
+			log.Infof("Waiting for git provider webhooks at http://%s:%d%s", o.BindAddress, o.Port, o.WebHookPath)
+		}
+	}
 	log.Infof("Waiting for dynamic Tekton Pipelines at http://%s:%d%s", o.BindAddress, o.Port, o.Path)
 	return http.ListenAndServe(":"+strconv.Itoa(o.Port), mux)
 }
@@ -168,14 +207,127 @@ func (o *ControllerPipelineRunnerOptions) startPipelineRun(w http.ResponseWriter
 	if o.Verbose {
 		log.Infof("got payload %#v", arguments)
 	}
-	pj := arguments.ProwJobSpec
+	results, err := o.triggerPipelineRun(arguments.Labels, arguments.ProwJobSpec)
+	if err != nil {
+		o.returnError(err, err.Error(), w, r)
+		return
+	}
+	err = o.marshalPayload(w, r, results)
+	if err != nil {
+		o.returnError(err, "failed to marshal payload", w, r)
+	}
+	return
+}
+
+// handleWebHookRequests triggers the jobs of push and pull request webhooks
+func (o *ControllerPipelineRunnerOptions) handleWebHookRequests(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		fmt.Fprintf(w, "Please POST git provider webhooks to this endpoint!\n")
+		return
+	}
+	event, err := webhooks.ParseWebhook(r, o.hmacToken)
+	if err != nil {
+		log.Warnf("%s\n", err)
+		status := http.StatusBadRequest
+		if errors.Cause(err) == webhooks.ErrInvalidSignature {
+			status = http.StatusForbidden
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+	if !event.Triggers() {
+		log.Infof("ignoring webhook %s\n", event.String())
+		fmt.Fprintf(w, "ignoring webhook %s\n", event.String())
+		return
+	}
+	jobs, err := o.webHookJobs(event)
+	if err != nil {
+		o.returnError(err, err.Error(), w, r)
+		return
+	}
+	response := &WebHookResponse{}
+	for _, job := range jobs {
+		response.Jobs = append(response.Jobs, job.Job)
+	}
+	log.Infof("webhook %s triggers jobs %v\n", event.String(), response.Jobs)
+
+	// lets trigger the pipelines in the background as git providers only wait a few seconds for the response
+	go func() {
+		err := o.stepGitCredentials()
+		if err != nil {
+			log.Warn(err.Error())
+		}
+		for _, job := range jobs {
+			_, err := o.triggerPipelineRun(nil, job)
+			if err != nil {
+				log.Errorf("failed to trigger job %s for webhook %s: %s\n", job.Job, event.String(), err)
+			}
+		}
+	}()
+
+	err = o.marshalPayload(w, r, response)
+	if err != nil {
+		o.returnError(err, "failed to marshal payload", w, r)
+	}
+}
+
+// webHookJobs returns the jobs which the pipeline schedulers of the team run for the webhook event
+func (o *ControllerPipelineRunnerOptions) webHookJobs(event *webhooks.Event) ([]prowapi.ProwJobSpec, error) {
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return nil, err
+	}
+	// lets look up the schedulers for each webhook so that changes to the Scheduler resources are picked up
+	scheduler, err := pipelinescheduler.SchedulerFor(jxClient, ns, event.Owner, event.Repo)
+	if err != nil {
+		return nil, err
+	}
+	answer := []prowapi.ProwJobSpec{}
+	refs := &prowapi.Refs{
+		Org:      event.Owner,
+		Repo:     event.Repo,
+		CloneURI: event.CloneURL,
+	}
+	if event.Kind == webhooks.EventKindPullRequest {
+		refs.BaseRef = event.BaseBranch
+		refs.BaseSHA = event.BaseSHA
+		refs.Pulls = []prowapi.Pull{
+			{
+				Number: event.PullRequestNumber,
+				Author: event.Author,
+				SHA:    event.SHA,
+			},
+		}
+		for _, presubmit := range pipelinescheduler.PresubmitsFor(scheduler, event.BaseBranch, event.Changes) {
+			answer = append(answer, prowapi.ProwJobSpec{
+				Type:    prowapi.PresubmitJob,
+				Job:     jobName(presubmit.JobBase),
+				Context: util.DereferenceString(presubmit.Context),
+				Refs:    refs,
+			})
+		}
+		return answer, nil
+	}
+	refs.BaseRef = event.Branch
+	refs.BaseSHA = event.SHA
+	for _, postsubmit := range pipelinescheduler.PostsubmitsFor(scheduler, event.Branch, event.Changes) {
+		answer = append(answer, prowapi.ProwJobSpec{
+			Type:    prowapi.PostsubmitJob,
+			Job:     jobName(postsubmit.JobBase),
+			Context: util.DereferenceString(postsubmit.Context),
+			Refs:    refs,
+		})
+	}
+	return answer, nil
+}
 
+// triggerPipelineRun creates the pipeline run of the job
+func (o *ControllerPipelineRunnerOptions) triggerPipelineRun(labels map[string]string, pj prowapi.ProwJobSpec) (*PipelineRunResponse, error) {
 	var revision string
 	var prNumber string
 
 	if pj.Refs == nil {
-		o.returnError(err, "no prowJobSpec.refs passed in so cannot determine git repository. Input: "+string(data), w, r)
-		return
+		return nil, fmt.Errorf("no prowJobSpec.refs passed in so cannot determine git repository")
 	}
 
 	// lets change this to support new pipelineresource type that handles batches
@@ -188,14 +340,12 @@ func (o *ControllerPipelineRunnerOptions) startPipelineRun(w http.ResponseWriter
 
 	envs, err := downwardapi.EnvForSpec(downwardapi.NewJobSpec(pj, "", ""))
 	if err != nil {
-		o.returnError(err, "failed to get env vars from prowjob", w, r)
-		return
+		return nil, errors.Wrap(err, "failed to get env vars from prowjob")
 	}
 
-	sourceURL := fmt.Sprintf("https://github.com/%s/%s.git", pj.Refs.Org, pj.Refs.Repo)
+	sourceURL := pj.Refs.CloneURI
 	if sourceURL == "" {
-		o.returnError(err, "missing sourceURL property", w, r)
-		return
+		sourceURL = fmt.Sprintf("https://github.com/%s/%s.git", pj.Refs.Org, pj.Refs.Repo)
 	}
 	if revision == "" {
 		revision = "master"
@@ -229,7 +379,7 @@ func (o *ControllerPipelineRunnerOptions) startPipelineRun(w http.ResponseWriter
 	pr.ServiceAccount = o.ServiceAccount
 
 	// turn map into string array with = separator to match type of custom labels which are CLI flags
-	for key, value := range arguments.Labels {
+	for key, value := range labels {
 		pr.CustomLabels = append(pr.CustomLabels, fmt.Sprintf("%s=%s", key, value))
 	}
 
@@ -242,18 +392,31 @@ func (o *ControllerPipelineRunnerOptions) startPipelineRun(w http.ResponseWriter
 
 	err = pr.Run()
 	if err != nil {
-		o.returnError(err, err.Error(), w, r)
-		return
+		return nil, err
 	}
-
-	results := &PipelineRunResponse{
+	return &PipelineRunResponse{
 		Resources: pr.Results.ObjectReferences(),
+	}, nil
+}
+
+// loadHMACToken loads the token used to verify webhooks from the flag, $HMAC_TOKEN or the hmac-token Secret of Prow
+func (o *ControllerPipelineRunnerOptions) loadHMACToken() ([]byte, error) {
+	if o.HMACToken == "" {
+		o.HMACToken = os.Getenv("HMAC_TOKEN")
 	}
-	err = o.marshalPayload(w, r, results)
+	if o.HMACToken != "" {
+		return []byte(o.HMACToken), nil
+	}
+	kubeClient, ns, err := o.KubeClientAndDevNamespace()
 	if err != nil {
-		o.returnError(err, "failed to marshal payload", w, r)
+		return nil, err
 	}
-	return
+	secret, err := kubeClient.CoreV1().Secrets(ns).Get("hmac-token", metav1.GetOptions{})
+	if err != nil {
+		log.Warnf("Failed to load the hmac-token Secret in namespace %s: %s\n", ns, err)
+		return nil, nil
+	}
+	return secret.Data["hmac"], nil
 }
 
 func (o *ControllerPipelineRunnerOptions) isReady() bool {
@@ -327,3 +490,10 @@ func getBranch(spec prowapi.ProwJobSpec) string {
 	}
 	return branch
 }
+
+func jobName(job *pipelinescheduler.JobBase) string {
+	if job == nil {
+		return ""
+	}
+	return util.DereferenceString(job.Name)
+}
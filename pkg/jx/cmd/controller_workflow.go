@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/jenkins-x/jx/pkg/environments"
@@ -44,6 +45,8 @@ type ControllerWorkflowOptions struct {
 	PullRequestPollDuration *time.Duration
 	workflowMap             map[string]*v1.Workflow
 	pipelineMap             map[string]*v1.PipelineActivity
+	// pipelineLock serialises the handling of the PipelineActivity, Fact and polling events which all use the pipelineMap
+	pipelineLock sync.Mutex
 
 	// Allow Git to be configured
 	ConfigureGitFn environments.ConfigureGitFn
@@ -87,6 +90,10 @@ func (o *ControllerWorkflowOptions) Run() error {
 	if err != nil {
 		return err
 	}
+	err = o.RegisterFactCRD()
+	if err != nil {
+		return err
+	}
 
 	if o.PullRequestPollTime != "" {
 		duration, err := time.ParseDuration(o.PullRequestPollTime)
@@ -168,6 +175,26 @@ func (o *ControllerWorkflowOptions) Run() error {
 
 	go pipelineController.Run(stop)
 
+	factListWatch := cache.NewListWatchFromClient(jxClient.JenkinsV1().RESTClient(), "facts", ns, fields.Everything())
+	kube.SortListWatchByName(factListWatch)
+	_, factController := cache.NewInformer(
+		factListWatch,
+		&v1.Fact{},
+		time.Minute*10,
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				o.onFactObj(obj, jxClient, ns)
+			},
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				o.onFactObj(newObj, jxClient, ns)
+			},
+			DeleteFunc: func(obj interface{}) {
+			},
+		},
+	)
+
+	go factController.Run(stop)
+
 	ticker := time.NewTicker(*o.PullRequestPollDuration)
 	go func() {
 		for t := range ticker.C {
@@ -175,7 +202,9 @@ func (o *ControllerWorkflowOptions) Run() error {
 				log.Infof("Polling to see if any PRs have merged: %v\n", t)
 			}
 			//o.pollGitPipelineStatuses(jxClient, ns)
+			o.pipelineLock.Lock()
 			o.ReloadAndPollGitPipelineStatuses(jxClient, ns)
+			o.pipelineLock.Unlock()
 		}
 	}()
 
@@ -253,6 +282,32 @@ func (o *ControllerWorkflowOptions) onActivityObj(obj interface{}, jxClient vers
 				pipeline = activity
 			}
 		}
+		o.pipelineLock.Lock()
+		defer o.pipelineLock.Unlock()
+		o.onActivity(pipeline, jxClient, ns)
+	}
+}
+
+// onFactObj retries the promotions of the pipeline blocked by their gates when a Fact about its build, such as its
+// test results or an approval, is recorded
+func (o *ControllerWorkflowOptions) onFactObj(obj interface{}, jxClient versioned.Interface, ns string) {
+	fact, ok := obj.(*v1.Fact)
+	if !ok {
+		log.Warnf("Object is not a Fact %#v\n", obj)
+		return
+	}
+	if fact == nil {
+		return
+	}
+	o.pipelineLock.Lock()
+	defer o.pipelineLock.Unlock()
+	for _, pipeline := range o.pipelineMap {
+		if !hasFailedPromotionGates(pipeline) || !factMatchesPipeline(fact, pipeline) {
+			continue
+		}
+		if o.Verbose {
+			log.Infof("Retrying the promotions of PipelineActivity %s as Fact %s was recorded\n", pipeline.Name, fact.Name)
+		}
 		o.onActivity(pipeline, jxClient, ns)
 	}
 }
@@ -330,6 +385,7 @@ func (o *ControllerWorkflowOptions) onActivity(pipeline *v1.PipelineActivity, jx
 						if canExecuteStep(flow, pipeline, &step, promoteStatusMap, envName) {
 							log.Infof("Creating PR for environment %s from PipelineActivity %s as current status is %#v\n", envName, pipeline.Name, status)
 							po := o.createPromoteOptions(repoName, envName, pipelineName, build, version)
+							po.Gates = step.Preconditions.Gates
 
 							err := po.Run()
 							if err != nil {
@@ -703,6 +759,31 @@ func canExecuteStep(workflow *v1.Workflow, activity *v1.PipelineActivity, step *
 	return true
 }
 
+// hasFailedPromotionGates returns true if a promotion of the pipeline was blocked by its gates
+func hasFailedPromotionGates(pipeline *v1.PipelineActivity) bool {
+	for _, step := range pipeline.Spec.Steps {
+		promote := step.Promote
+		if promote != nil && promote.Gates != nil && promote.Gates.Status == v1.ActivityStatusTypeFailed {
+			return true
+		}
+	}
+	return false
+}
+
+// factMatchesPipeline returns true if the Fact is about the build of the pipeline
+func factMatchesPipeline(fact *v1.Fact, pipeline *v1.PipelineActivity) bool {
+	labels := kube.PipelineFactLabels(pipeline.Spec.Pipeline, pipeline.Spec.Build)
+	if len(labels) == 0 {
+		return false
+	}
+	for k, v := range labels {
+		if fact.Labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
 // createPromoteStatus returns a map indexed by environment name of all the promotions in this pipeline
 func createPromoteStatus(pipeline *v1.PipelineActivity) map[string]*v1.PromoteActivityStep {
 	answer := map[string]*v1.PromoteActivityStep{}
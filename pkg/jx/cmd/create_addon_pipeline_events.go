@@ -18,6 +18,7 @@ import (
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/jenkins-x/jx/pkg/log"
+	pe "github.com/jenkins-x/jx/pkg/pipeline_events"
 	"github.com/jenkins-x/jx/pkg/util"
 )
 
@@ -26,6 +27,7 @@ const (
 	defaultPENamespace   = "pipeline-events"
 	defaultPEReleaseName = "jx-pipeline-events"
 	defaultPEVersion     = "0.0.11"
+	defaultPEUsername    = "pipeline-events"
 	kibanaServiceName    = "jx-pipeline-events-kibana"
 	kibanaDeploymentName = "jx-pipeline-events-kibana"
 	esDeploymentName     = "jx-pipeline-events-elasticsearch-client"
@@ -42,6 +44,12 @@ var (
 
 		# Create the pipeline-events addon in a custom namespace
 		jx create addon pipeline-events -n mynamespace
+
+		# Send pipeline events as CloudEvents to an existing event bus
+		jx create addon pipeline-events --sink cloudevents --url http://broker.events.svc.cluster.local
+
+		# Send pipeline events to a webhook signing the payloads with HMAC-SHA256
+		jx create addon pipeline-events --sink webhook --url https://audit.example.com/hooks/jx --secret mysecret
 	`)
 )
 
@@ -49,6 +57,9 @@ var (
 type CreateAddonPipelineEventsOptions struct {
 	CreateAddonOptions
 	Password string
+	Sink     string
+	URL      string
+	Secret   string
 }
 
 // NewCmdCreateAddonPipelineEvents creates a command object for the "create" command
@@ -78,11 +89,17 @@ func NewCmdCreateAddonPipelineEvents(commonOpts *opts.CommonOptions) *cobra.Comm
 	options.addFlags(cmd, defaultPENamespace, defaultPEReleaseName, defaultPEVersion)
 
 	cmd.Flags().StringVarP(&options.Password, "password", "p", "", "Password to access pipeline-events services such as Kibana and Elasticsearch.  Defaults to default Jenkins X admin password.")
+	cmd.Flags().StringVarP(&options.Sink, "sink", "", pe.SinkElasticsearch, fmt.Sprintf("The kind of sink to send pipeline events to. Supported sinks are: %s", strings.Join(pe.Sinks, ", ")))
+	cmd.Flags().StringVarP(&options.URL, "url", "", "", "The URL of the CloudEvents or webhook sink")
+	cmd.Flags().StringVarP(&options.Secret, "secret", "", "", "The secret used to sign webhook payloads, or the bearer token sent to a CloudEvents sink. Generated for webhooks if not specified")
 	return cmd
 }
 
 // Run implements the command
 func (o *CreateAddonPipelineEventsOptions) Run() error {
+	if o.Sink != "" && o.Sink != pe.SinkElasticsearch {
+		return o.createSink()
+	}
 
 	if o.ReleaseName == "" {
 		return util.MissingOption(optionRelease)
@@ -200,6 +217,56 @@ func (o *CreateAddonPipelineEventsOptions) Run() error {
 	log.Successf("kibana is available and running %s\n", kIng)
 	return nil
 }
+
+// createSink registers a CloudEvents or webhook sink as the pipeline events addon server
+func (o *CreateAddonPipelineEventsOptions) createSink() error {
+	if util.StringArrayIndex(pe.Sinks, o.Sink) < 0 {
+		return util.InvalidOption("sink", o.Sink, pe.Sinks)
+	}
+	if o.URL == "" {
+		return util.MissingOption("url")
+	}
+	token := o.Secret
+	generated := false
+	if token == "" && o.Sink == pe.SinkWebhook {
+		var err error
+		token, err = util.RandStringBytesMaskImprSrc(32)
+		if err != nil {
+			return errors.Wrap(err, "failed to generate the webhook secret")
+		}
+		generated = true
+	}
+
+	authConfigSvc, err := o.CreateAddonAuthConfigService()
+	if err != nil {
+		return err
+	}
+	config := authConfigSvc.Config()
+	server := config.GetOrCreateServerName(o.URL, o.Sink, kube.ValueKindPipelineEvent)
+	userAuth := config.GetOrCreateUserAuth(server.URL, defaultPEUsername)
+	userAuth.ApiToken = token
+	config.CurrentServer = server.URL
+	err = authConfigSvc.SaveConfig()
+	if err != nil {
+		return errors.Wrapf(err, "failed to save the %s sink", o.Sink)
+	}
+
+	tokenOptions := CreateTokenAddonOptions{
+		CreateOptions: CreateOptions{
+			CommonOptions: o.CommonOptions,
+		},
+	}
+	err = tokenOptions.updateAddonCredentialsSecret(server, userAuth)
+	if err != nil {
+		return errors.Wrapf(err, "failed to store the credentials of the %s sink", o.Sink)
+	}
+	if generated {
+		log.Infof("Generated a webhook secret which is stored in the Secret %s\n", util.ColorInfo(addonCredentialsSecretName(server)))
+	}
+	log.Successf("pipeline events will be sent to %s sink %s\n", o.Sink, o.URL)
+	return nil
+}
+
 func (o *CreateAddonPipelineEventsOptions) addExposecontrollerAnnotations(serviceName string) error {
 	client, err := o.KubeClient()
 	if err != nil {
@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 
+	"github.com/jenkins-x/jx/pkg/chats"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/opts"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	"github.com/jenkins-x/jx/pkg/log"
@@ -13,11 +14,20 @@ import (
 var (
 	createChatServer_long = templates.LongDesc(`
 		Adds a new chat server URL
+
+		The supported kinds are slack, mattermost and msteams. The URL of a Mattermost server includes the team name.
+		The URL of a Microsoft Teams server is the URL of the incoming webhook of the channel.
 `)
 
 	createChatServer_example = templates.Examples(`
 		# Add a new chat server URL
 		jx create chat server slack https://myroom.slack.server
+
+		# Add a Mattermost server for the 'myteam' team
+		jx create chat server mattermost https://mattermost.acme.com/myteam
+
+		# Add a Microsoft Teams channel incoming webhook
+		jx create chat server msteams https://outlook.office.com/webhook/...
 	`)
 )
 
@@ -61,6 +71,9 @@ func (o *CreateChatServerOptions) Run() error {
 		return missingChatArguments()
 	}
 	kind := args[0]
+	if util.StringArrayIndex(chats.ChatKinds, kind) < 0 {
+		return util.InvalidArg(kind, chats.ChatKinds)
+	}
 	name := o.Name
 	if name == "" {
 		name = kind
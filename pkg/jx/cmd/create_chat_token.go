@@ -87,6 +87,10 @@ func (o *CreateChatTokenOptions) Run() error {
 	if err != nil {
 		return err
 	}
+	if !chats.RequiresUserAuth(server.Kind) {
+		log.Infof("No token is required for %s chat server %s as it uses an incoming webhook\n", server.Kind, util.ColorInfo(server.URL))
+		return nil
+	}
 	if o.Username == "" {
 		return fmt.Errorf("No Username specified")
 	}
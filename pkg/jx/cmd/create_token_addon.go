@@ -144,6 +144,11 @@ func (o *CreateTokenAddonOptions) Run() error {
 	return nil
 }
 
+// addonCredentialsSecretName returns the name of the Secret which stores the credentials of the addon server
+func addonCredentialsSecretName(server *auth.AuthServer) string {
+	return kube.ToValidName(kube.SecretJenkinsPipelineAddonCredentials + server.Kind + "-" + server.Name)
+}
+
 func (o *CreateTokenAddonOptions) updateAddonCredentialsSecret(server *auth.AuthServer, userAuth *auth.UserAuth) error {
 	client, curNs, err := o.KubeClientAndNamespace()
 	if err != nil {
@@ -154,7 +159,7 @@ func (o *CreateTokenAddonOptions) updateAddonCredentialsSecret(server *auth.Auth
 		return err
 	}
 	options := metav1.GetOptions{}
-	name := kube.ToValidName(kube.SecretJenkinsPipelineAddonCredentials + server.Kind + "-" + server.Name)
+	name := addonCredentialsSecretName(server)
 	secrets := client.CoreV1().Secrets(ns)
 	secret, err := secrets.Get(name, options)
 	create := false
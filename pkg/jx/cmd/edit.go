@@ -54,6 +54,7 @@ func NewCmdEdit(commonOpts *opts.CommonOptions) *cobra.Command {
 	cmd.AddCommand(NewCmdCreateBranchPattern(commonOpts))
 	cmd.AddCommand(NewCmdEditAddon(commonOpts))
 	cmd.AddCommand(NewCmdEditAppJenkinsPlugins(commonOpts))
+	cmd.AddCommand(NewCmdEditBranchProtection(commonOpts))
 	cmd.AddCommand(NewCmdEditBuildpack(commonOpts))
 	cmd.AddCommand(NewCmdEditConfig(commonOpts))
 	cmd.AddCommand(NewCmdEditDeployKind(commonOpts))
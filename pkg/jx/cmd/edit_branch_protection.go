@@ -0,0 +1,247 @@
+package cmd
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	v1 "github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/opts"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const defaultProtectedBranch = "master"
+
+var (
+	editBranchProtectionLong = templates.LongDesc(`
+		Edits the branch protection policy of your team and applies it to the branches of every source repository.
+
+		The policy is stored in the team settings so that it is applied to the repositories of the team regardless of
+		whether Prow or Jenkins handles their webhooks. Any differences between the policy and the protection of each
+		branch are reported before the branch protection is updated.
+
+		Use '--dry-run' to only report the differences.
+
+` + opts.SeeAlsoText("jx get team", "jx start protection"))
+
+	editBranchProtectionExample = templates.Examples(`
+		# Require the pr-build status and one approval before merging into master of every source repository
+		jx edit branch-protection --context pr-build --approvals 1
+
+		# Only allow the pipeline user to push to master and enforce it for administrators too
+		jx edit branch-protection --restrict-pushes --push-user jenkins-x-bot --enforce-admins
+
+		# Report which repositories do not match the branch protection policy of the team
+		jx edit branch-protection --dry-run
+	`)
+)
+
+// EditBranchProtectionOptions the options for the edit branch-protection command
+type EditBranchProtectionOptions struct {
+	EditOptions
+
+	Policy v1.BranchProtection
+	DryRun bool
+}
+
+// NewCmdEditBranchProtection creates a command object for the "edit branch-protection" command
+func NewCmdEditBranchProtection(commonOpts *opts.CommonOptions) *cobra.Command {
+	options := &EditBranchProtectionOptions{
+		EditOptions: EditOptions{
+			CommonOptions: commonOpts,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "branch-protection",
+		Short:   "Edits the branch protection policy of your team and applies it to every source repository",
+		Aliases: []string{"branchprotection", "protection"},
+		Long:    editBranchProtectionLong,
+		Example: editBranchProtectionExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringArrayVarP(&options.Policy.Branches, "branch", "b", []string{}, "The branches to protect. Defaults to "+defaultProtectedBranch)
+	cmd.Flags().StringArrayVarP(&options.Policy.RequiredStatusContexts, "context", "c", []string{}, "The status contexts which must pass before a Pull Request can be merged")
+	cmd.Flags().IntVarP(&options.Policy.RequiredApprovals, "approvals", "a", 0, "The number of approvals a Pull Request needs before it can be merged")
+	cmd.Flags().BoolVarP(&options.Policy.RestrictPushes, "restrict-pushes", "", false, "Only allows the push users and teams to push to the protected branches")
+	cmd.Flags().StringArrayVarP(&options.Policy.PushUsers, "push-user", "", []string{}, "The users allowed to push to the protected branches when pushes are restricted")
+	cmd.Flags().StringArrayVarP(&options.Policy.PushTeams, "push-team", "", []string{}, "The teams allowed to push to the protected branches when pushes are restricted")
+	cmd.Flags().BoolVarP(&options.Policy.EnforceAdmins, "enforce-admins", "", false, "Enforces the branch protection for administrators too")
+	cmd.Flags().BoolVarP(&options.DryRun, "dry-run", "", false, "Only reports the differences between the policy and the protection of each branch without changing anything")
+	return cmd
+}
+
+// Run implements the command
+func (o *EditBranchProtectionOptions) Run() error {
+	settings, err := o.TeamSettings()
+	if err != nil {
+		return err
+	}
+	policy := o.mergePolicy(settings.BranchProtection)
+	if policy == nil {
+		return fmt.Errorf("no branch protection policy is configured for the team. Please specify one via the flags\n\n%s", editBranchProtectionExample)
+	}
+	if !o.DryRun && !reflect.DeepEqual(policy, settings.BranchProtection) {
+		callback := func(env *v1.Environment) error {
+			env.Spec.TeamSettings.BranchProtection = policy
+			log.Infof("Setting the team branch protection policy\n")
+			return nil
+		}
+		err = o.ModifyDevEnvironment(callback)
+		if err != nil {
+			return errors.Wrap(err, "failed to update the team settings")
+		}
+	}
+
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	repositories, err := jxClient.JenkinsV1().SourceRepositories(ns).List(metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "failed to list the SourceRepositories in namespace %s", ns)
+	}
+	items := repositories.Items
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Name < items[j].Name
+	})
+
+	branches := policy.Branches
+	if len(branches) == 0 {
+		branches = []string{defaultProtectedBranch}
+	}
+	desired := toGitBranchProtection(policy)
+	providers := map[string]gits.GitProvider{}
+	drifted := 0
+	failed := []string{}
+	fail := func(name string) {
+		if util.StringArrayIndex(failed, name) < 0 {
+			failed = append(failed, name)
+		}
+	}
+	for i := range items {
+		spec := &items[i].Spec
+		name := spec.Org + "/" + spec.Repo
+		provider, err := o.branchProtectionProvider(providers, spec)
+		if err != nil {
+			log.Warnf("Failed to create the git provider for %s: %s\n", name, err)
+			fail(name)
+			continue
+		}
+		for _, branch := range branches {
+			actual, err := provider.GetBranchProtection(spec.Org, spec.Repo, branch)
+			if err != nil {
+				log.Warnf("Failed to get the protection of branch %s of %s: %s\n", branch, name, err)
+				fail(name)
+				continue
+			}
+			drift := gits.BranchProtectionDrift(desired, actual)
+			if actual == nil {
+				drift = append([]string{"branch is not protected"}, drift...)
+			}
+			if len(drift) == 0 {
+				log.Infof("%s branch %s matches the policy\n", util.ColorInfo(name), util.ColorInfo(branch))
+				continue
+			}
+			drifted++
+			log.Infof("%s branch %s differs from the policy:\n", util.ColorInfo(name), util.ColorInfo(branch))
+			for _, d := range drift {
+				log.Infof("  * %s\n", util.ColorWarning(d))
+			}
+			if o.DryRun {
+				continue
+			}
+			err = provider.UpdateBranchProtection(spec.Org, spec.Repo, branch, desired)
+			if err != nil {
+				log.Warnf("Failed to update the protection of branch %s of %s: %s\n", branch, name, err)
+				fail(name)
+				continue
+			}
+			log.Infof("Updated the protection of %s branch %s\n", util.ColorInfo(name), util.ColorInfo(branch))
+		}
+	}
+	log.Infof("%d of %d repositories checked, %d branches differed from the policy\n", len(items)-len(failed), len(items), drifted)
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to apply the branch protection policy to %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// mergePolicy returns the existing policy of the team updated by any policy flags which have been specified
+func (o *EditBranchProtectionOptions) mergePolicy(existing *v1.BranchProtection) *v1.BranchProtection {
+	flags := o.Cmd.Flags()
+	names := []string{"branch", "context", "approvals", "restrict-pushes", "push-user", "push-team", "enforce-admins"}
+	changed := false
+	for _, name := range names {
+		if flags.Changed(name) {
+			changed = true
+		}
+	}
+	if !changed {
+		return existing
+	}
+	policy := &v1.BranchProtection{}
+	if existing != nil {
+		policy = existing.DeepCopy()
+	}
+	if flags.Changed("branch") {
+		policy.Branches = o.Policy.Branches
+	}
+	if flags.Changed("context") {
+		policy.RequiredStatusContexts = o.Policy.RequiredStatusContexts
+	}
+	if flags.Changed("approvals") {
+		policy.RequiredApprovals = o.Policy.RequiredApprovals
+	}
+	if flags.Changed("restrict-pushes") {
+		policy.RestrictPushes = o.Policy.RestrictPushes
+	}
+	if flags.Changed("push-user") {
+		policy.PushUsers = o.Policy.PushUsers
+	}
+	if flags.Changed("push-team") {
+		policy.PushTeams = o.Policy.PushTeams
+	}
+	if flags.Changed("enforce-admins") {
+		policy.EnforceAdmins = o.Policy.EnforceAdmins
+	}
+	return policy
+}
+
+// branchProtectionProvider returns the cached git provider for the server of the source repository
+func (o *EditBranchProtectionOptions) branchProtectionProvider(providers map[string]gits.GitProvider, spec *v1.SourceRepositorySpec) (gits.GitProvider, error) {
+	provider := providers[spec.Provider]
+	if provider != nil {
+		return provider, nil
+	}
+	gitURL := util.UrlJoin(spec.Provider, spec.Org, spec.Repo) + ".git"
+	provider, _, err := o.CreateGitProviderForURLWithoutKind(gitURL)
+	if err != nil {
+		return nil, err
+	}
+	providers[spec.Provider] = provider
+	return provider, nil
+}
+
+func toGitBranchProtection(policy *v1.BranchProtection) *gits.GitBranchProtection {
+	return &gits.GitBranchProtection{
+		RequiredStatusContexts: policy.RequiredStatusContexts,
+		RequiredApprovals:      policy.RequiredApprovals,
+		RestrictPushes:         policy.RestrictPushes,
+		PushUsers:              policy.PushUsers,
+		PushTeams:              policy.PushTeams,
+		EnforceAdmins:          policy.EnforceAdmins,
+	}
+}
@@ -43,6 +43,10 @@ type EditEnvOptions struct {
 	GitRepositoryOptions   gits.GitRepositoryOptions
 	Prefix                 string
 	BranchPattern          string
+	ReviewLabels           []string
+	Reviewers              []string
+	RequestOwners          bool
+	RequiredApprovals      int
 }
 
 // NewCmdEditEnv creates a command object for the "create" command
@@ -88,6 +92,11 @@ func NewCmdEditEnv(commonOpts *opts.CommonOptions) *cobra.Command {
 
 	cmd.Flags().BoolVarP(&options.NoGitOps, "no-gitops", "x", false, "Disables the use of GitOps on the environment so that promotion is implemented by directly modifying the resources via Helm instead of using a Git repository")
 
+	cmd.Flags().StringArrayVarP(&options.ReviewLabels, "review-labels", "", []string{}, "The labels added to the promotion Pull Requests of the environment")
+	cmd.Flags().StringArrayVarP(&options.Reviewers, "reviewers", "", []string{}, "The users requested to review the promotion Pull Requests of the environment")
+	cmd.Flags().BoolVarP(&options.RequestOwners, "request-owners", "", false, "Requests the approvers in the OWNERS file of the environment Git repository to review promotion Pull Requests")
+	cmd.Flags().IntVarP(&options.RequiredApprovals, "required-approvals", "", 0, "The number of approvals a promotion Pull Request needs before it is merged")
+
 	opts.AddGitRepoOptionsArguments(cmd, &options.GitRepositoryOptions)
 	options.HelmValuesConfig.AddExposeControllerValues(cmd, false)
 	return cmd
@@ -155,6 +164,7 @@ func (o *EditEnvOptions) Run() error {
 	if err != nil {
 		return err
 	}
+	o.updateReviewPolicy(env)
 	_, err = jxClient.JenkinsV1().Environments(ns).PatchUpdate(env)
 	if err != nil {
 		return err
@@ -178,3 +188,32 @@ func (o *EditEnvOptions) Run() error {
 	}
 	return nil
 }
+
+// updateReviewPolicy updates the review policy of the environment from the review flags which have been specified
+func (o *EditEnvOptions) updateReviewPolicy(env *v1.Environment) {
+	if o.Cmd == nil {
+		return
+	}
+	flags := o.Cmd.Flags()
+	if !flags.Changed("review-labels") && !flags.Changed("reviewers") && !flags.Changed("request-owners") &&
+		!flags.Changed("required-approvals") {
+		return
+	}
+	policy := env.Spec.ReviewPolicy
+	if policy == nil {
+		policy = &v1.ReviewPolicy{}
+	}
+	if flags.Changed("review-labels") {
+		policy.Labels = o.ReviewLabels
+	}
+	if flags.Changed("reviewers") {
+		policy.Reviewers = o.Reviewers
+	}
+	if flags.Changed("request-owners") {
+		policy.RequestOwners = o.RequestOwners
+	}
+	if flags.Changed("required-approvals") {
+		policy.RequiredApprovals = o.RequiredApprovals
+	}
+	env.Spec.ReviewPolicy = policy
+}
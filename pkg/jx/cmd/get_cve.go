@@ -87,25 +87,14 @@ func (o *GetCVEOptions) Run() error {
 		return fmt.Errorf("cannot create jx client: %v", err)
 	}
 
-	externalURL, err := o.EnsureAddonServiceAvailable(kube.AddonServices[defaultAnchoreName])
-	if err != nil {
-		log.Warnf("no CVE provider service found, are you in your teams dev environment?  Type `jx env` to switch.\n")
-		return fmt.Errorf("if no CVE provider running, try running `jx create addon anchore` in your teams dev environment: %v", err)
-	}
-
 	// if no flags are set try and guess the image name from the current directory
 	if o.ImageID == "" && o.ImageName == "" && o.Env == "" {
 		return fmt.Errorf("no --image-name, --image-id or --environment flags set\n")
 	}
 
-	server, auth, err := o.GetAddonAuthByKind(kube.ValueKindCVE, externalURL)
-	if err != nil {
-		return fmt.Errorf("error getting anchore engine auth details, %v", err)
-	}
-
-	p, err := cve.NewAnchoreProvider(server, auth)
+	p, err := createCVEProvider(o.CommonOptions)
 	if err != nil {
-		return fmt.Errorf("error creating anchore provider, %v", err)
+		return err
 	}
 	table := o.CreateTable()
 	table.AddRow("Image", util.ColorInfo("Severity"), "Vulnerability", "URL", "Package", "Fix")
@@ -133,3 +122,23 @@ func (o *GetCVEOptions) Run() error {
 	table.Render()
 	return nil
 }
+
+// createCVEProvider creates the CVE provider of the anchore addon of the team
+func createCVEProvider(o *opts.CommonOptions) (cve.CVEProvider, error) {
+	externalURL, err := o.EnsureAddonServiceAvailable(kube.AddonServices[defaultAnchoreName])
+	if err != nil {
+		log.Warnf("no CVE provider service found, are you in your teams dev environment?  Type `jx env` to switch.\n")
+		return nil, fmt.Errorf("if no CVE provider running, try running `jx create addon anchore` in your teams dev environment: %v", err)
+	}
+
+	server, auth, err := o.GetAddonAuthByKind(kube.ValueKindCVE, externalURL)
+	if err != nil {
+		return nil, fmt.Errorf("error getting anchore engine auth details, %v", err)
+	}
+
+	p, err := cve.NewAnchoreProvider(server, auth)
+	if err != nil {
+		return nil, fmt.Errorf("error creating anchore provider, %v", err)
+	}
+	return p, nil
+}
@@ -3,10 +3,7 @@ package cmd
 import (
 	"github.com/spf13/cobra"
 
-	"encoding/json"
-	"fmt"
-	"net/http"
-
+	"github.com/jenkins-x/jx/pkg/gits"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/opts"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 
@@ -16,22 +13,6 @@ import (
 	"github.com/jenkins-x/jx/pkg/log"
 )
 
-type RateLimits struct {
-	Resources RateResources `json:"resources"`
-}
-
-type RateResources struct {
-	Core    Rate `json:"core"`
-	Search  Rate `json:"search"`
-	GraphQL Rate `json:"graphql"`
-}
-
-type Rate struct {
-	Limit     int `json:"limit"`
-	Remaining int `json:"remaining"`
-	Reset     int `json:"reset"`
-}
-
 // GetAddonOptions the command line options
 type GetLimitsOptions struct {
 	GetOptions
@@ -39,7 +20,9 @@ type GetLimitsOptions struct {
 
 var (
 	get_limits_long = templates.LongDesc(`
-		Display the github limits for users
+		Display the API rate limits of the users of each git server.
+
+		Git servers which do not limit or report the rate of API requests are displayed as unlimited.
 
 `)
 
@@ -83,75 +66,37 @@ func (o *GetLimitsOptions) Run() error {
 	config := authConfigSvc.Config()
 
 	table := o.CreateTable()
-	table.AddRow("Name", "URL", "Username", "Limit", "Remaining", "Reset")
+	table.AddRow("Name", "URL", "Kind", "Username", "Resource", "Limit", "Remaining", "Reset")
 
 	for _, s := range config.Servers {
-		kind := s.Kind
-		if kind == "" {
-			kind = "github"
-		}
-
-		if kind == "github" {
-			for _, u := range s.Users {
-				r, err := o.GetLimits(s.URL, u.Username, u.ApiToken)
-				if err != nil {
-					return err
-				}
-
+		for _, u := range s.Users {
+			if u.IsInvalid() {
+				continue
+			}
+			provider, err := gits.CreateProvider(s, u, o.Git())
+			if err != nil {
+				log.Warnf("Failed to create the git provider for %s user %s: %s\n", s.URL, u.Username, err)
+				continue
+			}
+			limits, err := provider.GetRateLimits()
+			if err != nil {
+				log.Warnf("Failed to get the rate limits of %s user %s: %s\n", s.URL, u.Username, err)
+				continue
+			}
+			if len(limits) == 0 {
+				table.AddRow(s.Name, s.URL, provider.Kind(), u.Username, "", "unlimited", "", "")
+				continue
+			}
+			for _, r := range limits {
 				resetLabel := ""
-				if 0 != r.Resources.Core.Reset {
-					secondsUntilReset := int64(r.Resources.Core.Reset) - time.Now().Unix()
-					d := time.Duration(1000 * 1000 * 1000 * secondsUntilReset)
-					resetLabel = d.String()
+				if !r.Reset.IsZero() {
+					resetLabel = time.Until(r.Reset).Round(time.Second).String()
 				}
-
-				table.AddRow(s.Name, s.URL, u.Username, strconv.Itoa(r.Resources.Core.Limit), strconv.Itoa(r.Resources.Core.Remaining), resetLabel)
+				table.AddRow(s.Name, s.URL, provider.Kind(), u.Username, r.Resource, strconv.Itoa(r.Limit), strconv.Itoa(r.Remaining), resetLabel)
 			}
 		}
-
 	}
 	table.Render()
 
 	return nil
 }
-
-func (o *GetLimitsOptions) GetLimits(server string, username string, apitoken string) (RateLimits, error) {
-	url := fmt.Sprintf("https://%s:%s@api.github.com/rate_limit", username, apitoken)
-
-	// Build the request
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		log.Errorf("NewRequest: %s", err)
-		return RateLimits{}, err
-	}
-
-	// For control over HTTP client headers,
-	// redirect policy, and other settings,
-	// create a Client
-	// A Client is an HTTP client
-	client := &http.Client{}
-
-	// Send the request via a client
-	// Do sends an HTTP request and
-	// returns an HTTP response
-	resp, err := client.Do(req)
-	if err != nil {
-		log.Errorf("Do: %s", err)
-		return RateLimits{}, err
-	}
-
-	// Callers should close resp.Body
-	// when done reading from it
-	// Defer the closing of the body
-	defer resp.Body.Close()
-
-	// Fill the record with the data from the JSON
-	var limits RateLimits
-
-	// Use json.Decode for reading streams of JSON data
-	if err := json.NewDecoder(resp.Body).Decode(&limits); err != nil {
-		log.Errorf("Decode: %s", err)
-	}
-
-	return limits, nil
-}
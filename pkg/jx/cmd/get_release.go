@@ -2,11 +2,16 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/cloud/buckets"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/opts"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/sbom"
 	"github.com/jenkins-x/jx/pkg/util"
 	"github.com/spf13/cobra"
 )
@@ -17,6 +22,9 @@ type GetReleaseOptions struct {
 
 	Filter    string
 	Namespace string
+	SBOM      bool
+	Component string
+	Timeout   time.Duration
 }
 
 var (
@@ -30,6 +38,12 @@ var (
 
 		# Filter the releases 
 		jx get release -f myapp
+
+		# List the components of the releases from their software bill of materials
+		jx get release --sbom
+
+		# Find the releases which include a library
+		jx get release --component jackson-databind
 	`)
 )
 
@@ -55,6 +69,9 @@ func NewCmdGetRelease(commonOpts *opts.CommonOptions) *cobra.Command {
 	}
 	cmd.Flags().StringVarP(&options.Filter, "filter", "f", "", "Filter the releases with the given text")
 	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "The namespace to view or defaults to the current namespace")
+	cmd.Flags().BoolVarP(&options.SBOM, "sbom", "", false, "Display the components of the software bill of materials of the releases generated by 'jx step sbom'")
+	cmd.Flags().StringVarP(&options.Component, "component", "", "", "Only display the releases including a component whose name or package URL contains the given text. Implies --sbom")
+	cmd.Flags().DurationVarP(&options.Timeout, "timeout", "t", time.Second*30, "The timeout period to read each software bill of materials")
 
 	options.addGetFlags(cmd)
 	return cmd
@@ -83,6 +100,9 @@ func (o *GetReleaseOptions) Run() error {
 		log.Infof("To create a release try merging code to a master branch to trigger a pipeline or try: %s\n", util.ColorInfo("jx start build"))
 		return nil
 	}
+	if o.SBOM || o.Component != "" {
+		return o.renderComponents(releases)
+	}
 	table := o.CreateTable()
 	table.AddRow("NAME", "VERSION")
 	for _, release := range releases {
@@ -91,3 +111,41 @@ func (o *GetReleaseOptions) Run() error {
 	table.Render()
 	return nil
 }
+
+// renderComponents displays the components of the software bill of materials of each release
+func (o *GetReleaseOptions) renderComponents(releases []v1.Release) error {
+	authSvc, err := o.CreateGitAuthConfigService()
+	if err != nil {
+		return err
+	}
+	httpFn := CreateBucketHTTPFn(authSvc)
+	table := o.CreateTable()
+	table.AddRow("NAME", "VERSION", "COMPONENT", "COMPONENT VERSION")
+	for _, release := range releases {
+		u := release.Spec.SBOMURL
+		if u == "" {
+			if o.Component == "" {
+				table.AddRow(release.Spec.Name, release.Spec.Version, "", "")
+			}
+			continue
+		}
+		data, err := buckets.ReadURL(u, o.Timeout, httpFn)
+		if err != nil {
+			log.Warnf("Failed to read the SBOM of %s version %s from %s: %s\n", release.Spec.Name, release.Spec.Version, u, err)
+			continue
+		}
+		components, err := sbom.Unmarshal(data)
+		if err != nil {
+			log.Warnf("Failed to parse the SBOM of %s version %s from %s: %s\n", release.Spec.Name, release.Spec.Version, u, err)
+			continue
+		}
+		for _, c := range components {
+			if o.Component != "" && !strings.Contains(c.Description(), o.Component) && !strings.Contains(c.PURL(), o.Component) {
+				continue
+			}
+			table.AddRow(release.Spec.Name, release.Spec.Version, c.Description(), c.Version)
+		}
+	}
+	table.Render()
+	return nil
+}
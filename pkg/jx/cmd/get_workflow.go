@@ -114,6 +114,7 @@ func (o *GetWorkflowOptions) getWorkflow(name string, jxClient versioned.Interfa
 				lines = append(lines, lastSummary)
 			}
 			lastSummary.Resources = append(lastSummary.Resources, promote.Environment)
+			lastSummary.Gates = append(lastSummary.Gates, step.Preconditions.Gates...)
 			if len(step.Preconditions.Environments) > 0 {
 				lastSummary = nil
 			}
@@ -123,7 +124,11 @@ func (o *GetWorkflowOptions) getWorkflow(name string, jxClient versioned.Interfa
 		if i > 0 {
 			log.Info("    |\n")
 		}
-		log.Infof("%s to %s\n", summary.Action, strings.Join(summary.Resources, " + "))
+		if len(summary.Gates) > 0 {
+			log.Infof("%s to %s when %s\n", summary.Action, strings.Join(summary.Resources, " + "), strings.Join(summary.Gates, " and "))
+		} else {
+			log.Infof("%s to %s\n", summary.Action, strings.Join(summary.Resources, " + "))
+		}
 	}
 	return nil
 }
@@ -131,4 +136,5 @@ func (o *GetWorkflowOptions) getWorkflow(name string, jxClient versioned.Interfa
 type StepSummary struct {
 	Action    string
 	Resources []string
+	Gates     []string
 }
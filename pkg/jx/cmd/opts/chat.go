@@ -18,6 +18,9 @@ func (o *CommonOptions) CreateChatProvider(chatConfig *config.ChatConfig) (chats
 	config := authConfigSvc.Config()
 
 	server := config.GetOrCreateServer(u)
+	if !chats.RequiresUserAuth(server.Kind) {
+		return chats.CreateChatProvider(server.Kind, server, nil, o.BatchMode)
+	}
 	userAuth, err := config.PickServerUserAuth(server, "user to access the chat service at "+u, o.BatchMode, "", o.In, o.Out, o.Err)
 	if err != nil {
 		return nil, err
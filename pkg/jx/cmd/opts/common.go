@@ -404,7 +404,7 @@ func (o *CommonOptions) JXClientDevAndAdminNamespace() (versioned.Interface, str
 // Git returns the git client
 func (o *CommonOptions) Git() gits.Gitter {
 	if o.git == nil {
-		o.git = gits.NewGitCLI()
+		o.git = gits.NewGitter()
 	}
 	return o.git
 }
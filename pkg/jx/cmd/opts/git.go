@@ -333,6 +333,23 @@ func (o *CommonOptions) GitProviderForGitServerURL(gitServiceUrl string, gitKind
 	return gits.CreateProviderForURL(o.factory.IsInCluster(), authConfigSvc, gitKind, gitServiceUrl, o.Git(), o.BatchMode, o.In, o.Out, o.Err)
 }
 
+// CachingGitProvider decorates the git provider with conditional requests, caching and rate limit handling for
+// commands which make many requests. The caching is configured via the $JX_GIT_CACHE_* and $JX_GIT_RATE_LIMIT_*
+// environment variables
+func (o *CommonOptions) CachingGitProvider(provider gits.GitProvider) gits.GitProvider {
+	if provider == nil {
+		return nil
+	}
+	return gits.NewCachingGitProvider(provider, gits.DefaultCachingOptions())
+}
+
+// LogGitProviderMetrics logs how the requests of a caching git provider have been served
+func (o *CommonOptions) LogGitProviderMetrics(provider gits.GitProvider) {
+	if caching, ok := provider.(*gits.CachingGitProvider); ok {
+		log.Debugf("Git provider %s %s\n", provider.ServerURL(), caching.Metrics().String())
+	}
+}
+
 // CreateGitProviderForURLWithoutKind creates a git provider from URL wihtout kind
 func (o *CommonOptions) CreateGitProviderForURLWithoutKind(gitURL string) (gits.GitProvider, *gits.GitRepository, error) {
 	gitInfo, err := gits.ParseGitURL(gitURL)
@@ -0,0 +1,105 @@
+package opts
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/io/secrets"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/openpgp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ReleaseGPGData returns the data of the release GPG secret. If secrets are stored in Vault it is read from Vault,
+// where the keys must be armored, otherwise it is read from the Secret in the development or current namespace
+func (o *CommonOptions) ReleaseGPGData() (map[string][]byte, error) {
+	name := kube.SecretJenkinsReleaseGPG
+	if o.GetSecretsLocation() == secrets.VaultLocationKind {
+		client, err := o.SystemVaultClient("")
+		if err != nil {
+			return nil, err
+		}
+		values, err := client.Read(name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading the %s secret from Vault", name)
+		}
+		data := map[string][]byte{}
+		for k, v := range values {
+			if text, ok := v.(string); ok {
+				data[k] = []byte(text)
+			}
+		}
+		return data, nil
+	}
+	kubeClient, curNs, err := o.KubeClientAndNamespace()
+	if err != nil {
+		return nil, err
+	}
+	ns, _, err := kube.GetDevNamespace(kubeClient, curNs)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := kubeClient.CoreV1().Secrets(ns).Get(name, metav1.GetOptions{})
+	if err != nil && curNs != ns {
+		secret, err = kubeClient.CoreV1().Secrets(curNs).Get(name, metav1.GetOptions{})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find secret %s in namespace %s due to: %s", name, ns, err)
+	}
+	return secret.Data, nil
+}
+
+// ReleaseSigningKey returns the GPG private key of the release GPG secret used to sign release tags. If the keyID is
+// specified the key whose ID or identity matches it is used
+func (o *CommonOptions) ReleaseSigningKey(keyID string) (*openpgp.Entity, error) {
+	data, err := o.ReleaseGPGData()
+	if err != nil {
+		return nil, err
+	}
+	key := data[kube.SecretDataGPGSigningKey]
+	if len(key) == 0 {
+		key = data[kube.SecretDataGPGSecretKeyring]
+	}
+	if len(key) == 0 {
+		return nil, fmt.Errorf("no %s or %s found in the %s secret", kube.SecretDataGPGSigningKey,
+			kube.SecretDataGPGSecretKeyring, kube.SecretJenkinsReleaseGPG)
+	}
+	return gits.ReadSigningKey(key, string(data[kube.SecretDataGPGPassphrase]), keyID)
+}
+
+// TrustedReleaseKeys returns the GPG public keys trusted to sign release tags from the keyring file or, if no file is
+// specified, from the release GPG secret
+func (o *CommonOptions) TrustedReleaseKeys(keyringFile string) (openpgp.EntityList, error) {
+	if keyringFile != "" {
+		data, err := ioutil.ReadFile(keyringFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading the keyring %s", keyringFile)
+		}
+		return gits.ReadKeyRing(data)
+	}
+	data, err := o.ReleaseGPGData()
+	if err != nil {
+		return nil, err
+	}
+	keys := data[kube.SecretDataGPGTrustedKeys]
+	if len(keys) == 0 {
+		keys = data[kube.SecretDataGPGPublicKeyring]
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no %s or %s found in the %s secret", kube.SecretDataGPGTrustedKeys,
+			kube.SecretDataGPGPublicKeyring, kube.SecretJenkinsReleaseGPG)
+	}
+	return gits.ReadKeyRing(keys)
+}
+
+// VerifyReleaseTag checks that the tag in the git repository at the given directory is signed by one of the trusted
+// release keys returning the key which signed it
+func (o *CommonOptions) VerifyReleaseTag(dir string, tag string, keyringFile string) (*openpgp.Entity, error) {
+	keyring, err := o.TrustedReleaseKeys(keyringFile)
+	if err != nil {
+		return nil, err
+	}
+	return o.Git().VerifyTag(dir, tag, keyring)
+}
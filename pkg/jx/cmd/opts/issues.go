@@ -76,5 +76,5 @@ func (o *CommonOptions) CreateIssueProvider(dir string) (issues.IssueProvider, e
 	if err != nil {
 		return nil, err
 	}
-	return issues.CreateGitIssueProvider(gitProvider, gitInfo.Organisation, gitInfo.Name)
+	return issues.CreateGitIssueProvider(o.CachingGitProvider(gitProvider), gitInfo.Organisation, gitInfo.Name)
 }
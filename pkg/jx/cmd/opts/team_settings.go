@@ -242,6 +242,32 @@ func (o *CommonOptions) RegisterPipelineActivityCRD() error {
 	return nil
 }
 
+// RegisterFactCRD registers Fact CRD
+func (o *CommonOptions) RegisterFactCRD() error {
+	apisClient, err := o.ApiExtensionsClient()
+	if err != nil {
+		return err
+	}
+	err = kube.RegisterFactCRD(apisClient)
+	if err != nil {
+		return errors.Wrap(err, "failed to register the Fact CRD")
+	}
+	return nil
+}
+
+// RegisterSchedulerCRD registers Scheduler CRD
+func (o *CommonOptions) RegisterSchedulerCRD() error {
+	apisClient, err := o.ApiExtensionsClient()
+	if err != nil {
+		return err
+	}
+	err = kube.RegisterSchedulerCRD(apisClient)
+	if err != nil {
+		return errors.Wrap(err, "failed to register the Scheduler CRD")
+	}
+	return nil
+}
+
 // RegisterWorkflowCRD registers Workflow CRD
 func (o *CommonOptions) RegisterWorkflowCRD() error {
 	apisClient, err := o.ApiExtensionsClient()
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -18,14 +19,18 @@ import (
 
 	"github.com/blang/semver"
 	v1 "github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/client/clientset/versioned"
 	typev1 "github.com/jenkins-x/jx/pkg/client/clientset/versioned/typed/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/cve"
 	"github.com/jenkins-x/jx/pkg/gits"
 	"github.com/jenkins-x/jx/pkg/helm"
+	"github.com/jenkins-x/jx/pkg/imagesign"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/opts"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/jenkins-x/jx/pkg/log"
 	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/jenkins-x/jx/pkg/workflow"
 	"github.com/spf13/cobra"
 	survey "gopkg.in/AlecAivazis/survey.v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -65,6 +70,11 @@ type PromoteOptions struct {
 	PullRequestPollTime     string
 	Filter                  string
 	Alias                   string
+	RequireSignedTag        bool
+	RequireSignedImages     bool
+	TrustedKeyring          string
+	InsecureRegistries      []string
+	Gates                   []string
 
 	// allow git to be configured externally before a PR is created
 	ConfigureGitCallback environments.ConfigureGitFn
@@ -103,6 +113,15 @@ var (
 		# Promote a version of the myapp application to production
 		jx promote myapp --version 1.2.3 --env production
 
+		# Promote a version to production only if all the images of its chart are signed by the team
+		jx promote myapp --version 1.2.3 --env production --require-signed-images
+
+		# Promote a version to production only if its tests pass, its coverage is at least 80% and it has no critical CVEs
+		jx promote myapp --version 1.2.3 --env production --gate "tests.failed == 0" --gate "coverage >= 80" --gate "cve <= High"
+
+		# Promote a version to production only once it has been approved via 'jx step statement --name approved'
+		jx promote myapp --version 1.2.3 --env production --gate "statement.approved == true"
+
 		# To search for all the available charts for a given name use -f.
 		# e.g. to find a redis chart to install
 		jx promote -f redis
@@ -158,6 +177,11 @@ func (options *PromoteOptions) addPromoteOptions(cmd *cobra.Command) {
 	cmd.Flags().BoolVarP(&options.NoPoll, "no-poll", "", false, "Disables polling for Pull Request or Pipeline status")
 	cmd.Flags().BoolVarP(&options.NoWaitAfterMerge, "no-wait", "", false, "Disables waiting for completing promotion after the Pull request is merged")
 	cmd.Flags().BoolVarP(&options.IgnoreLocalFiles, "ignore-local-file", "", false, "Ignores the local file system when deducing the Git repository")
+	cmd.Flags().BoolVarP(&options.RequireSignedTag, "require-signed-tag", "", false, "Refuses to promote the version unless its release tag in the local Git repository is signed with a trusted GPG key")
+	cmd.Flags().BoolVarP(&options.RequireSignedImages, "require-signed-images", "", false, "Refuses to promote the version unless all the images of its rendered chart are signed with a trusted GPG key using 'jx step sign image'")
+	cmd.Flags().StringVarP(&options.TrustedKeyring, "trusted-keyring", "", "", "The keyring file of the GPG keys trusted to sign release tags and images. Defaults to the trusted keys of the "+kube.SecretJenkinsReleaseGPG+" secret")
+	cmd.Flags().StringArrayVarP(&options.InsecureRegistries, "insecure-registry", "", nil, "The registries which are accessed using plain HTTP when verifying image signatures")
+	cmd.Flags().StringArrayVarP(&options.Gates, "gate", "", nil, "A gate such as 'coverage >= 80', 'tests.failed == 0', 'cve <= High' or 'statement.approved == true' which must pass against the Facts of the pipeline and the CVEs of the images before promoting. Facts are recorded by 'jx step coverage', 'jx step test-results' and 'jx step statement'")
 }
 
 // Run implements this command
@@ -333,6 +357,12 @@ func (o *PromoteOptions) Promote(targetNS string, env *v1.Environment, warnIfAut
 		return nil, nil
 	}
 	version := o.Version
+	if o.RequireSignedTag {
+		err := o.verifyReleaseTag(version)
+		if err != nil {
+			return nil, err
+		}
+	}
 	info := util.ColorInfo
 	if version == "" {
 		log.Infof("Promoting latest version of app %s to namespace %s\n", info(app), info(targetNS))
@@ -353,6 +383,12 @@ func (o *PromoteOptions) Promote(targetNS string, env *v1.Environment, warnIfAut
 		FullAppName: fullAppName,
 		Version:     version,
 	}
+	if o.RequireSignedImages {
+		err := o.verifySignedImages(targetNS, releaseName, version)
+		if err != nil {
+			return releaseInfo, err
+		}
+	}
 
 	if warnIfAuto && env != nil && env.Spec.PromotionStrategy == v1.PromotionStrategyTypeAutomatic && !o.BatchMode {
 		log.Infof("%s", util.ColorWarning(fmt.Sprintf("WARNING: The Environment %s is setup to promote automatically as part of the CI/CD Pipelines.\n\n", env.Name)))
@@ -376,6 +412,12 @@ func (o *PromoteOptions) Promote(targetNS string, env *v1.Environment, warnIfAut
 		return releaseInfo, err
 	}
 	promoteKey := o.createPromoteKey(env)
+	if len(o.Gates) > 0 {
+		err = o.checkGates(jxClient, targetNS, releaseName, version, promoteKey)
+		if err != nil {
+			return releaseInfo, err
+		}
+	}
 	if env != nil {
 		source := &env.Spec.Source
 		if source.URL != "" && env.Spec.Kind.IsPermanent() {
@@ -477,6 +519,12 @@ func (o *PromoteOptions) PromoteViaPullRequest(env *v1.Environment, releaseInfo
 	if err != nil {
 		return errors.Wrapf(err, "creating git provider for %s", env.Spec.Source.URL)
 	}
+	if !o.NoMergePullRequest {
+		err = environments.ValidateReviewPolicy(gitProvider, env.Spec.ReviewPolicy)
+		if err != nil {
+			return errors.Wrapf(err, "promoting to environment %s", env.Name)
+		}
+	}
 	environmentsDir, err := o.EnvironmentsDir()
 	if err != nil {
 		return errors.Wrapf(err, "getting environments dir")
@@ -578,6 +626,7 @@ func (o *PromoteOptions) waitForGitOpsPullRequest(ns string, env *v1.Environment
 	logHasMergeSha := false
 	logMergeStatusError := false
 	logNoMergeStatuses := false
+	logReviewPolicy := ""
 	urlStatusMap := map[string]string{}
 	urlStatusTargetURLMap := map[string]string{}
 
@@ -713,11 +762,21 @@ func (o *PromoteOptions) waitForGitOpsPullRequest(ns string, env *v1.Environment
 					} else {
 						if status == "success" {
 							if !o.NoMergePullRequest {
-								err = gitProvider.MergePullRequest(pr, "jx promote automatically merged promotion PR")
+								approved, message, err := environments.ReviewPolicyApproved(gitProvider, pr, env.Spec.ReviewPolicy)
 								if err != nil {
-									if !logMergeFailure {
-										logMergeFailure = true
-										log.Warnf("Failed to merge the Pull Request %s due to %s maybe I don't have karma?\n", pr.URL, err)
+									log.Warnf("Failed to check the review policy of the Pull Request %s due to %s\n", pr.URL, err)
+								} else if !approved {
+									if message != logReviewPolicy {
+										logReviewPolicy = message
+										log.Infof("Pull Request %s is waiting for reviews: %s\n", util.ColorInfo(pr.URL), message)
+									}
+								} else {
+									err = gitProvider.MergePullRequest(pr, "jx promote automatically merged promotion PR")
+									if err != nil {
+										if !logMergeFailure {
+											logMergeFailure = true
+											log.Warnf("Failed to merge the Pull Request %s due to %s maybe I don't have karma?\n", pr.URL, err)
+										}
 									}
 								}
 							}
@@ -775,6 +834,164 @@ func (o *PromoteOptions) findLatestVersion(app string) (string, error) {
 	return maxString, nil
 }
 
+// verifyReleaseTag fails if the release tag of the version is not signed with a trusted GPG key
+func (o *PromoteOptions) verifyReleaseTag(version string) error {
+	if version == "" {
+		return fmt.Errorf("the --version option is required when using --require-signed-tag")
+	}
+	tag := "v" + version
+	err := o.Git().FetchTags("")
+	if err != nil {
+		log.Warnf("Failed to fetch the git tags: %s\n", err)
+	}
+	signer, err := o.VerifyReleaseTag("", tag, o.TrustedKeyring)
+	if err != nil {
+		return errors.Wrapf(err, "refusing to promote version %s as its tag %s does not have a valid signature", version, tag)
+	}
+	log.Infof("Tag %s has a valid signature from %s\n", util.ColorInfo(tag), util.ColorInfo(gits.KeyName(signer)))
+	return nil
+}
+
+// verifySignedImages fails unless all the images of the rendered chart of the version are signed with a trusted GPG key
+func (o *PromoteOptions) verifySignedImages(targetNS string, releaseName string, version string) error {
+	if version == "" {
+		return fmt.Errorf("the --version option is required when using --require-signed-images")
+	}
+	images, err := o.releaseImages(targetNS, releaseName, version)
+	if err != nil {
+		return err
+	}
+	keyring, err := o.TrustedReleaseKeys(o.TrustedKeyring)
+	if err != nil {
+		return err
+	}
+	registry := imagesign.NewRegistry(o.InsecureRegistries)
+	failures := []string{}
+	for _, image := range images {
+		ref, err := imagesign.ParseReference(image)
+		if err == nil {
+			var signature *imagesign.Signature
+			signature, err = imagesign.Verify(registry, ref, keyring)
+			if err == nil {
+				log.Infof("Image %s has a valid signature from %s\n", util.ColorInfo(image), util.ColorInfo(gits.KeyName(signature.Signer)))
+				continue
+			}
+		}
+		failures = append(failures, fmt.Sprintf("%s: %s", image, err))
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("refusing to promote version %s as its images do not have valid signatures:\n%s", version, strings.Join(failures, "\n"))
+	}
+	return nil
+}
+
+// releaseImages returns the images of the rendered chart of the version
+func (o *PromoteOptions) releaseImages(targetNS string, releaseName string, version string) ([]string, error) {
+	app := o.Application
+	dir, err := ioutil.TempDir("", "jx-promote-"+app)
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	err = o.Helm().FetchChart(app, version, true, dir, o.HelmRepositoryURL, "", "")
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch the chart of %s version %s from %s", app, version, o.HelmRepositoryURL)
+	}
+	outputDir := filepath.Join(dir, "output")
+	err = o.Helm().Template(filepath.Join(dir, app), releaseName, targetNS, outputDir, false, nil, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to render the chart of %s version %s", app, version)
+	}
+	images, err := imagesign.DirManifestImages(outputDir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find the images of the chart of %s version %s", app, version)
+	}
+	return images, nil
+}
+
+// checkGates evaluates the gates against the Facts of the pipeline being promoted and the vulnerabilities of the images
+// of the version. The results are recorded on the PipelineActivity and it fails if any gate does not pass
+func (o *PromoteOptions) checkGates(jxClient versioned.Interface, targetNS string, releaseName string, version string, promoteKey *kube.PromoteStepActivityKey) error {
+	facts := []v1.Fact{}
+	if promoteKey.Pipeline != "" {
+		var err error
+		facts, err = kube.GetFacts(jxClient, o.Namespace, kube.PipelineFactLabels(promoteKey.Pipeline, promoteKey.Build))
+		if err != nil {
+			return err
+		}
+	} else {
+		log.Warnf("No pipeline found for app %s so its gates are evaluated without any Facts\n", o.Application)
+	}
+	inputs := &workflow.GateInputs{
+		Facts: facts,
+		Vulnerabilities: func() ([]cve.Vulnerability, error) {
+			return o.releaseVulnerabilities(targetNS, releaseName, version)
+		},
+	}
+	results, err := workflow.EvaluateGates(o.Gates, inputs)
+	if err != nil {
+		return err
+	}
+	for _, result := range results {
+		if result.Passed {
+			log.Infof("Gate %s passed: %s\n", util.ColorInfo(result.Gate), result.Message)
+		} else {
+			log.Warnf("Gate %s failed: %s\n", result.Gate, result.Message)
+		}
+	}
+	message := workflow.FailedGatesMessage(results)
+	recordGates := func(a *v1.PipelineActivity, s *v1.PipelineActivityStep, ps *v1.PromoteActivityStep, p *v1.PromoteGatesStep) error {
+		p.Results = results
+		p.Description = message
+		if version != "" && a.Spec.Version == "" {
+			a.Spec.Version = version
+		}
+		if message != "" {
+			return kube.FailedPromotionGates(a, s, ps, p)
+		}
+		return kube.CompletePromotionGates(a, s, ps, p)
+	}
+	err = promoteKey.OnPromoteGates(jxClient, o.Namespace, recordGates)
+	if err != nil {
+		log.Warnf("Failed to update PipelineActivity: %s\n", err)
+	}
+	if message != "" {
+		return fmt.Errorf("refusing to promote app %s version %s as its gates failed: %s", o.Application, version, message)
+	}
+	return nil
+}
+
+// releaseVulnerabilities returns the vulnerabilities the CVE provider found in the images of the version
+func (o *PromoteOptions) releaseVulnerabilities(targetNS string, releaseName string, version string) ([]cve.Vulnerability, error) {
+	if version == "" {
+		return nil, fmt.Errorf("the --version option is required when using cve gates")
+	}
+	provider, err := createCVEProvider(o.CommonOptions)
+	if err != nil {
+		return nil, err
+	}
+	images, err := o.releaseImages(targetNS, releaseName, version)
+	if err != nil {
+		return nil, err
+	}
+	answer := []cve.Vulnerability{}
+	for _, image := range images {
+		ref, err := imagesign.ParseReference(image)
+		if err != nil {
+			return nil, err
+		}
+		vulnerabilities, err := provider.GetImageVulnerabilities(cve.CVEQuery{
+			ImageName: ref.Repository,
+			Vesion:    ref.Tag,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to find the vulnerabilities of image %s", image)
+		}
+		answer = append(answer, vulnerabilities...)
+	}
+	return answer, nil
+}
+
 func (o *PromoteOptions) verifyHelmConfigured() error {
 	helmHomeDir := filepath.Join(util.HomeDir(), ".helm")
 	exists, err := util.FileExists(helmHomeDir)
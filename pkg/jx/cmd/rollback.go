@@ -0,0 +1,241 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	v1 "github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/environments"
+	"github.com/jenkins-x/jx/pkg/helm"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/opts"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+// RollbackOptions contains the command line flags
+type RollbackOptions struct {
+	PromoteOptions
+
+	ToVersion string
+	AutoMerge bool
+}
+
+var (
+	rollbackLong = templates.LongDesc(`
+		Rolls back an application in a permanent environment to a previous version.
+
+		A Pull Request is created on the GitOps repository of the environment which pins the application to the previous version.
+		The previous version is found from the history of the environment repository or, if the application has only been promoted once, from the Releases of the application.
+
+		The rollback is recorded as a promotion in the PipelineActivity of the application.
+`)
+
+	rollbackExample = templates.Examples(`
+		# Roll back myapp in production to the version promoted before the current one
+		jx rollback --env production --app myapp
+
+		# Roll back myapp in production to version 1.2.3 and merge the Pull Request once its checks pass
+		jx rollback --env production --app myapp --to 1.2.3 --auto-merge
+	`)
+)
+
+// NewCmdRollback creates the new command for: jx rollback
+func NewCmdRollback(commonOpts *opts.CommonOptions) *cobra.Command {
+	options := &RollbackOptions{
+		PromoteOptions: PromoteOptions{
+			CommonOptions: commonOpts,
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "rollback [application]",
+		Short:   "Rolls back an application in an Environment to a previous version",
+		Long:    rollbackLong,
+		Example: rollbackExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Environment, opts.OptionEnvironment, "e", "", "The Environment to roll back")
+	cmd.Flags().StringVarP(&options.Application, opts.OptionApplication, "a", "", "The Application to roll back")
+	cmd.Flags().StringVarP(&options.ToVersion, "to", "", "", "The version to roll back to. Defaults to the version promoted before the current version")
+	cmd.Flags().BoolVarP(&options.AutoMerge, "auto-merge", "", false, "Waits for the checks of the Pull Request to pass and merges it")
+	cmd.Flags().StringVarP(&options.Timeout, opts.OptionTimeout, "t", "1h", "The timeout to wait for the Pull Request to merge when using --auto-merge")
+	cmd.Flags().StringVarP(&options.PullRequestPollTime, optionPullRequestPollTime, "", "20s", "Poll time when waiting for a Pull Request to merge")
+	cmd.Flags().BoolVarP(&options.NoWaitAfterMerge, "no-wait", "", false, "Disables waiting for the environment pipeline to complete after the Pull Request is merged")
+	return cmd
+}
+
+// Run implements this command
+func (o *RollbackOptions) Run() error {
+	app := o.Application
+	if app == "" && len(o.Args) > 0 {
+		app = o.Args[0]
+	}
+	if app == "" {
+		return util.MissingOption(opts.OptionApplication)
+	}
+	o.Application = app
+	if o.Environment == "" {
+		return util.MissingOption(opts.OptionEnvironment)
+	}
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	o.Namespace = ns
+	o.Activities = jxClient.JenkinsV1().PipelineActivities(ns)
+	// the local directory is not the source of the application being rolled back
+	o.IgnoreLocalFiles = true
+
+	duration, err := time.ParseDuration(o.PullRequestPollTime)
+	if err != nil {
+		return fmt.Errorf("Invalid duration format %s for option --%s: %s", o.PullRequestPollTime, optionPullRequestPollTime, err)
+	}
+	o.PullRequestPollDuration = &duration
+	timeout, err := time.ParseDuration(o.Timeout)
+	if err != nil {
+		return fmt.Errorf("Invalid duration format %s for option --%s: %s", o.Timeout, opts.OptionTimeout, err)
+	}
+	o.TimeoutDuration = &timeout
+
+	targetNS, env, err := o.GetTargetNamespace("", o.Environment)
+	if err != nil {
+		return err
+	}
+	if env.Spec.Source.URL == "" || !env.Spec.Kind.IsPermanent() {
+		return fmt.Errorf("the environment %s is not a GitOps environment so it cannot be rolled back with a Pull Request. Use 'jx promote' to install a previous version", env.Name)
+	}
+
+	gitProvider, _, err := o.CreateGitProviderForURLWithoutKind(env.Spec.Source.URL)
+	if err != nil {
+		return errors.Wrapf(err, "creating git provider for %s", env.Spec.Source.URL)
+	}
+	if o.AutoMerge {
+		err = environments.ValidateReviewPolicy(gitProvider, env.Spec.ReviewPolicy)
+		if err != nil {
+			return errors.Wrapf(err, "rolling back environment %s", env.Name)
+		}
+	}
+	environmentsDir, err := o.EnvironmentsDir()
+	if err != nil {
+		return errors.Wrapf(err, "getting environments dir")
+	}
+	options := environments.EnvironmentPullRequestOptions{
+		ConfigGitFn: o.ConfigureGitCallback,
+		Gitter:      o.Git(),
+		GitProvider: gitProvider,
+	}
+	dir, _, _, _, err := options.PullEnvironmentRepo(env, environmentsDir)
+	if err != nil {
+		return errors.Wrapf(err, "pulling environment repo %s into %s", env.Spec.Source.URL, environmentsDir)
+	}
+	history, err := environments.AppVersionHistory(o.Git(), dir, app)
+	if err != nil {
+		return err
+	}
+	if len(history) == 0 {
+		return fmt.Errorf("the app %s has not been promoted to the environment %s", app, env.Name)
+	}
+	current := history[0]
+	releases, err := kube.GetOrderedReleases(jxClient, ns, app)
+	if err != nil {
+		return err
+	}
+	version, err := rollbackVersion(app, current, o.ToVersion, history, releases)
+	if err != nil {
+		return err
+	}
+	log.Infof("Rolling back app %s in environment %s from version %s to %s\n", util.ColorInfo(app), util.ColorInfo(env.Name),
+		util.ColorInfo(current), util.ColorInfo(version))
+
+	o.Version = version
+	o.ReleaseName = targetNS + "-" + app
+	releaseInfo := &ReleaseInfo{
+		ReleaseName: o.ReleaseName,
+		FullAppName: app,
+		Version:     version,
+	}
+	details := environments.PullRequestDetails{
+		BranchName: "rollback-" + app + "-" + version,
+		Title:      "rollback " + app + " to " + version,
+		Message:    fmt.Sprintf("Roll back %s from version %s to %s", app, current, version),
+	}
+	options.ModifyChartFn = func(requirements *helm.Requirements, metadata *chart.Metadata, values map[string]interface{},
+		templates map[string]string, dir string, details *environments.PullRequestDetails) error {
+		dep := environments.FindAppDependency(requirements, app)
+		if dep == nil {
+			return fmt.Errorf("the app %s is not in the requirements of the environment %s", app, env.Name)
+		}
+		dep.Version = version
+		return nil
+	}
+	info, err := options.Create(env, environmentsDir, &details, nil, "")
+	if err != nil {
+		return err
+	}
+	if info == nil || info.PullRequest == nil {
+		return fmt.Errorf("no Pull Request was created to roll back %s to version %s", app, version)
+	}
+	releaseInfo.PullRequestInfo = info
+	log.Infof("Created Pull Request %s\n", util.ColorInfo(info.PullRequest.URL))
+
+	promoteKey := o.createPromoteKey(env)
+	startRollbackPR := func(a *v1.PipelineActivity, s *v1.PipelineActivityStep, ps *v1.PromoteActivityStep, p *v1.PromotePullRequestStep) error {
+		kube.StartPromotionPullRequest(a, s, ps, p)
+		p.PullRequestURL = info.PullRequest.URL
+		if a.Spec.Version == "" {
+			a.Spec.Version = version
+		}
+		return nil
+	}
+	err = promoteKey.OnPromotePullRequest(jxClient, ns, startRollbackPR)
+	if err != nil {
+		log.Warnf("Failed to update PipelineActivity: %s\n", err)
+	}
+	if !o.AutoMerge {
+		return nil
+	}
+	// lets sleep a little before we try poll for the PR status
+	time.Sleep(waitAfterPullRequestCreated)
+	return o.WaitForPromotion(targetNS, env, releaseInfo)
+}
+
+// rollbackVersion returns the version to roll back to from the current version. If no version is specified it is the
+// version promoted before the current version was first introduced in the environment history or, if there is none,
+// the Release before the current one. The releases must be ordered with the latest version first
+func rollbackVersion(app string, current string, to string, history []string, releases []v1.Release) (string, error) {
+	released := []string{}
+	for _, release := range releases {
+		if release.Spec.Name == app && release.Spec.Version != "" {
+			released = append(released, release.Spec.Version)
+		}
+	}
+	if to != "" {
+		version := strings.TrimPrefix(to, "v")
+		if version == current {
+			return "", fmt.Errorf("the app %s is already at version %s", app, version)
+		}
+		if util.StringArrayIndex(history, version) < 0 && util.StringArrayIndex(released, version) < 0 {
+			return "", fmt.Errorf("version %s of the app %s has not been released", version, app)
+		}
+		return version, nil
+	}
+	previous := environments.VersionBeforeCurrent(history)
+	if previous != "" {
+		return previous, nil
+	}
+	idx := util.StringArrayIndex(released, current)
+	if idx >= 0 && idx+1 < len(released) {
+		return released[idx+1], nil
+	}
+	return "", fmt.Errorf("no version of the app %s was released before %s. Use --to to specify the version", app, current)
+}
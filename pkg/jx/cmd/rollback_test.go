@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"testing"
+
+	v1 "github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRollbackVersion(t *testing.T) {
+	t.Parallel()
+	release := func(name string, version string) v1.Release {
+		return v1.Release{Spec: v1.ReleaseSpec{Name: name, Version: version}}
+	}
+	releases := []v1.Release{
+		release("myapp", "1.0.3"),
+		release("myapp2", "2.0.0"),
+		release("myapp", "1.0.2"),
+		release("myapp", "1.0.1"),
+	}
+	testCases := []struct {
+		current  string
+		to       string
+		history  []string
+		expected string
+		fails    bool
+	}{
+		{"1.0.2", "", []string{"1.0.2", "1.0.1"}, "1.0.1", false},
+		{"1.0.3", "", []string{"1.0.3"}, "1.0.2", false},
+		// the second of two rollbacks in a row skips the version the first one rolled back from
+		{"1.0.2", "", []string{"1.0.2", "1.0.3", "1.0.2", "1.0.1"}, "1.0.1", false},
+		{"1.0.1", "", []string{"1.0.1", "1.0.2", "1.0.3", "1.0.2", "1.0.1"}, "", true},
+		{"1.0.1", "", []string{"1.0.1"}, "", true},
+		{"1.0.3", "v1.0.1", []string{"1.0.3"}, "1.0.1", false},
+		{"1.0.3", "1.0.0", []string{"1.0.3", "1.0.0"}, "1.0.0", false},
+		{"1.0.3", "2.0.0", []string{"1.0.3"}, "", true},
+		{"1.0.3", "1.0.3", []string{"1.0.3", "1.0.2"}, "", true},
+	}
+	for _, tc := range testCases {
+		version, err := rollbackVersion("myapp", tc.current, tc.to, tc.history, releases)
+		if tc.fails {
+			assert.Error(t, err, "current %s to %s", tc.current, tc.to)
+			continue
+		}
+		assert.NoError(t, err, "current %s to %s", tc.current, tc.to)
+		assert.Equal(t, tc.expected, version, "current %s to %s", tc.current, tc.to)
+	}
+}
@@ -37,9 +37,11 @@ func NewCmdStep(commonOpts *opts.CommonOptions) *cobra.Command {
 	cmd.AddCommand(NewCmdStepBlog(commonOpts))
 	cmd.AddCommand(NewCmdStepChangelog(commonOpts))
 	cmd.AddCommand(NewCmdStepCredential(commonOpts))
+	cmd.AddCommand(NewCmdStepCoverage(commonOpts))
 	cmd.AddCommand(NewCmdStepCreate(commonOpts))
 	cmd.AddCommand(NewCmdStepCustomPipeline(commonOpts))
 	cmd.AddCommand(NewCmdStepEnv(commonOpts))
+	cmd.AddCommand(NewCmdStepExport(commonOpts))
 	cmd.AddCommand(NewCmdStepGet(commonOpts))
 	cmd.AddCommand(NewCmdStepGit(commonOpts))
 	cmd.AddCommand(NewCmdStepGpgCredentials(commonOpts))
@@ -51,9 +53,14 @@ func NewCmdStep(commonOpts *opts.CommonOptions) *cobra.Command {
 	cmd.AddCommand(NewCmdStepPre(commonOpts))
 	cmd.AddCommand(NewCmdStepPR(commonOpts))
 	cmd.AddCommand(NewCmdStepPost(commonOpts))
+	cmd.AddCommand(NewCmdStepProvenance(commonOpts))
 	cmd.AddCommand(NewCmdStepRelease(commonOpts))
+	cmd.AddCommand(NewCmdStepSBOM(commonOpts))
+	cmd.AddCommand(NewCmdStepSign(commonOpts))
 	cmd.AddCommand(NewCmdStepSplitMonorepo(commonOpts))
+	cmd.AddCommand(NewCmdStepStatement(commonOpts))
 	cmd.AddCommand(NewCmdStepTag(commonOpts))
+	cmd.AddCommand(NewCmdStepTestResults(commonOpts))
 	cmd.AddCommand(NewCmdStepValidate(commonOpts))
 	cmd.AddCommand(NewCmdStepVerify(commonOpts))
 	cmd.AddCommand(NewCmdStepWaitForArtifact(commonOpts))
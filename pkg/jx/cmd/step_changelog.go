@@ -51,6 +51,10 @@ type StepChangelogOptions struct {
 	Footer              string
 	FooterFile          string
 	OutputMarkdownFile  string
+	Template            string
+	TemplateFile        string
+	ChangelogFile       string
+	UpdateChangelog     bool
 	OverwriteCRD        bool
 	GenerateCRD         bool
 	GenerateReleaseYaml bool
@@ -71,6 +75,11 @@ type StepChangelogState struct {
 }
 
 const (
+	// changelogCommitMessagePrefix the prefix of the message of the commits updating the changelog file
+	changelogCommitMessagePrefix = "chore: update the changelog for version"
+	// skipCIMarker asks the CI server not to build the commit of the changelog so that it does not trigger a release
+	skipCIMarker = "[skip ci]"
+
 	ReleaseName = `{{ .Chart.Name }}-{{ .Chart.Version | replace "+" "_" }}`
 
 	SpecName    = `{{ .Chart.Name }}`
@@ -120,6 +129,10 @@ e.g. define environment variables GIT_USERNAME and GIT_API_TOKEN
 
 		This command also generates a Release Custom Resource Definition you can include in your helm chart to give metadata about the changelog of the application along with metadata about the release (git tag, url, commits, issues fixed etc). Including this metadata in a helm charts means we can do things like automatically comment on issues when they hit Staging or Production; or give detailed descriptions of what things have changed when using GitOps to update versions in an environment by referencing the fixed issues in the Pull Request.
 
+		The format of the changelog can be customised with a go template which can use the release, the commits grouped by their Conventional Commits type, the issues, pull requests and contributors. The template is specified via '--template' or '--template-file', otherwise the '`+gits.DefaultChangelogTemplateFile+`' file in the repository is used if it exists, then the changelog template of the team settings.
+
+		The changelog of the version can also be added to the '`+gits.DefaultChangelogFile+`' file of the repository in Keep a Changelog format: https://keepachangelog.com/ and committed via the '--update-changelog' option. The commit is pushed to the branch checked out in the repository, which should be the branch being released. The commit message contains '`+skipCIMarker+`' so that pushing it does not trigger another release and the changelog is not updated if the latest commit already updated it or if HEAD is detached
+
 		You can opt out of the release YAML generation via the '--generate-yaml=false' option
 		
 		To update the release notes on GitHub / Gitea this command needs a git API token.
@@ -136,6 +149,9 @@ e.g. define environment variables GIT_USERNAME and GIT_API_TOKEN
 		# specify the version and a header template
 		jx step changelog --header-file docs/dev/changelog-header.md --version 1.2.3
 
+		# render the changelog with a template and commit it to the CHANGELOG.md file
+		jx step changelog --template-file docs/changelog.tmpl --update-changelog --version 1.2.3
+
 `)
 
 	GitHubIssueRegex = regexp.MustCompile(`(\#\d+)`)
@@ -184,6 +200,10 @@ func NewCmdStepChangelog(commonOpts *opts.CommonOptions) *cobra.Command {
 	cmd.Flags().StringVarP(&options.HeaderFile, "header-file", "", "", "The file name of the changelog header in markdown for the changelog. Can use go template expressions on the ReleaseSpec object: https://golang.org/pkg/text/template/")
 	cmd.Flags().StringVarP(&options.Footer, "footer", "", "", "The changelog footer in markdown for the changelog. Can use go template expressions on the ReleaseSpec object: https://golang.org/pkg/text/template/")
 	cmd.Flags().StringVarP(&options.FooterFile, "footer-file", "", "", "The file name of the changelog footer in markdown for the changelog. Can use go template expressions on the ReleaseSpec object: https://golang.org/pkg/text/template/")
+	cmd.Flags().StringVarP(&options.Template, "template", "", "", "The go template used to render the changelog instead of the default format. Can use the Release, Groups, Issues, PullRequests, Contributors and Breaking fields of the changelog data: https://golang.org/pkg/text/template/")
+	cmd.Flags().StringVarP(&options.TemplateFile, "template-file", "", "", "The file name of the go template used to render the changelog. Defaults to the "+gits.DefaultChangelogTemplateFile+" file in the repository if it exists, then the changelog template of the team settings")
+	cmd.Flags().StringVarP(&options.ChangelogFile, "changelog-file", "", gits.DefaultChangelogFile, "The changelog file in the repository to update with the changelog of the version")
+	cmd.Flags().BoolVarP(&options.UpdateChangelog, "update-changelog", "", false, "Adds the changelog of the version to the changelog file in Keep a Changelog format then commits and pushes it to the current branch without triggering another release")
 
 	return cmd
 }
@@ -313,6 +333,9 @@ func (o *StepChangelogOptions) Run() error {
 	if err != nil {
 		foundGitProvider = false
 		log.Warnf("Could not create GitProvide so cannot update the release notes: %s\n", err)
+	} else {
+		gitProvider = o.CachingGitProvider(gitProvider)
+		defer o.LogGitProviderMetrics(gitProvider)
 	}
 	o.State.GitProvider = gitProvider
 	o.State.FoundIssueNames = map[string]bool{}
@@ -370,7 +393,7 @@ func (o *StepChangelogOptions) Run() error {
 	}
 
 	// lets try to update the release
-	markdown, err := gits.GenerateMarkdown(&release.Spec, gitInfo)
+	markdown, err := o.generateMarkdown(&release.Spec, gitInfo, gitDir)
 	if err != nil {
 		return err
 	}
@@ -383,6 +406,12 @@ func (o *StepChangelogOptions) Run() error {
 		return err
 	}
 	markdown = header + markdown + footer
+	if o.UpdateChangelog {
+		err = o.updateChangelogFile(gitDir, markdown)
+		if err != nil {
+			return err
+		}
+	}
 	if version != "" && o.UpdateRelease && foundGitProvider {
 		releaseInfo := &gits.GitRelease{
 			Name:    version,
@@ -695,6 +724,107 @@ func fullCommitMessageText(commit *object.Commit) string {
 
 }
 
+// generateMarkdown renders the changelog using the changelog template of the command, repository or team if there is
+// one, otherwise using the default format
+func (o *StepChangelogOptions) generateMarkdown(releaseSpec *v1.ReleaseSpec, gitInfo *gits.GitRepository, gitDir string) (string, error) {
+	templateText := o.Template
+	if templateText == "" {
+		templateFile := o.TemplateFile
+		if templateFile == "" {
+			defaultFile := filepath.Join(gitDir, gits.DefaultChangelogTemplateFile)
+			exists, err := util.FileExists(defaultFile)
+			if err != nil {
+				return "", err
+			}
+			if exists {
+				templateFile = defaultFile
+			}
+		}
+		if templateFile != "" {
+			data, err := ioutil.ReadFile(templateFile)
+			if err != nil {
+				return "", errors.Wrapf(err, "reading the changelog template %s", templateFile)
+			}
+			templateText = string(data)
+		}
+	}
+	if templateText == "" {
+		teamSettings, err := o.TeamSettings()
+		if err != nil {
+			log.Warnf("Failed to load the team settings to find the changelog template: %s\n", err)
+		} else {
+			templateText = teamSettings.ChangelogTemplate
+		}
+	}
+	if templateText == "" {
+		return gits.GenerateMarkdown(releaseSpec, gitInfo)
+	}
+	return gits.GenerateChangelog(releaseSpec, gitInfo, templateText)
+}
+
+// updateChangelogFile adds the changelog of the version to the changelog file of the repository, commits it and pushes
+// the commit to the current branch so that the next release starts from the updated changelog
+func (o *StepChangelogOptions) updateChangelogFile(gitDir string, markdown string) error {
+	version := o.Version
+	if version == "" {
+		log.Warnf("Cannot update the changelog file %s as no --version was specified\n", o.ChangelogFile)
+		return nil
+	}
+	branch, err := o.Git().Branch(gitDir)
+	if err != nil {
+		return errors.Wrapf(err, "getting the current branch of %s", gitDir)
+	}
+	if branch == "" || branch == "HEAD" {
+		log.Warnf("Cannot update the changelog file %s as HEAD is detached in %s. Please check out the branch being released\n", o.ChangelogFile, gitDir)
+		return nil
+	}
+	// the push of the changelog commit may trigger another release so never update the changelog twice in a row
+	message, err := o.Git().GetLatestCommitMessage(gitDir)
+	if err == nil && strings.HasPrefix(message, changelogCommitMessagePrefix) {
+		log.Infof("Not updating the changelog file %s as the latest commit already updated it\n", o.ChangelogFile)
+		return nil
+	}
+	fileName := o.ChangelogFile
+	if !filepath.IsAbs(fileName) {
+		fileName = filepath.Join(gitDir, fileName)
+	}
+	exists, err := util.FileExists(fileName)
+	if err != nil {
+		return err
+	}
+	changelog := ""
+	if exists {
+		data, err := ioutil.ReadFile(fileName)
+		if err != nil {
+			return errors.Wrapf(err, "reading the changelog %s", fileName)
+		}
+		changelog = string(data)
+	}
+	changelog = gits.UpdateChangelog(changelog, version, time.Now().Format("2006-01-02"), "", markdown)
+	err = ioutil.WriteFile(fileName, []byte(changelog), util.DefaultWritePermissions)
+	if err != nil {
+		return errors.Wrapf(err, "writing the changelog %s", fileName)
+	}
+	path, err := filepath.Rel(gitDir, fileName)
+	if err != nil {
+		return errors.Wrapf(err, "the changelog %s is not in the repository %s", fileName, gitDir)
+	}
+	err = o.Git().Add(gitDir, path)
+	if err != nil {
+		return err
+	}
+	err = o.Git().CommitIfChanges(gitDir, fmt.Sprintf("%s %s %s", changelogCommitMessagePrefix, version, skipCIMarker))
+	if err != nil {
+		return err
+	}
+	err = o.Git().Push(gitDir)
+	if err != nil {
+		return errors.Wrapf(err, "pushing the changelog %s", path)
+	}
+	log.Infof("Updated the changelog %s\n", util.ColorInfo(fileName))
+	return nil
+}
+
 func (o *StepChangelogOptions) getTemplateResult(releaseSpec *v1.ReleaseSpec, templateName string, templateText string, templateFile string) (string, error) {
 	if templateText == "" {
 		if templateFile == "" {
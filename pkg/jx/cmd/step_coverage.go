@@ -0,0 +1,209 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/config"
+	"github.com/jenkins-x/jx/pkg/coverage"
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/opts"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	defaultCoverageStatusContext = "coverage"
+)
+
+// StepCoverageOptions contains the command line flags
+type StepCoverageOptions struct {
+	StepOptions
+
+	Dir         string
+	ReportFiles []string
+	Format      string
+	BaseBranch  string
+	Context     string
+	Threshold   float64
+	NoStatus    bool
+}
+
+var (
+	stepCoverageLong = templates.LongDesc(`
+		This pipeline step command parses code coverage reports and records the line and branch coverage as a Fact.
+
+		Supported report formats are Go coverprofile, Cobertura XML and JaCoCo XML.
+
+		When running in a Pull Request the coverage is compared against the latest coverage Fact of the base branch
+		and a commit status is set. The status fails if the coverage drops by more than the 'coverage.threshold' percentage
+		configured in the jenkins-x.yml file.
+`)
+
+	stepCoverageExample = templates.Examples(`
+		# record the coverage of a go project
+		jx step coverage --report-file coverage.out
+
+		# record the coverage of a maven project
+		jx step coverage --report-file target/site/jacoco/jacoco.xml
+`)
+)
+
+// NewCmdStepCoverage creates the command
+func NewCmdStepCoverage(commonOpts *opts.CommonOptions) *cobra.Command {
+	options := StepCoverageOptions{
+		StepOptions: StepOptions{
+			CommonOptions: commonOpts,
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "coverage",
+		Short:   "Records the code coverage of a build as a Fact and checks it against the base branch",
+		Long:    stepCoverageLong,
+		Example: stepCoverageExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Dir, "dir", "d", "", "the directory of the project")
+	cmd.Flags().StringArrayVarP(&options.ReportFiles, "report-file", "f", []string{}, "the coverage report files to parse")
+	cmd.Flags().StringVarP(&options.Format, "format", "", "", fmt.Sprintf("the format of the coverage reports. If not specified it is detected from the file. Supported formats are: %s", strings.Join(coverage.Formats, ", ")))
+	cmd.Flags().StringVarP(&options.BaseBranch, "base-branch", "b", "", "the base branch to compare the coverage against. Defaults to $PULL_BASE_REF or master")
+	cmd.Flags().StringVarP(&options.Context, "context", "c", "", "the commit status context. Defaults to the value in jenkins-x.yml or 'coverage'")
+	cmd.Flags().Float64VarP(&options.Threshold, "threshold", "t", 0, "the maximum allowed drop in coverage percentage. Defaults to the value in jenkins-x.yml")
+	cmd.Flags().BoolVarP(&options.NoStatus, "no-status", "", false, "disables setting the commit status on Pull Requests")
+	return cmd
+}
+
+// Run implements this command
+func (o *StepCoverageOptions) Run() error {
+	if len(o.ReportFiles) == 0 {
+		return util.MissingOption("report-file")
+	}
+	report := &coverage.Report{}
+	for _, file := range o.ReportFiles {
+		r, err := coverage.ParseFile(file, o.Format)
+		if err != nil {
+			return err
+		}
+		report.Add(r)
+	}
+	log.Infof("Coverage is %s\n", util.ColorInfo(report.String()))
+
+	projectConfig, _, err := config.LoadProjectConfig(o.Dir)
+	if err != nil {
+		return errors.Wrap(err, "failed to load the project configuration")
+	}
+	coverageConfig := projectConfig.Coverage
+	if coverageConfig == nil {
+		coverageConfig = &config.CoverageConfig{}
+	}
+	if !o.IsFlagExplicitlySet("threshold") {
+		o.Threshold = coverageConfig.Threshold
+	}
+	if o.Context == "" {
+		o.Context = coverageConfig.Context
+	}
+	if o.Context == "" {
+		o.Context = defaultCoverageStatusContext
+	}
+
+	owner, repo, branch, build, err := o.pipelineFactSubject(o.Dir)
+	if err != nil {
+		return err
+	}
+	fact := kube.NewPipelineFact("jx-coverage", v1.FactTypeCoverage, owner+"/"+repo+"/"+branch, build)
+	fact.Spec.Measurements = report.Measurements()
+	err = o.recordPipelineFact(fact)
+	if err != nil {
+		return err
+	}
+	log.Infof("Recorded coverage Fact %s\n", util.ColorInfo(fact.Name))
+
+	if !o.isPullRequest(branch) || o.NoStatus {
+		return nil
+	}
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+
+	baseBranch := o.BaseBranch
+	if baseBranch == "" {
+		baseBranch = os.Getenv("PULL_BASE_REF")
+	}
+	if baseBranch == "" {
+		baseBranch = "master"
+	}
+	baseFact, err := kube.GetLatestFact(jxClient, ns, v1.FactTypeCoverage, map[string]string{
+		v1.FactLabelOrg:    kube.ToValidName(owner),
+		v1.FactLabelRepo:   kube.ToValidName(repo),
+		v1.FactLabelBranch: kube.ToValidName(baseBranch),
+	})
+	if err != nil {
+		return err
+	}
+	comparison := &coverage.Comparison{
+		Current:   report,
+		Threshold: o.Threshold,
+	}
+	if baseFact != nil {
+		comparison.Base, err = coverage.ReportFromFact(baseFact)
+		if err != nil {
+			return err
+		}
+	} else {
+		log.Warnf("No coverage Fact found for base branch %s of %s/%s\n", baseBranch, owner, repo)
+	}
+	return o.updateCoverageStatus(owner, repo, comparison)
+}
+
+func (o *StepCoverageOptions) isPullRequest(branch string) bool {
+	return os.Getenv("PULL_NUMBER") != "" || strings.HasPrefix(strings.ToUpper(branch), "PR-")
+}
+
+func (o *StepCoverageOptions) updateCoverageStatus(owner string, repo string, comparison *coverage.Comparison) error {
+	sha := os.Getenv(PULL_PULL_SHA)
+	if sha == "" {
+		var err error
+		sha, err = o.Git().GetLatestCommitSha(o.Dir)
+		if err != nil {
+			return errors.Wrap(err, "failed to find the latest commit sha")
+		}
+	}
+	_, provider, _, err := o.CreateGitProvider(o.Dir)
+	if err != nil {
+		return err
+	}
+	if provider == nil {
+		return fmt.Errorf("no Git provider could be found for dir %s", o.Dir)
+	}
+	state := "success"
+	if comparison.Failed() {
+		state = "failure"
+	}
+	status := &gits.GitRepoStatus{
+		Context:     o.Context,
+		State:       state,
+		Description: comparison.Description(),
+	}
+	_, err = provider.UpdateCommitStatus(owner, repo, sha, status)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update the %s commit status on %s/%s for %s", o.Context, owner, repo, sha)
+	}
+	if comparison.Failed() {
+		log.Warnf("Coverage gate failed: %s\n", comparison.Description())
+	} else {
+		log.Infof("Coverage gate passed: %s\n", comparison.Description())
+	}
+	return nil
+}
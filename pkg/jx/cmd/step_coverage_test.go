@@ -0,0 +1,114 @@
+package cmd_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/gits"
+	helm_test "github.com/jenkins-x/jx/pkg/helm/mocks"
+	"github.com/jenkins-x/jx/pkg/jx/cmd"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/opts"
+	"github.com/jenkins-x/jx/pkg/kube"
+	resources_test "github.com/jenkins-x/jx/pkg/kube/resources/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestStepCoverageRecordsFact(t *testing.T) {
+	env := map[string]string{
+		"REPO_OWNER":      "myorg",
+		"REPO_NAME":       "myrepo",
+		"BRANCH_NAME":     "master",
+		"JX_BUILD_NUMBER": "3",
+		"PULL_NUMBER":     "",
+	}
+	for k, v := range env {
+		old, ok := os.LookupEnv(k)
+		if ok {
+			defer os.Setenv(k, old)
+		} else {
+			defer os.Unsetenv(k)
+		}
+		if v == "" {
+			os.Unsetenv(k)
+		} else {
+			os.Setenv(k, v)
+		}
+	}
+
+	dir, err := ioutil.TempDir("", "test-step-coverage-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	reportFile := filepath.Join(dir, "coverage.out")
+	err = ioutil.WriteFile(reportFile, []byte("mode: set\nmain.go:1.1,3.2 2 1\nmain.go:4.1,4.10 1 0\n"), 0644)
+	require.NoError(t, err)
+
+	o := &cmd.StepCoverageOptions{
+		StepOptions: cmd.StepOptions{
+			CommonOptions: &opts.CommonOptions{},
+		},
+		Dir:         dir,
+		ReportFiles: []string{reportFile},
+	}
+	cmd.ConfigureTestOptionsWithResources(o.CommonOptions,
+		[]runtime.Object{},
+		[]runtime.Object{},
+		&gits.GitFake{
+			Remotes: []gits.GitRemote{
+				{
+					Name: "origin",
+					URL:  "https://github.com/myorg/myrepo.git",
+				},
+			},
+			CurrentBranch: "master",
+		},
+		nil,
+		helm_test.NewMockHelmer(),
+		resources_test.NewMockInstaller(),
+	)
+	o.Cmd = cmd.NewCmdStepCoverage(o.CommonOptions)
+
+	err = o.Run()
+	require.NoError(t, err)
+
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	require.NoError(t, err)
+	factLabels := map[string]string{
+		v1.FactLabelOrg:    "myorg",
+		v1.FactLabelRepo:   "myrepo",
+		v1.FactLabelBranch: "master",
+	}
+	fact, err := kube.GetLatestFact(jxClient, ns, v1.FactTypeCoverage, factLabels)
+	require.NoError(t, err)
+	require.NotNil(t, fact, "no coverage Fact recorded")
+	assert.Equal(t, "3", fact.Labels[v1.FactLabelBuildNumber])
+	assertCoverageMeasurement(t, fact, v1.MeasurementCount, v1.CodeCoverageMeasurementCoverage, 3)
+	assertCoverageMeasurement(t, fact, v1.MeasurementCount, v1.CodeCoverageMeasurementMissed, 1)
+	assertCoverageMeasurement(t, fact, v1.MeasurementPercent, v1.CodeCoverageMeasurementCoverage, 75)
+
+	// running the same build again updates the existing Fact
+	err = ioutil.WriteFile(reportFile, []byte("mode: set\nmain.go:1.1,4.10 3 1\n"), 0644)
+	require.NoError(t, err)
+	err = o.Run()
+	require.NoError(t, err)
+
+	facts, err := kube.GetFacts(jxClient, ns, factLabels)
+	require.NoError(t, err)
+	require.Len(t, facts, 1)
+	assertCoverageMeasurement(t, &facts[0], v1.MeasurementCount, v1.CodeCoverageMeasurementMissed, 0)
+	assertCoverageMeasurement(t, &facts[0], v1.MeasurementPercent, v1.CodeCoverageMeasurementCoverage, 100)
+}
+
+func assertCoverageMeasurement(t *testing.T, fact *v1.Fact, measurementType string, name string, expected int) {
+	for _, m := range fact.Spec.Measurements {
+		if m.Name == name && m.MeasurementType == measurementType && len(m.Tags) > 0 && m.Tags[0] == v1.CodeCoverageCountTypeLines {
+			assert.Equal(t, expected, m.MeasurementValue, "%s %s measurement", measurementType, name)
+			return
+		}
+	}
+	assert.Fail(t, "missing measurement", "no %s %s measurement for lines in Fact %s", measurementType, name, fact.Name)
+}
@@ -120,6 +120,8 @@ type StepCreateTaskResults struct {
 	PipelineRun    *pipelineapi.PipelineRun
 	Structure      *v1.PipelineStructure
 	PipelineParams []pipelineapi.Param
+	// ParsedPipelineHash the hash of the ParsedPipeline the CRDs were generated from
+	ParsedPipelineHash string
 }
 
 // NewCmdStepCreateTask Creates a new Command object
@@ -558,6 +560,11 @@ func (o *StepCreateTaskOptions) GenerateTektonCRDs(packsDir string, projectConfi
 		return nil, nil, nil, nil, nil, errors.Wrapf(validateErr, "Validation failed for Pipeline")
 	}
 
+	o.Results.ParsedPipelineHash, err = parsed.Hash()
+	if err != nil {
+		return nil, nil, nil, nil, nil, err
+	}
+
 	pipeline, tasks, structure, err = parsed.GenerateCRDs(pipelineResourceName, o.BuildNumber, ns, o.PodTemplates, o.GetDefaultTaskInputs().Params, o.SourceName)
 	if err != nil {
 		return nil, nil, nil, nil, nil, errors.Wrapf(err, "Generation failed for Pipeline")
@@ -994,6 +1001,13 @@ func (o *StepCreateTaskOptions) applyPipeline(pipeline *pipelineapi.Pipeline, ta
 
 	structure.OwnerReferences = []metav1.OwnerReference{pipelineOwnerReference}
 	run.OwnerReferences = []metav1.OwnerReference{pipelineOwnerReference}
+	if o.Results.ParsedPipelineHash != "" {
+		// lets record the pipeline that was run for the build provenance
+		if run.Annotations == nil {
+			run.Annotations = map[string]string{}
+		}
+		run.Annotations[syntax.AnnotationParsedPipelineHash] = o.Results.ParsedPipelineHash
+	}
 
 	_, err = tekton.CreatePipelineRun(tektonClient, ns, run)
 	if err != nil {
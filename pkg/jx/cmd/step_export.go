@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"github.com/jenkins-x/jx/pkg/jx/cmd/opts"
+	"github.com/spf13/cobra"
+)
+
+// StepExportOptions contains the command line flags
+type StepExportOptions struct {
+	StepOptions
+}
+
+// NewCmdStepExport Creates a new Command object for the "step export" command
+func NewCmdStepExport(commonOpts *opts.CommonOptions) *cobra.Command {
+	options := &StepExportOptions{
+		StepOptions: StepOptions{
+			CommonOptions: commonOpts,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "export [command]",
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.AddCommand(NewCmdStepExportTrace(commonOpts))
+	return cmd
+}
+
+// Run implements this command
+func (o *StepExportOptions) Run() error {
+	return o.Cmd.Help()
+}
@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/opts"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/tracing"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	otlpEndpointEnvVar = "OTEL_EXPORTER_OTLP_ENDPOINT"
+)
+
+// StepExportTraceOptions contains the command line flags
+type StepExportTraceOptions struct {
+	StepOptions
+
+	Dir          string
+	Activity     string
+	OTLPEndpoint string
+	OTLPHeaders  []string
+	OutputFile   string
+}
+
+var (
+	stepExportTraceLong = templates.LongDesc(`
+		Exports a pipeline activity as a distributed trace.
+
+		The pipeline is the root span with child spans for each stage and step along with any preview and promotion steps.
+		The trace is either sent to an OpenTelemetry collector using OTLP/HTTP or written as a Jaeger JSON file which can be
+		opened in the Jaeger UI to see where the pipeline spends its time.
+
+		If no OTLP endpoint is specified via the '--otlp-endpoint' flag or the $OTEL_EXPORTER_OTLP_ENDPOINT environment variable
+		a Jaeger JSON file is written.
+`)
+
+	stepExportTraceExample = templates.Examples(`
+		# export the trace of the current pipeline to an OpenTelemetry collector
+		jx step export trace --otlp-endpoint http://otel-collector:4318
+
+		# write the trace of a pipeline activity as a Jaeger JSON file
+		jx step export trace --activity myorg-myapp-master-1 -o trace.json
+`)
+)
+
+// NewCmdStepExportTrace creates the command
+func NewCmdStepExportTrace(commonOpts *opts.CommonOptions) *cobra.Command {
+	options := &StepExportTraceOptions{
+		StepOptions: StepOptions{
+			CommonOptions: commonOpts,
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "trace",
+		Short:   "Exports a pipeline activity as a distributed trace over OTLP/HTTP or as a Jaeger JSON file",
+		Long:    stepExportTraceLong,
+		Example: stepExportTraceExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Dir, "dir", "d", ".", "The directory of the git repository used to find the current pipeline")
+	cmd.Flags().StringVarP(&options.Activity, "activity", "a", "", "The name of the PipelineActivity to export. Defaults to the current pipeline")
+	cmd.Flags().StringVarP(&options.OTLPEndpoint, "otlp-endpoint", "e", "", "The OTLP/HTTP endpoint of the OpenTelemetry collector. Defaults to $"+otlpEndpointEnvVar)
+	cmd.Flags().StringArrayVarP(&options.OTLPHeaders, "otlp-header", "", nil, "Additional HTTP headers to send to the collector in the form 'name=value'")
+	cmd.Flags().StringVarP(&options.OutputFile, "output", "o", "", "The Jaeger JSON file to write. Defaults to 'trace-<activity>.json' if no OTLP endpoint is specified")
+	return cmd
+}
+
+// Run implements this command
+func (o *StepExportTraceOptions) Run() error {
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	name := o.Activity
+	if name == "" {
+		name, err = o.currentActivityName()
+		if err != nil {
+			return err
+		}
+	}
+	activity, err := jxClient.JenkinsV1().PipelineActivities(ns).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "failed to find PipelineActivity %s in namespace %s", name, ns)
+	}
+	trace := tracing.FromActivity(activity, time.Now())
+
+	endpoint := o.OTLPEndpoint
+	if endpoint == "" {
+		endpoint = os.Getenv(otlpEndpointEnvVar)
+	}
+	if endpoint != "" {
+		headers := map[string]string{}
+		for _, header := range o.OTLPHeaders {
+			values := strings.SplitN(header, "=", 2)
+			if len(values) != 2 {
+				return util.InvalidOptionf("otlp-header", header, "headers must be in the form name=value")
+			}
+			headers[values[0]] = values[1]
+		}
+		exporter := tracing.NewOTLPExporter(endpoint, headers)
+		err = exporter.Export(trace)
+		if err != nil {
+			return errors.Wrapf(err, "failed to export the trace of %s", name)
+		}
+		log.Infof("Exported trace %s with %d spans to %s\n", util.ColorInfo(trace.TraceID), len(trace.Spans), util.ColorInfo(exporter.Endpoint))
+		if o.OutputFile == "" {
+			return nil
+		}
+	}
+
+	fileName := o.OutputFile
+	if fileName == "" {
+		fileName = fmt.Sprintf("trace-%s.json", name)
+	}
+	err = tracing.WriteJaegerFile(trace, fileName)
+	if err != nil {
+		return errors.Wrapf(err, "failed to write the trace file %s", fileName)
+	}
+	log.Infof("Wrote trace %s with %d spans to %s\n", util.ColorInfo(trace.TraceID), len(trace.Spans), util.ColorInfo(fileName))
+	return nil
+}
+
+func (o *StepExportTraceOptions) currentActivityName() (string, error) {
+	owner := os.Getenv("REPO_OWNER")
+	repo := os.Getenv("REPO_NAME")
+	if owner == "" || repo == "" {
+		gitInfo, err := o.FindGitInfo(o.Dir)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to find the git information to determine the current pipeline")
+		}
+		if owner == "" {
+			owner = gitInfo.Organisation
+		}
+		if repo == "" {
+			repo = gitInfo.Name
+		}
+	}
+	branch := o.GetBranchName(o.Dir)
+	build := o.GetBuildNumber()
+	if build == "" {
+		return "", util.MissingOption("activity")
+	}
+	return kube.ToValidName(owner + "-" + repo + "-" + branch + "-" + build), nil
+}
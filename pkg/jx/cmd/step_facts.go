@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/pkg/errors"
+)
+
+// pipelineFactSubject returns the owner, repository, branch and build number of the current build which the Facts
+// about the build are recorded against
+func (o *StepOptions) pipelineFactSubject(dir string) (string, string, string, string, error) {
+	gitInfo, err := o.FindGitInfo(dir)
+	if err != nil {
+		return "", "", "", "", errors.Wrap(err, "failed to find the git information")
+	}
+	owner := os.Getenv("REPO_OWNER")
+	if owner == "" {
+		owner = gitInfo.Organisation
+	}
+	repo := os.Getenv("REPO_NAME")
+	if repo == "" {
+		repo = gitInfo.Name
+	}
+	return owner, repo, o.GetBranchName(dir), o.GetBuildNumber(), nil
+}
+
+// recordPipelineFact creates or updates the Fact about a build of a pipeline in the development namespace
+func (o *StepOptions) recordPipelineFact(fact *v1.Fact) error {
+	err := o.RegisterFactCRD()
+	if err != nil {
+		return err
+	}
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	_, err = kube.GetOrCreateFact(jxClient, ns, fact)
+	return err
+}
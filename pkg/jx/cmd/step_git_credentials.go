@@ -32,6 +32,8 @@ var (
 	StepGitCredentialsLong = templates.LongDesc(`
 		This pipeline step generates a Git credentials file for the current Git provider pipeline Secrets
 
+		If a Secret contains a GitHub App rather than a token then a short lived installation token of the App is used
+
 `)
 
 	StepGitCredentialsExample = templates.Examples(`
@@ -110,21 +112,23 @@ func (o *StepGitCredentialsOptions) createGitCredentialsFile(fileName string, se
 func (o *StepGitCredentialsOptions) CreateGitCredentialsFromSecrets(secretList *corev1.SecretList) []byte {
 	var buffer bytes.Buffer
 	if secretList != nil {
-		for _, secret := range secretList.Items {
+		for i := range secretList.Items {
+			secret := &secretList.Items[i]
 			labels := secret.Labels
 			annotations := secret.Annotations
 			data := secret.Data
 			if labels != nil && labels[kube.LabelKind] == kube.ValueKindGit && annotations != nil {
 				u := annotations[kube.AnnotationURL]
 				if u != "" && data != nil {
-					username := data[kube.SecretDataUsername]
-					pwd := data[kube.SecretDataPassword]
-					if len(username) > 0 && len(pwd) > 0 {
+					userAuth, err := kube.GitSecretUserAuth(secret, u)
+					if err != nil {
+						log.Warnf("Ignoring pipeline credential %s: %s\n", secret.Name, err)
+					} else if userAuth != nil {
 						u2, err := url.Parse(u)
 						if err != nil {
 							log.Warnf("Ignoring invalid Git service URL %s for pipeline credential %s\n", u, secret.Name)
 						} else {
-							u2.User = url.UserPassword(string(username), string(pwd))
+							u2.User = url.UserPassword(userAuth.Username, userAuth.ApiToken)
 							buffer.WriteString(u2.String() + "\n")
 
 							// lets write the other http protocol for completeness
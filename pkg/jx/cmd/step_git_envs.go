@@ -80,7 +80,8 @@ func (o *StepGitEnvsOptions) getGitCredentials(secrets *corev1.SecretList, servi
 	if secrets == nil {
 		return "", "", errors.New("no git credentials found")
 	}
-	for _, secret := range secrets.Items {
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
 		labels := secret.Labels
 		data := secret.Data
 		if data == nil {
@@ -92,9 +93,14 @@ func (o *StepGitEnvsOptions) getGitCredentials(secrets *corev1.SecretList, servi
 				continue
 			}
 			if strings.EqualFold(serviceKind, foundServiceKind) {
-				username := string(data[kube.SecretDataUsername])
-				pwd := string(data[kube.SecretDataPassword])
-				return username, pwd, nil
+				userAuth, err := kube.GitSecretUserAuth(secret, secret.Annotations[kube.AnnotationURL])
+				if err != nil {
+					return "", "", err
+				}
+				if userAuth == nil {
+					return "", "", nil
+				}
+				return userAuth.Username, userAuth.ApiToken, nil
 			}
 		}
 	}
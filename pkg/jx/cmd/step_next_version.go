@@ -5,12 +5,16 @@ import (
 	"encoding/xml"
 	"fmt"
 	"io/ioutil"
+	"path"
 	"path/filepath"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 
+	"github.com/jenkins-x/jx/pkg/gits"
 	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
 
 	"encoding/json"
 
@@ -36,7 +40,14 @@ type StepNextVersionOptions struct {
 	Tag           bool
 	UseGitTagOnly bool
 	NewVersion    string
+	Semantic      bool
+	Prerelease    string
+	// PrereleaseBranches the pre-release channels of branches in the form branch=channel where branch may be a glob
+	PrereleaseBranches []string
 	StepOptions
+
+	// unreleasedChanges is false if there are no commits since the latest release tag so there is nothing to tag
+	unreleasedChanges bool
 }
 
 type Project struct {
@@ -50,6 +61,11 @@ type PackageJSON struct {
 var (
 	StepNextVersionLong = templates.LongDesc(`
 		This pipeline step command works out a semantic version, writes a file ./VERSION and optionally updates a file
+
+		With --semantic the version is worked out from the Conventional Commits (https://conventionalcommits.org/) since
+		the latest release tag: a type followed by '!' or a 'BREAKING CHANGE' footer increments the major version, a 'feat'
+		the minor version and anything else the patch version. Branches can release pre-release versions such as 1.2.0-rc.1
+		by mapping them to a pre-release channel.
 `)
 
 	StepNextVersionExample = templates.Examples(`
@@ -57,6 +73,8 @@ var (
 		jx step next-version --filename package.json
 		jx step next-version --filename package.json --tag
 		jx step next-version --filename package.json --tag --version 1.2.3
+		jx step next-version --semantic --tag
+		jx step next-version --semantic --prerelease-branch 'release/*=rc' --tag
 `)
 )
 
@@ -83,6 +101,9 @@ func NewCmdStepNextVersion(commonOpts *opts.CommonOptions) *cobra.Command {
 	cmd.Flags().StringVarP(&options.Dir, "dir", "d", "", "the directory to look for files that contain a pom.xml or Makefile with the project version to bump")
 	cmd.Flags().BoolVarP(&options.Tag, "tag", "t", false, "tag and push new version")
 	cmd.Flags().BoolVarP(&options.UseGitTagOnly, "use-git-tag-only", "", false, "only use a git tag so work out new semantic version, else specify filename [pom.xml,package.json,Makefile,Chart.yaml]")
+	cmd.Flags().BoolVarP(&options.Semantic, "semantic", "", false, "work out the new version from the Conventional Commits since the latest release tag, which is not tagged again if there are no commits since it")
+	cmd.Flags().StringVarP(&options.Prerelease, "prerelease", "", "", "the pre-release channel of the new semantic version such as rc. Defaults to the channel of the current branch")
+	cmd.Flags().StringArrayVarP(&options.PrereleaseBranches, "prerelease-branch", "", []string{}, "the pre-release channel of branches in the form branch=channel, e.g. 'release/*=rc'")
 
 	return cmd
 }
@@ -90,8 +111,13 @@ func NewCmdStepNextVersion(commonOpts *opts.CommonOptions) *cobra.Command {
 func (o *StepNextVersionOptions) Run() error {
 
 	var err error
+	o.unreleasedChanges = true
 	if o.NewVersion == "" {
-		o.NewVersion, err = o.getNewVersionFromTag()
+		if o.Semantic {
+			o.NewVersion, err = o.getNewVersionFromCommits()
+		} else {
+			o.NewVersion, err = o.getNewVersionFromTag()
+		}
 		if err != nil {
 			return err
 		}
@@ -114,7 +140,9 @@ func (o *StepNextVersionOptions) Run() error {
 	}
 
 	// if tag set then tag it
-	if o.Tag {
+	if o.Tag && !o.unreleasedChanges {
+		log.Warnf("Not tagging version %s as there are no commits since it was released\n", o.NewVersion)
+	} else if o.Tag {
 		tagOptions := StepTagOptions{
 			Flags: StepTagFlags{
 				Version: o.NewVersion,
@@ -344,6 +372,123 @@ func (o *StepNextVersionOptions) getNewVersionFromTag() (string, error) {
 	return fmt.Sprintf("%d.%d.%d", majorVersion, minorVersion, patchVersion), nil
 }
 
+// getNewVersionFromCommits increments the latest release tag by the largest change of the conventional commits since it
+func (o *StepNextVersionOptions) getNewVersionFromCommits() (string, error) {
+	err := o.Git().FetchTags(o.Dir)
+	if err != nil {
+		return "", fmt.Errorf("error fetching tags: %v", err)
+	}
+	tags, err := o.Git().Tags(o.Dir)
+	if err != nil {
+		return "", err
+	}
+	latest, latestTag := LatestReleaseVersion(tags)
+	messages, err := o.Git().GetCommitMessagesSince(o.Dir, latestTag)
+	if err != nil {
+		return "", errors.Wrapf(err, "finding the commits since %s", latestTag)
+	}
+	if latestTag != "" && len(messages) == 0 {
+		// nothing has changed since the last release so lets keep its version
+		if o.Verbose {
+			log.Infof("found no commits since %s so keeping the version\n", latestTag)
+		}
+		o.unreleasedChanges = false
+		return latest.String(), nil
+	}
+	change := gits.VersionChangePatch
+	for _, message := range messages {
+		c := gits.ParseVersionChange(message)
+		if c > change {
+			change = c
+		}
+	}
+	channel := o.Prerelease
+	if channel == "" {
+		branch, err := o.Git().Branch(o.Dir)
+		if err != nil {
+			return "", err
+		}
+		channel, err = PrereleaseChannel(branch, o.PrereleaseBranches)
+		if err != nil {
+			return "", err
+		}
+	}
+	if o.Verbose {
+		log.Infof("found %d commits since %s requiring a %s change\n", len(messages), latest, change)
+	}
+	return NextSemanticVersion(latest, change, channel, tags), nil
+}
+
+// LatestReleaseVersion returns the latest semantic version of the tags, ignoring pre-releases, along with its tag or
+// 0.0.0 and an empty tag if there are no release tags
+func LatestReleaseVersion(tags []string) (semver.Version, string) {
+	latest := semver.Version{}
+	latestTag := ""
+	for _, tag := range tags {
+		v, err := semver.Parse(strings.TrimPrefix(tag, "v"))
+		if err != nil || len(v.Pre) > 0 {
+			continue
+		}
+		if latestTag == "" || v.GT(latest) {
+			latest = v
+			latestTag = tag
+		}
+	}
+	return latest, latestTag
+}
+
+// PrereleaseChannel returns the pre-release channel of the branch from the branch=channel mappings or an empty string
+// if the branch releases final versions
+func PrereleaseChannel(branch string, mappings []string) (string, error) {
+	for _, mapping := range mappings {
+		parts := strings.SplitN(mapping, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return "", fmt.Errorf("invalid pre-release branch %s, should be of the form branch=channel", mapping)
+		}
+		matched, err := path.Match(parts[0], branch)
+		if err != nil {
+			return "", errors.Wrapf(err, "invalid pre-release branch pattern %s", parts[0])
+		}
+		if matched {
+			return parts[1], nil
+		}
+	}
+	return "", nil
+}
+
+// NextSemanticVersion increments the latest release version by the change. For a pre-release channel the version is
+// suffixed with the channel and the next number of the channel from the tags, e.g. 1.3.0-rc.2
+func NextSemanticVersion(latest semver.Version, change gits.VersionChange, channel string, tags []string) string {
+	next := semver.Version{Major: latest.Major, Minor: latest.Minor, Patch: latest.Patch}
+	switch change {
+	case gits.VersionChangeMajor:
+		next.Major++
+		next.Minor = 0
+		next.Patch = 0
+	case gits.VersionChangeMinor:
+		next.Minor++
+		next.Patch = 0
+	default:
+		next.Patch++
+	}
+	if channel == "" {
+		return next.String()
+	}
+	prefix := next.String() + "-" + channel + "."
+	number := 0
+	for _, tag := range tags {
+		tag = strings.TrimPrefix(tag, "v")
+		if !strings.HasPrefix(tag, prefix) {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimPrefix(tag, prefix))
+		if err == nil && n > number {
+			number = n
+		}
+	}
+	return fmt.Sprintf("%s%d", prefix, number+1)
+}
+
 // SetVersion Sets the version...
 func (o *StepNextVersionOptions) SetVersion() error {
 	var err error
@@ -3,6 +3,7 @@ package cmd_test
 import (
 	"testing"
 
+	"github.com/jenkins-x/jx/pkg/gits"
 	"github.com/jenkins-x/jx/pkg/jx/cmd"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/opts"
 	"github.com/stretchr/testify/assert"
@@ -58,3 +59,33 @@ func TestChart(t *testing.T) {
 
 	assert.Equal(t, "0.0.1-SNAPSHOT", v, "error with GetVersion for a pom.xml")
 }
+
+func TestNextSemanticVersion(t *testing.T) {
+	t.Parallel()
+	tags := []string{"v1.1.0", "v1.2.3", "v1.2.10-rc.1", "v1.3.0-rc.1", "v1.3.0-rc.2", "not-a-version"}
+	latest, latestTag := cmd.LatestReleaseVersion(tags)
+	assert.Equal(t, "1.2.3", latest.String())
+	assert.Equal(t, "v1.2.3", latestTag)
+
+	assert.Equal(t, "1.2.4", cmd.NextSemanticVersion(latest, gits.VersionChangePatch, "", tags))
+	assert.Equal(t, "1.3.0", cmd.NextSemanticVersion(latest, gits.VersionChangeMinor, "", tags))
+	assert.Equal(t, "2.0.0", cmd.NextSemanticVersion(latest, gits.VersionChangeMajor, "", tags))
+	assert.Equal(t, "1.3.0-rc.3", cmd.NextSemanticVersion(latest, gits.VersionChangeMinor, "rc", tags))
+	assert.Equal(t, "2.0.0-beta.1", cmd.NextSemanticVersion(latest, gits.VersionChangeMajor, "beta", tags))
+
+	latest, latestTag = cmd.LatestReleaseVersion(nil)
+	assert.Equal(t, "", latestTag)
+	assert.Equal(t, "0.0.1", cmd.NextSemanticVersion(latest, gits.VersionChangePatch, "", nil))
+}
+
+func TestPrereleaseChannel(t *testing.T) {
+	t.Parallel()
+	mappings := []string{"release/*=rc", "develop=beta"}
+	for branch, expected := range map[string]string{"master": "", "develop": "beta", "release/1.3": "rc"} {
+		channel, err := cmd.PrereleaseChannel(branch, mappings)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, channel, "pre-release channel for branch %s", branch)
+	}
+	_, err := cmd.PrereleaseChannel("master", []string{"master"})
+	assert.Error(t, err)
+}
@@ -25,12 +25,16 @@ type StepPRLabelsOptions struct {
 	Dir         string
 	Prefix      string
 	PullRequest string
+	Add         []string
+	Remove      []string
 }
 
 var (
 	labelLong = templates.LongDesc(`
 		Creates environment variables from the labels in a pull request.
 
+		Labels can be added to or removed from the pull request with the '--add' and '--remove' arguments.
+
 		Environment variables are prefixed per default with ` + DefaultPrefix + `.
         You can use the '--prefix' argument to set a different prefix.
     `)
@@ -46,6 +50,9 @@ var (
 		jx step pr --pr PR-34
 		jx step pr --pr 34
 
+		# Add the 'approved' label and remove the 'do-not-merge' label of a pull-request
+		jx step pr labels --add approved --remove do-not-merge
+
     `)
 )
 
@@ -68,6 +75,8 @@ func NewCmdStepPRLabels(commonOpts *opts.CommonOptions) *cobra.Command {
 	}
 	cmd.Flags().StringVarP(&options.PullRequest, "pr", "", "", "Git Pull Request number")
 	cmd.Flags().StringVarP(&options.Prefix, "prefix", "p", "", "Environment variable prefix")
+	cmd.Flags().StringArrayVarP(&options.Add, "add", "", []string{}, "The labels to add to the pull request")
+	cmd.Flags().StringArrayVarP(&options.Remove, "remove", "", []string{}, "The labels to remove from the pull request")
 	return cmd
 }
 
@@ -99,6 +108,27 @@ func (o *StepPRLabelsOptions) Run() error {
 		return errors.Wrapf(err, "failed to find PullRequest %d", prNum)
 	}
 
+	if len(o.Add) > 0 {
+		err = provider.AddPRLabels(pr, o.Add)
+		if err != nil {
+			return errors.Wrapf(err, "failed to add labels %s to PullRequest %d", strings.Join(o.Add, ", "), prNum)
+		}
+		log.Infof("Added labels %s to PullRequest %d\n", strings.Join(o.Add, ", "), prNum)
+	}
+	for _, label := range o.Remove {
+		err = provider.RemovePRLabel(pr, label)
+		if err != nil {
+			return errors.Wrapf(err, "failed to remove label %s from PullRequest %d", label, prNum)
+		}
+		log.Infof("Removed label %s from PullRequest %d\n", label, prNum)
+	}
+	if len(o.Add) > 0 || len(o.Remove) > 0 {
+		pr, err = provider.GetPullRequest(gitInfo.Organisation, gitInfo, prNum)
+		if err != nil {
+			return errors.Wrapf(err, "failed to find PullRequest %d", prNum)
+		}
+	}
+
 	reg, err := regexp.Compile("[^a-zA-Z0-9]+")
 	if err != nil {
 		return errors.Wrapf(err, "failed to create regex %v", reg)
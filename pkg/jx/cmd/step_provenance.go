@@ -0,0 +1,293 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	jenkinsv1 "github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/builds"
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/opts"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/provenance"
+	"github.com/jenkins-x/jx/pkg/tekton/syntax"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/jenkins-x/jx/pkg/version"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	provenanceFileName = "provenance.json"
+)
+
+// StepProvenanceOptions contains the command line flags
+type StepProvenanceOptions struct {
+	StepOptions
+
+	Dir             string
+	GitURL          string
+	Branch          string
+	Revision        string
+	Images          []string
+	DigestFile      string
+	Artifacts       []string
+	Name            string
+	Version         string
+	VersionFile     string
+	SigningKeyID    string
+	OutputFile      string
+	NoStore         bool
+	StorageLocation jenkinsv1.StorageLocation
+}
+
+var (
+	stepProvenanceLong = templates.LongDesc(`
+		Generates the signed build provenance of the images and files produced by a release pipeline.
+
+		The provenance is an in-toto statement with an SLSA provenance predicate which records the git revision that was built, the hash of the pipeline that was run, the builder images with their digests, the parameters of the pipeline and the digests of the images and files produced.
+		It is signed with the GPG signing key of the ` + kube.SecretJenkinsReleaseGPG + ` secret and wrapped in a DSSE envelope.
+
+		The provenance is stored in the team's storage location for the '` + kube.ClassificationProvenance + `' classifier and linked from the Release of the version and the attachments of the PipelineActivity.
+		Use 'jx step verify provenance' to verify an image was produced by a repository and branch.
+` + storageSupportDescription + opts.SeeAlsoText("jx step verify provenance", "jx edit storage"))
+
+	stepProvenanceExample = templates.Examples(`
+		# generates the provenance of the image built by kaniko which wrote its digest to a file
+		jx step provenance --image gcr.io/myorg/myapp:1.2.3 --digest-file /workspace/digest
+
+		# generates the provenance of an image and some binaries
+		jx step provenance --image gcr.io/myorg/myapp:1.2.3@sha256:0123... --artifact 'dist/*'
+`)
+)
+
+// NewCmdStepProvenance creates the `jx step provenance` command
+func NewCmdStepProvenance(commonOpts *opts.CommonOptions) *cobra.Command {
+	options := &StepProvenanceOptions{
+		StepOptions: StepOptions{
+			CommonOptions: commonOpts,
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "provenance",
+		Short:   "Generates the signed build provenance of a release",
+		Long:    stepProvenanceLong,
+		Example: stepProvenanceExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	addStorageLocationFlags(cmd, &options.StorageLocation)
+
+	cmd.Flags().StringVarP(&options.Dir, "dir", "d", "", "the directory of the git repository. Defaults to the current directory")
+	cmd.Flags().StringVarP(&options.GitURL, "source-url", "", "", "the git URL of the repository which was built. Defaults to the upstream URL of the git repository in the directory")
+	cmd.Flags().StringVarP(&options.Branch, "branch", "b", "", "the branch which was built. Defaults to $BRANCH_NAME or the current branch")
+	cmd.Flags().StringVarP(&options.Revision, "revision", "r", "", "the git revision which was built. Defaults to $PULL_BASE_SHA or the latest commit")
+	cmd.Flags().StringArrayVarP(&options.Images, "image", "i", nil, "an image produced by the build as NAME@sha256:DIGEST")
+	cmd.Flags().StringVarP(&options.DigestFile, "digest-file", "", "", "the file containing the digest of the image if a single image without a digest is specified, such as written by 'kaniko --digest-file'")
+	cmd.Flags().StringArrayVarP(&options.Artifacts, "artifact", "a", nil, "the files produced by the build. Glob patterns are supported")
+	cmd.Flags().StringVarP(&options.Name, "name", "n", "", "the name of the application. Defaults to the name of the git repository")
+	cmd.Flags().StringVarP(&options.Version, VERSION, "v", "", "the version of the release")
+	cmd.Flags().StringVarP(&options.VersionFile, "version-file", "", defaultVersionFile, "The file name used to load the version number from if no '--version' option is specified")
+	cmd.Flags().StringVarP(&options.SigningKeyID, "signing-key", "", "", "the ID or identity of the GPG key to sign the provenance with if the secret contains several keys")
+	cmd.Flags().StringVarP(&options.OutputFile, "output", "o", "", "the file to write the signed provenance to")
+	cmd.Flags().BoolVarP(&options.NoStore, "no-store", "", false, "disables storing the provenance and linking it from the Release and PipelineActivity")
+	return cmd
+}
+
+// Run implements this command
+func (o *StepProvenanceOptions) Run() error {
+	var err error
+	if o.Dir == "" {
+		o.Dir, err = os.Getwd()
+		if err != nil {
+			return err
+		}
+	}
+	if len(o.Images) == 0 && len(o.Artifacts) == 0 {
+		return util.MissingOption("image")
+	}
+	releaseVersion, err := findReleaseVersion(o.Dir, o.Version, o.VersionFile)
+	if err != nil {
+		return err
+	}
+	var gitInfo *gits.GitRepository
+	if o.GitURL != "" {
+		gitInfo, err = gits.ParseGitURL(o.GitURL)
+	} else {
+		gitInfo, err = o.FindGitInfo(o.Dir)
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to find the git repository which was built")
+	}
+	appName := o.Name
+	if appName == "" {
+		appName = gitInfo.Name
+	}
+	statement, err := o.createStatement(gitInfo, appName)
+	if err != nil {
+		return err
+	}
+
+	signer, err := o.ReleaseSigningKey(o.SigningKeyID)
+	if err != nil {
+		return errors.Wrap(err, "failed to find the key to sign the provenance")
+	}
+	data, err := provenance.Sign(statement, signer)
+	if err != nil {
+		return err
+	}
+	log.Infof("Signed the provenance of %s version %s with %s\n", util.ColorInfo(appName), util.ColorInfo(releaseVersion), util.ColorInfo(gits.KeyName(signer)))
+
+	if o.OutputFile != "" {
+		err = ioutil.WriteFile(o.OutputFile, data, util.DefaultWritePermissions)
+		if err != nil {
+			return errors.Wrapf(err, "failed to save the provenance to %s", o.OutputFile)
+		}
+		log.Infof("generated: %s\n", util.ColorInfo(o.OutputFile))
+	}
+	if o.NoStore {
+		return nil
+	}
+	u, err := o.storeReleaseData(&o.StorageLocation, kube.ClassificationProvenance, gitInfo, releaseVersion, provenanceFileName, data)
+	if err != nil {
+		return err
+	}
+	return o.linkReleaseData(gitInfo, appName, releaseVersion, kube.ClassificationProvenance, u, func(release *jenkinsv1.Release) {
+		release.Spec.ProvenanceURL = u
+	})
+}
+
+// createStatement creates the provenance statement of the build
+func (o *StepProvenanceOptions) createStatement(gitInfo *gits.GitRepository, appName string) (*provenance.Statement, error) {
+	branch := o.Branch
+	if branch == "" {
+		branch = os.Getenv(envVarBranchName)
+	}
+	if branch == "" {
+		branch = os.Getenv("PULL_BASE_REF")
+	}
+	if branch == "" {
+		var err error
+		branch, err = o.Git().Branch(o.Dir)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to find the branch which was built")
+		}
+	}
+	revision := o.Revision
+	if revision == "" {
+		revision = os.Getenv("PULL_BASE_SHA")
+	}
+	if revision == "" {
+		var err error
+		revision, err = o.Git().GetLatestCommitSha(o.Dir)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to find the revision which was built")
+		}
+	}
+
+	builderID := "https://jenkins-x.io/jx@" + version.GetVersion()
+	statement := provenance.NewStatement(builderID, gitInfo.HttpCloneURL(), branch, revision)
+	pipeline, build := o.GetPipelineName(gitInfo, "", "", appName)
+	statement.Predicate.BuildConfig.Pipeline = pipeline
+	statement.Predicate.BuildConfig.Build = build
+
+	err := o.addBuildPodDetails(statement)
+	if err != nil {
+		return nil, err
+	}
+	finished := time.Now().UTC()
+	statement.Predicate.Metadata.BuildFinishedOn = &finished
+
+	for _, image := range o.Images {
+		if o.DigestFile != "" && !strings.Contains(image, "@") {
+			if len(o.Images) > 1 {
+				return nil, fmt.Errorf("the --digest-file option can only be used with a single --image")
+			}
+			data, err := ioutil.ReadFile(o.DigestFile)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to read the image digest file %s", o.DigestFile)
+			}
+			image += "@" + strings.TrimSpace(string(data))
+		}
+		err = statement.AddImageSubject(image)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, pattern := range o.Artifacts {
+		fileNames, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid file pattern %s", pattern)
+		}
+		if len(fileNames) == 0 {
+			return nil, fmt.Errorf("no files found matching %s", pattern)
+		}
+		for _, fileName := range fileNames {
+			digest, err := util.SHA256File(fileName)
+			if err != nil {
+				return nil, err
+			}
+			statement.AddSubject(filepath.Base(fileName), provenance.DigestSHA256, digest)
+		}
+	}
+	return statement, nil
+}
+
+// addBuildPodDetails adds the builder images, parameters and pipeline hash of the build pod this step runs in
+func (o *StepProvenanceOptions) addBuildPodDetails(statement *provenance.Statement) error {
+	podName := os.Getenv("HOSTNAME")
+	if podName == "" {
+		log.Warnf("Not running in a build pod so the builder images will not be recorded\n")
+		return nil
+	}
+	kubeClient, ns, err := o.KubeClientAndNamespace()
+	if err != nil {
+		return err
+	}
+	pod, err := kubeClient.CoreV1().Pods(ns).Get(podName, metav1.GetOptions{})
+	if err != nil {
+		log.Warnf("Could not find the build pod %s in namespace %s so the builder images will not be recorded: %s\n", podName, ns, err)
+		return nil
+	}
+	if pod.Status.StartTime != nil {
+		started := pod.Status.StartTime.UTC()
+		statement.Predicate.Metadata.BuildStartedOn = &started
+	}
+	for _, status := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+		statement.AddMaterial(status.Image, provenance.DigestSHA256, provenance.ContainerImageDigest(status.ImageID))
+	}
+	statement.SortMaterials()
+
+	pipelineRunName := pod.Labels[builds.LabelPipelineRunName]
+	if pipelineRunName == "" {
+		return nil
+	}
+	tektonClient, _, err := o.TektonClient()
+	if err != nil {
+		return err
+	}
+	pr, err := tektonClient.TektonV1alpha1().PipelineRuns(ns).Get(pipelineRunName, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "failed to find the PipelineRun %s in namespace %s", pipelineRunName, ns)
+	}
+	statement.Predicate.Metadata.BuildInvocationID = string(pr.UID)
+	statement.Predicate.BuildConfig.ParsedPipelineHash = pr.Annotations[syntax.AnnotationParsedPipelineHash]
+	if len(pr.Spec.Params) > 0 {
+		statement.Predicate.Invocation.Parameters = map[string]string{}
+		for _, param := range pr.Spec.Params {
+			statement.Predicate.Invocation.Parameters[param.Name] = param.Value
+		}
+	}
+	return nil
+}
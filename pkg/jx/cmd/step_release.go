@@ -69,6 +69,7 @@ func NewCmdStepRelease(commonOpts *opts.CommonOptions) *cobra.Command {
 	cmd.Flags().StringVarP(&options.HelmRepositoryURL, "helm-repo-url", "", helm.InClusterHelmRepositoryURL, "The Helm Repository URL to use for the App")
 	cmd.Flags().StringVarP(&options.Build, "build", "", "", "The Build number which is used to update the PipelineActivity. If not specified its defaulted from  the '$BUILD_NUMBER' environment variable")
 
+	cmd.AddCommand(NewCmdStepReleaseUpload(commonOpts))
 	return cmd
 }
 
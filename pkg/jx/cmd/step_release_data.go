@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	jenkinsv1 "github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/collector"
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// findReleaseVersion returns the version without any 'v' prefix or, if no version is specified, the version in the
+// version file which is relative to the directory
+func findReleaseVersion(dir string, version string, versionFile string) (string, error) {
+	if version == "" && versionFile != "" {
+		if !filepath.IsAbs(versionFile) {
+			versionFile = filepath.Join(dir, versionFile)
+		}
+		exists, err := util.FileExists(versionFile)
+		if err != nil {
+			return "", err
+		}
+		if exists {
+			data, err := ioutil.ReadFile(versionFile)
+			if err != nil {
+				return "", err
+			}
+			version = strings.TrimSpace(string(data))
+		}
+	}
+	if version == "" {
+		return "", util.MissingOption(VERSION)
+	}
+	return strings.TrimPrefix(version, "v"), nil
+}
+
+// devRelease returns the Release of the version of the application in the development environment which is
+// created if 'jx step changelog' has not been run yet
+func devRelease(gitInfo *gits.GitRepository, appName string, version string) *jenkinsv1.Release {
+	return &jenkinsv1.Release{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: kube.ToValidName(appName + "-" + version),
+		},
+		Spec: jenkinsv1.ReleaseSpec{
+			Name:          appName,
+			Version:       version,
+			GitHTTPURL:    gitInfo.HttpsURL(),
+			GitCloneURL:   gitInfo.URL,
+			GitOwner:      gitInfo.Organisation,
+			GitRepository: gitInfo.Name,
+		},
+	}
+}
+
+// storeReleaseData stores the data of the version of the repository in the storage location, defaulting to the
+// team's storage location of the classifier, returning the URL to access it
+func (o *StepOptions) storeReleaseData(location *jenkinsv1.StorageLocation, classifier string, gitInfo *gits.GitRepository, version string, fileName string, data []byte) (string, error) {
+	settings, err := o.TeamSettings()
+	if err != nil {
+		return "", err
+	}
+	if location.Classifier != "" {
+		classifier = location.Classifier
+	}
+	if location.IsEmpty() {
+		*location = *settings.StorageLocationOrDefault(classifier)
+		if location.IsEmpty() {
+			// we have no team settings so lets store the data in the git repository
+			location.GitURL = gitInfo.URL
+		}
+	}
+	coll, err := collector.NewCollector(location, settings, o.Git())
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to create the collector for storage settings %s", location.Description())
+	}
+	storagePath := filepath.Join("jenkins-x", classifier, gitInfo.Organisation, gitInfo.Name, version, fileName)
+	u, err := coll.CollectData(data, storagePath)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to store %s", storagePath)
+	}
+	log.Infof("stored: %s\n", util.ColorInfo(u))
+	return u, nil
+}
+
+// linkReleaseData links the URL of the stored data from the Release of the version using the update function and
+// from the attachments of the PipelineActivity of the current build
+func (o *StepOptions) linkReleaseData(gitInfo *gits.GitRepository, appName string, version string, classifier string, u string, update func(release *jenkinsv1.Release)) error {
+	client, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return errors.Wrap(err, "cannot create the JX client")
+	}
+	release, err := kube.UpdateRelease(client, ns, devRelease(gitInfo, appName, version), update)
+	if err != nil {
+		return err
+	}
+	log.Infof("Linked the %s from Release %s\n", classifier, util.ColorInfo(release.Name))
+
+	pipeline, build := o.GetPipelineName(gitInfo, "", "", appName)
+	if pipeline == "" || build == "" {
+		log.Infof("No pipeline and build number available on $JOB_NAME and $BUILD_NUMBER so cannot update PipelineActivities with the %s\n", classifier)
+		return nil
+	}
+	key := &kube.PromoteStepActivityKey{
+		PipelineActivityKey: kube.PipelineActivityKey{
+			Name:     kube.ToValidName(pipeline + "-" + build),
+			Pipeline: pipeline,
+			Build:    build,
+			Version:  version,
+			GitInfo:  gitInfo,
+		},
+	}
+	a, _, err := key.GetOrCreate(client, ns)
+	if err != nil {
+		return err
+	}
+	a.Spec.Attachments = append(a.Spec.Attachments, jenkinsv1.Attachment{
+		Name: classifier,
+		URLs: []string{u},
+	})
+	_, err = client.JenkinsV1().PipelineActivities(ns).PatchUpdate(a)
+	return err
+}
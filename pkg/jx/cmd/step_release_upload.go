@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/opts"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	defaultChecksumsFile = "SHA256SUMS"
+)
+
+// StepReleaseUploadOptions contains the command line flags
+type StepReleaseUploadOptions struct {
+	StepOptions
+
+	Dir           string
+	GitURL        string
+	Tag           string
+	Version       string
+	VersionFile   string
+	ChecksumsFile string
+	NoChecksums   bool
+}
+
+var (
+	stepReleaseUploadLong = templates.LongDesc(`
+		Uploads files as assets of the release of a tag on the git provider of the repository.
+
+		The files can be specified as glob patterns. A ` + defaultChecksumsFile + ` file containing the SHA256 checksums of the files is also uploaded unless '--no-checksums' is specified.
+
+		GitHub and Gitea releases have the files attached. On GitLab the files are uploaded to a generic package of the project whose version is the tag and linked from the release.
+
+		The release of the tag must already exist, such as the release created by 'jx step changelog'.
+`)
+
+	stepReleaseUploadExample = templates.Examples(`
+		# upload the binaries to the release of tag v1.2.3
+		jx step release upload --tag v1.2.3 dist/*
+
+		# upload the binaries to the release of the version in the VERSION file
+		jx step release upload 'dist/*.tar.gz'
+`)
+)
+
+// NewCmdStepReleaseUpload creates the `jx step release upload` command
+func NewCmdStepReleaseUpload(commonOpts *opts.CommonOptions) *cobra.Command {
+	options := &StepReleaseUploadOptions{
+		StepOptions: StepOptions{
+			CommonOptions: commonOpts,
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "upload [files]",
+		Short:   "Uploads files as assets of the release of a tag",
+		Long:    stepReleaseUploadLong,
+		Example: stepReleaseUploadExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Dir, "dir", "d", "", "the directory of the git repository")
+	cmd.Flags().StringVarP(&options.GitURL, "git-url", "", "", "the git URL of the repository. Defaults to the upstream URL of the git repository in the directory")
+	cmd.Flags().StringVarP(&options.Tag, "tag", "t", "", "the tag of the release. Defaults to the version prefixed with 'v'")
+	cmd.Flags().StringVarP(&options.Version, VERSION, "v", "", "the version of the release")
+	cmd.Flags().StringVarP(&options.VersionFile, "version-file", "", defaultVersionFile, "The file name used to load the version number from if no '--version' or '--tag' option is specified")
+	cmd.Flags().StringVarP(&options.ChecksumsFile, "checksums-file", "", defaultChecksumsFile, "the name of the checksums asset")
+	cmd.Flags().BoolVarP(&options.NoChecksums, "no-checksums", "", false, "disables uploading the checksums of the files")
+	return cmd
+}
+
+// Run implements this command
+func (o *StepReleaseUploadOptions) Run() error {
+	if len(o.Args) == 0 {
+		return util.MissingArgument("files")
+	}
+	fileNames, err := o.findFiles()
+	if err != nil {
+		return err
+	}
+	tag, err := o.findTag()
+	if err != nil {
+		return err
+	}
+
+	gitURL := o.GitURL
+	if gitURL == "" {
+		gitInfo, err := o.FindGitInfo(o.Dir)
+		if err != nil {
+			return err
+		}
+		gitURL = gitInfo.URL
+	}
+	gitInfo, err := gits.ParseGitURL(gitURL)
+	if err != nil {
+		return err
+	}
+	provider, err := o.GitProviderForURL(gitURL, "git repository")
+	if err != nil {
+		return errors.Wrapf(err, "creating the git provider for %s", gitURL)
+	}
+
+	for _, fileName := range fileNames {
+		err = o.uploadAsset(provider, gitInfo, tag, filepath.Base(fileName), fileName)
+		if err != nil {
+			return err
+		}
+	}
+	if o.NoChecksums {
+		return nil
+	}
+
+	sums, err := util.SHA256Sums(fileNames)
+	if err != nil {
+		return err
+	}
+	tmpDir, err := ioutil.TempDir("", "jx-release-upload-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+	checksumsFile := filepath.Join(tmpDir, o.ChecksumsFile)
+	err = ioutil.WriteFile(checksumsFile, []byte(sums), util.DefaultWritePermissions)
+	if err != nil {
+		return err
+	}
+	return o.uploadAsset(provider, gitInfo, tag, o.ChecksumsFile, checksumsFile)
+}
+
+func (o *StepReleaseUploadOptions) uploadAsset(provider gits.GitProvider, gitInfo *gits.GitRepository, tag string, name string, fileName string) error {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	asset, err := provider.UploadReleaseAsset(gitInfo.Organisation, gitInfo.Name, tag, name, file)
+	if err != nil {
+		return err
+	}
+	log.Infof("Uploaded %s to release %s: %s\n", util.ColorInfo(name), util.ColorInfo(tag), asset.BrowserDownloadURL)
+	return nil
+}
+
+// findFiles returns the files matching the arguments which may be glob patterns
+func (o *StepReleaseUploadOptions) findFiles() ([]string, error) {
+	answer := []string{}
+	names := map[string]string{}
+	for _, arg := range o.Args {
+		matches, err := filepath.Glob(arg)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid file pattern %s", arg)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no files found matching %s", arg)
+		}
+		for _, match := range matches {
+			info, err := os.Stat(match)
+			if err != nil {
+				return nil, err
+			}
+			if info.IsDir() {
+				continue
+			}
+			// assets are named after the base name of the file so they must be unique
+			name := filepath.Base(match)
+			if existing, ok := names[name]; ok {
+				if existing == match {
+					continue
+				}
+				return nil, fmt.Errorf("the files %s and %s would both be uploaded as asset %s", existing, match, name)
+			}
+			if !o.NoChecksums && name == o.ChecksumsFile {
+				return nil, fmt.Errorf("the file %s would be replaced by the checksums asset %s", match, name)
+			}
+			names[name] = match
+			answer = append(answer, match)
+		}
+	}
+	if len(answer) == 0 {
+		return nil, fmt.Errorf("no files found matching %s", strings.Join(o.Args, " "))
+	}
+	return answer, nil
+}
+
+func (o *StepReleaseUploadOptions) findTag() (string, error) {
+	if o.Tag != "" {
+		return o.Tag, nil
+	}
+	version := o.Version
+	if version == "" && o.VersionFile != "" {
+		versionFile := o.VersionFile
+		if o.Dir != "" && !filepath.IsAbs(versionFile) {
+			versionFile = filepath.Join(o.Dir, versionFile)
+		}
+		exists, err := util.FileExists(versionFile)
+		if err != nil {
+			return "", err
+		}
+		if exists {
+			data, err := ioutil.ReadFile(versionFile)
+			if err != nil {
+				return "", err
+			}
+			version = strings.TrimSpace(string(data))
+		}
+	}
+	if version == "" {
+		return "", util.MissingOption("tag")
+	}
+	return "v" + version, nil
+}
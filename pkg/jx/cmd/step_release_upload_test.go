@@ -0,0 +1,53 @@
+package cmd_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/jx/cmd"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/opts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStepReleaseUpload(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "test-step-release-upload")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "myapp-linux"), []byte("linux"), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "myapp-darwin"), []byte("darwin"), 0644))
+
+	repo := gits.NewFakeRepository("jstrachan", "myapp")
+	repo.Releases = map[string]*gits.GitRelease{
+		"v1.2.3": {
+			Name:    "1.2.3",
+			TagName: "v1.2.3",
+		},
+	}
+	commonOpts := &opts.CommonOptions{}
+	commonOpts.SetFakeGitProvider(gits.NewFakeProvider(repo))
+	options := &cmd.StepReleaseUploadOptions{
+		StepOptions: cmd.StepOptions{
+			CommonOptions: commonOpts,
+		},
+		GitURL:        "https://fake.git/jstrachan/myapp.git",
+		Tag:           "v1.2.3",
+		ChecksumsFile: "SHA256SUMS",
+	}
+	options.Args = []string{filepath.Join(dir, "myapp-*")}
+
+	err = options.Run()
+	require.NoError(t, err)
+
+	assets := *repo.Releases["v1.2.3"].Assets
+	names := []string{}
+	for _, asset := range assets {
+		names = append(names, asset.Name)
+	}
+	assert.Equal(t, []string{"myapp-darwin", "myapp-linux", "SHA256SUMS"}, names)
+	assert.Equal(t, "https://fake.git/jstrachan/myapp/releases/download/v1.2.3/SHA256SUMS", assets[2].BrowserDownloadURL)
+}
@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	jenkinsv1 "github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/opts"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/sbom"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// StepSBOMOptions contains the command line flags
+type StepSBOMOptions struct {
+	StepOptions
+
+	Dir             string
+	Format          string
+	OutputFile      string
+	Name            string
+	Version         string
+	VersionFile     string
+	MavenTree       bool
+	NoStore         bool
+	StorageLocation jenkinsv1.StorageLocation
+}
+
+var (
+	stepSBOMLong = templates.LongDesc(`
+		Generates the software bill of materials (SBOM) of the release of an application in the CycloneDX or SPDX JSON format.
+
+		The components of the SBOM are found from the go.sum, pom.xml, package-lock.json and requirements.txt files in the directory.
+		A pom.xml only declares the direct dependencies so use '--maven-tree' to include the transitive dependencies using 'mvn dependency:tree'.
+
+		The SBOM is stored in the team's storage location for the '` + kube.ClassificationSBOM + `' classifier and linked from the Release of the version and the attachments of the PipelineActivity.
+		Use 'jx get release --sbom' to find the releases which include a component.
+` + storageSupportDescription + opts.SeeAlsoText("jx get release", "jx edit storage"))
+
+	stepSBOMExample = templates.Examples(`
+		# generates and stores the CycloneDX SBOM of the version in the VERSION file
+		jx step sbom
+
+		# generates and stores the SPDX SBOM of a version including the transitive Maven dependencies
+		jx step sbom --format spdx --version 1.2.3 --maven-tree
+
+		# generates an SBOM file without storing it
+		jx step sbom --no-store --output sbom.json
+`)
+)
+
+// NewCmdStepSBOM creates the `jx step sbom` command
+func NewCmdStepSBOM(commonOpts *opts.CommonOptions) *cobra.Command {
+	options := &StepSBOMOptions{
+		StepOptions: StepOptions{
+			CommonOptions: commonOpts,
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "sbom",
+		Short:   "Generates the software bill of materials of a release",
+		Long:    stepSBOMLong,
+		Example: stepSBOMExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	addStorageLocationFlags(cmd, &options.StorageLocation)
+
+	cmd.Flags().StringVarP(&options.Dir, "dir", "d", "", "the directory to find the dependencies in. Defaults to the current directory")
+	cmd.Flags().StringVarP(&options.Format, "format", "f", sbom.FormatCycloneDX, fmt.Sprintf("the format of the SBOM. Supported formats are: %s", strings.Join(sbom.Formats, ", ")))
+	cmd.Flags().StringVarP(&options.OutputFile, "output", "o", "", "the file to write the SBOM to")
+	cmd.Flags().StringVarP(&options.Name, "name", "n", "", "the name of the application. Defaults to the name of the git repository")
+	cmd.Flags().StringVarP(&options.Version, VERSION, "v", "", "the version of the release")
+	cmd.Flags().StringVarP(&options.VersionFile, "version-file", "", defaultVersionFile, "The file name used to load the version number from if no '--version' option is specified")
+	cmd.Flags().BoolVarP(&options.MavenTree, "maven-tree", "", false, "runs 'mvn dependency:tree' to include the transitive dependencies of any pom.xml")
+	cmd.Flags().BoolVarP(&options.NoStore, "no-store", "", false, "disables storing the SBOM and linking it from the Release and PipelineActivity")
+	return cmd
+}
+
+// Run implements this command
+func (o *StepSBOMOptions) Run() error {
+	var err error
+	if o.Dir == "" {
+		o.Dir, err = os.Getwd()
+		if err != nil {
+			return err
+		}
+	}
+	if util.StringArrayIndex(sbom.Formats, o.Format) < 0 {
+		return util.InvalidOption("format", o.Format, sbom.Formats)
+	}
+	version, err := findReleaseVersion(o.Dir, o.Version, o.VersionFile)
+	if err != nil {
+		return err
+	}
+	var gitInfo *gits.GitRepository
+	if !o.NoStore || o.Name == "" {
+		gitInfo, err = o.FindGitInfo(o.Dir)
+		if err != nil {
+			return errors.Wrapf(err, "failed to find the git information in the directory %s", o.Dir)
+		}
+	}
+	appName := o.Name
+	if appName == "" {
+		appName = gitInfo.Name
+	}
+
+	if o.MavenTree {
+		err = o.generateMavenDependencyTree()
+		if err != nil {
+			return err
+		}
+	}
+	components, err := sbom.Scan(o.Dir)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find the components in %s", o.Dir)
+	}
+	log.Infof("Found %d components of %s version %s\n", len(components), util.ColorInfo(appName), util.ColorInfo(version))
+
+	data, err := sbom.Marshal(o.Format, appName, version, components)
+	if err != nil {
+		return err
+	}
+	if o.OutputFile != "" {
+		err = ioutil.WriteFile(o.OutputFile, data, util.DefaultWritePermissions)
+		if err != nil {
+			return errors.Wrapf(err, "failed to save the SBOM to %s", o.OutputFile)
+		}
+		log.Infof("generated: %s\n", util.ColorInfo(o.OutputFile))
+	}
+	if o.NoStore {
+		return nil
+	}
+
+	u, err := o.storeReleaseData(&o.StorageLocation, kube.ClassificationSBOM, gitInfo, version, o.Format+".json", data)
+	if err != nil {
+		return err
+	}
+	return o.linkReleaseData(gitInfo, appName, version, kube.ClassificationSBOM, u, func(release *jenkinsv1.Release) {
+		release.Spec.SBOMURL = u
+	})
+}
+
+// generateMavenDependencyTree writes the dependency tree of each Maven module so the transitive dependencies are found
+func (o *StepSBOMOptions) generateMavenDependencyTree() error {
+	exists, err := util.FileExists(filepath.Join(o.Dir, "pom.xml"))
+	if err != nil || !exists {
+		return err
+	}
+	err = o.RunCommandFromDir(o.Dir, "mvn", "-B", "dependency:tree", "-DoutputType=text", "-DoutputFile="+sbom.MavenDependencyTreeFile)
+	if err != nil {
+		return errors.Wrap(err, "failed to generate the Maven dependency tree")
+	}
+	return nil
+}
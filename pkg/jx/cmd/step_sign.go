@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"github.com/jenkins-x/jx/pkg/jx/cmd/opts"
+	"github.com/spf13/cobra"
+)
+
+// StepSignOptions contains the command line flags
+type StepSignOptions struct {
+	StepOptions
+}
+
+// NewCmdStepSign creates the `jx step sign` command
+func NewCmdStepSign(commonOpts *opts.CommonOptions) *cobra.Command {
+	options := &StepSignOptions{
+		StepOptions: StepOptions{
+			CommonOptions: commonOpts,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "sign",
+		Short: "sign [command]",
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.AddCommand(NewCmdStepSignImage(commonOpts))
+	return cmd
+}
+
+// Run implements this command
+func (o *StepSignOptions) Run() error {
+	return o.Cmd.Help()
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/imagesign"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/opts"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// StepSignImageOptions contains the command line flags
+type StepSignImageOptions struct {
+	StepOptions
+
+	SigningKeyID       string
+	DigestFile         string
+	InsecureRegistries []string
+}
+
+var (
+	stepSignImageLong = templates.LongDesc(`
+		Signs the digest of a container image and pushes the signature to the registry next to the image.
+
+		The digest is signed with the GPG signing key of the ` + kube.SecretJenkinsReleaseGPG + ` secret, which is read from Vault if secrets are stored in Vault.
+		The signature is pushed to the tag 'sha256-DIGEST.sig' of the image repository using the credentials of the Docker config file.
+
+		Use 'jx promote --require-signed-images' to only promote charts whose images are signed with a trusted key.
+`)
+
+	stepSignImageExample = templates.Examples(`
+		# signs an image
+		jx step sign image gcr.io/myorg/myapp:1.2.3
+
+		# signs the image built by kaniko which wrote its digest to a file
+		jx step sign image gcr.io/myorg/myapp:1.2.3 --digest-file /workspace/digest
+`)
+)
+
+// NewCmdStepSignImage creates the `jx step sign image` command
+func NewCmdStepSignImage(commonOpts *opts.CommonOptions) *cobra.Command {
+	options := &StepSignImageOptions{
+		StepOptions: StepOptions{
+			CommonOptions: commonOpts,
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "image [image]",
+		Short:   "Signs the digest of a container image and pushes the signature to the registry",
+		Long:    stepSignImageLong,
+		Example: stepSignImageExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.SigningKeyID, "signing-key", "", "", "the ID or identity of the GPG key to sign the image with if the secret contains several keys")
+	cmd.Flags().StringVarP(&options.DigestFile, "digest-file", "", "", "the file containing the digest of the image if the image has no digest, such as written by 'kaniko --digest-file'")
+	cmd.Flags().StringArrayVarP(&options.InsecureRegistries, "insecure-registry", "", nil, "the registries which are accessed using plain HTTP")
+	return cmd
+}
+
+// Run implements this command
+func (o *StepSignImageOptions) Run() error {
+	if len(o.Args) != 1 {
+		return util.MissingArgument("image")
+	}
+	image := o.Args[0]
+	if o.DigestFile != "" && !strings.Contains(image, "@") {
+		data, err := ioutil.ReadFile(o.DigestFile)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read the image digest file %s", o.DigestFile)
+		}
+		image += "@" + strings.TrimSpace(string(data))
+	}
+	ref, err := imagesign.ParseReference(image)
+	if err != nil {
+		return err
+	}
+	signer, err := o.ReleaseSigningKey(o.SigningKeyID)
+	if err != nil {
+		return errors.Wrap(err, "failed to find the key to sign the image")
+	}
+	sigRef, err := imagesign.Sign(imagesign.NewRegistry(o.InsecureRegistries), ref, signer, nil)
+	if err != nil {
+		return errors.Wrapf(err, "failed to sign image %s", image)
+	}
+	log.Infof("Signed image %s with %s and pushed the signature to %s\n", util.ColorInfo(ref.String()),
+		util.ColorInfo(gits.KeyName(signer)), util.ColorInfo(sigRef.String()))
+	return nil
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/opts"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// StepStatementOptions contains the command line flags
+type StepStatementOptions struct {
+	StepOptions
+
+	Dir           string
+	Name          string
+	Value         bool
+	StatementType string
+	Pipeline      string
+	Build         string
+}
+
+var (
+	stepStatementLong = templates.LongDesc(`
+		Records a statement, such as that a build was approved, as a Fact about a build of a pipeline.
+
+		The statement is used by promotion gates such as 'statement.approved == true'. By default the statement is about the current build of the pipeline. Use '--pipeline' and '--build' to make a statement about another build, for example to approve a promotion which is blocked by its gates.
+
+		Promotions of the workflow which are blocked by their gates are retried when a Fact about their build is recorded.
+`)
+
+	stepStatementExample = templates.Examples(`
+		# approve the current build
+		jx step statement --name approved
+
+		# approve build 3 of the master branch of myorg/myapp so its blocked promotion is retried
+		jx step statement --name approved --pipeline myorg/myapp/master --build 3
+
+		# reject build 3
+		jx step statement --name approved --value=false --pipeline myorg/myapp/master --build 3
+`)
+)
+
+// NewCmdStepStatement creates the command
+func NewCmdStepStatement(commonOpts *opts.CommonOptions) *cobra.Command {
+	options := StepStatementOptions{
+		StepOptions: StepOptions{
+			CommonOptions: commonOpts,
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "statement",
+		Short:   "Records a statement such as an approval about a build as a Fact",
+		Long:    stepStatementLong,
+		Example: stepStatementExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Dir, "dir", "d", "", "the directory of the project used to find the current pipeline")
+	cmd.Flags().StringVarP(&options.Name, "name", "n", "", "the name of the statement such as 'approved'")
+	cmd.Flags().BoolVarP(&options.Value, "value", "", true, "the value of the statement")
+	cmd.Flags().StringVarP(&options.StatementType, "type", "t", "approval", "the type of the statement")
+	cmd.Flags().StringVarP(&options.Pipeline, "pipeline", "p", "", "the pipeline in the form 'owner/repo/branch'. Defaults to the current pipeline")
+	cmd.Flags().StringVarP(&options.Build, "build", "b", "", "the build number of the pipeline. Defaults to the current build")
+	return cmd
+}
+
+// Run implements this command
+func (o *StepStatementOptions) Run() error {
+	if o.Name == "" {
+		return util.MissingOption("name")
+	}
+	pipeline := o.Pipeline
+	build := o.Build
+	if pipeline == "" {
+		owner, repo, branch, currentBuild, err := o.pipelineFactSubject(o.Dir)
+		if err != nil {
+			return err
+		}
+		pipeline = owner + "/" + repo + "/" + branch
+		if build == "" {
+			build = currentBuild
+		}
+	}
+	if len(kube.PipelineFactLabels(pipeline, "")) < 3 {
+		return fmt.Errorf("invalid pipeline %s which should be of the form 'owner/repo/branch'", pipeline)
+	}
+	if build == "" {
+		return util.MissingOption("build")
+	}
+	fact := kube.NewPipelineFact("jx-statement-"+o.Name, v1.FactTypeStatement, pipeline, build)
+	fact.Spec.Statements = []v1.Statement{
+		{
+			Name:             o.Name,
+			StatementType:    o.StatementType,
+			MeasurementValue: o.Value,
+		},
+	}
+	err := o.recordPipelineFact(fact)
+	if err != nil {
+		return err
+	}
+	log.Infof("Recorded statement %s as %t on build %s of %s in Fact %s\n", util.ColorInfo(o.Name), o.Value,
+		util.ColorInfo(build), util.ColorInfo(pipeline), util.ColorInfo(fact.Name))
+	return nil
+}
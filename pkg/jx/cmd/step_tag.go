@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/jenkins-x/jx/pkg/gits"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/opts"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	"github.com/jenkins-x/jx/pkg/kube"
@@ -38,6 +39,8 @@ type StepTagFlags struct {
 	VersionFile          string
 	ChartsDir            string
 	ChartValueRepository string
+	Sign                 bool
+	SigningKeyID         string
 }
 
 var (
@@ -51,12 +54,18 @@ var (
 		git tag -fa v$(VERSION) -m "Release version $(VERSION)"
 		git push origin v$(VERSION)
 
+		With --sign the tag is signed with the GPG key of the ` + "`" + kube.SecretJenkinsReleaseGPG + "`" + ` secret, which is read from
+		Vault if secrets are stored in Vault, so that it can be checked with 'jx step verify tag'.
+
 `)
 
 	stepTagExample = templates.Examples(`
 
 		jx step tag --version 1.0.0
 
+		# create a GPG signed tag
+		jx step tag --version 1.0.0 --sign
+
 `)
 )
 
@@ -84,6 +93,8 @@ func NewCmdStepTag(commonOpts *opts.CommonOptions) *cobra.Command {
 
 	cmd.Flags().StringVarP(&options.Flags.ChartsDir, "charts-dir", "d", "", "the directory of the chart to update the version")
 	cmd.Flags().StringVarP(&options.Flags.ChartValueRepository, "charts-value-repository", "r", "", "the fully qualified image name without the version tag. e.g. 'dockerregistry/myorg/myapp'")
+	cmd.Flags().BoolVarP(&options.Flags.Sign, "sign", "", false, "sign the tag with the GPG key of the "+kube.SecretJenkinsReleaseGPG+" secret")
+	cmd.Flags().StringVarP(&options.Flags.SigningKeyID, "signing-key", "", "", "the ID or identity of the GPG key to sign the tag with if the secret contains several keys")
 
 	return cmd
 }
@@ -143,9 +154,22 @@ func (o *StepTagOptions) Run() error {
 		return err
 	}
 
-	err = o.Git().CreateTag("", tag, fmt.Sprintf("release %s", o.Flags.Version))
-	if err != nil {
-		return err
+	message := fmt.Sprintf("release %s", o.Flags.Version)
+	if o.Flags.Sign {
+		signer, err := o.ReleaseSigningKey(o.Flags.SigningKeyID)
+		if err != nil {
+			return err
+		}
+		err = o.Git().CreateSignedTag("", tag, message, signer)
+		if err != nil {
+			return err
+		}
+		log.Infof("Signed tag %s with GPG key %s\n", tag, util.ColorInfo(gits.KeyName(signer)))
+	} else {
+		err = o.Git().CreateTag("", tag, message)
+		if err != nil {
+			return err
+		}
 	}
 
 	if o.Verbose {
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/opts"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/testresults"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// StepTestResultsOptions contains the command line flags
+type StepTestResultsOptions struct {
+	StepOptions
+
+	Dir         string
+	ReportFiles []string
+}
+
+var (
+	stepTestResultsLong = templates.LongDesc(`
+		This pipeline step command parses JUnit XML test reports and records the number of total, passed, failed and skipped tests as a Fact about the build.
+
+		The Fact is used by promotion gates such as 'tests.failed == 0'.
+`)
+
+	stepTestResultsExample = templates.Examples(`
+		# record the test results of a maven project
+		jx step test-results --report-file "target/surefire-reports/TEST-*.xml"
+
+		# record the test results of a go project converted with go-junit-report
+		jx step test-results --report-file report.xml
+`)
+)
+
+// NewCmdStepTestResults creates the command
+func NewCmdStepTestResults(commonOpts *opts.CommonOptions) *cobra.Command {
+	options := StepTestResultsOptions{
+		StepOptions: StepOptions{
+			CommonOptions: commonOpts,
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "test-results",
+		Short:   "Records the test results of a build as a Fact",
+		Long:    stepTestResultsLong,
+		Example: stepTestResultsExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Dir, "dir", "d", "", "the directory of the project")
+	cmd.Flags().StringArrayVarP(&options.ReportFiles, "report-file", "f", []string{}, "the JUnit XML report files to parse which may be glob patterns")
+	return cmd
+}
+
+// Run implements this command
+func (o *StepTestResultsOptions) Run() error {
+	if len(o.ReportFiles) == 0 {
+		return util.MissingOption("report-file")
+	}
+	report := &testresults.Report{}
+	for _, pattern := range o.ReportFiles {
+		files, err := filepath.Glob(pattern)
+		if err != nil {
+			return errors.Wrapf(err, "invalid report file pattern %s", pattern)
+		}
+		if len(files) == 0 {
+			return fmt.Errorf("no test reports found matching %s", pattern)
+		}
+		for _, file := range files {
+			r, err := testresults.ParseJUnitFile(file)
+			if err != nil {
+				return err
+			}
+			report.Add(r)
+		}
+	}
+	log.Infof("Test results are %s\n", util.ColorInfo(report.String()))
+
+	owner, repo, branch, build, err := o.pipelineFactSubject(o.Dir)
+	if err != nil {
+		return err
+	}
+	fact := kube.NewPipelineFact("jx-tests", v1.FactTypeTestResults, owner+"/"+repo+"/"+branch, build)
+	fact.Spec.Measurements = report.Measurements()
+	err = o.recordPipelineFact(fact)
+	if err != nil {
+		return err
+	}
+	log.Infof("Recorded test results Fact %s\n", util.ColorInfo(fact.Name))
+	return nil
+}
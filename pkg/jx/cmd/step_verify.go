@@ -28,6 +28,8 @@ func NewCmdStepVerify(commonOpts *opts.CommonOptions) *cobra.Command {
 		},
 	}
 	cmd.AddCommand(NewCmdStepVerifyPod(commonOpts))
+	cmd.AddCommand(NewCmdStepVerifyProvenance(commonOpts))
+	cmd.AddCommand(NewCmdStepVerifyTag(commonOpts))
 	return cmd
 }
 
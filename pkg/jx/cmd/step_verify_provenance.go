@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/cloud/buckets"
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/imagesign"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/opts"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/provenance"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/openpgp"
+)
+
+// StepVerifyProvenanceOptions contains the command line flags
+type StepVerifyProvenanceOptions struct {
+	StepOptions
+
+	File               string
+	Repository         string
+	Branch             string
+	Keyring            string
+	Timeout            time.Duration
+	InsecureRegistries []string
+}
+
+var (
+	stepVerifyProvenanceLong = templates.LongDesc(`
+		Verifies that an image was produced by a release pipeline of a repository and branch using its signed build provenance.
+
+		The provenance is found from the Releases of the application whose name is the last path element of the image name, or read from a file.
+		Its signature must be from a trusted GPG key which are read from the keyring file or, if no file is specified, from the ` + kube.SecretJenkinsReleaseGPG + ` secret.
+
+		The digest of the image must match the digest of the image in the provenance. If the image has no digest it is resolved from its registry.
+` + opts.SeeAlsoText("jx step provenance"))
+
+	stepVerifyProvenanceExample = templates.Examples(`
+		# verifies the image was built from the master branch of the myorg/myapp repository
+		jx step verify provenance gcr.io/myorg/myapp:1.2.3@sha256:0123... --repo myorg/myapp --branch master
+
+		# verifies an image using a provenance file and the trusted keys of a keyring file
+		jx step verify provenance gcr.io/myorg/myapp:1.2.3 --file provenance.json --keyring trusted-keys.asc
+`)
+)
+
+// NewCmdStepVerifyProvenance creates the `jx step verify provenance` command
+func NewCmdStepVerifyProvenance(commonOpts *opts.CommonOptions) *cobra.Command {
+	options := &StepVerifyProvenanceOptions{
+		StepOptions: StepOptions{
+			CommonOptions: commonOpts,
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "provenance [image]",
+		Short:   "Verifies that an image was produced by a release pipeline of a repository and branch",
+		Long:    stepVerifyProvenanceLong,
+		Example: stepVerifyProvenanceExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.File, "file", "f", "", "the signed provenance file. Defaults to the provenance linked from the Release of the image")
+	cmd.Flags().StringVarP(&options.Repository, "repo", "r", "", "the git URL or 'owner/name' of the repository the image must be built from")
+	cmd.Flags().StringVarP(&options.Branch, "branch", "b", "", "the branch the image must be built from")
+	cmd.Flags().StringVarP(&options.Keyring, "keyring", "k", "", "the armored or binary keyring file of the trusted GPG keys")
+	cmd.Flags().DurationVarP(&options.Timeout, "timeout", "t", time.Second*30, "The timeout period to read each provenance")
+	cmd.Flags().StringArrayVarP(&options.InsecureRegistries, "insecure-registry", "", nil, "the registries which are accessed using plain HTTP when resolving the digest of the image")
+	return cmd
+}
+
+// Run implements this command
+func (o *StepVerifyProvenanceOptions) Run() error {
+	if len(o.Args) != 1 {
+		return util.MissingArgument("image")
+	}
+	image, err := o.resolveImageDigest(o.Args[0])
+	if err != nil {
+		return err
+	}
+	keyring, err := o.TrustedReleaseKeys(o.Keyring)
+	if err != nil {
+		return err
+	}
+
+	if o.File != "" {
+		data, err := ioutil.ReadFile(o.File)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read the provenance file %s", o.File)
+		}
+		statement, signer, err := o.verifyProvenance(image, data, keyring)
+		if err != nil {
+			return errors.Wrapf(err, "failed to verify the provenance of %s in %s", image, o.File)
+		}
+		o.logVerified(image, statement, signer)
+		return nil
+	}
+
+	urls, err := o.findProvenanceURLs(image)
+	if err != nil {
+		return err
+	}
+	if len(urls) == 0 {
+		return fmt.Errorf("no Release of %s has a provenance. Use 'jx step provenance' in the release pipeline", image)
+	}
+	authSvc, err := o.CreateGitAuthConfigService()
+	if err != nil {
+		return err
+	}
+	httpFn := CreateBucketHTTPFn(authSvc)
+	failures := []string{}
+	for _, u := range urls {
+		data, err := buckets.ReadURL(u, o.Timeout, httpFn)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %s", u, err))
+			continue
+		}
+		statement, signer, err := o.verifyProvenance(image, data, keyring)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %s", u, err))
+			continue
+		}
+		o.logVerified(image, statement, signer)
+		return nil
+	}
+	return fmt.Errorf("failed to verify the provenance of %s:\n%s", image, strings.Join(failures, "\n"))
+}
+
+// resolveImageDigest returns the image with the digest of its manifest so that the provenance is matched against the
+// content of the image rather than a tag which can be moved
+func (o *StepVerifyProvenanceOptions) resolveImageDigest(image string) (string, error) {
+	_, digest := provenance.SplitImageDigest(image)
+	if digest != "" {
+		return image, nil
+	}
+	ref, err := imagesign.ParseReference(image)
+	if err != nil {
+		return "", err
+	}
+	digest, err = imagesign.NewRegistry(o.InsecureRegistries).ResolveDigest(ref)
+	if err != nil {
+		return "", err
+	}
+	return image + "@" + digest, nil
+}
+
+// findProvenanceURLs returns the provenance URLs of the Releases of the application of the image whose version
+// matches the tag of the image if it has one
+func (o *StepVerifyProvenanceOptions) findProvenanceURLs(image string) ([]string, error) {
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create the JX client")
+	}
+	appName := provenance.ImageApp(image)
+	releases, err := kube.GetOrderedReleases(jxClient, ns, appName)
+	if err != nil {
+		return nil, err
+	}
+	tag := strings.TrimPrefix(provenance.ImageTag(image), "v")
+	urls := []string{}
+	// the releases are ordered with the latest version first
+	for _, release := range releases {
+		if release.Spec.ProvenanceURL == "" || release.Spec.Name != appName || (tag != "" && release.Spec.Version != tag) {
+			continue
+		}
+		urls = append(urls, release.Spec.ProvenanceURL)
+	}
+	return urls, nil
+}
+
+func (o *StepVerifyProvenanceOptions) verifyProvenance(image string, data []byte, keyring openpgp.EntityList) (*provenance.Statement, *openpgp.Entity, error) {
+	statement, signer, err := provenance.Verify(data, keyring)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !statement.MatchesImage(image) {
+		return nil, nil, fmt.Errorf("the provenance is not of image %s", image)
+	}
+	err = statement.CheckSource(o.Repository, o.Branch)
+	if err != nil {
+		return nil, nil, err
+	}
+	return statement, signer, nil
+}
+
+func (o *StepVerifyProvenanceOptions) logVerified(image string, statement *provenance.Statement, signer *openpgp.Entity) {
+	gitURL, branch, revision := statement.Source()
+	log.Successf("Image %s was built from %s branch %s revision %s with a provenance signed by %s", util.ColorInfo(image),
+		util.ColorInfo(gitURL), util.ColorInfo(branch), util.ColorInfo(revision), util.ColorInfo(gits.KeyName(signer)))
+}
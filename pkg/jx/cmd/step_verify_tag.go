@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/opts"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// StepVerifyTagOptions contains the command line flags
+type StepVerifyTagOptions struct {
+	StepOptions
+
+	Dir         string
+	Tag         string
+	Version     string
+	VersionFile string
+	Keyring     string
+}
+
+var (
+	stepVerifyTagLong = templates.LongDesc(`
+		Verifies that a release tag is signed with a trusted GPG key.
+
+		The trusted keys are read from the keyring file or, if no file is specified, from the ` + kube.SecretJenkinsReleaseGPG + ` secret.
+`)
+
+	stepVerifyTagExample = templates.Examples(`
+		# verifies the tag of the version in the VERSION file
+		jx step verify tag
+
+		# verifies the tag v1.2.3 with the trusted keys of a keyring file
+		jx step verify tag --tag v1.2.3 --keyring trusted-keys.asc
+`)
+)
+
+// NewCmdStepVerifyTag creates the `jx step verify tag` command
+func NewCmdStepVerifyTag(commonOpts *opts.CommonOptions) *cobra.Command {
+	options := &StepVerifyTagOptions{
+		StepOptions: StepOptions{
+			CommonOptions: commonOpts,
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "tag",
+		Short:   "Verifies that a release tag is signed with a trusted GPG key",
+		Long:    stepVerifyTagLong,
+		Example: stepVerifyTagExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Dir, "dir", "d", "", "the directory of the git repository")
+	cmd.Flags().StringVarP(&options.Tag, "tag", "t", "", "the tag to verify. Defaults to the version prefixed with 'v'")
+	cmd.Flags().StringVarP(&options.Version, VERSION, "v", "", "the version whose tag is verified")
+	cmd.Flags().StringVarP(&options.VersionFile, "version-file", "", defaultVersionFile, "The file name used to load the version number from if no '--version' or '--tag' option is specified")
+	cmd.Flags().StringVarP(&options.Keyring, "keyring", "k", "", "the armored or binary keyring file of the trusted GPG keys")
+	return cmd
+}
+
+// Run implements this command
+func (o *StepVerifyTagOptions) Run() error {
+	tag := o.Tag
+	if tag == "" {
+		version := o.Version
+		if version == "" && o.VersionFile != "" {
+			exists, err := util.FileExists(o.VersionFile)
+			if err != nil {
+				return err
+			}
+			if exists {
+				data, err := ioutil.ReadFile(o.VersionFile)
+				if err != nil {
+					return err
+				}
+				version = strings.TrimSpace(string(data))
+			}
+		}
+		if version == "" {
+			return util.MissingOption("tag")
+		}
+		tag = "v" + version
+	}
+	signer, err := o.VerifyReleaseTag(o.Dir, tag, o.Keyring)
+	if err != nil {
+		return errors.Wrapf(err, "failed to verify the signature of tag %s", tag)
+	}
+	log.Successf("Tag %s has a valid signature from %s", util.ColorInfo(tag), util.ColorInfo(gits.KeyName(signer)))
+	return nil
+}
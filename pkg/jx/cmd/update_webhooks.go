@@ -20,6 +20,8 @@ type UpdateWebhooksOptions struct {
 	*opts.CommonOptions
 	Org             string
 	Repo            string
+	GitServerURL    string
+	GitKind         string
 	ExactHookMatch  bool
 	PreviousHookUrl string
 	HMAC            string
@@ -38,6 +40,9 @@ var (
 
 		jx update webhooks --org=mycorp
 
+		# update the webhooks of a group on GitLab
+		jx update webhooks --org=mygroup --git-provider-url=https://gitlab.com
+
 `)
 )
 
@@ -59,6 +64,9 @@ func NewCmdUpdateWebhooks(commonOpts *opts.CommonOptions) *cobra.Command {
 
 	cmd.Flags().StringVarP(&options.Org, "org", "o", "jenkins-x", "The name of the git organisation to query")
 	cmd.Flags().StringVarP(&options.Repo, "repo", "r", "", "The name of the repository to query")
+	cmd.Flags().StringVarP(&options.GitServerURL, "git-provider-url", "", gits.GitHubURL, "The Git server URL of the repositories")
+	cmd.Flags().StringVarP(&options.GitKind, "git-provider-kind", "", "",
+		"Kind of Git server. If not specified, kind of server will be autodetected from Git provider URL. Possible values: "+strings.Join(gits.KindGits, ", "))
 	cmd.Flags().BoolVarP(&options.ExactHookMatch, "exact-hook-url-match", "", true, "Whether to exactly match the hook based on the URL")
 	cmd.Flags().StringVarP(&options.PreviousHookUrl, "previous-hook-url", "", "", "Whether to match based on an another URL")
 	cmd.Flags().StringVarP(&options.HMAC, "hmac", "", "", "Don't use the HMAC token from the cluster, use the provided token")
@@ -113,10 +121,19 @@ func (options *UpdateWebhooksOptions) Run() error {
 		}
 	}
 
-	git, err := options.GitProviderForGitServerURL(gits.GitHubURL, "github")
+	gitKind := options.GitKind
+	if gitKind == "" {
+		gitKind, err = options.GitServerHostURLKind(options.GitServerURL)
+		if err != nil {
+			return errors.Wrapf(err, "unable to determine the kind of git server %s", options.GitServerURL)
+		}
+	}
+	git, err := options.GitProviderForGitServerURL(options.GitServerURL, gitKind)
 	if err != nil {
 		return errors.Wrap(err, "unable to determine git provider")
 	}
+	git = options.CachingGitProvider(git)
+	defer options.LogGitProviderMetrics(git)
 
 	if options.Repo != "" {
 		options.updateRepoHook(git, options.Repo, webhookURL, isProwEnabled, hmacToken)
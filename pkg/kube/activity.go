@@ -44,6 +44,7 @@ type PromoteStepActivityKey struct {
 
 type PromotePullRequestFn func(*v1.PipelineActivity, *v1.PipelineActivityStep, *v1.PromoteActivityStep, *v1.PromotePullRequestStep) error
 type PromoteUpdateFn func(*v1.PipelineActivity, *v1.PipelineActivityStep, *v1.PromoteActivityStep, *v1.PromoteUpdateStep) error
+type PromoteGatesFn func(*v1.PipelineActivity, *v1.PipelineActivityStep, *v1.PromoteActivityStep, *v1.PromoteGatesStep) error
 
 type PipelineDetails struct {
 	GitOwner      string
@@ -505,6 +506,25 @@ func (k *PromoteStepActivityKey) GetOrCreatePromoteUpdate(jxClient versioned.Int
 	return a, s, p, p.Update, created, err
 }
 
+// GetOrCreatePromoteGates gets or creates the PromoteGates for the key
+func (k *PromoteStepActivityKey) GetOrCreatePromoteGates(jxClient versioned.Interface, ns string) (*v1.PipelineActivity, *v1.PipelineActivityStep, *v1.PromoteActivityStep, *v1.PromoteGatesStep, bool, error) {
+	a, s, p, created, err := k.GetOrCreatePromote(jxClient, ns)
+	if err != nil {
+		return nil, nil, nil, nil, created, err
+	}
+	if p.Gates == nil {
+		created = true
+		p.Gates = &v1.PromoteGatesStep{
+			CoreActivityStep: v1.CoreActivityStep{
+				StartedTimestamp: &metav1.Time{
+					Time: time.Now(),
+				},
+			},
+		}
+	}
+	return a, s, p, p.Gates, created, err
+}
+
 //OnPromotePullRequest updates activities on a Promote PR
 func (k *PromoteStepActivityKey) OnPromotePullRequest(jxClient versioned.Interface, ns string, fn PromotePullRequestFn) error {
 	if !k.IsValid() {
@@ -565,6 +585,33 @@ func (k *PromoteStepActivityKey) OnPromoteUpdate(jxClient versioned.Interface, n
 	return err
 }
 
+// OnPromoteGates updates activities on the evaluation of the gates of a Promote
+func (k *PromoteStepActivityKey) OnPromoteGates(jxClient versioned.Interface, ns string, fn PromoteGatesFn) error {
+	if !k.IsValid() {
+		return nil
+	}
+	activities := jxClient.JenkinsV1().PipelineActivities(ns)
+	if activities == nil {
+		log.Warn("Warning: no PipelineActivities client available!")
+		return nil
+	}
+	a, s, ps, p, added, err := k.GetOrCreatePromoteGates(jxClient, ns)
+	if err != nil {
+		return err
+	}
+	p1 := asYaml(a)
+	err = fn(a, s, ps, p)
+	if err != nil {
+		return err
+	}
+	p2 := asYaml(a)
+
+	if added || p1 == "" || p1 != p2 {
+		_, err = activities.PatchUpdate(a)
+	}
+	return err
+}
+
 func asYaml(activity *v1.PipelineActivity) string {
 	data, err := yaml.Marshal(activity)
 	if err == nil {
@@ -152,3 +152,40 @@ func FailedPromotionUpdate(a *v1.PipelineActivity, s *v1.PipelineActivityStep, p
 	p.Status = v1.ActivityStatusTypeFailed
 	return nil
 }
+
+func CompletePromotionGates(a *v1.PipelineActivity, s *v1.PipelineActivityStep, ps *v1.PromoteActivityStep, p *v1.PromoteGatesStep) error {
+	// lets restart a promotion which was previously blocked by its gates
+	if ps.Status == v1.ActivityStatusTypeFailed && p.Status == v1.ActivityStatusTypeFailed {
+		ps.Status = v1.ActivityStatusTypeRunning
+		ps.CompletedTimestamp = nil
+	}
+	StartPromote(ps)
+	if p.StartedTimestamp == nil {
+		p.StartedTimestamp = &metav1.Time{
+			Time: time.Now(),
+		}
+	}
+	if p.Status != v1.ActivityStatusTypeSucceeded {
+		p.CompletedTimestamp = &metav1.Time{
+			Time: time.Now(),
+		}
+	}
+	p.Status = v1.ActivityStatusTypeSucceeded
+	return nil
+}
+
+func FailedPromotionGates(a *v1.PipelineActivity, s *v1.PipelineActivityStep, ps *v1.PromoteActivityStep, p *v1.PromoteGatesStep) error {
+	FailedPromote(ps)
+	if p.StartedTimestamp == nil {
+		p.StartedTimestamp = &metav1.Time{
+			Time: time.Now(),
+		}
+	}
+	if p.CompletedTimestamp == nil {
+		p.CompletedTimestamp = &metav1.Time{
+			Time: time.Now(),
+		}
+	}
+	p.Status = v1.ActivityStatusTypeFailed
+	return nil
+}
@@ -274,6 +274,31 @@ const (
 	// SecretDataPassword the password in a Secret/Credentials
 	SecretDataPassword = "password"
 
+	// SecretDataGPGSigningKey the armored or binary GPG private key used to sign release tags in the release GPG Secret
+	SecretDataGPGSigningKey = "signing-key.asc"
+
+	// SecretDataGPGSecretKeyring the secret keyring of the release GPG Secret used to sign release tags if there is no
+	// signing key
+	SecretDataGPGSecretKeyring = "secring.gpg"
+
+	// SecretDataGPGPassphrase the passphrase of the GPG signing key in the release GPG Secret
+	SecretDataGPGPassphrase = "passphrase"
+
+	// SecretDataGPGTrustedKeys the armored GPG public keys trusted to sign release tags in the release GPG Secret
+	SecretDataGPGTrustedKeys = "trusted-keys.asc"
+
+	// SecretDataGPGPublicKeyring the public keyring of the release GPG Secret which is trusted if there are no trusted keys
+	SecretDataGPGPublicKeyring = "pubring.gpg"
+
+	// SecretDataGitHubAppID the ID of a GitHub App in a git Secret/Credentials
+	SecretDataGitHubAppID = "githubAppId"
+
+	// SecretDataGitHubAppPrivateKey the PEM encoded private key of a GitHub App in a git Secret/Credentials
+	SecretDataGitHubAppPrivateKey = "githubAppPrivateKey"
+
+	// SecretDataGitHubAppOwner the organisation or user whose installation of a GitHub App is used by default
+	SecretDataGitHubAppOwner = "githubAppOwner"
+
 	// SecretBasicAuth the name for the Jenkins X basic auth secret
 	SecretBasicAuth = "jx-basic-auth"
 
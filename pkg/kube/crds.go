@@ -100,6 +100,10 @@ func RegisterPipelineCRDs(apiClient apiextensionsclientset.Interface) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to register the SourceRepository CRD")
 	}
+	err = RegisterSchedulerCRD(apiClient)
+	if err != nil {
+		return errors.Wrap(err, "failed to register the Scheduler CRD")
+	}
 	return nil
 }
 
@@ -385,6 +389,20 @@ func RegisterSourceRepositoryCRD(apiClient apiextensionsclientset.Interface) err
 	return RegisterCRD(apiClient, name, names, columns, jenkinsio.GroupName, jenkinsio.Package, jenkinsio.Version)
 }
 
+// RegisterSchedulerCRD ensures that the CRD is registered for Schedulers
+func RegisterSchedulerCRD(apiClient apiextensionsclientset.Interface) error {
+	name := "schedulers." + jenkinsio.GroupName
+	names := &v1beta1.CustomResourceDefinitionNames{
+		Kind:       "Scheduler",
+		ListKind:   "SchedulerList",
+		Plural:     "schedulers",
+		Singular:   "scheduler",
+		Categories: []string{"all"},
+	}
+	columns := []v1beta1.CustomResourceColumnDefinition{}
+	return RegisterCRD(apiClient, name, names, columns, jenkinsio.GroupName, jenkinsio.Package, jenkinsio.Version)
+}
+
 // RegisterPluginCRD ensures that the CRD is registered for Plugin
 func RegisterPluginCRD(apiClient apiextensionsclientset.Interface) error {
 	name := "plugins." + jenkinsio.GroupName
@@ -0,0 +1,121 @@
+package kube
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/client/clientset/versioned"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// GetOrCreateFact creates or updates the given fact resource
+func GetOrCreateFact(jxClient versioned.Interface, ns string, fact *v1.Fact) (*v1.Fact, error) {
+	factInterface := jxClient.JenkinsV1().Facts(ns)
+	name := fact.Name
+	old, err := factInterface.Get(name, metav1.GetOptions{})
+	if err == nil {
+		old.Labels = fact.Labels
+		old.Spec = fact.Spec
+		answer, err := factInterface.PatchUpdate(old)
+		if err != nil {
+			return answer, errors.Wrapf(err, "Failed to update Fact %s in namespace %s", name, ns)
+		}
+		return answer, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, errors.Wrapf(err, "Failed to get Fact %s in namespace %s", name, ns)
+	}
+	answer, err := factInterface.Create(fact)
+	if err != nil {
+		return answer, errors.Wrapf(err, "Failed to create Fact %s in namespace %s", name, ns)
+	}
+	return answer, nil
+}
+
+// GetLatestFact returns the fact of the given type matching the labels with the highest build number
+// or nil if there is no matching fact
+func GetLatestFact(jxClient versioned.Interface, ns string, factType string, matchLabels map[string]string) (*v1.Fact, error) {
+	facts, err := GetFacts(jxClient, ns, matchLabels)
+	if err != nil {
+		return nil, err
+	}
+	for i := range facts {
+		if facts[i].Spec.FactType == factType {
+			return &facts[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// GetFacts returns the facts of any type matching the labels with the highest build number first
+func GetFacts(jxClient versioned.Interface, ns string, matchLabels map[string]string) ([]v1.Fact, error) {
+	list, err := jxClient.JenkinsV1().Facts(ns).List(metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(matchLabels).String(),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list Facts in namespace %s", ns)
+	}
+	facts := list.Items
+	sort.Sort(FactOrder(facts))
+	return facts, nil
+}
+
+// PipelineFactLabels returns the labels of the Facts about the build of the pipeline in the form 'owner/repo/branch'.
+// If the build is blank the labels match the Facts about all the builds of the pipeline
+func PipelineFactLabels(pipeline string, build string) map[string]string {
+	answer := map[string]string{}
+	paths := strings.Split(pipeline, "/")
+	if len(paths) >= 3 {
+		answer[v1.FactLabelOrg] = ToValidName(paths[len(paths)-3])
+		answer[v1.FactLabelRepo] = ToValidName(paths[len(paths)-2])
+		answer[v1.FactLabelBranch] = ToValidName(paths[len(paths)-1])
+	}
+	if build != "" {
+		answer[v1.FactLabelBuildNumber] = build
+	}
+	return answer
+}
+
+// NewPipelineFact returns a Fact of the given type about the build of the pipeline in the form 'owner/repo/branch'
+// with the labels matched by PipelineFactLabels
+func NewPipelineFact(name string, factType string, pipeline string, build string) *v1.Fact {
+	pipelineName := ToValidName(strings.Replace(pipeline, "/", "-", -1) + "-" + build)
+	labels := PipelineFactLabels(pipeline, build)
+	labels[v1.FactLabelSubjectKind] = "PipelineActivity"
+	labels[v1.FactLabelPipelineName] = pipelineName
+	return &v1.Fact{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   ToValidName(name + "-" + pipelineName),
+			Labels: labels,
+		},
+		Spec: v1.FactSpec{
+			Name:     name,
+			FactType: factType,
+			SubjectReference: v1.ResourceReference{
+				Kind: "PipelineActivity",
+				Name: pipelineName,
+			},
+		},
+	}
+}
+
+// FactOrder sorts facts with the newest build first
+type FactOrder []v1.Fact
+
+func (a FactOrder) Len() int      { return len(a) }
+func (a FactOrder) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+func (a FactOrder) Less(i, j int) bool {
+	f1 := a[i]
+	f2 := a[j]
+	b1, err1 := strconv.Atoi(f1.Labels[v1.FactLabelBuildNumber])
+	b2, err2 := strconv.Atoi(f2.Labels[v1.FactLabelBuildNumber])
+	if err1 == nil && err2 == nil && b1 != b2 {
+		return b1 > b2
+	}
+	return f2.CreationTimestamp.Before(&f1.CreationTimestamp)
+}
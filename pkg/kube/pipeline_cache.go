@@ -18,8 +18,15 @@ type PipelineNamespaceCache struct {
 	stop      chan struct{}
 	//Flag to indicate whether the cache has done its initial load & is in sync.
 	ready bool
+
+	listenersLock sync.RWMutex
+	listeners     []PipelineActivityListener
 }
 
+// PipelineActivityListener is invoked when a PipelineActivity is added, updated or deleted.
+// The old pipeline is nil when a pipeline is added and the new pipeline is nil when it is deleted
+type PipelineActivityListener func(oldPipeline *v1.PipelineActivity, newPipeline *v1.PipelineActivity)
+
 // NewPipelineCache creates a cache of pipelines for a namespace
 func NewPipelineCache(jxClient versioned.Interface, ns string) *PipelineNamespaceCache {
 	pipeline := &v1.PipelineActivity{}
@@ -43,10 +50,10 @@ func NewPipelineCache(jxClient versioned.Interface, ns string) *PipelineNamespac
 		time.Minute*10,
 		cache.ResourceEventHandlerFuncs{
 			AddFunc: func(obj interface{}) {
-				pipelineCache.onPipelineObj(obj, jxClient, ns)
+				pipelineCache.onPipelineObj(nil, obj, jxClient, ns)
 			},
 			UpdateFunc: func(oldObj, newObj interface{}) {
-				pipelineCache.onPipelineObj(newObj, jxClient, ns)
+				pipelineCache.onPipelineObj(oldObj, newObj, jxClient, ns)
 			},
 			DeleteFunc: func(obj interface{}) {
 				pipelineCache.onPipelineDelete(obj, jxClient, ns)
@@ -98,7 +105,22 @@ func (c *PipelineNamespaceCache) ForEach(callback func(*v1.PipelineActivity)) {
 	c.pipelines.Range(onEntry)
 }
 
-func (c *PipelineNamespaceCache) onPipelineObj(obj interface{}, jxClient versioned.Interface, ns string) {
+// AddListener registers a listener which is invoked whenever a PipelineActivity in this namespace changes
+func (c *PipelineNamespaceCache) AddListener(listener PipelineActivityListener) {
+	c.listenersLock.Lock()
+	defer c.listenersLock.Unlock()
+	c.listeners = append(c.listeners, listener)
+}
+
+func (c *PipelineNamespaceCache) notifyListeners(oldPipeline *v1.PipelineActivity, newPipeline *v1.PipelineActivity) {
+	c.listenersLock.RLock()
+	defer c.listenersLock.RUnlock()
+	for _, listener := range c.listeners {
+		listener(oldPipeline, newPipeline)
+	}
+}
+
+func (c *PipelineNamespaceCache) onPipelineObj(oldObj interface{}, obj interface{}, jxClient versioned.Interface, ns string) {
 	pipeline, ok := obj.(*v1.PipelineActivity)
 	if !ok {
 		log.Warnf("Object is not a PipelineActivity %#v\n", obj)
@@ -106,6 +128,8 @@ func (c *PipelineNamespaceCache) onPipelineObj(obj interface{}, jxClient version
 	}
 	if pipeline != nil {
 		c.pipelines.Store(pipeline.Name, pipeline)
+		oldPipeline, _ := oldObj.(*v1.PipelineActivity)
+		c.notifyListeners(oldPipeline, pipeline)
 	}
 }
 
@@ -117,5 +141,6 @@ func (c *PipelineNamespaceCache) onPipelineDelete(obj interface{}, jxClient vers
 	}
 	if pipeline != nil {
 		c.pipelines.Delete(pipeline.Name)
+		c.notifyListeners(pipeline, nil)
 	}
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
 	"github.com/jenkins-x/jx/pkg/client/clientset/versioned"
 	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -17,13 +18,47 @@ func GetOrCreateRelease(jxClient versioned.Interface, ns string, release *v1.Rel
 	name := release.Name
 	old, err := releaseInterface.Get(name, metav1.GetOptions{})
 	if err == nil {
+		sbomURL := old.Spec.SBOMURL
+		provenanceURL := old.Spec.ProvenanceURL
 		old.Spec = release.Spec
+		// lets keep the SBOM and provenance if they were generated before the release
+		if old.Spec.SBOMURL == "" {
+			old.Spec.SBOMURL = sbomURL
+		}
+		if old.Spec.ProvenanceURL == "" {
+			old.Spec.ProvenanceURL = provenanceURL
+		}
+		answer, err := releaseInterface.PatchUpdate(old)
+		if err != nil {
+			return answer, errors.Wrapf(err, "Failed to update Release %s in namespace %s", name, ns)
+		}
+		return answer, nil
+	}
+	answer, err := releaseInterface.Create(release)
+	if err != nil {
+		return answer, errors.Wrapf(err, "Failed to create Release %s in namespace %s", name, ns)
+	}
+	return answer, nil
+}
+
+// UpdateRelease applies the update function to the Release with the name of the given release and patches it,
+// creating the given release with the update applied if it does not exist yet
+func UpdateRelease(jxClient versioned.Interface, ns string, release *v1.Release, update func(release *v1.Release)) (*v1.Release, error) {
+	releaseInterface := jxClient.JenkinsV1().Releases(ns)
+	name := release.Name
+	old, err := releaseInterface.Get(name, metav1.GetOptions{})
+	if err == nil {
+		update(old)
 		answer, err := releaseInterface.PatchUpdate(old)
 		if err != nil {
 			return answer, errors.Wrapf(err, "Failed to update Release %s in namespace %s", name, ns)
 		}
 		return answer, nil
 	}
+	if !apierrors.IsNotFound(err) {
+		return nil, errors.Wrapf(err, "Failed to get Release %s in namespace %s", name, ns)
+	}
+	update(release)
 	answer, err := releaseInterface.Create(release)
 	if err != nil {
 		return answer, errors.Wrapf(err, "Failed to create Release %s in namespace %s", name, ns)
@@ -2,7 +2,11 @@ package kube
 
 import (
 	"sort"
+	"strconv"
+	"strings"
 
+	"github.com/jenkins-x/jx/pkg/auth"
+	"github.com/jenkins-x/jx/pkg/gits"
 	"github.com/jenkins-x/jx/pkg/log"
 	"github.com/jenkins-x/jx/pkg/util"
 	"github.com/pkg/errors"
@@ -72,3 +76,53 @@ func DefaultModifySecret(kubeClient kubernetes.Interface, ns string, name string
 	}
 	return secret, nil
 }
+
+// GitHubAppSecretUserAuth returns the user auth of the GitHub App in a git Secret or nil if the Secret does not
+// contain a GitHub App
+func GitHubAppSecretUserAuth(secret *v1.Secret) (*auth.UserAuth, error) {
+	data := secret.Data
+	if data == nil || len(data[SecretDataGitHubAppID]) == 0 {
+		return nil, nil
+	}
+	text := strings.TrimSpace(string(data[SecretDataGitHubAppID]))
+	appID, err := strconv.ParseInt(text, 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid GitHub App ID %s in Secret %s", text, secret.Name)
+	}
+	privateKey := string(data[SecretDataGitHubAppPrivateKey])
+	if privateKey == "" {
+		return nil, errors.Errorf("no GitHub App private key found in Secret %s", secret.Name)
+	}
+	return &auth.UserAuth{
+		Username:            string(data[SecretDataUsername]),
+		GitHubAppID:         appID,
+		GitHubAppPrivateKey: privateKey,
+		GitHubAppOwner:      string(data[SecretDataGitHubAppOwner]),
+	}, nil
+}
+
+// GitSecretUserAuth returns the user auth of a git Secret for the git server at serverURL or nil if the Secret has no
+// credentials. If the Secret contains a GitHub App then an installation token of the App is used as the ApiToken so
+// that the user auth can be used like a personal token for git operations
+func GitSecretUserAuth(secret *v1.Secret, serverURL string) (*auth.UserAuth, error) {
+	userAuth, err := GitHubAppSecretUserAuth(secret)
+	if err != nil {
+		return nil, err
+	}
+	if userAuth != nil {
+		err = userAuth.ResolveGitHubAppToken(gits.GitHubAPIURL(serverURL), "")
+		if err != nil {
+			return nil, errors.Wrapf(err, "creating an installation token of the GitHub App in Secret %s", secret.Name)
+		}
+		userAuth.Username = auth.GitHubAppUsername
+		return userAuth, nil
+	}
+	data := secret.Data
+	if data == nil || len(data[SecretDataUsername]) == 0 || len(data[SecretDataPassword]) == 0 {
+		return nil, nil
+	}
+	return &auth.UserAuth{
+		Username: string(data[SecretDataUsername]),
+		ApiToken: string(data[SecretDataPassword]),
+	}, nil
+}
@@ -11,12 +11,18 @@ const (
 
 	// ClassificationCoverage stores code coverage results/reports
 	ClassificationCoverage = "coverage"
+
+	// ClassificationSBOM stores the software bill of materials of releases
+	ClassificationSBOM = "sbom"
+
+	// ClassificationProvenance stores the signed build provenance of releases
+	ClassificationProvenance = "provenance"
 )
 
 var (
 	// Classifications the common classification names
 	Classifications = []string{
-		ClassificationCoverage, ClassificationTests, ClassificationLogs,
+		ClassificationCoverage, ClassificationTests, ClassificationLogs, ClassificationSBOM, ClassificationProvenance,
 	}
 
 	// ClassificationValues the classification values as a string
@@ -0,0 +1,125 @@
+// Package notifications sends chat messages about pipeline failures, recoveries and promotions
+package notifications
+
+import (
+	"strconv"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+)
+
+// EventKind the kind of notification event
+type EventKind string
+
+const (
+	// EventPipelineFailed a pipeline failed
+	EventPipelineFailed EventKind = "PipelineFailed"
+	// EventPipelineRecovered a pipeline succeeded after the previous build of the pipeline failed
+	EventPipelineRecovered EventKind = "PipelineRecovered"
+	// EventPromotionPullRequest a Pull Request was opened to promote a version to an environment
+	EventPromotionPullRequest EventKind = "PromotionPullRequest"
+	// EventPromotionMerged a promotion Pull Request was merged into an environment
+	EventPromotionMerged EventKind = "PromotionMerged"
+)
+
+// Event is a change of a pipeline activity which should be notified
+type Event struct {
+	Kind           EventKind
+	Activity       *v1.PipelineActivity
+	Previous       *v1.PipelineActivity
+	Environment    string
+	PullRequestURL string
+	MergeCommitSHA string
+}
+
+// Events returns the notification events caused by the change of the activity from the old to the new version.
+// The previous activity is the previous build of the same pipeline, if there is one, used to detect recoveries.
+//
+// No events are returned for newly added activities so that existing activities are not notified when a controller starts
+func Events(oldActivity *v1.PipelineActivity, newActivity *v1.PipelineActivity, previous *v1.PipelineActivity) []Event {
+	if oldActivity == nil || newActivity == nil {
+		return nil
+	}
+	answer := []Event{}
+	status := newActivity.Spec.Status
+	if status != oldActivity.Spec.Status {
+		if IsFailed(status) {
+			answer = append(answer, Event{
+				Kind:     EventPipelineFailed,
+				Activity: newActivity,
+				Previous: previous,
+			})
+		} else if status == v1.ActivityStatusTypeSucceeded && previous != nil && IsFailed(previous.Spec.Status) {
+			answer = append(answer, Event{
+				Kind:     EventPipelineRecovered,
+				Activity: newActivity,
+				Previous: previous,
+			})
+		}
+	}
+
+	for i := range newActivity.Spec.Steps {
+		promote := newActivity.Spec.Steps[i].Promote
+		if promote == nil || promote.PullRequest == nil {
+			continue
+		}
+		pr := promote.PullRequest
+		oldPR := findPromotePullRequest(oldActivity, promote.Environment)
+		if pr.PullRequestURL != "" && (oldPR == nil || oldPR.PullRequestURL == "") {
+			answer = append(answer, Event{
+				Kind:           EventPromotionPullRequest,
+				Activity:       newActivity,
+				Environment:    promote.Environment,
+				PullRequestURL: pr.PullRequestURL,
+			})
+		}
+		if pr.MergeCommitSHA != "" && (oldPR == nil || oldPR.MergeCommitSHA == "") {
+			answer = append(answer, Event{
+				Kind:           EventPromotionMerged,
+				Activity:       newActivity,
+				Environment:    promote.Environment,
+				PullRequestURL: pr.PullRequestURL,
+				MergeCommitSHA: pr.MergeCommitSHA,
+			})
+		}
+	}
+	return answer
+}
+
+// IsFailed returns true if the status is a failure
+func IsFailed(status v1.ActivityStatusType) bool {
+	return status == v1.ActivityStatusTypeFailed || status == v1.ActivityStatusTypeError
+}
+
+// PreviousActivity returns the activity with the highest build number of the same pipeline which is lower than the
+// build number of the given activity
+func PreviousActivity(activities []*v1.PipelineActivity, activity *v1.PipelineActivity) *v1.PipelineActivity {
+	build, err := strconv.Atoi(activity.Spec.Build)
+	if err != nil {
+		return nil
+	}
+	var answer *v1.PipelineActivity
+	answerBuild := 0
+	for _, a := range activities {
+		if a.Spec.Pipeline != activity.Spec.Pipeline {
+			continue
+		}
+		b, err := strconv.Atoi(a.Spec.Build)
+		if err != nil || b >= build {
+			continue
+		}
+		if answer == nil || b > answerBuild {
+			answer = a
+			answerBuild = b
+		}
+	}
+	return answer
+}
+
+func findPromotePullRequest(activity *v1.PipelineActivity, environment string) *v1.PromotePullRequestStep {
+	for _, step := range activity.Spec.Steps {
+		if step.Promote != nil && step.Promote.Environment == environment {
+			return step.Promote.PullRequest
+		}
+	}
+	return nil
+}
@@ -0,0 +1,290 @@
+package notifications
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/chats"
+	"github.com/jenkins-x/jx/pkg/config"
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/users"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const (
+	maxCommitAuthors = 20
+)
+
+// ChatConfigLoader returns the chat configuration for the repository of the activity or nil if chat is not configured
+type ChatConfigLoader func(activity *v1.PipelineActivity) (*config.ChatConfig, error)
+
+// ChatProviderFactory creates the chat provider for the given configuration
+type ChatProviderFactory func(chatConfig *config.ChatConfig) (chats.ChatProvider, error)
+
+// MentionResolver returns the users to mention in the notification of the event
+type MentionResolver func(event *Event, chatKind string) ([]chats.Mention, error)
+
+// Notifier sends chat messages when pipelines fail or recover and when promotions are raised or merged. Each message
+// mentions the commit authors. Recoveries are replied in the thread of the failure message and merges in the thread
+// of the promotion message
+type Notifier struct {
+	ConfigLoader    ChatConfigLoader
+	ProviderFactory ChatProviderFactory
+	MentionResolver MentionResolver
+	// Branches the branches whose pipeline failures and recoveries are notified. Defaults to master
+	Branches []string
+
+	lock    sync.Mutex
+	threads map[string]*chats.MessageReference
+}
+
+// NewNotifier creates a new notifier
+func NewNotifier(configLoader ChatConfigLoader, providerFactory ChatProviderFactory, mentionResolver MentionResolver) *Notifier {
+	return &Notifier{
+		ConfigLoader:    configLoader,
+		ProviderFactory: providerFactory,
+		MentionResolver: mentionResolver,
+		Branches:        []string{"master"},
+		threads:         map[string]*chats.MessageReference{},
+	}
+}
+
+// Notify sends the chat messages for the event
+func (n *Notifier) Notify(event *Event) error {
+	activity := event.Activity
+	if (event.Kind == EventPipelineFailed || event.Kind == EventPipelineRecovered) && util.StringArrayIndex(n.Branches, activity.BranchName()) < 0 {
+		return nil
+	}
+	chatConfig, err := n.ConfigLoader(activity)
+	if err != nil {
+		return err
+	}
+	if chatConfig == nil || chatConfig.URL == "" {
+		return nil
+	}
+	channel := chatConfig.DeveloperChannel
+	if event.Kind == EventPromotionPullRequest || event.Kind == EventPromotionMerged {
+		if chatConfig.UserChannel != "" {
+			channel = chatConfig.UserChannel
+		}
+	}
+	if channel == "" {
+		return nil
+	}
+	provider, err := n.ProviderFactory(chatConfig)
+	if err != nil {
+		return err
+	}
+	if provider == nil {
+		return nil
+	}
+
+	message := CreateMessage(event)
+	if n.MentionResolver != nil {
+		mentions, err := n.MentionResolver(event, chatConfig.Kind)
+		if err != nil {
+			log.Warnf("Failed to resolve the users to mention for %s: %s\n", activity.Name, err)
+		}
+		message.Mentions = mentions
+	}
+
+	switch event.Kind {
+	case EventPipelineFailed:
+		ref, err := provider.SendMessage(channel, message)
+		if err != nil {
+			return err
+		}
+		n.putThread(failureKey(activity), ref)
+	case EventPipelineRecovered:
+		ref := n.takeThread(failureKey(activity))
+		return replyAndUpdate(provider, channel, ref, message, fmt.Sprintf(":x: Pipeline **%s** failed, fixed in build #%s", activity.Spec.Pipeline, activity.Spec.Build))
+	case EventPromotionPullRequest:
+		ref, err := provider.SendMessage(channel, message)
+		if err != nil {
+			return err
+		}
+		n.putThread(promotionKey(activity, event.Environment), ref)
+	case EventPromotionMerged:
+		ref := n.takeThread(promotionKey(activity, event.Environment))
+		return replyAndUpdate(provider, channel, ref, message, fmt.Sprintf(":rocket: Promotion of **%s** version **%s** to **%s** merged", activity.RepositoryName(), versionOf(activity), event.Environment))
+	}
+	return nil
+}
+
+// putThread remembers the message which later messages with the key are replied to. The lock is only held while
+// accessing the threads so that a slow chat server does not delay the notifications of other pipelines
+func (n *Notifier) putThread(key string, ref *chats.MessageReference) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if n.threads == nil {
+		n.threads = map[string]*chats.MessageReference{}
+	}
+	n.threads[key] = ref
+}
+
+// takeThread removes and returns the message to reply to for the key or nil if there is none
+func (n *Notifier) takeThread(key string) *chats.MessageReference {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	ref := n.threads[key]
+	delete(n.threads, key)
+	return ref
+}
+
+// replyAndUpdate replies to the thread of the message, updating the original message text.
+// If there is no original message the message is sent to the channel
+func replyAndUpdate(provider chats.ChatProvider, channel string, ref *chats.MessageReference, message *chats.Message, updatedText string) error {
+	if ref == nil {
+		_, err := provider.SendMessage(channel, message)
+		return err
+	}
+	message.ThreadID = ref.ID
+	_, err := provider.SendMessage(ref.Channel, message)
+	if err != nil {
+		return err
+	}
+	_, err = provider.UpdateMessage(ref, &chats.Message{Text: updatedText})
+	return err
+}
+
+// CreateMessage creates the chat message for the event. The text uses standard markdown which each chat provider
+// converts to the format of its service
+func CreateMessage(event *Event) *chats.Message {
+	activity := event.Activity
+	spec := &activity.Spec
+	title := fmt.Sprintf("%s #%s", spec.Pipeline, spec.Build)
+	titleURL := spec.BuildLogsURL
+	if titleURL == "" {
+		titleURL = spec.BuildURL
+	}
+	message := &chats.Message{
+		Title:    title,
+		TitleURL: titleURL,
+		Details:  spec.LastCommitMessage,
+	}
+	if spec.LastCommitSHA != "" {
+		sha := spec.LastCommitSHA
+		if len(sha) > 7 {
+			sha = sha[0:7]
+		}
+		sha = util.MarkdownLink(sha, spec.LastCommitURL)
+		message.Fields = append(message.Fields, chats.MessageField{Title: "Commit", Value: sha, Short: true})
+	}
+	if version := versionOf(activity); version != "" {
+		message.Fields = append(message.Fields, chats.MessageField{Title: "Version", Value: version, Short: true})
+	}
+
+	switch event.Kind {
+	case EventPipelineFailed:
+		message.Text = fmt.Sprintf(":x: Pipeline **%s** failed", title)
+		message.Color = chats.MessageColorDanger
+	case EventPipelineRecovered:
+		message.Text = fmt.Sprintf(":white_check_mark: Pipeline **%s** is fixed", title)
+		message.Color = chats.MessageColorGood
+	case EventPromotionPullRequest:
+		message.Text = fmt.Sprintf(":rocket: Promoting **%s** version **%s** to **%s**", activity.RepositoryName(), versionOf(activity), event.Environment)
+		message.Title = event.PullRequestURL
+		message.TitleURL = event.PullRequestURL
+		message.Color = chats.MessageColorWarning
+	case EventPromotionMerged:
+		message.Text = fmt.Sprintf(":tada: **%s** version **%s** was promoted to **%s**", activity.RepositoryName(), versionOf(activity), event.Environment)
+		message.Title = event.PullRequestURL
+		message.TitleURL = event.PullRequestURL
+		message.Color = chats.MessageColorGood
+	}
+	return message
+}
+
+// CommitAuthors returns the authors of the commits of the activity. If there is a previous build of the pipeline the
+// authors of all the commits since the previous build started are returned
+func CommitAuthors(gitProvider gits.GitProvider, activity *v1.PipelineActivity, previous *v1.PipelineActivity) ([]*gits.GitUser, error) {
+	answer := []*gits.GitUser{}
+	found := map[string]bool{}
+	add := func(user *gits.GitUser) {
+		if user == nil {
+			return
+		}
+		key := user.Login
+		if key == "" {
+			key = user.Email
+		}
+		if key == "" || found[key] {
+			return
+		}
+		found[key] = true
+		answer = append(answer, user)
+	}
+	if activity.Spec.Author != "" {
+		add(&gits.GitUser{Login: activity.Spec.Author})
+	}
+	sha := activity.Spec.LastCommitSHA
+	if sha == "" {
+		return answer, nil
+	}
+	args := &gits.ListCommitsArguments{
+		SHA:     sha,
+		PerPage: 1,
+	}
+	if previous != nil && previous.Spec.StartedTimestamp != nil {
+		args.Since = previous.Spec.StartedTimestamp.Time
+		args.PerPage = maxCommitAuthors
+	}
+	commits, err := gitProvider.ListCommits(activity.RepositoryOwner(), activity.RepositoryName(), args)
+	if err != nil {
+		return answer, err
+	}
+	for _, commit := range commits {
+		add(commit.Author)
+	}
+	return answer, nil
+}
+
+// ResolveMentions resolves the git users to Jenkins X users returning the mentions for the given kind of chat.
+// Users who have no account reference for the chat service are mentioned by name
+func ResolveMentions(resolver *users.GitUserResolver, chatKind string, gitUsers []*gits.GitUser) ([]chats.Mention, error) {
+	answer := []chats.Mention{}
+	key := chats.UserIDKey(chatKind)
+	for _, gitUser := range gitUsers {
+		mention := chats.Mention{
+			Name: gitUser.Name,
+		}
+		if gitUser.Login != "" {
+			mention.Name = "@" + gitUser.Login
+		}
+		user, err := resolver.Resolve(gitUser)
+		if err != nil {
+			return answer, err
+		}
+		if user != nil {
+			for _, account := range user.Spec.Accounts {
+				if account.Provider == key {
+					mention.ID = account.ID
+				}
+			}
+			if mention.Name == "" {
+				mention.Name = user.Spec.Name
+			}
+		}
+		if mention.ID != "" || mention.Name != "" {
+			answer = append(answer, mention)
+		}
+	}
+	return answer, nil
+}
+
+func versionOf(activity *v1.PipelineActivity) string {
+	if activity.Spec.Version != "" {
+		return activity.Spec.Version
+	}
+	return activity.Status.Version
+}
+
+func failureKey(activity *v1.PipelineActivity) string {
+	return "failed/" + activity.Spec.Pipeline
+}
+
+func promotionKey(activity *v1.PipelineActivity, environment string) string {
+	return "promote/" + activity.Name + "/" + environment
+}
@@ -0,0 +1,156 @@
+package notifications_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/chats"
+	"github.com/jenkins-x/jx/pkg/config"
+	"github.com/jenkins-x/jx/pkg/notifications"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	developerChannel = "#dev"
+	userChannel      = "#releases"
+)
+
+func TestEventsForFailureAndRecovery(t *testing.T) {
+	t.Parallel()
+	failed := createActivity("1", v1.ActivityStatusTypeFailed)
+	running := createActivity("2", v1.ActivityStatusTypeRunning)
+	succeeded := createActivity("2", v1.ActivityStatusTypeSucceeded)
+
+	previous := notifications.PreviousActivity([]*v1.PipelineActivity{failed, running}, succeeded)
+	require.NotNil(t, previous)
+	assert.Equal(t, "1", previous.Spec.Build)
+
+	events := notifications.Events(running, succeeded, previous)
+	require.Len(t, events, 1)
+	assert.Equal(t, notifications.EventPipelineRecovered, events[0].Kind)
+
+	events = notifications.Events(createActivity("1", v1.ActivityStatusTypeRunning), failed, nil)
+	require.Len(t, events, 1)
+	assert.Equal(t, notifications.EventPipelineFailed, events[0].Kind)
+
+	assert.Empty(t, notifications.Events(nil, failed, nil), "added activities should not be notified")
+	assert.Empty(t, notifications.Events(failed, failed, nil), "unchanged activities should not be notified")
+}
+
+func TestEventsForPromotion(t *testing.T) {
+	t.Parallel()
+	old := createActivity("1", v1.ActivityStatusTypeRunning)
+	opened := withPromotion(createActivity("1", v1.ActivityStatusTypeRunning), "https://github.com/myorg/env-staging/pull/1", "")
+	merged := withPromotion(createActivity("1", v1.ActivityStatusTypeRunning), "https://github.com/myorg/env-staging/pull/1", "abc123")
+
+	events := notifications.Events(old, opened, nil)
+	require.Len(t, events, 1)
+	assert.Equal(t, notifications.EventPromotionPullRequest, events[0].Kind)
+	assert.Equal(t, "staging", events[0].Environment)
+
+	events = notifications.Events(opened, merged, nil)
+	require.Len(t, events, 1)
+	assert.Equal(t, notifications.EventPromotionMerged, events[0].Kind)
+	assert.Equal(t, "abc123", events[0].MergeCommitSHA)
+}
+
+func TestNotifierRepliesInThreads(t *testing.T) {
+	t.Parallel()
+	provider := chats.NewFakeChatProvider()
+	notifier := createNotifier(provider)
+
+	failed := createActivity("1", v1.ActivityStatusTypeFailed)
+	err := notifier.Notify(&notifications.Event{Kind: notifications.EventPipelineFailed, Activity: failed})
+	require.NoError(t, err)
+	messages := provider.Messages[developerChannel]
+	require.Len(t, messages, 1)
+	assert.Equal(t, chats.MessageColorDanger, messages[0].Color)
+	require.Len(t, messages[0].Mentions, 1)
+	assert.Equal(t, "U123", messages[0].Mentions[0].ID)
+
+	fixed := createActivity("2", v1.ActivityStatusTypeSucceeded)
+	err = notifier.Notify(&notifications.Event{Kind: notifications.EventPipelineRecovered, Activity: fixed, Previous: failed})
+	require.NoError(t, err)
+	messages = provider.Messages[developerChannel]
+	require.Len(t, messages, 3)
+	assert.Equal(t, "1", messages[1].ThreadID, "the recovery should be a reply to the failure")
+	assert.Equal(t, chats.MessageColorGood, messages[1].Color)
+	assert.Contains(t, messages[2].Text, "fixed in build #2")
+
+	promoted := withPromotion(createActivity("2", v1.ActivityStatusTypeRunning), "https://github.com/myorg/env-staging/pull/1", "")
+	err = notifier.Notify(&notifications.Event{Kind: notifications.EventPromotionPullRequest, Activity: promoted, Environment: "staging", PullRequestURL: "https://github.com/myorg/env-staging/pull/1"})
+	require.NoError(t, err)
+	err = notifier.Notify(&notifications.Event{Kind: notifications.EventPromotionMerged, Activity: promoted, Environment: "staging", PullRequestURL: "https://github.com/myorg/env-staging/pull/1"})
+	require.NoError(t, err)
+	messages = provider.Messages[userChannel]
+	require.Len(t, messages, 3)
+	assert.Empty(t, messages[0].ThreadID)
+	require.Len(t, messages[0].Mentions, 1, "the promotion should mention the commit authors")
+	assert.NotEmpty(t, messages[1].ThreadID)
+	require.Len(t, messages[1].Mentions, 1, "the merge should mention the commit authors")
+	assert.Contains(t, messages[1].Text, "was promoted to **staging**")
+	assert.Contains(t, messages[2].Text, "merged")
+}
+
+func TestNotifierIgnoresOtherBranches(t *testing.T) {
+	t.Parallel()
+	provider := chats.NewFakeChatProvider()
+	notifier := createNotifier(provider)
+
+	failed := createActivity("1", v1.ActivityStatusTypeFailed)
+	failed.Spec.Pipeline = "myorg/myapp/PR-1"
+	err := notifier.Notify(&notifications.Event{Kind: notifications.EventPipelineFailed, Activity: failed})
+	require.NoError(t, err)
+	assert.Empty(t, provider.Messages)
+}
+
+func createNotifier(provider chats.ChatProvider) *notifications.Notifier {
+	chatConfig := &config.ChatConfig{
+		Kind:             chats.Slack,
+		URL:              "https://myorg.slack.com",
+		DeveloperChannel: developerChannel,
+		UserChannel:      userChannel,
+	}
+	return notifications.NewNotifier(
+		func(activity *v1.PipelineActivity) (*config.ChatConfig, error) {
+			return chatConfig, nil
+		},
+		func(chatConfig *config.ChatConfig) (chats.ChatProvider, error) {
+			return provider, nil
+		},
+		func(event *notifications.Event, chatKind string) ([]chats.Mention, error) {
+			return []chats.Mention{{ID: "U123", Name: "@jstrachan"}}, nil
+		})
+}
+
+func createActivity(build string, status v1.ActivityStatusType) *v1.PipelineActivity {
+	return &v1.PipelineActivity{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "myorg-myapp-master-" + build,
+		},
+		Spec: v1.PipelineActivitySpec{
+			Pipeline:      "myorg/myapp/master",
+			Build:         build,
+			GitOwner:      "myorg",
+			GitRepository: "myapp",
+			Version:       "0.0." + build,
+			Status:        status,
+		},
+	}
+}
+
+func withPromotion(activity *v1.PipelineActivity, prURL string, mergeSHA string) *v1.PipelineActivity {
+	activity.Spec.Steps = append(activity.Spec.Steps, v1.PipelineActivityStep{
+		Kind: v1.ActivityStepKindTypePromote,
+		Promote: &v1.PromoteActivityStep{
+			Environment: "staging",
+			PullRequest: &v1.PromotePullRequestStep{
+				PullRequestURL: prURL,
+				MergeCommitSHA: mergeSHA,
+			},
+		},
+	})
+	return activity
+}
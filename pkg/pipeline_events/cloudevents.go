@@ -0,0 +1,134 @@
+package pipline_events
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/pborman/uuid"
+)
+
+const (
+	// CloudEventsSpecVersion the version of the CloudEvents specification which is implemented
+	CloudEventsSpecVersion = "1.0"
+
+	// CloudEventsModeBinary sends the event data as the HTTP body with the attributes as ce- headers
+	CloudEventsModeBinary = "binary"
+	// CloudEventsModeStructured sends the whole event as an application/cloudevents+json HTTP body
+	CloudEventsModeStructured = "structured"
+
+	// EventTypeActivity the CloudEvents type for PipelineActivity events
+	EventTypeActivity = "io.jenkins-x.pipeline.activity"
+	// EventTypeRelease the CloudEvents type for Release events
+	EventTypeRelease = "io.jenkins-x.release"
+
+	defaultCloudEventsSource = "/jenkins-x/pipelines"
+)
+
+// CloudEvent is a CloudEvents v1.0 event in the structured JSON format
+type CloudEvent struct {
+	SpecVersion     string          `json:"specversion"`
+	Type            string          `json:"type"`
+	Source          string          `json:"source"`
+	ID              string          `json:"id"`
+	Time            string          `json:"time,omitempty"`
+	Subject         string          `json:"subject,omitempty"`
+	DataContentType string          `json:"datacontenttype,omitempty"`
+	Data            json.RawMessage `json:"data,omitempty"`
+}
+
+// CloudEventsProvider implements PipelineEventsProvider interface by sending CloudEvents over HTTP
+type CloudEventsProvider struct {
+	URL      string
+	Mode     string
+	Source   string
+	Token    string
+	Delivery *Delivery
+}
+
+// NewCloudEventsProvider creates a new CloudEvents provider for the given sink URL and content mode.
+// If the token is not blank it is sent as a bearer token to the sink
+func NewCloudEventsProvider(url string, mode string, token string, delivery *Delivery) (PipelineEventsProvider, error) {
+	if url == "" {
+		return nil, fmt.Errorf("no CloudEvents sink URL specified")
+	}
+	if mode == "" {
+		mode = CloudEventsModeBinary
+	}
+	if mode != CloudEventsModeBinary && mode != CloudEventsModeStructured {
+		return nil, fmt.Errorf("unknown CloudEvents mode %s. Supported modes are %s and %s", mode, CloudEventsModeBinary, CloudEventsModeStructured)
+	}
+	if delivery == nil {
+		delivery = NewDelivery("")
+	}
+	return &CloudEventsProvider{
+		URL:      url,
+		Mode:     mode,
+		Source:   defaultCloudEventsSource,
+		Token:    token,
+		Delivery: delivery,
+	}, nil
+}
+
+// SendActivity sends the pipeline activity as a CloudEvent
+func (c *CloudEventsProvider) SendActivity(a *v1.PipelineActivity) error {
+	return c.send(EventTypeActivity, a.Namespace+"/"+a.Name, a)
+}
+
+// SendRelease sends the release as a CloudEvent
+func (c *CloudEventsProvider) SendRelease(r *v1.Release) error {
+	return c.send(EventTypeRelease, r.Namespace+"/"+r.Name, r)
+}
+
+func (c *CloudEventsProvider) send(eventType string, subject string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	event := &CloudEvent{
+		SpecVersion:     CloudEventsSpecVersion,
+		Type:            eventType,
+		Source:          c.Source,
+		ID:              uuid.New(),
+		Time:            time.Now().UTC().Format(time.RFC3339),
+		Subject:         subject,
+		DataContentType: "application/json",
+		Data:            data,
+	}
+	headers, body, err := c.Encode(event)
+	if err != nil {
+		return err
+	}
+	if c.Token != "" {
+		headers["Authorization"] = "Bearer " + c.Token
+	}
+	return c.Delivery.Post(c.URL, headers, body)
+}
+
+// Encode returns the HTTP headers and body for the event using the content mode of the provider
+func (c *CloudEventsProvider) Encode(event *CloudEvent) (map[string]string, []byte, error) {
+	if c.Mode == CloudEventsModeStructured {
+		body, err := json.Marshal(event)
+		if err != nil {
+			return nil, nil, err
+		}
+		return map[string]string{
+			"Content-Type": "application/cloudevents+json; charset=UTF-8",
+		}, body, nil
+	}
+	headers := map[string]string{
+		"Content-Type":   event.DataContentType,
+		"ce-specversion": event.SpecVersion,
+		"ce-type":        event.Type,
+		"ce-source":      event.Source,
+		"ce-id":          event.ID,
+	}
+	if event.Time != "" {
+		headers["ce-time"] = event.Time
+	}
+	if event.Subject != "" {
+		headers["ce-subject"] = event.Subject
+	}
+	return headers, event.Data, nil
+}
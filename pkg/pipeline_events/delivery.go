@@ -0,0 +1,172 @@
+package pipline_events
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultMaxAttempts    = 5
+	defaultInitialBackoff = time.Second
+	defaultMaxBackoff     = 30 * time.Second
+)
+
+var (
+	// deadLetterExcludedHeaders the credentials and signatures which are not written to the dead letter file
+	deadLetterExcludedHeaders = []string{"Authorization", WebhookSignatureHeader}
+
+	deadLetterLock sync.Mutex
+)
+
+// DeadLetter is a failed delivery which is appended as a JSON line to the dead letter file
+type DeadLetter struct {
+	Time    time.Time         `json:"time"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    json.RawMessage   `json:"body"`
+	Error   string            `json:"error"`
+}
+
+// Delivery POSTs payloads to a URL retrying with exponential backoff. Payloads which could not be delivered
+// are written to the dead letter file if one is configured
+type Delivery struct {
+	Client         *http.Client
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	DeadLetterFile string
+}
+
+// NewDelivery creates a new delivery with the default retry settings
+func NewDelivery(deadLetterFile string) *Delivery {
+	return &Delivery{
+		Client:         http.DefaultClient,
+		MaxAttempts:    defaultMaxAttempts,
+		InitialBackoff: defaultInitialBackoff,
+		MaxBackoff:     defaultMaxBackoff,
+		DeadLetterFile: deadLetterFile,
+	}
+}
+
+// Post sends the body with the given headers to the URL
+func (d *Delivery) Post(url string, headers map[string]string, body []byte) error {
+	attempts := d.MaxAttempts
+	if attempts <= 0 {
+		attempts = 1
+	}
+	backoff := d.InitialBackoff
+	var err error
+	for i := 1; i <= attempts; i++ {
+		var retry bool
+		retry, err = d.post(url, headers, body)
+		if err == nil {
+			return nil
+		}
+		if !retry || i == attempts {
+			break
+		}
+		log.Warnf("Failed to deliver event to %s on attempt %d of %d, retrying in %s: %s\n", url, i, attempts, backoff, err)
+		time.Sleep(backoff)
+		backoff *= 2
+		if d.MaxBackoff > 0 && backoff > d.MaxBackoff {
+			backoff = d.MaxBackoff
+		}
+	}
+	deadLetterErr := WriteDeadLetter(d.DeadLetterFile, url, headers, body, err)
+	if deadLetterErr != nil {
+		log.Warnf("Failed to write dead letter for %s: %s\n", url, deadLetterErr)
+	}
+	return errors.Wrapf(err, "failed to deliver event to %s", url)
+}
+
+// post sends a single request returning whether the request should be retried
+func (d *Delivery) post(url string, headers map[string]string, body []byte) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	client := d.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	data, _ := ioutil.ReadAll(resp.Body)
+	err = fmt.Errorf("error response POSTing to %s: %s %s", url, resp.Status, string(data))
+	retry := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusRequestTimeout
+	return retry, err
+}
+
+// WriteDeadLetter appends the payload which was not delivered to the URL to the dead letter file. The credentials and
+// signatures in the headers are not written. Nothing is written if no file is specified
+func WriteDeadLetter(file string, url string, headers map[string]string, body []byte, cause error) error {
+	if file == "" {
+		return nil
+	}
+	letter := DeadLetter{
+		Time:    time.Now(),
+		URL:     url,
+		Headers: deadLetterHeaders(headers),
+		Body:    json.RawMessage(body),
+	}
+	if cause != nil {
+		letter.Error = cause.Error()
+	}
+	if !json.Valid(body) {
+		quoted, err := json.Marshal(string(body))
+		if err != nil {
+			return err
+		}
+		letter.Body = quoted
+	}
+	data, err := json.Marshal(&letter)
+	if err != nil {
+		return err
+	}
+	deadLetterLock.Lock()
+	defer deadLetterLock.Unlock()
+	f, err := os.OpenFile(file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, util.DefaultWritePermissions)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(append(data, '\n'))
+	return err
+}
+
+// deadLetterHeaders returns a copy of the headers without the credentials and signatures so they are not persisted
+func deadLetterHeaders(headers map[string]string) map[string]string {
+	answer := map[string]string{}
+	for k, v := range headers {
+		excluded := false
+		for _, name := range deadLetterExcludedHeaders {
+			if http.CanonicalHeaderKey(k) == http.CanonicalHeaderKey(name) {
+				excluded = true
+				break
+			}
+		}
+		if !excluded {
+			answer[k] = v
+		}
+	}
+	return answer
+}
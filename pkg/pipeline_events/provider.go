@@ -1,10 +1,49 @@
 package pipline_events
 
 import (
+	"fmt"
+
 	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/auth"
+)
+
+const (
+	// SinkElasticsearch sends events to the Elasticsearch instance of the pipeline-events addon
+	SinkElasticsearch = "elasticsearch"
+	// SinkCloudEvents sends events as CloudEvents using the binary content mode
+	SinkCloudEvents = "cloudevents"
+	// SinkCloudEventsStructured sends events as CloudEvents using the structured content mode
+	SinkCloudEventsStructured = "cloudevents-structured"
+	// SinkWebhook sends events as JSON payloads signed with HMAC-SHA256
+	SinkWebhook = "webhook"
 )
 
+// Sinks the supported kinds of pipeline events sink
+var Sinks = []string{SinkElasticsearch, SinkCloudEvents, SinkCloudEventsStructured, SinkWebhook}
+
 type PipelineEventsProvider interface {
 	SendActivity(a *v1.PipelineActivity) error
 	SendRelease(a *v1.Release) error
 }
+
+// CreateProvider creates the provider for the sink kind stored as the name of the addon server.
+// Failed deliveries to CloudEvents and webhook sinks are appended to the dead letter file if it is not blank
+func CreateProvider(server *auth.AuthServer, user *auth.UserAuth, deadLetterFile string) (PipelineEventsProvider, error) {
+	token := ""
+	if user != nil {
+		token = user.ApiToken
+	}
+	switch server.Name {
+	case SinkCloudEvents:
+		return NewCloudEventsProvider(server.URL, CloudEventsModeBinary, token, NewDelivery(deadLetterFile))
+	case SinkCloudEventsStructured:
+		return NewCloudEventsProvider(server.URL, CloudEventsModeStructured, token, NewDelivery(deadLetterFile))
+	case SinkWebhook:
+		return NewWebhookProvider(server.URL, token, NewDelivery(deadLetterFile))
+	default:
+		if user == nil {
+			return nil, fmt.Errorf("no user found for elasticsearch %s", server.URL)
+		}
+		return NewElasticsearchProvider(server, user)
+	}
+}
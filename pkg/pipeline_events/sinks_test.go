@@ -0,0 +1,160 @@
+package pipline_events_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	pe "github.com/jenkins-x/jx/pkg/pipeline_events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCloudEventsBinaryMode(t *testing.T) {
+	t.Parallel()
+	var headers http.Header
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header
+		body, _ = ioutil.ReadAll(r.Body)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	provider, err := pe.NewCloudEventsProvider(server.URL, pe.CloudEventsModeBinary, "", nil)
+	require.NoError(t, err)
+	err = provider.SendActivity(createActivity())
+	require.NoError(t, err)
+
+	assert.Equal(t, pe.CloudEventsSpecVersion, headers.Get("ce-specversion"))
+	assert.Equal(t, pe.EventTypeActivity, headers.Get("ce-type"))
+	assert.NotEmpty(t, headers.Get("ce-id"))
+	assert.Equal(t, "application/json", headers.Get("Content-Type"))
+
+	activity := v1.PipelineActivity{}
+	err = json.Unmarshal(body, &activity)
+	require.NoError(t, err)
+	assert.Equal(t, "myorg-myapp-master-1", activity.Name)
+}
+
+func TestCloudEventsStructuredMode(t *testing.T) {
+	t.Parallel()
+	var event pe.CloudEvent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer mytoken", r.Header.Get("Authorization"))
+		err := json.NewDecoder(r.Body).Decode(&event)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	provider, err := pe.NewCloudEventsProvider(server.URL, pe.CloudEventsModeStructured, "mytoken", nil)
+	require.NoError(t, err)
+	err = provider.SendActivity(createActivity())
+	require.NoError(t, err)
+
+	assert.Equal(t, pe.CloudEventsSpecVersion, event.SpecVersion)
+	assert.Equal(t, pe.EventTypeActivity, event.Type)
+	assert.Equal(t, "jx/myorg-myapp-master-1", event.Subject)
+}
+
+func TestWebhookSignsPayload(t *testing.T) {
+	t.Parallel()
+	secret := "s3cr3t"
+	valid := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		valid = pe.ValidateSignature(secret, body, r.Header.Get(pe.WebhookSignatureHeader))
+		assert.Equal(t, "PipelineActivity", r.Header.Get(pe.WebhookEventHeader))
+	}))
+	defer server.Close()
+
+	provider, err := pe.NewWebhookProvider(server.URL, secret, nil)
+	require.NoError(t, err)
+	err = provider.SendActivity(createActivity())
+	require.NoError(t, err)
+	assert.True(t, valid, "the webhook payload signature should be valid")
+}
+
+func TestDeliveryRetriesAndWritesDeadLetter(t *testing.T) {
+	t.Parallel()
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "test-pipeline-events-dead-letter")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	deadLetterFile := filepath.Join(dir, "dead-letters.jsonl")
+
+	delivery := pe.NewDelivery(deadLetterFile)
+	delivery.MaxAttempts = 3
+	delivery.InitialBackoff = 0
+
+	provider, err := pe.NewWebhookProvider(server.URL, "secret", delivery)
+	require.NoError(t, err)
+	err = provider.SendActivity(createActivity())
+	require.Error(t, err)
+	assert.Equal(t, 3, attempts, "delivery attempts")
+
+	f, err := os.Open(deadLetterFile)
+	require.NoError(t, err)
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	require.True(t, scanner.Scan(), "expected a dead letter")
+	letter := pe.DeadLetter{}
+	err = json.Unmarshal(scanner.Bytes(), &letter)
+	require.NoError(t, err)
+	assert.Equal(t, server.URL, letter.URL)
+	assert.NotEmpty(t, letter.Error)
+	assert.Equal(t, "PipelineActivity", letter.Headers[pe.WebhookEventHeader])
+	assert.NotContains(t, letter.Headers, pe.WebhookSignatureHeader, "the signature should not be persisted")
+}
+
+func TestDeadLetterDoesNotContainToken(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "test-pipeline-events-dead-letter")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	deadLetterFile := filepath.Join(dir, "dead-letters.jsonl")
+
+	provider, err := pe.NewCloudEventsProvider(server.URL, pe.CloudEventsModeBinary, "mytoken", pe.NewDelivery(deadLetterFile))
+	require.NoError(t, err)
+	err = provider.SendActivity(createActivity())
+	require.Error(t, err)
+
+	data, err := ioutil.ReadFile(deadLetterFile)
+	require.NoError(t, err)
+	require.NotEmpty(t, data, "expected a dead letter")
+	assert.NotContains(t, string(data), "mytoken")
+	assert.NotContains(t, string(data), "Authorization")
+	assert.Contains(t, string(data), pe.EventTypeActivity)
+}
+
+func createActivity() *v1.PipelineActivity {
+	return &v1.PipelineActivity{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myorg-myapp-master-1",
+			Namespace: "jx",
+		},
+		Spec: v1.PipelineActivitySpec{
+			Pipeline: "myorg/myapp/master",
+			Build:    "1",
+			Status:   v1.ActivityStatusTypeSucceeded,
+		},
+	}
+}
@@ -0,0 +1,93 @@
+package pipline_events
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+)
+
+const (
+	// WebhookSignatureHeader the HTTP header containing the HMAC-SHA256 signature of the body
+	WebhookSignatureHeader = "X-Jx-Signature-256"
+	// WebhookEventHeader the HTTP header containing the kind of event
+	WebhookEventHeader = "X-Jx-Event"
+
+	webhookSignaturePrefix = "sha256="
+)
+
+// WebhookPayload is the JSON body posted to a generic webhook
+type WebhookPayload struct {
+	Kind string          `json:"kind"`
+	Data json.RawMessage `json:"data"`
+}
+
+// WebhookProvider implements PipelineEventsProvider interface by posting signed JSON payloads to a URL
+type WebhookProvider struct {
+	URL      string
+	Secret   string
+	Delivery *Delivery
+}
+
+// NewWebhookProvider creates a new generic webhook provider which signs payloads with the given secret
+func NewWebhookProvider(url string, secret string, delivery *Delivery) (PipelineEventsProvider, error) {
+	if url == "" {
+		return nil, fmt.Errorf("no webhook URL specified")
+	}
+	if secret == "" {
+		return nil, fmt.Errorf("no webhook secret specified to sign the payloads")
+	}
+	if delivery == nil {
+		delivery = NewDelivery("")
+	}
+	return &WebhookProvider{
+		URL:      url,
+		Secret:   secret,
+		Delivery: delivery,
+	}, nil
+}
+
+// SendActivity posts the pipeline activity to the webhook
+func (w *WebhookProvider) SendActivity(a *v1.PipelineActivity) error {
+	return w.send("PipelineActivity", a)
+}
+
+// SendRelease posts the release to the webhook
+func (w *WebhookProvider) SendRelease(r *v1.Release) error {
+	return w.send("Release", r)
+}
+
+func (w *WebhookProvider) send(kind string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(&WebhookPayload{
+		Kind: kind,
+		Data: data,
+	})
+	if err != nil {
+		return err
+	}
+	headers := map[string]string{
+		"Content-Type":         "application/json",
+		WebhookEventHeader:     kind,
+		WebhookSignatureHeader: SignPayload(w.Secret, body),
+	}
+	return w.Delivery.Post(w.URL, headers, body)
+}
+
+// SignPayload returns the signature header value of the body using HMAC-SHA256 with the given secret
+func SignPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return webhookSignaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// ValidateSignature returns true if the signature header value matches the body signed with the secret
+func ValidateSignature(secret string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(SignPayload(secret, body)), []byte(signature))
+}
@@ -0,0 +1,256 @@
+// Package pipelinemetrics exposes Prometheus metrics about pipelines, releases and promotions
+// calculated from the PipelineActivity, Release and Environment resources of a team.
+package pipelinemetrics
+
+import (
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	listers "github.com/jenkins-x/jx/pkg/client/listers/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	namespace = "jx"
+
+	// BranchKindRelease a pipeline on the master branch
+	BranchKindRelease = "release"
+	// BranchKindPullRequest a pipeline on a pull request
+	BranchKindPullRequest = "pullrequest"
+	// BranchKindFeature a pipeline on any other branch
+	BranchKindFeature = "feature"
+)
+
+// DurationBuckets the histogram buckets in seconds used for pipeline, stage, queue and lead time durations
+var DurationBuckets = []float64{10, 30, 60, 120, 300, 600, 900, 1800, 3600, 7200, 14400, 43200, 86400, 259200, 604800}
+
+var (
+	pipelineDurationDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "pipeline", "duration_seconds"),
+		"The duration of completed pipelines",
+		[]string{"owner", "repository", "branch_kind", "status"}, nil)
+	stageDurationDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "pipeline", "stage_duration_seconds"),
+		"The duration of completed pipeline stages",
+		[]string{"owner", "repository", "branch_kind", "stage", "status"}, nil)
+	queueWaitDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "pipeline", "queue_wait_seconds"),
+		"The time between a pipeline being triggered and its first stage starting",
+		[]string{"owner", "repository", "branch_kind"}, nil)
+	promotionLeadTimeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "promotion", "lead_time_seconds"),
+		"The time between a release pipeline starting and the version being promoted to an environment",
+		[]string{"owner", "repository", "environment"}, nil)
+	pipelinesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "pipelines", "current"),
+		"The number of pipelines which are currently pending or running",
+		[]string{"owner", "repository", "status"}, nil)
+	releasesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "releases", "total"),
+		"The number of releases of each repository",
+		[]string{"owner", "repository"}, nil)
+	environmentDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "environment", "info"),
+		"Information about each environment",
+		[]string{"environment", "kind", "namespace", "promotion_strategy"}, nil)
+)
+
+// Collector is a prometheus.Collector which calculates the metrics from the current state of the listers on each scrape
+type Collector struct {
+	Activities   listers.PipelineActivityNamespaceLister
+	Releases     listers.ReleaseNamespaceLister
+	Environments listers.EnvironmentNamespaceLister
+}
+
+// histogramKey identifies a single histogram by its label values
+type histogramKey string
+
+type histogram struct {
+	labelValues []string
+	count       uint64
+	sum         float64
+	buckets     map[float64]uint64
+}
+
+type histograms struct {
+	desc  *prometheus.Desc
+	items map[histogramKey]*histogram
+}
+
+func newHistograms(desc *prometheus.Desc) *histograms {
+	return &histograms{
+		desc:  desc,
+		items: map[histogramKey]*histogram{},
+	}
+}
+
+func (h *histograms) observe(value float64, labelValues ...string) {
+	key := histogramKey(strings.Join(labelValues, "\x00"))
+	item := h.items[key]
+	if item == nil {
+		item = &histogram{
+			labelValues: labelValues,
+			buckets:     map[float64]uint64{},
+		}
+		for _, b := range DurationBuckets {
+			item.buckets[b] = 0
+		}
+		h.items[key] = item
+	}
+	item.count++
+	item.sum += value
+	for _, b := range DurationBuckets {
+		if value <= b {
+			item.buckets[b]++
+		}
+	}
+}
+
+func (h *histograms) collect(ch chan<- prometheus.Metric) {
+	for _, item := range h.items {
+		metric, err := prometheus.NewConstHistogram(h.desc, item.count, item.sum, item.buckets, item.labelValues...)
+		if err != nil {
+			log.Warnf("Failed to create histogram %s: %s\n", h.desc.String(), err)
+			continue
+		}
+		ch <- metric
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- pipelineDurationDesc
+	ch <- stageDurationDesc
+	ch <- queueWaitDesc
+	ch <- promotionLeadTimeDesc
+	ch <- pipelinesDesc
+	ch <- releasesDesc
+	ch <- environmentDesc
+}
+
+// Collect implements prometheus.Collector
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	if c.Activities != nil {
+		c.collectActivities(ch)
+	}
+	if c.Releases != nil {
+		c.collectReleases(ch)
+	}
+	if c.Environments != nil {
+		c.collectEnvironments(ch)
+	}
+}
+
+func (c *Collector) collectActivities(ch chan<- prometheus.Metric) {
+	activities, err := c.Activities.List(labels.Everything())
+	if err != nil {
+		log.Warnf("Failed to list PipelineActivities: %s\n", err)
+		return
+	}
+	pipelineDurations := newHistograms(pipelineDurationDesc)
+	stageDurations := newHistograms(stageDurationDesc)
+	queueWaits := newHistograms(queueWaitDesc)
+	leadTimes := newHistograms(promotionLeadTimeDesc)
+	current := map[[3]string]int{}
+
+	for _, a := range activities {
+		activity := a.DeepCopy()
+		spec := &activity.Spec
+		owner := activity.RepositoryOwner()
+		repo := activity.RepositoryName()
+		branchKind := BranchKind(activity.BranchName())
+
+		switch spec.Status {
+		case v1.ActivityStatusTypePending, v1.ActivityStatusTypeRunning:
+			current[[3]string{owner, repo, string(spec.Status)}]++
+		}
+		if spec.Status.IsTerminated() && spec.StartedTimestamp != nil && spec.CompletedTimestamp != nil {
+			pipelineDurations.observe(seconds(spec.StartedTimestamp.Time, spec.CompletedTimestamp.Time), owner, repo, branchKind, string(spec.Status))
+		}
+		if wait, ok := QueueWait(activity); ok {
+			queueWaits.observe(wait.Seconds(), owner, repo, branchKind)
+		}
+		for _, step := range spec.Steps {
+			if stage := step.Stage; stage != nil {
+				if stage.Status.IsTerminated() && stage.StartedTimestamp != nil && stage.CompletedTimestamp != nil {
+					stageDurations.observe(seconds(stage.StartedTimestamp.Time, stage.CompletedTimestamp.Time), owner, repo, branchKind, stage.Name, string(stage.Status))
+				}
+			}
+			if promote := step.Promote; promote != nil {
+				if promote.Status == v1.ActivityStatusTypeSucceeded && spec.StartedTimestamp != nil && promote.CompletedTimestamp != nil {
+					leadTimes.observe(seconds(spec.StartedTimestamp.Time, promote.CompletedTimestamp.Time), owner, repo, promote.Environment)
+				}
+			}
+		}
+	}
+
+	pipelineDurations.collect(ch)
+	stageDurations.collect(ch)
+	queueWaits.collect(ch)
+	leadTimes.collect(ch)
+	for key, count := range current {
+		ch <- prometheus.MustNewConstMetric(pipelinesDesc, prometheus.GaugeValue, float64(count), key[0], key[1], key[2])
+	}
+}
+
+func (c *Collector) collectReleases(ch chan<- prometheus.Metric) {
+	releases, err := c.Releases.List(labels.Everything())
+	if err != nil {
+		log.Warnf("Failed to list Releases: %s\n", err)
+		return
+	}
+	counts := map[[2]string]int{}
+	for _, release := range releases {
+		counts[[2]string{release.Spec.GitOwner, release.Spec.GitRepository}]++
+	}
+	for key, count := range counts {
+		ch <- prometheus.MustNewConstMetric(releasesDesc, prometheus.GaugeValue, float64(count), key[0], key[1])
+	}
+}
+
+func (c *Collector) collectEnvironments(ch chan<- prometheus.Metric) {
+	environments, err := c.Environments.List(labels.Everything())
+	if err != nil {
+		log.Warnf("Failed to list Environments: %s\n", err)
+		return
+	}
+	for _, env := range environments {
+		ch <- prometheus.MustNewConstMetric(environmentDesc, prometheus.GaugeValue, 1, env.Name, string(env.Spec.Kind), env.Spec.Namespace, string(env.Spec.PromotionStrategy))
+	}
+}
+
+// BranchKind returns the kind of branch used for the metric labels
+func BranchKind(branch string) string {
+	switch {
+	case branch == "master":
+		return BranchKindRelease
+	case strings.HasPrefix(strings.ToUpper(branch), "PR-"):
+		return BranchKindPullRequest
+	default:
+		return BranchKindFeature
+	}
+}
+
+// QueueWait returns the time between the activity being created and its first stage starting
+func QueueWait(activity *v1.PipelineActivity) (time.Duration, bool) {
+	created := activity.CreationTimestamp.Time
+	if created.IsZero() {
+		return 0, false
+	}
+	for _, step := range activity.Spec.Steps {
+		if step.Stage != nil && step.Stage.StartedTimestamp != nil {
+			return step.Stage.StartedTimestamp.Time.Sub(created), true
+		}
+	}
+	if activity.Spec.StartedTimestamp != nil {
+		return activity.Spec.StartedTimestamp.Time.Sub(created), true
+	}
+	return 0, false
+}
+
+func seconds(start time.Time, end time.Time) float64 {
+	return end.Sub(start).Seconds()
+}
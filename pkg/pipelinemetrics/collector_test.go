@@ -0,0 +1,164 @@
+package pipelinemetrics_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	listers "github.com/jenkins-x/jx/pkg/client/listers/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/pipelinemetrics"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+const testNamespace = "jx"
+
+func TestCollectorCalculatesPipelineMetrics(t *testing.T) {
+	t.Parallel()
+	created := time.Date(2019, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	activities := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	require.NoError(t, activities.Add(&v1.PipelineActivity{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "myorg-myapp-master-1",
+			Namespace:         testNamespace,
+			CreationTimestamp: metav1.NewTime(created),
+		},
+		Spec: v1.PipelineActivitySpec{
+			Pipeline:           "myorg/myapp/master",
+			Build:              "1",
+			GitOwner:           "myorg",
+			GitRepository:      "myapp",
+			Status:             v1.ActivityStatusTypeSucceeded,
+			StartedTimestamp:   timestamp(created, 5*time.Second),
+			CompletedTimestamp: timestamp(created, 300*time.Second),
+			Steps: []v1.PipelineActivityStep{
+				{
+					Kind: v1.ActivityStepKindTypeStage,
+					Stage: &v1.StageActivityStep{
+						CoreActivityStep: v1.CoreActivityStep{
+							Name:               "build",
+							Status:             v1.ActivityStatusTypeSucceeded,
+							StartedTimestamp:   timestamp(created, 20*time.Second),
+							CompletedTimestamp: timestamp(created, 100*time.Second),
+						},
+					},
+				},
+				{
+					Kind: v1.ActivityStepKindTypePromote,
+					Promote: &v1.PromoteActivityStep{
+						CoreActivityStep: v1.CoreActivityStep{
+							Name:               "promote: staging",
+							Status:             v1.ActivityStatusTypeSucceeded,
+							StartedTimestamp:   timestamp(created, 100*time.Second),
+							CompletedTimestamp: timestamp(created, 245*time.Second),
+						},
+						Environment: "staging",
+					},
+				},
+			},
+		},
+	}))
+	require.NoError(t, activities.Add(&v1.PipelineActivity{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myorg-myapp-pr-2-1",
+			Namespace: testNamespace,
+		},
+		Spec: v1.PipelineActivitySpec{
+			Pipeline:      "myorg/myapp/PR-2",
+			Build:         "1",
+			GitOwner:      "myorg",
+			GitRepository: "myapp",
+			Status:        v1.ActivityStatusTypeRunning,
+		},
+	}))
+
+	releases := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	require.NoError(t, releases.Add(&v1.Release{
+		ObjectMeta: metav1.ObjectMeta{Name: "myapp-0.0.1", Namespace: testNamespace},
+		Spec:       v1.ReleaseSpec{GitOwner: "myorg", GitRepository: "myapp", Version: "0.0.1"},
+	}))
+
+	environments := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	require.NoError(t, environments.Add(&v1.Environment{
+		ObjectMeta: metav1.ObjectMeta{Name: "staging", Namespace: testNamespace},
+		Spec: v1.EnvironmentSpec{
+			Kind:              v1.EnvironmentKindTypePermanent,
+			Namespace:         "jx-staging",
+			PromotionStrategy: v1.PromotionStrategyTypeAutomatic,
+		},
+	}))
+
+	collector := &pipelinemetrics.Collector{
+		Activities:   listers.NewPipelineActivityLister(activities).PipelineActivities(testNamespace),
+		Releases:     listers.NewReleaseLister(releases).Releases(testNamespace),
+		Environments: listers.NewEnvironmentLister(environments).Environments(testNamespace),
+	}
+	registry := prometheus.NewRegistry()
+	require.NoError(t, registry.Register(collector))
+	families, err := registry.Gather()
+	require.NoError(t, err)
+
+	metrics := map[string][]*dto.Metric{}
+	for _, family := range families {
+		metrics[family.GetName()] = family.GetMetric()
+	}
+
+	pipelines := metrics["jx_pipeline_duration_seconds"]
+	require.Len(t, pipelines, 1)
+	assert.Equal(t, uint64(1), pipelines[0].GetHistogram().GetSampleCount())
+	assert.Equal(t, 295.0, pipelines[0].GetHistogram().GetSampleSum())
+	assert.Equal(t, "release", labelValue(pipelines[0], "branch_kind"))
+
+	stages := metrics["jx_pipeline_stage_duration_seconds"]
+	require.Len(t, stages, 1)
+	assert.Equal(t, 80.0, stages[0].GetHistogram().GetSampleSum())
+	assert.Equal(t, "build", labelValue(stages[0], "stage"))
+
+	queueWaits := metrics["jx_pipeline_queue_wait_seconds"]
+	require.Len(t, queueWaits, 1)
+	assert.Equal(t, 20.0, queueWaits[0].GetHistogram().GetSampleSum())
+
+	leadTimes := metrics["jx_promotion_lead_time_seconds"]
+	require.Len(t, leadTimes, 1)
+	assert.Equal(t, 240.0, leadTimes[0].GetHistogram().GetSampleSum())
+	assert.Equal(t, "staging", labelValue(leadTimes[0], "environment"))
+
+	current := metrics["jx_pipelines_current"]
+	require.Len(t, current, 1)
+	assert.Equal(t, 1.0, current[0].GetGauge().GetValue())
+	assert.Equal(t, string(v1.ActivityStatusTypeRunning), labelValue(current[0], "status"))
+
+	releaseCounts := metrics["jx_releases_total"]
+	require.Len(t, releaseCounts, 1)
+	assert.Equal(t, 1.0, releaseCounts[0].GetGauge().GetValue())
+
+	envs := metrics["jx_environment_info"]
+	require.Len(t, envs, 1)
+	assert.Equal(t, "jx-staging", labelValue(envs[0], "namespace"))
+}
+
+func TestBranchKind(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, pipelinemetrics.BranchKindRelease, pipelinemetrics.BranchKind("master"))
+	assert.Equal(t, pipelinemetrics.BranchKindPullRequest, pipelinemetrics.BranchKind("PR-123"))
+	assert.Equal(t, pipelinemetrics.BranchKindFeature, pipelinemetrics.BranchKind("feature-x"))
+}
+
+func timestamp(t time.Time, offset time.Duration) *metav1.Time {
+	answer := metav1.NewTime(t.Add(offset))
+	return &answer
+}
+
+func labelValue(metric *dto.Metric, name string) string {
+	for _, label := range metric.GetLabel() {
+		if label.GetName() == name {
+			return label.GetValue()
+		}
+	}
+	return ""
+}
@@ -387,7 +387,7 @@ func applyToPresubmits(parent *Presubmits, child *Presubmits) error {
 	for _, parent := range parent.Items {
 		var found []*Presubmit
 		for _, child := range child.Items {
-			if child.Name == parent.Name {
+			if child.Name != nil && parent.Name != nil && *child.Name == *parent.Name {
 				found = append(found, child)
 			}
 		}
@@ -401,8 +401,8 @@ func applyToPresubmits(parent *Presubmits, child *Presubmits) error {
 			} else if parent.JobBase != nil {
 				applyToJobBase(parent.JobBase, child.JobBase)
 			}
-			if child.RunIfChanged == nil {
-				child.RunIfChanged = parent.RunIfChanged
+			if child.RegexpChangeMatcher == nil {
+				child.RegexpChangeMatcher = parent.RegexpChangeMatcher
 			} else if parent.RegexpChangeMatcher != nil {
 				applyToRegexpChangeMatcher(parent.RegexpChangeMatcher, child.RegexpChangeMatcher)
 			}
@@ -437,11 +437,6 @@ func applyToPresubmits(parent *Presubmits, child *Presubmits) error {
 			} else if parent.ContextPolicy != nil {
 				applyToRepoContextPolicy(parent.ContextPolicy, child.ContextPolicy)
 			}
-			if child.Branches == nil {
-				child.Branches = parent.Branches
-			} else if parent.Branches != nil {
-				applyToReplaceableSliceOfStrings(parent.Branches, child.Branches)
-			}
 			if child.Policy == nil {
 				child.Policy = parent.Policy
 			} else if parent.Policy != nil {
@@ -452,6 +447,8 @@ func applyToPresubmits(parent *Presubmits, child *Presubmits) error {
 			} else if parent.Query != nil {
 				applyToQuery(parent.Query, child.Query)
 			}
+		} else {
+			child.Items = append(child.Items, parent)
 		}
 	}
 	return nil
@@ -38,3 +38,45 @@ func TestBuildWithMergedMerger(t *testing.T) {
 	assert.Equal(t, parent.Merger.MergeType, merged.Merger.MergeType)
 	assert.Equal(t, child.Merger.SquashLabel, merged.Merger.SquashLabel)
 }
+
+func TestBuildWithMergedPresubmits(t *testing.T) {
+	parentName := "pr-build"
+	childName := "pr-build"
+	otherName := "integration"
+	runIfChanged := "^src/"
+	alwaysRun := true
+	parent := &pipelinescheduler.Scheduler{
+		Presubmits: &pipelinescheduler.Presubmits{
+			Items: []*pipelinescheduler.Presubmit{
+				{
+					JobBase:             &pipelinescheduler.JobBase{Name: &parentName},
+					RegexpChangeMatcher: &pipelinescheduler.RegexpChangeMatcher{RunIfChanged: &runIfChanged},
+					AlwaysRun:           &alwaysRun,
+				},
+				{
+					JobBase: &pipelinescheduler.JobBase{Name: &otherName},
+				},
+			},
+		},
+	}
+	child := &pipelinescheduler.Scheduler{
+		Presubmits: &pipelinescheduler.Presubmits{
+			Items: []*pipelinescheduler.Presubmit{
+				{
+					// the names are compared by value rather than by pointer
+					JobBase: &pipelinescheduler.JobBase{Name: &childName},
+				},
+			},
+		},
+	}
+	merged, err := pipelinescheduler.Build([]*pipelinescheduler.Scheduler{parent, child})
+	assert.NoError(t, err)
+	assert.Len(t, merged.Presubmits.Items, 2)
+	presubmit := merged.Presubmits.Items[0]
+	assert.Equal(t, childName, *presubmit.Name)
+	assert.Equal(t, &runIfChanged, presubmit.RunIfChanged,
+		"the change matcher of the parent should be used if the child has none")
+	assert.Equal(t, &alwaysRun, presubmit.AlwaysRun)
+	assert.Equal(t, otherName, *merged.Presubmits.Items[1].Name,
+		"presubmits of the parent without a match in the child should be appended")
+}
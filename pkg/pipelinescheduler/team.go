@@ -0,0 +1,114 @@
+package pipelinescheduler
+
+import (
+	jenkinsv1 "github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/client/clientset/versioned"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DefaultPresubmitName the name and context of the default job which builds pull requests
+	DefaultPresubmitName = "serverless-jenkins"
+	// DefaultPostsubmitName the name of the default job which releases the master branch
+	DefaultPostsubmitName = "release"
+)
+
+// SchedulerFor builds the scheduler of the repository from the default Scheduler of the team, referenced by the
+// team settings of the dev environment, and the Scheduler referenced by the SourceRepository of the repository.
+// If no scheduler is configured pull requests to any branch are built and the master branch is released
+func SchedulerFor(jxClient versioned.Interface, ns string, org string, repo string) (*Scheduler, error) {
+	schedulers := []*Scheduler{}
+	devEnv, err := kube.GetDevEnvironment(jxClient, ns)
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting the dev environment in namespace %s", ns)
+	}
+	if devEnv != nil && devEnv.Spec.TeamSettings.DefaultScheduler.Name != "" {
+		scheduler, err := getScheduler(jxClient, ns, devEnv.Spec.TeamSettings.DefaultScheduler.Name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "getting the default scheduler of the team")
+		}
+		schedulers = append(schedulers, scheduler)
+	}
+	sourceRepository, err := getSourceRepository(jxClient, ns, org, repo)
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting the SourceRepository of %s/%s", org, repo)
+	}
+	if sourceRepository != nil && sourceRepository.Spec.Scheduler.Name != "" {
+		scheduler, err := getScheduler(jxClient, ns, sourceRepository.Spec.Scheduler.Name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "getting the scheduler of %s/%s", org, repo)
+		}
+		schedulers = append(schedulers, scheduler)
+	}
+	if len(schedulers) == 0 {
+		return DefaultScheduler(), nil
+	}
+	answer, err := Build(schedulers)
+	if err != nil {
+		return nil, errors.Wrapf(err, "building the pipeline scheduler of %s/%s", org, repo)
+	}
+	return answer, nil
+}
+
+// getScheduler returns a copy of the spec of the Scheduler as the schedulers are merged in place
+func getScheduler(jxClient versioned.Interface, ns string, name string) (*Scheduler, error) {
+	scheduler, err := jxClient.JenkinsV1().Schedulers(ns).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return scheduler.Spec.DeepCopy(), nil
+}
+
+// getSourceRepository returns the SourceRepository of the repository or nil if there is none
+func getSourceRepository(jxClient versioned.Interface, ns string, org string, repo string) (*jenkinsv1.SourceRepository, error) {
+	repositories := jxClient.JenkinsV1().SourceRepositories(ns)
+	answer, err := repositories.Get(kube.ToValidName(org+"-"+repo), metav1.GetOptions{})
+	if err == nil {
+		return answer, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+	list, err := repositories.List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range list.Items {
+		sr := &list.Items[i]
+		if sr.Spec.Org == org && sr.Spec.Repo == repo {
+			return sr, nil
+		}
+	}
+	return nil, nil
+}
+
+// DefaultScheduler returns the scheduler which builds pull requests to any branch and releases the master branch
+func DefaultScheduler() *Scheduler {
+	presubmit := DefaultPresubmitName
+	postsubmit := DefaultPostsubmitName
+	alwaysRun := true
+	return &Scheduler{
+		Presubmits: &Presubmits{
+			Items: []*Presubmit{
+				{
+					JobBase:   &JobBase{Name: &presubmit},
+					AlwaysRun: &alwaysRun,
+					Context:   &presubmit,
+				},
+			},
+		},
+		Postsubmits: &Postsubmits{
+			Items: []*Postsubmit{
+				{
+					JobBase: &JobBase{Name: &postsubmit},
+					Brancher: &Brancher{
+						Branches: &ReplaceableSliceOfStrings{Items: []string{"master"}},
+					},
+				},
+			},
+		},
+	}
+}
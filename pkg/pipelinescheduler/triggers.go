@@ -0,0 +1,95 @@
+package pipelinescheduler
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// RunsAgainstBranch returns true if jobs of the brancher should run against the branch. The branches are regular expressions
+// which have to match the whole branch name. An empty brancher runs against all branches
+func RunsAgainstBranch(b *Brancher, branch string) bool {
+	if b == nil {
+		return true
+	}
+	if b.SkipBranches != nil && matchesAny(b.SkipBranches.Items, branch) {
+		return false
+	}
+	if b.Branches == nil || len(b.Branches.Items) == 0 {
+		return true
+	}
+	return matchesAny(b.Branches.Items, branch)
+}
+
+// RunsAgainstChanges returns true if any of the changed files match RunIfChanged. Jobs always run if there is no
+// RunIfChanged or the changes are not known
+func RunsAgainstChanges(m *RegexpChangeMatcher, changes []string) bool {
+	if m == nil || m.RunIfChanged == nil || *m.RunIfChanged == "" || changes == nil {
+		return true
+	}
+	re, err := regexp.Compile(*m.RunIfChanged)
+	if err != nil {
+		return true
+	}
+	for _, change := range changes {
+		if re.MatchString(change) {
+			return true
+		}
+	}
+	return false
+}
+
+// PresubmitsFor returns the presubmits of the scheduler which run automatically for pull requests to the branch with the changes.
+// Presubmits which only run when triggered by a comment are excluded unless they run if the changes match
+func PresubmitsFor(s *Scheduler, branch string, changes []string) []*Presubmit {
+	answer := []*Presubmit{}
+	if s == nil || s.Presubmits == nil {
+		return answer
+	}
+	for _, presubmit := range s.Presubmits.Items {
+		if presubmit == nil {
+			continue
+		}
+		runIfChanged := presubmit.RegexpChangeMatcher != nil && presubmit.RunIfChanged != nil && *presubmit.RunIfChanged != ""
+		if !runIfChanged && presubmit.AlwaysRun != nil && !*presubmit.AlwaysRun {
+			continue
+		}
+		if RunsAgainstBranch(presubmit.Brancher, branch) && RunsAgainstChanges(presubmit.RegexpChangeMatcher, changes) {
+			answer = append(answer, presubmit)
+		}
+	}
+	return answer
+}
+
+// PostsubmitsFor returns the postsubmits of the scheduler which run for pushes to the branch with the changes
+func PostsubmitsFor(s *Scheduler, branch string, changes []string) []*Postsubmit {
+	answer := []*Postsubmit{}
+	if s == nil || s.Postsubmits == nil {
+		return answer
+	}
+	for _, postsubmit := range s.Postsubmits.Items {
+		if postsubmit == nil {
+			continue
+		}
+		if RunsAgainstBranch(postsubmit.Brancher, branch) && RunsAgainstChanges(postsubmit.RegexpChangeMatcher, changes) {
+			answer = append(answer, postsubmit)
+		}
+	}
+	return answer
+}
+
+func matchesAny(patterns []string, branch string) bool {
+	if len(patterns) == 0 {
+		return false
+	}
+	re, err := regexp.Compile(fmt.Sprintf("^(?:%s)$", strings.Join(patterns, "|")))
+	if err != nil {
+		for _, pattern := range patterns {
+			if pattern == branch {
+				return true
+			}
+		}
+		return false
+	}
+	return re.MatchString(branch)
+}
@@ -0,0 +1,156 @@
+package pipelinescheduler_test
+
+import (
+	"testing"
+
+	"github.com/ghodss/yaml"
+	jenkinsv1 "github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	v1fake "github.com/jenkins-x/jx/pkg/client/clientset/versioned/fake"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/pipelinescheduler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const defaultScheduler = `
+presubmits:
+  items:
+  - name: pr-build
+    context: pr-build
+    alwaysRun: true
+  - name: integration
+    context: integration
+    alwaysRun: false
+postsubmits:
+  items:
+  - name: release
+    branches:
+      items:
+      - master
+      - release-.*
+`
+
+const docsScheduler = `
+presubmits:
+  items:
+  - name: docs
+    context: docs
+    runIfChanged: "^docs/"
+    skipBranches:
+      items:
+      - gh-pages
+`
+
+const runIfChangedScheduler = `
+presubmits:
+  items:
+  - name: pr-build
+    context: pr-build
+  - name: integration
+    context: integration
+    alwaysRun: false
+  - name: charts
+    context: charts
+    alwaysRun: false
+    runIfChanged: "^charts/"
+`
+
+func TestPresubmitsForRunIfChanged(t *testing.T) {
+	t.Parallel()
+	scheduler := &pipelinescheduler.Scheduler{}
+	err := yaml.Unmarshal([]byte(runIfChangedScheduler), scheduler)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"pr-build", "charts"},
+		presubmitNames(pipelinescheduler.PresubmitsFor(scheduler, "master", []string{"charts/myapp/values.yaml"})),
+		"presubmits which do not always run should run if the changes match")
+	assert.Equal(t, []string{"pr-build"},
+		presubmitNames(pipelinescheduler.PresubmitsFor(scheduler, "master", []string{"README.md"})))
+}
+
+func TestSchedulerFor(t *testing.T) {
+	t.Parallel()
+	ns := "jx"
+	jxClient := v1fake.NewSimpleClientset(
+		&jenkinsv1.Environment{
+			ObjectMeta: metav1.ObjectMeta{Name: kube.LabelValueDevEnvironment, Namespace: ns},
+			Spec: jenkinsv1.EnvironmentSpec{
+				TeamSettings: jenkinsv1.TeamSettings{
+					DefaultScheduler: jenkinsv1.ResourceReference{Kind: "Scheduler", Name: "default-scheduler"},
+				},
+			},
+		},
+		&jenkinsv1.SourceRepository{
+			ObjectMeta: metav1.ObjectMeta{Name: "jenkins-x-docs", Namespace: ns},
+			Spec: jenkinsv1.SourceRepositorySpec{
+				Org:       "jenkins-x",
+				Repo:      "docs",
+				Scheduler: jenkinsv1.ResourceReference{Kind: "Scheduler", Name: "docs-scheduler"},
+			},
+		},
+		&jenkinsv1.SourceRepository{
+			ObjectMeta: metav1.ObjectMeta{Name: "jenkins-x-jx", Namespace: ns},
+			Spec: jenkinsv1.SourceRepositorySpec{
+				Org:  "jenkins-x",
+				Repo: "jx",
+			},
+		},
+		createScheduler(t, ns, "default-scheduler", defaultScheduler),
+		createScheduler(t, ns, "docs-scheduler", docsScheduler),
+	)
+
+	scheduler, err := pipelinescheduler.SchedulerFor(jxClient, ns, "jenkins-x", "jx")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pr-build"}, presubmitNames(pipelinescheduler.PresubmitsFor(scheduler, "master", nil)),
+		"presubmits which do not always run should be skipped")
+	assert.Len(t, pipelinescheduler.PostsubmitsFor(scheduler, "master", nil), 1)
+	assert.Len(t, pipelinescheduler.PostsubmitsFor(scheduler, "release-1.0", nil), 1)
+	assert.Len(t, pipelinescheduler.PostsubmitsFor(scheduler, "my-release-1.0", nil), 0,
+		"branches should match the whole branch name")
+
+	scheduler, err = pipelinescheduler.SchedulerFor(jxClient, ns, "jenkins-x", "docs")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"docs", "pr-build"},
+		presubmitNames(pipelinescheduler.PresubmitsFor(scheduler, "master", []string{"docs/index.md"})))
+	assert.Equal(t, []string{"pr-build"},
+		presubmitNames(pipelinescheduler.PresubmitsFor(scheduler, "master", []string{"README.md"})))
+	assert.Equal(t, []string{"pr-build"},
+		presubmitNames(pipelinescheduler.PresubmitsFor(scheduler, "gh-pages", []string{"docs/index.md"})))
+	assert.Equal(t, []string{"docs", "pr-build"}, presubmitNames(pipelinescheduler.PresubmitsFor(scheduler, "master", nil)),
+		"jobs should run if the changes are not known")
+
+	scheduler, err = pipelinescheduler.SchedulerFor(jxClient, ns, "jenkins-x", "jx")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pr-build"}, presubmitNames(pipelinescheduler.PresubmitsFor(scheduler, "master", nil)),
+		"building a scheduler should not modify the Scheduler resources")
+}
+
+func TestSchedulerForWithoutSchedulers(t *testing.T) {
+	t.Parallel()
+	jxClient := v1fake.NewSimpleClientset()
+
+	scheduler, err := pipelinescheduler.SchedulerFor(jxClient, "jx", "jenkins-x", "jx")
+	require.NoError(t, err)
+	assert.Equal(t, []string{pipelinescheduler.DefaultPresubmitName},
+		presubmitNames(pipelinescheduler.PresubmitsFor(scheduler, "feature", nil)))
+	assert.Len(t, pipelinescheduler.PostsubmitsFor(scheduler, "master", nil), 1)
+	assert.Len(t, pipelinescheduler.PostsubmitsFor(scheduler, "feature", nil), 0)
+}
+
+func createScheduler(t *testing.T, ns string, name string, spec string) *jenkinsv1.Scheduler {
+	scheduler := &jenkinsv1.Scheduler{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
+	}
+	err := yaml.Unmarshal([]byte(spec), &scheduler.Spec)
+	require.NoError(t, err)
+	return scheduler
+}
+
+func presubmitNames(presubmits []*pipelinescheduler.Presubmit) []string {
+	answer := []string{}
+	for _, presubmit := range presubmits {
+		answer = append(answer, *presubmit.Name)
+	}
+	return answer
+}
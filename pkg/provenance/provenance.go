@@ -0,0 +1,286 @@
+package provenance
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// StatementType the in-toto statement type: https://github.com/in-toto/attestation
+	StatementType = "https://in-toto.io/Statement/v0.1"
+	// PredicateType the SLSA provenance predicate type: https://slsa.dev/provenance/v0.2
+	PredicateType = "https://slsa.dev/provenance/v0.2"
+	// BuildType the type of the builds done by Jenkins X pipelines
+	BuildType = "https://jenkins-x.io/provenance/pipeline@v1"
+
+	// DigestSHA1 the digest algorithm of git commits
+	DigestSHA1 = "sha1"
+	// DigestSHA256 the digest algorithm of container images and files
+	DigestSHA256 = "sha256"
+
+	gitURIPrefix    = "git+"
+	branchRefPrefix = "refs/heads/"
+)
+
+// Statement is an in-toto statement whose predicate is the SLSA provenance of the subjects
+type Statement struct {
+	Type          string    `json:"_type"`
+	PredicateType string    `json:"predicateType"`
+	Subject       []Subject `json:"subject"`
+	Predicate     Predicate `json:"predicate"`
+}
+
+// Subject an artifact produced by the build such as a container image or file
+type Subject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// Predicate the SLSA provenance of a build
+type Predicate struct {
+	Builder     Builder     `json:"builder"`
+	BuildType   string      `json:"buildType"`
+	Invocation  Invocation  `json:"invocation"`
+	BuildConfig BuildConfig `json:"buildConfig,omitempty"`
+	Metadata    Metadata    `json:"metadata,omitempty"`
+	Materials   []Material  `json:"materials,omitempty"`
+}
+
+// Builder identifies the build system
+type Builder struct {
+	ID string `json:"id"`
+}
+
+// Invocation describes how the build was started
+type Invocation struct {
+	ConfigSource ConfigSource      `json:"configSource"`
+	Parameters   map[string]string `json:"parameters,omitempty"`
+	Environment  map[string]string `json:"environment,omitempty"`
+}
+
+// ConfigSource the git revision of the repository the pipeline was defined in
+type ConfigSource struct {
+	URI        string            `json:"uri"`
+	Digest     map[string]string `json:"digest"`
+	EntryPoint string            `json:"entryPoint,omitempty"`
+}
+
+// BuildConfig the pipeline that was run
+type BuildConfig struct {
+	// ParsedPipelineHash the SHA256 hash of the ParsedPipeline the Tekton pipeline was generated from
+	ParsedPipelineHash string `json:"parsedPipelineSha256,omitempty"`
+	Pipeline           string `json:"pipeline,omitempty"`
+	Build              string `json:"build,omitempty"`
+}
+
+// Metadata the metadata of the build
+type Metadata struct {
+	BuildInvocationID string     `json:"buildInvocationId,omitempty"`
+	BuildStartedOn    *time.Time `json:"buildStartedOn,omitempty"`
+	BuildFinishedOn   *time.Time `json:"buildFinishedOn,omitempty"`
+	Reproducible      bool       `json:"reproducible"`
+}
+
+// Material an input of the build such as the git repository or a builder image
+type Material struct {
+	URI    string            `json:"uri"`
+	Digest map[string]string `json:"digest,omitempty"`
+}
+
+// NewStatement creates the provenance statement of a build of the git revision of the branch of a repository
+func NewStatement(builderID string, gitURL string, branch string, revision string) *Statement {
+	source := ConfigSource{
+		URI:    GitURI(gitURL, branch),
+		Digest: map[string]string{DigestSHA1: revision},
+	}
+	return &Statement{
+		Type:          StatementType,
+		PredicateType: PredicateType,
+		Subject:       []Subject{},
+		Predicate: Predicate{
+			Builder: Builder{
+				ID: builderID,
+			},
+			BuildType: BuildType,
+			Invocation: Invocation{
+				ConfigSource: source,
+			},
+			Materials: []Material{
+				{
+					URI:    source.URI,
+					Digest: source.Digest,
+				},
+			},
+		},
+	}
+}
+
+// GitURI returns the SLSA URI of the branch of a git repository such as 'git+https://github.com/org/repo@refs/heads/master'
+func GitURI(gitURL string, branch string) string {
+	answer := gitURIPrefix + gitURL
+	if branch != "" {
+		answer += "@" + branchRefPrefix + strings.TrimPrefix(branch, branchRefPrefix)
+	}
+	return answer
+}
+
+// Source returns the git URL, branch and revision the subjects were built from
+func (s *Statement) Source() (string, string, string) {
+	source := s.Predicate.Invocation.ConfigSource
+	gitURL := strings.TrimPrefix(source.URI, gitURIPrefix)
+	branch := ""
+	if idx := strings.LastIndex(gitURL, "@"+branchRefPrefix); idx >= 0 {
+		branch = gitURL[idx+len("@"+branchRefPrefix):]
+		gitURL = gitURL[0:idx]
+	}
+	return gitURL, branch, source.Digest[DigestSHA1]
+}
+
+// AddSubject adds an artifact produced by the build
+func (s *Statement) AddSubject(name string, algorithm string, digest string) {
+	s.Subject = append(s.Subject, Subject{
+		Name:   name,
+		Digest: map[string]string{algorithm: digest},
+	})
+}
+
+// AddImageSubject adds a container image produced by the build from its reference, which must include the digest
+// such as 'gcr.io/org/app:1.0.0@sha256:abc...'
+func (s *Statement) AddImageSubject(image string) error {
+	name, digest := SplitImageDigest(image)
+	if digest == "" {
+		return fmt.Errorf("the image %s has no digest. Images are specified as NAME@sha256:DIGEST", image)
+	}
+	s.AddSubject(ImageName(name), DigestSHA256, digest)
+	return nil
+}
+
+// AddMaterial adds an input of the build
+func (s *Statement) AddMaterial(uri string, algorithm string, digest string) {
+	for _, m := range s.Predicate.Materials {
+		if m.URI == uri && m.Digest[algorithm] == digest {
+			return
+		}
+	}
+	material := Material{
+		URI: uri,
+	}
+	if digest != "" {
+		material.Digest = map[string]string{algorithm: digest}
+	}
+	s.Predicate.Materials = append(s.Predicate.Materials, material)
+}
+
+// SortMaterials sorts the materials after the git repository so the statement is stable
+func (s *Statement) SortMaterials() {
+	materials := s.Predicate.Materials
+	if len(materials) < 2 {
+		return
+	}
+	rest := materials[1:]
+	sort.Slice(rest, func(i, j int) bool {
+		return rest[i].URI < rest[j].URI
+	})
+}
+
+// MatchesImage returns true if the image is one of the subjects. The image reference must have a digest which matches
+// the digest of the subject as a tag can be moved to another image
+func (s *Statement) MatchesImage(image string) bool {
+	name, digest := SplitImageDigest(image)
+	if digest == "" {
+		return false
+	}
+	name = ImageName(name)
+	for _, subject := range s.Subject {
+		if subject.Name == name && subject.Digest[DigestSHA256] == digest {
+			return true
+		}
+	}
+	return false
+}
+
+// SplitImageDigest splits an image reference such as 'gcr.io/org/app:1.0.0@sha256:abc...' into the image and the
+// hex digest
+func SplitImageDigest(image string) (string, string) {
+	idx := strings.Index(image, "@")
+	if idx < 0 {
+		return image, ""
+	}
+	return image[0:idx], strings.TrimPrefix(image[idx+1:], DigestSHA256+":")
+}
+
+// ImageName returns the name of the image without its tag
+func ImageName(image string) string {
+	image, _ = SplitImageDigest(image)
+	// the tag follows the last path element so lets not confuse the port of a registry with a tag
+	if idx := strings.LastIndex(image, ":"); idx > strings.LastIndex(image, "/") {
+		return image[0:idx]
+	}
+	return image
+}
+
+// ImageTag returns the tag of the image or an empty string if it has no tag
+func ImageTag(image string) string {
+	image, _ = SplitImageDigest(image)
+	name := ImageName(image)
+	return strings.TrimPrefix(image[len(name):], ":")
+}
+
+// ImageApp returns the last path element of the image name which by convention is the name of the application
+func ImageApp(image string) string {
+	return path.Base(ImageName(image))
+}
+
+// ContainerImageDigest returns the hex digest of the image ID of a container status such as
+// 'docker-pullable://gcr.io/org/builder@sha256:abc...' or an empty string if the image ID is not a repository digest
+func ContainerImageDigest(imageID string) string {
+	marker := "@" + DigestSHA256 + ":"
+	if idx := strings.Index(imageID, marker); idx >= 0 {
+		return imageID[idx+len(marker):]
+	}
+	return ""
+}
+
+// CheckSource returns an error unless the subjects were built from the branch of the repository. The repository can
+// be a git URL or 'owner/name'. Empty values are not checked
+func (s *Statement) CheckSource(repository string, branch string) error {
+	gitURL, actualBranch, _ := s.Source()
+	if repository != "" {
+		expected := normalizeRepository(repository)
+		actual := normalizeRepository(gitURL)
+		if strings.Count(expected, "/") == 1 {
+			// only the owner and name so lets ignore the host of the actual repository
+			parts := strings.Split(actual, "/")
+			if len(parts) >= 2 {
+				actual = strings.Join(parts[len(parts)-2:], "/")
+			}
+		}
+		if expected != actual {
+			return fmt.Errorf("built from repository %s not %s", gitURL, repository)
+		}
+	}
+	if branch != "" && strings.TrimPrefix(branch, branchRefPrefix) != actualBranch {
+		return fmt.Errorf("built from branch %s not %s", actualBranch, branch)
+	}
+	return nil
+}
+
+// normalizeRepository returns the lower case host and path of a git URL such as 'github.com/owner/name'
+func normalizeRepository(gitURL string) string {
+	text := strings.ToLower(strings.TrimSpace(gitURL))
+	if idx := strings.Index(text, "://"); idx >= 0 {
+		text = text[idx+3:]
+	} else if idx := strings.Index(text, ":"); idx >= 0 && strings.Contains(text[0:idx], "@") {
+		// scp like syntax such as git@github.com:owner/name.git
+		text = text[0:idx] + "/" + text[idx+1:]
+	}
+	if idx := strings.Index(text, "@"); idx >= 0 && idx < strings.Index(text, "/") {
+		// lets remove any user
+		text = text[idx+1:]
+	}
+	text = strings.TrimSuffix(strings.TrimSuffix(text, "/"), ".git")
+	return text
+}
@@ -0,0 +1,100 @@
+package provenance_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/provenance"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/openpgp"
+)
+
+const testDigest = "1111111111111111111111111111111111111111111111111111111111111111"
+
+func TestSignAndVerify(t *testing.T) {
+	t.Parallel()
+	signer, err := openpgp.NewEntity("Jenkins X", "", "jenkins-x@googlegroups.com", nil)
+	require.NoError(t, err)
+	other, err := openpgp.NewEntity("Someone Else", "", "someone@example.com", nil)
+	require.NoError(t, err)
+
+	statement := provenance.NewStatement("https://jenkins-x.io/jx", "https://github.com/jenkins-x/jx.git", "master", "abcdef123456")
+	statement.Predicate.BuildConfig.ParsedPipelineHash = "cafe"
+	err = statement.AddImageSubject("gcr.io/jenkinsxio/jx:1.2.3@sha256:" + testDigest)
+	require.NoError(t, err)
+	err = statement.AddImageSubject("gcr.io/jenkinsxio/jx:1.2.3")
+	assert.Error(t, err, "images must have a digest")
+	statement.AddMaterial("gcr.io/jenkinsxio/builder-go", provenance.DigestSHA256, testDigest)
+	statement.AddMaterial("gcr.io/jenkinsxio/builder-go", provenance.DigestSHA256, testDigest)
+	assert.Len(t, statement.Predicate.Materials, 2, "duplicate materials are ignored")
+
+	data, err := provenance.Sign(statement, signer)
+	require.NoError(t, err)
+
+	actual, key, err := provenance.Verify(data, openpgp.EntityList{other, signer})
+	require.NoError(t, err)
+	assert.Equal(t, signer.PrimaryKey.KeyId, key.PrimaryKey.KeyId)
+	assert.Equal(t, statement, actual)
+
+	gitURL, branch, revision := actual.Source()
+	assert.Equal(t, "https://github.com/jenkins-x/jx.git", gitURL)
+	assert.Equal(t, "master", branch)
+	assert.Equal(t, "abcdef123456", revision)
+
+	_, _, err = provenance.Verify(data, openpgp.EntityList{other})
+	assert.Error(t, err, "signed by an untrusted key")
+
+	// lets tamper with the payload
+	envelope := &provenance.Envelope{}
+	require.NoError(t, json.Unmarshal(data, envelope))
+	payload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+	require.NoError(t, err)
+	envelope.Payload = base64.StdEncoding.EncodeToString([]byte(strings.Replace(string(payload), "master", "hacked", 1)))
+	tampered, err := json.Marshal(envelope)
+	require.NoError(t, err)
+	_, _, err = provenance.Verify(tampered, openpgp.EntityList{signer})
+	assert.Error(t, err, "tampered payload")
+
+	envelope.Signatures = nil
+	unsigned, err := json.Marshal(envelope)
+	require.NoError(t, err)
+	_, _, err = provenance.Verify(unsigned, openpgp.EntityList{signer})
+	assert.Equal(t, provenance.ErrNotSigned, err)
+}
+
+func TestMatchesImage(t *testing.T) {
+	t.Parallel()
+	statement := provenance.NewStatement("https://jenkins-x.io/jx", "https://github.com/jenkins-x/jx.git", "master", "abcdef123456")
+	err := statement.AddImageSubject("localhost:5000/jenkinsxio/jx:1.2.3@sha256:" + testDigest)
+	require.NoError(t, err)
+
+	assert.True(t, statement.MatchesImage("localhost:5000/jenkinsxio/jx@sha256:"+testDigest))
+	assert.True(t, statement.MatchesImage("localhost:5000/jenkinsxio/jx:1.2.3@sha256:"+testDigest))
+	assert.False(t, statement.MatchesImage("localhost:5000/jenkinsxio/jx"))
+	assert.False(t, statement.MatchesImage("localhost:5000/jenkinsxio/jx:1.2.3"))
+	assert.False(t, statement.MatchesImage("localhost:5000/jenkinsxio/jx@sha256:2222"))
+	assert.False(t, statement.MatchesImage("localhost:5000/jenkinsxio/other@sha256:"+testDigest))
+
+	assert.Equal(t, "jx", provenance.ImageApp("localhost:5000/jenkinsxio/jx:1.2.3"))
+	assert.Equal(t, "1.2.3", provenance.ImageTag("localhost:5000/jenkinsxio/jx:1.2.3@sha256:"+testDigest))
+	assert.Equal(t, "", provenance.ImageTag("localhost:5000/jenkinsxio/jx"))
+
+	assert.Equal(t, testDigest, provenance.ContainerImageDigest("docker-pullable://gcr.io/jenkinsxio/builder-go@sha256:"+testDigest))
+	assert.Equal(t, "", provenance.ContainerImageDigest("docker://sha256:"+testDigest))
+}
+
+func TestCheckSource(t *testing.T) {
+	t.Parallel()
+	statement := provenance.NewStatement("https://jenkins-x.io/jx", "https://github.com/jenkins-x/jx.git", "master", "abcdef123456")
+
+	assert.NoError(t, statement.CheckSource("", ""))
+	assert.NoError(t, statement.CheckSource("jenkins-x/jx", "master"))
+	assert.NoError(t, statement.CheckSource("https://github.com/jenkins-x/jx", "refs/heads/master"))
+	assert.NoError(t, statement.CheckSource("git@github.com:jenkins-x/jx.git", ""))
+	assert.Error(t, statement.CheckSource("jenkins-x/other", ""))
+	assert.Error(t, statement.CheckSource("https://gitlab.com/jenkins-x/jx.git", ""))
+	assert.Error(t, statement.CheckSource("jenkins-x/jx", "feature"))
+}
@@ -0,0 +1,108 @@
+package provenance
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/openpgp"
+)
+
+const (
+	// PayloadType the DSSE payload type of in-toto statements
+	PayloadType = "application/vnd.in-toto+json"
+)
+
+// ErrNotSigned is returned when verifying an envelope which has no signatures
+var ErrNotSigned = errors.New("provenance is not signed")
+
+// Envelope a DSSE envelope of a signed statement: https://github.com/secure-systems-lab/dsse
+type Envelope struct {
+	PayloadType string      `json:"payloadType"`
+	Payload     string      `json:"payload"`
+	Signatures  []Signature `json:"signatures"`
+}
+
+// Signature a signature of the envelope payload
+type Signature struct {
+	KeyID string `json:"keyid,omitempty"`
+	// Sig the base64 encoded binary OpenPGP detached signature of the pre-authentication encoding of the payload
+	Sig string `json:"sig"`
+}
+
+// Sign signs the statement with the GPG key returning the JSON DSSE envelope
+func Sign(statement *Statement, signer *openpgp.Entity) ([]byte, error) {
+	payload, err := json.Marshal(statement)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshalling the provenance statement")
+	}
+	var signature bytes.Buffer
+	err = openpgp.DetachSign(&signature, signer, bytes.NewReader(preAuthEncoding(PayloadType, payload)), nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "signing the provenance with GPG key %s", signer.PrimaryKey.KeyIdString())
+	}
+	envelope := &Envelope{
+		PayloadType: PayloadType,
+		Payload:     base64.StdEncoding.EncodeToString(payload),
+		Signatures: []Signature{
+			{
+				KeyID: signer.PrimaryKey.KeyIdString(),
+				Sig:   base64.StdEncoding.EncodeToString(signature.Bytes()),
+			},
+		},
+	}
+	return json.MarshalIndent(envelope, "", "  ")
+}
+
+// Verify checks the signatures of the JSON DSSE envelope against the keyring returning the statement and the key
+// which signed it
+func Verify(data []byte, keyring openpgp.EntityList) (*Statement, *openpgp.Entity, error) {
+	envelope := &Envelope{}
+	err := json.Unmarshal(data, envelope)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "parsing the provenance envelope")
+	}
+	if envelope.PayloadType != PayloadType {
+		return nil, nil, fmt.Errorf("unsupported provenance payload type %s", envelope.PayloadType)
+	}
+	payload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "decoding the provenance payload")
+	}
+	if len(envelope.Signatures) == 0 {
+		return nil, nil, ErrNotSigned
+	}
+	message := preAuthEncoding(envelope.PayloadType, payload)
+	var verifyErr error
+	for _, s := range envelope.Signatures {
+		sig, err := base64.StdEncoding.DecodeString(s.Sig)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "decoding the provenance signature")
+		}
+		signer, err := openpgp.CheckDetachedSignature(keyring, bytes.NewReader(message), bytes.NewReader(sig))
+		if err != nil {
+			verifyErr = err
+			continue
+		}
+		statement := &Statement{}
+		err = json.Unmarshal(payload, statement)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "parsing the provenance statement")
+		}
+		if statement.Type != StatementType || statement.PredicateType != PredicateType {
+			return nil, nil, fmt.Errorf("unsupported provenance statement %s with predicate %s", statement.Type, statement.PredicateType)
+		}
+		return statement, signer, nil
+	}
+	return nil, nil, errors.Wrap(verifyErr, "no valid signature of the provenance from a trusted key")
+}
+
+// preAuthEncoding returns the DSSE pre-authentication encoding of the payload which is what is signed
+func preAuthEncoding(payloadType string, payload []byte) []byte {
+	var buffer bytes.Buffer
+	fmt.Fprintf(&buffer, "DSSEv1 %d %s %d ", len(payloadType), payloadType, len(payload))
+	buffer.Write(payload)
+	return buffer.Bytes()
+}
@@ -0,0 +1,213 @@
+package sbom
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/pborman/uuid"
+)
+
+var spdxIDInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9.\-]`)
+
+// cycloneDX is a CycloneDX 1.2 JSON document
+type cycloneDX struct {
+	BOMFormat    string               `json:"bomFormat"`
+	SpecVersion  string               `json:"specVersion"`
+	SerialNumber string               `json:"serialNumber,omitempty"`
+	Version      int                  `json:"version"`
+	Metadata     *cycloneDXMetadata   `json:"metadata,omitempty"`
+	Components   []cycloneDXComponent `json:"components"`
+}
+
+type cycloneDXMetadata struct {
+	Timestamp string              `json:"timestamp,omitempty"`
+	Tools     []cycloneDXTool     `json:"tools,omitempty"`
+	Component *cycloneDXComponent `json:"component,omitempty"`
+}
+
+type cycloneDXTool struct {
+	Name string `json:"name"`
+}
+
+type cycloneDXComponent struct {
+	Type    string `json:"type"`
+	Group   string `json:"group,omitempty"`
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+	Scope   string `json:"scope,omitempty"`
+	PURL    string `json:"purl,omitempty"`
+}
+
+func newCycloneDX(name string, version string, created string, components []Component) *cycloneDX {
+	doc := &cycloneDX{
+		BOMFormat:    "CycloneDX",
+		SpecVersion:  "1.2",
+		SerialNumber: "urn:uuid:" + uuid.New(),
+		Version:      1,
+		Metadata: &cycloneDXMetadata{
+			Timestamp: created,
+			Tools:     []cycloneDXTool{{Name: toolName}},
+			Component: &cycloneDXComponent{
+				Type:    "application",
+				Name:    name,
+				Version: version,
+			},
+		},
+		Components: []cycloneDXComponent{},
+	}
+	for _, c := range components {
+		scope := ""
+		switch c.Scope {
+		case "":
+		case "test", "provided", "system", "dev":
+			scope = "optional"
+		default:
+			scope = "required"
+		}
+		doc.Components = append(doc.Components, cycloneDXComponent{
+			Type:    "library",
+			Group:   c.Group,
+			Name:    c.Name,
+			Version: c.Version,
+			Scope:   scope,
+			PURL:    c.PURL(),
+		})
+	}
+	return doc
+}
+
+func (d *cycloneDX) toComponents() ([]Component, error) {
+	answer := []Component{}
+	for _, c := range d.Components {
+		if c.PURL != "" {
+			component, err := ParsePURL(c.PURL)
+			if err != nil {
+				return nil, err
+			}
+			answer = append(answer, *component)
+			continue
+		}
+		answer = append(answer, Component{
+			Group:   c.Group,
+			Name:    c.Name,
+			Version: c.Version,
+		})
+	}
+	return answer, nil
+}
+
+// spdxDocument is an SPDX 2.2 JSON document
+type spdxDocument struct {
+	SPDXVersion       string             `json:"spdxVersion"`
+	DataLicense       string             `json:"dataLicense"`
+	SPDXID            string             `json:"SPDXID"`
+	Name              string             `json:"name"`
+	DocumentNamespace string             `json:"documentNamespace"`
+	CreationInfo      spdxCreationInfo   `json:"creationInfo"`
+	Packages          []spdxPackage      `json:"packages"`
+	Relationships     []spdxRelationship `json:"relationships,omitempty"`
+}
+
+type spdxCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type spdxPackage struct {
+	SPDXID           string            `json:"SPDXID"`
+	Name             string            `json:"name"`
+	VersionInfo      string            `json:"versionInfo,omitempty"`
+	DownloadLocation string            `json:"downloadLocation"`
+	FilesAnalyzed    bool              `json:"filesAnalyzed"`
+	ExternalRefs     []spdxExternalRef `json:"externalRefs,omitempty"`
+}
+
+type spdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+type spdxRelationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+func newSPDX(name string, version string, created string, components []Component) *spdxDocument {
+	rootID := spdxID("Package", name)
+	doc := &spdxDocument{
+		SPDXVersion:       "SPDX-2.2",
+		DataLicense:       "CC0-1.0",
+		SPDXID:            "SPDXRef-DOCUMENT",
+		Name:              name + "-" + version,
+		DocumentNamespace: fmt.Sprintf("https://jenkins-x.io/spdx/%s-%s-%s", name, version, uuid.New()),
+		CreationInfo: spdxCreationInfo{
+			Created:  created,
+			Creators: []string{"Tool: " + toolName},
+		},
+		Packages: []spdxPackage{
+			{
+				SPDXID:           rootID,
+				Name:             name,
+				VersionInfo:      version,
+				DownloadLocation: "NOASSERTION",
+			},
+		},
+		Relationships: []spdxRelationship{
+			{
+				SPDXElementID:      "SPDXRef-DOCUMENT",
+				RelationshipType:   "DESCRIBES",
+				RelatedSPDXElement: rootID,
+			},
+		},
+	}
+	for i, c := range components {
+		id := spdxID("Package", fmt.Sprintf("%s-%d", c.Description(), i))
+		doc.Packages = append(doc.Packages, spdxPackage{
+			SPDXID:           id,
+			Name:             c.Description(),
+			VersionInfo:      c.Version,
+			DownloadLocation: "NOASSERTION",
+			ExternalRefs: []spdxExternalRef{
+				{
+					ReferenceCategory: "PACKAGE-MANAGER",
+					ReferenceType:     "purl",
+					ReferenceLocator:  c.PURL(),
+				},
+			},
+		})
+		doc.Relationships = append(doc.Relationships, spdxRelationship{
+			SPDXElementID:      rootID,
+			RelationshipType:   "DEPENDS_ON",
+			RelatedSPDXElement: id,
+		})
+	}
+	return doc
+}
+
+func spdxID(kind string, name string) string {
+	return "SPDXRef-" + kind + "-" + spdxIDInvalidChars.ReplaceAllString(name, "-")
+}
+
+func (d *spdxDocument) toComponents() ([]Component, error) {
+	answer := []Component{}
+	for _, p := range d.Packages {
+		purl := ""
+		for _, ref := range p.ExternalRefs {
+			if ref.ReferenceType == "purl" {
+				purl = ref.ReferenceLocator
+			}
+		}
+		if purl == "" {
+			// the package of the software itself or one we know nothing about
+			continue
+		}
+		component, err := ParsePURL(purl)
+		if err != nil {
+			return nil, err
+		}
+		answer = append(answer, *component)
+	}
+	return answer, nil
+}
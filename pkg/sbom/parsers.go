@@ -0,0 +1,285 @@
+package sbom
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	mavenPropertyRegex     = regexp.MustCompile(`\$\{([^}]+)\}`)
+	pythonRequirementRegex = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._\-]*)\s*(\[[^\]]*\])?\s*(?:===?\s*([^\s;,#]+))?`)
+	pythonNameRegex        = regexp.MustCompile(`[-_.]+`)
+)
+
+// ParseGoSum parses the modules of a go.sum file. The go.sum contains every module version needed to build the module,
+// where the go.mod only entries are ignored as only the go.mod of the version was needed
+func ParseGoSum(data []byte) ([]Component, error) {
+	answer := []Component{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("line %d: expected 'module version hash' but got %q", lineNumber, scanner.Text())
+		}
+		if strings.HasSuffix(fields[1], "/go.mod") {
+			continue
+		}
+		answer = append(answer, Component{
+			Ecosystem: EcosystemGo,
+			Name:      fields[0],
+			Version:   fields[1],
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return answer, nil
+}
+
+type mavenProject struct {
+	GroupID    string            `xml:"groupId"`
+	ArtifactID string            `xml:"artifactId"`
+	Version    string            `xml:"version"`
+	Parent     mavenDependency   `xml:"parent"`
+	Properties mavenProperties   `xml:"properties"`
+	Managed    []mavenDependency `xml:"dependencyManagement>dependencies>dependency"`
+	Deps       []mavenDependency `xml:"dependencies>dependency"`
+}
+
+type mavenDependency struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Version    string `xml:"version"`
+	Scope      string `xml:"scope"`
+}
+
+type mavenProperties map[string]string
+
+// UnmarshalXML reads the arbitrary elements of the properties
+func (p *mavenProperties) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	*p = mavenProperties{}
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			value := ""
+			err = d.DecodeElement(&value, &t)
+			if err != nil {
+				return err
+			}
+			(*p)[t.Name.Local] = strings.TrimSpace(value)
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// ParsePom parses the dependencies declared in a pom.xml resolving the versions from the dependency management and
+// properties of the pom. It cannot find the transitive dependencies so the output of 'mvn dependency:tree' should be
+// used where Maven is available
+func ParsePom(data []byte) ([]Component, error) {
+	project := &mavenProject{}
+	err := xml.Unmarshal(data, project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the pom.xml: %s", err)
+	}
+	properties := map[string]string{}
+	for k, v := range project.Properties {
+		properties[k] = v
+	}
+	groupID := project.GroupID
+	if groupID == "" {
+		groupID = project.Parent.GroupID
+	}
+	version := project.Version
+	if version == "" {
+		version = project.Parent.Version
+	}
+	properties["project.groupId"] = groupID
+	properties["project.version"] = version
+	properties["project.parent.version"] = project.Parent.Version
+
+	resolve := func(text string) string {
+		return mavenPropertyRegex.ReplaceAllStringFunc(text, func(expression string) string {
+			value, ok := properties[expression[2:len(expression)-1]]
+			if !ok {
+				return expression
+			}
+			return value
+		})
+	}
+	managed := map[string]string{}
+	for _, dep := range project.Managed {
+		managed[resolve(dep.GroupID)+":"+resolve(dep.ArtifactID)] = resolve(dep.Version)
+	}
+	answer := []Component{}
+	for _, dep := range project.Deps {
+		c := Component{
+			Ecosystem: EcosystemMaven,
+			Group:     resolve(dep.GroupID),
+			Name:      resolve(dep.ArtifactID),
+			Version:   resolve(dep.Version),
+			Scope:     dep.Scope,
+		}
+		if c.Version == "" {
+			c.Version = managed[c.Group+":"+c.Name]
+		}
+		if c.Scope == "" {
+			c.Scope = "compile"
+		}
+		answer = append(answer, c)
+	}
+	return answer, nil
+}
+
+// ParseMavenDependencyTree parses the text output of 'mvn dependency:tree -DoutputFile=...' where each dependency is
+// of the form 'groupId:artifactId:type[:classifier]:version[:scope]'. The first line is the project itself
+func ParseMavenDependencyTree(data []byte) ([]Component, error) {
+	answer := []Component{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	first := true
+	for scanner.Scan() {
+		line := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "[INFO]"))
+		// lets strip the tree drawing characters
+		line = strings.TrimLeft(line, "|+-\\ ")
+		if line == "" {
+			continue
+		}
+		fields := strings.Fields(line)
+		parts := strings.Split(fields[0], ":")
+		if len(parts) < 4 {
+			continue
+		}
+		if first {
+			// the project whose dependencies these are
+			first = false
+			continue
+		}
+		c := Component{
+			Ecosystem: EcosystemMaven,
+			Group:     parts[0],
+			Name:      parts[1],
+		}
+		switch len(parts) {
+		case 4:
+			c.Version = parts[3]
+		case 5:
+			c.Version = parts[3]
+			c.Scope = parts[4]
+		default:
+			c.Version = parts[4]
+			c.Scope = parts[5]
+		}
+		answer = append(answer, c)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return answer, nil
+}
+
+type npmLockDependency struct {
+	Version      string                        `json:"version"`
+	Dev          bool                          `json:"dev"`
+	Dependencies map[string]*npmLockDependency `json:"dependencies"`
+}
+
+type npmPackageLock struct {
+	Packages     map[string]*npmLockDependency `json:"packages"`
+	Dependencies map[string]*npmLockDependency `json:"dependencies"`
+}
+
+// ParsePackageLock parses the installed packages of an npm package-lock.json including the nested dependencies of
+// lockfile version 1 and the node_modules packages of later versions
+func ParsePackageLock(data []byte) ([]Component, error) {
+	lock := &npmPackageLock{}
+	err := json.Unmarshal(data, lock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the package-lock.json: %s", err)
+	}
+	answer := []Component{}
+	if len(lock.Packages) > 0 {
+		for path, dep := range lock.Packages {
+			idx := strings.LastIndex(path, "node_modules/")
+			if idx < 0 || dep == nil {
+				// the root project or a linked workspace
+				continue
+			}
+			answer = append(answer, npmComponent(path[idx+len("node_modules/"):], dep))
+		}
+		return answer, nil
+	}
+	var addDependencies func(deps map[string]*npmLockDependency)
+	addDependencies = func(deps map[string]*npmLockDependency) {
+		for name, dep := range deps {
+			if dep == nil {
+				continue
+			}
+			answer = append(answer, npmComponent(name, dep))
+			addDependencies(dep.Dependencies)
+		}
+	}
+	addDependencies(lock.Dependencies)
+	return answer, nil
+}
+
+func npmComponent(name string, dep *npmLockDependency) Component {
+	c := Component{
+		Ecosystem: EcosystemNPM,
+		Name:      name,
+		Version:   dep.Version,
+	}
+	if strings.HasPrefix(name, "@") {
+		if idx := strings.Index(name, "/"); idx > 0 {
+			c.Group = name[0:idx]
+			c.Name = name[idx+1:]
+		}
+	}
+	if dep.Dev {
+		c.Scope = "dev"
+	}
+	return c
+}
+
+// ParseRequirements parses the packages of a pip requirements.txt. Only pinned versions using == are known
+func ParseRequirements(data []byte) ([]Component, error) {
+	answer := []Component{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if idx := strings.Index(line, " #"); idx >= 0 {
+			line = strings.TrimSpace(line[0:idx])
+		}
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "-") || strings.Contains(line, "://") {
+			// lets ignore comments, options, included files and URLs
+			continue
+		}
+		matches := pythonRequirementRegex.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+		answer = append(answer, Component{
+			Ecosystem: EcosystemPyPI,
+			// PEP 503 normalised names
+			Name:    strings.ToLower(pythonNameRegex.ReplaceAllString(matches[1], "-")),
+			Version: matches[3],
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return answer, nil
+}
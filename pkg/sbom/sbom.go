@@ -0,0 +1,182 @@
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// FormatCycloneDX the CycloneDX JSON format: https://cyclonedx.org/
+	FormatCycloneDX = "cyclonedx"
+	// FormatSPDX the SPDX JSON format: https://spdx.dev/
+	FormatSPDX = "spdx"
+
+	// EcosystemGo Go modules
+	EcosystemGo = "golang"
+	// EcosystemMaven Maven artifacts
+	EcosystemMaven = "maven"
+	// EcosystemNPM npm packages
+	EcosystemNPM = "npm"
+	// EcosystemPyPI Python packages
+	EcosystemPyPI = "pypi"
+
+	toolName = "jx"
+)
+
+// Formats the supported SBOM formats
+var Formats = []string{FormatCycloneDX, FormatSPDX}
+
+// Component a library or package the software depends on
+type Component struct {
+	// Ecosystem the package ecosystem which is also the type of its package URL such as golang or maven
+	Ecosystem string
+	// Group the optional group of the component such as the Maven groupId or npm scope
+	Group   string
+	Name    string
+	Version string
+	// Scope the optional scope of the dependency such as compile or test for Maven
+	Scope string
+}
+
+// Description returns the group qualified name of the component
+func (c *Component) Description() string {
+	if c.Group == "" {
+		return c.Name
+	}
+	if c.Ecosystem == EcosystemMaven {
+		return c.Group + ":" + c.Name
+	}
+	return c.Group + "/" + c.Name
+}
+
+// PURL returns the package URL of the component: https://github.com/package-url/purl-spec
+func (c *Component) PURL() string {
+	name := escapePURLPath(c.Name)
+	if c.Group != "" {
+		name = escapePURLPath(c.Group) + "/" + name
+	}
+	answer := "pkg:" + c.Ecosystem + "/" + name
+	if c.Version != "" {
+		answer += "@" + url.PathEscape(c.Version)
+	}
+	return answer
+}
+
+func escapePURLPath(path string) string {
+	parts := strings.Split(path, "/")
+	for i, part := range parts {
+		// the @ of npm scopes must be encoded as it separates the version
+		parts[i] = strings.Replace(url.PathEscape(part), "@", "%40", -1)
+	}
+	return strings.Join(parts, "/")
+}
+
+// ParsePURL parses a package URL into a component
+func ParsePURL(purl string) (*Component, error) {
+	if !strings.HasPrefix(purl, "pkg:") {
+		return nil, fmt.Errorf("invalid package URL %s", purl)
+	}
+	text := strings.TrimPrefix(purl, "pkg:")
+	// lets ignore any qualifiers and subpath
+	if idx := strings.IndexAny(text, "?#"); idx >= 0 {
+		text = text[0:idx]
+	}
+	idx := strings.Index(text, "/")
+	if idx <= 0 {
+		return nil, fmt.Errorf("invalid package URL %s", purl)
+	}
+	answer := &Component{
+		Ecosystem: text[0:idx],
+	}
+	text = text[idx+1:]
+	if idx = strings.LastIndex(text, "@"); idx >= 0 {
+		version, err := url.PathUnescape(text[idx+1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid version in package URL %s: %s", purl, err)
+		}
+		answer.Version = version
+		text = text[0:idx]
+	}
+	path, err := url.PathUnescape(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid name in package URL %s: %s", purl, err)
+	}
+	if idx = strings.LastIndex(path, "/"); idx > 0 && answer.Ecosystem != EcosystemGo {
+		answer.Group = path[0:idx]
+		path = path[idx+1:]
+	}
+	answer.Name = path
+	return answer, nil
+}
+
+// SortComponents sorts the components and removes duplicates
+func SortComponents(components []Component) []Component {
+	sort.Slice(components, func(i, j int) bool {
+		a := components[i]
+		b := components[j]
+		if a.Ecosystem != b.Ecosystem {
+			return a.Ecosystem < b.Ecosystem
+		}
+		if a.Description() != b.Description() {
+			return a.Description() < b.Description()
+		}
+		return a.Version < b.Version
+	})
+	answer := []Component{}
+	for i, c := range components {
+		if i > 0 && c.PURL() == answer[len(answer)-1].PURL() {
+			continue
+		}
+		answer = append(answer, c)
+	}
+	return answer
+}
+
+// Marshal generates the SBOM of the named and versioned software in the given format
+func Marshal(format string, name string, version string, components []Component) ([]byte, error) {
+	created := time.Now().UTC().Format(time.RFC3339)
+	var doc interface{}
+	switch format {
+	case FormatCycloneDX:
+		doc = newCycloneDX(name, version, created, components)
+	case FormatSPDX:
+		doc = newSPDX(name, version, created, components)
+	default:
+		return nil, fmt.Errorf("unknown SBOM format %s. Supported formats are: %s", format, strings.Join(Formats, ", "))
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// Unmarshal returns the components of a CycloneDX or SPDX JSON SBOM
+func Unmarshal(data []byte) ([]Component, error) {
+	header := struct {
+		BOMFormat   string `json:"bomFormat"`
+		SPDXVersion string `json:"spdxVersion"`
+	}{}
+	err := json.Unmarshal(data, &header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the SBOM: %s", err)
+	}
+	switch {
+	case header.BOMFormat == "CycloneDX":
+		doc := &cycloneDX{}
+		err = json.Unmarshal(data, doc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the CycloneDX SBOM: %s", err)
+		}
+		return doc.toComponents()
+	case header.SPDXVersion != "":
+		doc := &spdxDocument{}
+		err = json.Unmarshal(data, doc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the SPDX SBOM: %s", err)
+		}
+		return doc.toComponents()
+	default:
+		return nil, fmt.Errorf("the SBOM is neither a CycloneDX nor an SPDX JSON document")
+	}
+}
@@ -0,0 +1,90 @@
+package sbom_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/sbom"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScan(t *testing.T) {
+	t.Parallel()
+	components, err := sbom.Scan(filepath.Join("test_data", "workspace"))
+	require.NoError(t, err)
+
+	purls := []string{}
+	for _, c := range components {
+		purls = append(purls, c.PURL())
+	}
+	assert.Equal(t, []string{
+		"pkg:golang/github.com/pkg/errors@v0.8.1",
+		"pkg:golang/github.com/spf13/cobra@v0.0.3",
+		"pkg:maven/com.fasterxml.jackson.core/jackson-databind@2.9.8",
+		"pkg:maven/junit/junit@4.12",
+		"pkg:maven/org.slf4j/slf4j-api@1.7.26",
+		"pkg:npm/%40babel/code-frame@7.0.0",
+		"pkg:npm/debug@2.6.9",
+		"pkg:npm/lodash@4.17.11",
+		"pkg:pypi/flask@1.0.2",
+		"pkg:pypi/pyyaml",
+		"pkg:pypi/requests@2.21.0",
+	}, purls)
+}
+
+func TestParseMavenDependencyTree(t *testing.T) {
+	t.Parallel()
+	data, err := ioutil.ReadFile(filepath.Join("test_data", "dependency-tree.txt"))
+	require.NoError(t, err)
+	components, err := sbom.ParseMavenDependencyTree(data)
+	require.NoError(t, err)
+
+	assert.Equal(t, []sbom.Component{
+		{Ecosystem: sbom.EcosystemMaven, Group: "com.fasterxml.jackson.core", Name: "jackson-databind", Version: "2.9.8", Scope: "compile"},
+		{Ecosystem: sbom.EcosystemMaven, Group: "com.fasterxml.jackson.core", Name: "jackson-annotations", Version: "2.9.0", Scope: "compile"},
+		{Ecosystem: sbom.EcosystemMaven, Group: "com.fasterxml.jackson.core", Name: "jackson-core", Version: "2.9.8", Scope: "compile"},
+		{Ecosystem: sbom.EcosystemMaven, Group: "io.netty", Name: "netty-transport-native-epoll", Version: "4.1.34.Final", Scope: "runtime"},
+	}, components)
+}
+
+func TestParsePackageLockVersion1(t *testing.T) {
+	t.Parallel()
+	data, err := ioutil.ReadFile(filepath.Join("test_data", "package-lock-v1.json"))
+	require.NoError(t, err)
+	components, err := sbom.ParsePackageLock(data)
+	require.NoError(t, err)
+
+	assert.Equal(t, []sbom.Component{
+		{Ecosystem: sbom.EcosystemNPM, Group: "@types", Name: "node", Version: "11.13.0", Scope: "dev"},
+		{Ecosystem: sbom.EcosystemNPM, Name: "debug", Version: "2.6.9"},
+		{Ecosystem: sbom.EcosystemNPM, Name: "lodash", Version: "4.17.11"},
+	}, sbom.SortComponents(components))
+}
+
+func TestMarshalAndUnmarshal(t *testing.T) {
+	t.Parallel()
+	components := []sbom.Component{
+		{Ecosystem: sbom.EcosystemGo, Name: "github.com/pkg/errors", Version: "v0.8.1"},
+		{Ecosystem: sbom.EcosystemMaven, Group: "junit", Name: "junit", Version: "4.12", Scope: "test"},
+		{Ecosystem: sbom.EcosystemNPM, Group: "@babel", Name: "code-frame", Version: "7.0.0"},
+	}
+	for _, format := range sbom.Formats {
+		data, err := sbom.Marshal(format, "myapp", "1.0.0", components)
+		require.NoError(t, err, "format %s", format)
+
+		actual, err := sbom.Unmarshal(data)
+		require.NoError(t, err, "format %s", format)
+		for i := range actual {
+			// the scope is not kept in the package URL
+			actual[i].Scope = components[i].Scope
+		}
+		assert.Equal(t, components, actual, "format %s", format)
+	}
+
+	_, err := sbom.Marshal("doesnotexist", "myapp", "1.0.0", components)
+	assert.Error(t, err)
+	_, err = sbom.Unmarshal([]byte(`{"name": "myapp"}`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,72 @@
+package sbom
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// MavenDependencyTreeFile the file name of the 'mvn dependency:tree' output which is used instead of any pom.xml
+	// in the same directory so that transitive dependencies are included
+	MavenDependencyTreeFile = "dependency-tree.txt"
+)
+
+// parsers the dependency files and their parsers
+var parsers = map[string]func([]byte) ([]Component, error){
+	"go.sum":                ParseGoSum,
+	"pom.xml":               ParsePom,
+	"package-lock.json":     ParsePackageLock,
+	"requirements.txt":      ParseRequirements,
+	MavenDependencyTreeFile: ParseMavenDependencyTree,
+}
+
+// ignoredDirs directories which are not part of the source of the software
+var ignoredDirs = map[string]bool{
+	".git":         true,
+	"node_modules": true,
+	"target":       true,
+	"vendor":       true,
+}
+
+// Scan finds the components of the software in the given directory from its go.sum, pom.xml, package-lock.json and
+// requirements.txt files, sorted and with duplicates removed
+func Scan(dir string) ([]Component, error) {
+	answer := []Component{}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if path != dir && ignoredDirs[info.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		parser, ok := parsers[info.Name()]
+		if !ok {
+			return nil
+		}
+		if info.Name() == "pom.xml" {
+			if _, err := os.Stat(filepath.Join(filepath.Dir(path), MavenDependencyTreeFile)); err == nil {
+				return nil
+			}
+		}
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		components, err := parser(data)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse %s", path)
+		}
+		answer = append(answer, components...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return SortComponents(answer), nil
+}
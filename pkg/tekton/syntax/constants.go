@@ -7,6 +7,9 @@ const (
 	// LabelStageName - the name for the label that will have the stage name on the Task.
 	LabelStageName = "jenkins.io/task-stage-name"
 
+	// AnnotationParsedPipelineHash - the annotation on the PipelineRun with the hash of the ParsedPipeline it was generated from.
+	AnnotationParsedPipelineHash = "jenkins.io/parsed-pipeline-sha256"
+
 	// DefaultStageNameForBuildPack - the name we use for the single stage created from build packs currently.
 	DefaultStageNameForBuildPack = "from-build-pack"
 )
@@ -2,6 +2,7 @@ package syntax
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -685,6 +686,16 @@ func (j *ParsedPipeline) AddContainerEnvVarsToPipeline(origEnv []corev1.EnvVar)
 	}
 }
 
+// Hash returns the SHA256 hash of the JSON representation of the pipeline, which identifies the pipeline that was run
+// in build provenance.
+func (j *ParsedPipeline) Hash() (string, error) {
+	data, err := json.Marshal(j)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal the pipeline")
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(data)), nil
+}
+
 func scopedEnv(newEnv []corev1.EnvVar, parentEnv []corev1.EnvVar) []corev1.EnvVar {
 	if len(parentEnv) == 0 && len(newEnv) == 0 {
 		return nil
@@ -2131,3 +2131,39 @@ func TestParsedPipelineHelpers(t *testing.T) {
 		t.Fatalf("ParsedPipeline diff -want, +got: %v", d)
 	}
 }
+
+func TestParsedPipelineHash(t *testing.T) {
+	newPipeline := func(arg string) *syntax.ParsedPipeline {
+		return ParsedPipeline(
+			PipelineAgent("some-image"),
+			PipelineStage("A Working Stage",
+				StageStep(
+					StepCmd("echo"),
+					StepArg(arg),
+				),
+			),
+		)
+	}
+
+	hash, err := newPipeline("hello").Hash()
+	if err != nil {
+		t.Fatalf("Failed to hash the pipeline: %s", err)
+	}
+	if len(hash) != 64 {
+		t.Errorf("Expected a SHA256 hex digest but got %s", hash)
+	}
+	sameHash, err := newPipeline("hello").Hash()
+	if err != nil {
+		t.Fatalf("Failed to hash the pipeline: %s", err)
+	}
+	if hash != sameHash {
+		t.Errorf("Expected the same pipeline to have the same hash but got %s and %s", hash, sameHash)
+	}
+	otherHash, err := newPipeline("world").Hash()
+	if err != nil {
+		t.Fatalf("Failed to hash the pipeline: %s", err)
+	}
+	if hash == otherHash {
+		t.Errorf("Expected a different pipeline to have a different hash %s", hash)
+	}
+}
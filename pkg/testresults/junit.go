@@ -0,0 +1,106 @@
+package testresults
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/pkg/errors"
+)
+
+// Report the counts of the test cases of one or more test reports
+type Report struct {
+	Total   int
+	Passed  int
+	Failed  int
+	Skipped int
+}
+
+// junitTestSuite is either a testsuites or a testsuite element as both can contain nested testsuite elements
+type junitTestSuite struct {
+	XMLName   xml.Name
+	Suites    []junitTestSuite `xml:"testsuite"`
+	TestCases []junitTestCase  `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name     string     `xml:"name,attr"`
+	Failures []xml.Name `xml:"failure"`
+	Errors   []xml.Name `xml:"error"`
+	Skipped  *xml.Name  `xml:"skipped"`
+}
+
+// Add adds the counts of the other report to this report
+func (r *Report) Add(other *Report) {
+	r.Total += other.Total
+	r.Passed += other.Passed
+	r.Failed += other.Failed
+	r.Skipped += other.Skipped
+}
+
+// String returns a human readable summary of the report
+func (r *Report) String() string {
+	return fmt.Sprintf("%d tests, %d passed, %d failed, %d skipped", r.Total, r.Passed, r.Failed, r.Skipped)
+}
+
+// Measurements returns the Fact measurements for the report
+func (r *Report) Measurements() []v1.Measurement {
+	return []v1.Measurement{
+		{Name: v1.TestResultsMeasurementTotal, MeasurementType: v1.MeasurementCount, MeasurementValue: r.Total},
+		{Name: v1.TestResultsMeasurementPassed, MeasurementType: v1.MeasurementCount, MeasurementValue: r.Passed},
+		{Name: v1.TestResultsMeasurementFailed, MeasurementType: v1.MeasurementCount, MeasurementValue: r.Failed},
+		{Name: v1.TestResultsMeasurementSkipped, MeasurementType: v1.MeasurementCount, MeasurementValue: r.Skipped},
+	}
+}
+
+// ParseJUnitFile parses the given JUnit XML report file
+func ParseJUnitFile(fileName string) (*Report, error) {
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load file %s", fileName)
+	}
+	report, err := ParseJUnit(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse JUnit report %s", filepath.Base(fileName))
+	}
+	return report, nil
+}
+
+// ParseJUnit parses a JUnit XML report whose root element is either a testsuites or a testsuite element. A test case
+// with a failure or an error has failed
+func ParseJUnit(data []byte) (*Report, error) {
+	root := junitTestSuite{}
+	err := xml.Unmarshal(data, &root)
+	if err != nil {
+		return nil, err
+	}
+	if root.XMLName.Local != "testsuites" && root.XMLName.Local != "testsuite" {
+		return nil, fmt.Errorf("the root element is %s rather than testsuites or testsuite", root.XMLName.Local)
+	}
+	report := &Report{}
+	report.addSuite(&root)
+	return report, nil
+}
+
+func (r *Report) addSuite(suite *junitTestSuite) {
+	for i := range suite.Suites {
+		r.addSuite(&suite.Suites[i])
+	}
+	r.addTestCases(suite.TestCases)
+}
+
+func (r *Report) addTestCases(testCases []junitTestCase) {
+	for _, testCase := range testCases {
+		r.Total++
+		switch {
+		case len(testCase.Failures) > 0 || len(testCase.Errors) > 0:
+			r.Failed++
+		case testCase.Skipped != nil:
+			r.Skipped++
+		default:
+			r.Passed++
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package testresults_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/testresults"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseJUnitFile(t *testing.T) {
+	t.Parallel()
+	report, err := testresults.ParseJUnitFile(filepath.Join("test_data", "junit.xml"))
+	require.NoError(t, err)
+	assert.Equal(t, testresults.Report{Total: 5, Passed: 2, Failed: 2, Skipped: 1}, *report)
+
+	single, err := testresults.ParseJUnitFile(filepath.Join("test_data", "TEST-single.xml"))
+	require.NoError(t, err)
+	assert.Equal(t, testresults.Report{Total: 2, Passed: 2}, *single)
+
+	report.Add(single)
+	assert.Equal(t, "7 tests, 4 passed, 2 failed, 1 skipped", report.String())
+	assert.Contains(t, report.Measurements(), v1.Measurement{Name: v1.TestResultsMeasurementFailed, MeasurementType: v1.MeasurementCount, MeasurementValue: 2})
+
+	_, err = testresults.ParseJUnit([]byte("<coverage/>"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,122 @@
+package tracing
+
+import (
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const (
+	jaegerProcessID = "p1"
+	jaegerChildOf   = "CHILD_OF"
+)
+
+// JaegerTraces is the JSON format of the Jaeger query API which can be loaded into the Jaeger UI
+type JaegerTraces struct {
+	Data []JaegerTrace `json:"data"`
+}
+
+// JaegerTrace a trace in the Jaeger JSON format
+type JaegerTrace struct {
+	TraceID   string                   `json:"traceID"`
+	Spans     []JaegerSpan             `json:"spans"`
+	Processes map[string]JaegerProcess `json:"processes"`
+}
+
+// JaegerSpan a span in the Jaeger JSON format
+type JaegerSpan struct {
+	TraceID       string            `json:"traceID"`
+	SpanID        string            `json:"spanID"`
+	OperationName string            `json:"operationName"`
+	References    []JaegerReference `json:"references"`
+	StartTime     int64             `json:"startTime"`
+	Duration      int64             `json:"duration"`
+	Tags          []JaegerTag       `json:"tags"`
+	Logs          []interface{}     `json:"logs"`
+	ProcessID     string            `json:"processID"`
+}
+
+// JaegerReference a reference to the parent span
+type JaegerReference struct {
+	RefType string `json:"refType"`
+	TraceID string `json:"traceID"`
+	SpanID  string `json:"spanID"`
+}
+
+// JaegerTag a tag on a span or process
+type JaegerTag struct {
+	Key   string      `json:"key"`
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+// JaegerProcess the process which created the spans
+type JaegerProcess struct {
+	ServiceName string      `json:"serviceName"`
+	Tags        []JaegerTag `json:"tags"`
+}
+
+// ToJaeger converts the trace into the Jaeger JSON format
+func ToJaeger(trace *Trace) *JaegerTraces {
+	spans := []JaegerSpan{}
+	for _, span := range trace.Spans {
+		references := []JaegerReference{}
+		if span.ParentSpanID != "" {
+			references = append(references, JaegerReference{
+				RefType: jaegerChildOf,
+				TraceID: trace.TraceID,
+				SpanID:  span.ParentSpanID,
+			})
+		}
+		tags := []JaegerTag{}
+		for _, key := range sortedKeys(span.Attributes) {
+			tags = append(tags, JaegerTag{
+				Key:   key,
+				Type:  "string",
+				Value: span.Attributes[key],
+			})
+		}
+		if span.Status == StatusCodeError {
+			tags = append(tags, JaegerTag{
+				Key:   "error",
+				Type:  "bool",
+				Value: true,
+			})
+		}
+		spans = append(spans, JaegerSpan{
+			TraceID:       trace.TraceID,
+			SpanID:        span.SpanID,
+			OperationName: span.Name,
+			References:    references,
+			StartTime:     span.Start.UnixNano() / 1000,
+			Duration:      int64(span.Duration() / 1000),
+			Tags:          tags,
+			Logs:          []interface{}{},
+			ProcessID:     jaegerProcessID,
+		})
+	}
+	return &JaegerTraces{
+		Data: []JaegerTrace{
+			{
+				TraceID: trace.TraceID,
+				Spans:   spans,
+				Processes: map[string]JaegerProcess{
+					jaegerProcessID: {
+						ServiceName: trace.ServiceName,
+						Tags:        []JaegerTag{},
+					},
+				},
+			},
+		},
+	}
+}
+
+// WriteJaegerFile writes the trace as a Jaeger JSON file which can be opened in the Jaeger UI
+func WriteJaegerFile(trace *Trace, fileName string) error {
+	data, err := json.MarshalIndent(ToJaeger(trace), "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(fileName, data, util.DefaultWritePermissions)
+}
@@ -0,0 +1,180 @@
+package tracing
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// OTLPTracesPath the path of the OTLP/HTTP traces endpoint
+	OTLPTracesPath = "/v1/traces"
+
+	otlpScopeName        = "github.com/jenkins-x/jx"
+	otlpSpanKindInternal = 1
+)
+
+// OTLPExporter exports traces to an OpenTelemetry collector using OTLP/HTTP with the JSON encoding
+type OTLPExporter struct {
+	Endpoint string
+	Headers  map[string]string
+	Client   *http.Client
+}
+
+// NewOTLPExporter creates a new exporter for the given collector endpoint such as http://otel-collector:4318.
+// If the endpoint has no path the default traces path is appended
+func NewOTLPExporter(endpoint string, headers map[string]string) *OTLPExporter {
+	endpoint = strings.TrimSuffix(endpoint, "/")
+	if !strings.HasSuffix(endpoint, OTLPTracesPath) {
+		endpoint += OTLPTracesPath
+	}
+	return &OTLPExporter{
+		Endpoint: endpoint,
+		Headers:  headers,
+		Client:   http.DefaultClient,
+	}
+}
+
+// Export sends the trace to the collector
+func (e *OTLPExporter) Export(trace *Trace) error {
+	body, err := json.Marshal(ToOTLP(trace))
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, e.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range e.Headers {
+		req.Header.Set(k, v)
+	}
+	client := e.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		data, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("error response exporting trace to %s: %s %s", e.Endpoint, resp.Status, string(data))
+	}
+	return nil
+}
+
+// OTLPTraces is the JSON encoding of an OTLP ExportTraceServiceRequest
+type OTLPTraces struct {
+	ResourceSpans []OTLPResourceSpans `json:"resourceSpans"`
+}
+
+// OTLPResourceSpans the spans of a resource
+type OTLPResourceSpans struct {
+	Resource   OTLPResource     `json:"resource"`
+	ScopeSpans []OTLPScopeSpans `json:"scopeSpans"`
+}
+
+// OTLPResource the resource which created the spans
+type OTLPResource struct {
+	Attributes []OTLPAttribute `json:"attributes"`
+}
+
+// OTLPScopeSpans the spans created by an instrumentation scope
+type OTLPScopeSpans struct {
+	Scope OTLPScope  `json:"scope"`
+	Spans []OTLPSpan `json:"spans"`
+}
+
+// OTLPScope the instrumentation scope
+type OTLPScope struct {
+	Name string `json:"name"`
+}
+
+// OTLPSpan a span
+type OTLPSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	ParentSpanID      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              int             `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []OTLPAttribute `json:"attributes,omitempty"`
+	Status            OTLPStatus      `json:"status"`
+}
+
+// OTLPStatus the status of a span
+type OTLPStatus struct {
+	Code StatusCode `json:"code"`
+}
+
+// OTLPAttribute a key value attribute
+type OTLPAttribute struct {
+	Key   string    `json:"key"`
+	Value OTLPValue `json:"value"`
+}
+
+// OTLPValue a string attribute value
+type OTLPValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+// ToOTLP converts the trace into the OTLP JSON encoding
+func ToOTLP(trace *Trace) *OTLPTraces {
+	spans := []OTLPSpan{}
+	for _, span := range trace.Spans {
+		spans = append(spans, OTLPSpan{
+			TraceID:           trace.TraceID,
+			SpanID:            span.SpanID,
+			ParentSpanID:      span.ParentSpanID,
+			Name:              span.Name,
+			Kind:              otlpSpanKindInternal,
+			StartTimeUnixNano: strconv.FormatInt(span.Start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(span.End.UnixNano(), 10),
+			Attributes:        toOTLPAttributes(span.Attributes),
+			Status:            OTLPStatus{Code: span.Status},
+		})
+	}
+	return &OTLPTraces{
+		ResourceSpans: []OTLPResourceSpans{
+			{
+				Resource: OTLPResource{
+					Attributes: toOTLPAttributes(map[string]string{"service.name": trace.ServiceName}),
+				},
+				ScopeSpans: []OTLPScopeSpans{
+					{
+						Scope: OTLPScope{Name: otlpScopeName},
+						Spans: spans,
+					},
+				},
+			},
+		},
+	}
+}
+
+func toOTLPAttributes(attributes map[string]string) []OTLPAttribute {
+	answer := []OTLPAttribute{}
+	for _, key := range sortedKeys(attributes) {
+		answer = append(answer, OTLPAttribute{
+			Key:   key,
+			Value: OTLPValue{StringValue: attributes[key]},
+		})
+	}
+	return answer
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := []string{}
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
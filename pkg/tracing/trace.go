@@ -0,0 +1,239 @@
+// Package tracing converts PipelineActivity resources into distributed traces which can be exported
+// over OTLP/HTTP or written as Jaeger JSON files so that the time spent in each stage of a pipeline can be visualised
+package tracing
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DefaultServiceName the service name used if the activity has no repository
+	DefaultServiceName = "jenkins-x"
+
+	// AttributePipeline the pipeline name of the activity
+	AttributePipeline = "jx.pipeline"
+	// AttributeBuild the build number of the activity
+	AttributeBuild = "jx.build"
+	// AttributeStatus the status of the activity or step
+	AttributeStatus = "jx.status"
+	// AttributeStepKind the kind of the pipeline step
+	AttributeStepKind = "jx.step.kind"
+	// AttributeEnvironment the environment being previewed or promoted to
+	AttributeEnvironment = "jx.environment"
+	// AttributeVersion the version being built
+	AttributeVersion = "jx.version"
+	// AttributePullRequestURL the URL of the pull request of a preview or promotion
+	AttributePullRequestURL = "jx.pull_request.url"
+	// AttributeOwner the git owner of the repository
+	AttributeOwner = "vcs.owner"
+	// AttributeRepository the git repository name
+	AttributeRepository = "vcs.repository"
+	// AttributeBranch the git branch
+	AttributeBranch = "vcs.branch"
+	// AttributeCommit the last git commit SHA
+	AttributeCommit = "vcs.commit"
+)
+
+// StatusCode is the status of a span
+type StatusCode int
+
+const (
+	// StatusCodeUnset the span has not completed or completed without a known status
+	StatusCodeUnset StatusCode = 0
+	// StatusCodeOK the span completed successfully
+	StatusCodeOK StatusCode = 1
+	// StatusCodeError the span failed
+	StatusCodeError StatusCode = 2
+)
+
+// Span is a timed operation in a pipeline trace
+type Span struct {
+	SpanID       string
+	ParentSpanID string
+	Name         string
+	Start        time.Time
+	End          time.Time
+	Status       StatusCode
+	Attributes   map[string]string
+}
+
+// Duration returns the duration of the span
+func (s *Span) Duration() time.Duration {
+	return s.End.Sub(s.Start)
+}
+
+// Trace is a trace of a single pipeline run. The first span is the root span of the pipeline
+type Trace struct {
+	TraceID     string
+	ServiceName string
+	Spans       []*Span
+}
+
+// FromActivity creates a trace from the given pipeline activity. The pipeline is the root span with child spans
+// for each stage, step, preview and promotion. Spans which have not completed yet end at the given time.
+//
+// The trace and span IDs are derived from the activity name so that exporting the same activity again results in the same IDs
+func FromActivity(activity *v1.PipelineActivity, now time.Time) *Trace {
+	spec := &activity.Spec
+	owner := activity.RepositoryOwner()
+	repo := activity.RepositoryName()
+	serviceName := DefaultServiceName
+	if owner != "" && repo != "" {
+		serviceName = owner + "/" + repo
+	}
+	trace := &Trace{
+		TraceID:     hashID(activity.Namespace+"/"+activity.Name, 16),
+		ServiceName: serviceName,
+	}
+
+	start := activity.CreationTimestamp.Time
+	if spec.StartedTimestamp != nil {
+		start = spec.StartedTimestamp.Time
+	}
+	if start.IsZero() {
+		start = earliestStart(activity)
+	}
+	if start.IsZero() {
+		start = now
+	}
+	root := trace.addSpan("", spec.Pipeline+" #"+spec.Build, start, spec.CompletedTimestamp, spec.Status, now)
+	root.Attributes[AttributePipeline] = spec.Pipeline
+	root.Attributes[AttributeBuild] = spec.Build
+	setAttribute(root, AttributeOwner, owner)
+	setAttribute(root, AttributeRepository, repo)
+	setAttribute(root, AttributeBranch, activity.BranchName())
+	setAttribute(root, AttributeCommit, spec.LastCommitSHA)
+	setAttribute(root, AttributeVersion, spec.Version)
+
+	for i := range spec.Steps {
+		step := &spec.Steps[i]
+		switch {
+		case step.Stage != nil:
+			stage := step.Stage
+			stageSpan := trace.addCoreSpan(root, &stage.CoreActivityStep, step.Kind, now)
+			if stageSpan == nil {
+				continue
+			}
+			for j := range stage.Steps {
+				trace.addCoreSpan(stageSpan, &stage.Steps[j], "", now)
+			}
+		case step.Preview != nil:
+			preview := step.Preview
+			span := trace.addCoreSpan(root, &preview.CoreActivityStep, step.Kind, now)
+			if span != nil {
+				setAttribute(span, AttributeEnvironment, preview.Environment)
+				setAttribute(span, AttributePullRequestURL, preview.PullRequestURL)
+			}
+		case step.Promote != nil:
+			promote := step.Promote
+			span := trace.addCoreSpan(root, &promote.CoreActivityStep, step.Kind, now)
+			if span == nil {
+				continue
+			}
+			setAttribute(span, AttributeEnvironment, promote.Environment)
+			if pr := promote.PullRequest; pr != nil {
+				prSpan := trace.addCoreSpan(span, &pr.CoreActivityStep, "", now)
+				if prSpan != nil {
+					setAttribute(prSpan, AttributePullRequestURL, pr.PullRequestURL)
+				}
+			}
+			if update := promote.Update; update != nil {
+				trace.addCoreSpan(span, &update.CoreActivityStep, "", now)
+			}
+		}
+	}
+	return trace
+}
+
+// Root returns the root span of the trace
+func (t *Trace) Root() *Span {
+	if len(t.Spans) == 0 {
+		return nil
+	}
+	return t.Spans[0]
+}
+
+func (t *Trace) addCoreSpan(parent *Span, step *v1.CoreActivityStep, kind v1.ActivityStepKindType, now time.Time) *Span {
+	if step.StartedTimestamp == nil {
+		return nil
+	}
+	name := step.Name
+	if name == "" {
+		name = string(kind)
+	}
+	span := t.addSpan(parent.SpanID, name, step.StartedTimestamp.Time, step.CompletedTimestamp, step.Status, now)
+	span.ParentSpanID = parent.SpanID
+	setAttribute(span, AttributeStepKind, string(kind))
+	return span
+}
+
+func (t *Trace) addSpan(parentID string, name string, start time.Time, completed *metav1.Time, status v1.ActivityStatusType, now time.Time) *Span {
+	end := now
+	if completed != nil {
+		end = completed.Time
+	}
+	if end.Before(start) {
+		end = start
+	}
+	span := &Span{
+		SpanID:     hashID(t.TraceID+"/"+parentID+"/"+name+"/"+start.String(), 8),
+		Name:       name,
+		Start:      start,
+		End:        end,
+		Status:     ToStatusCode(status),
+		Attributes: map[string]string{},
+	}
+	setAttribute(span, AttributeStatus, string(status))
+	t.Spans = append(t.Spans, span)
+	return span
+}
+
+// ToStatusCode converts the status of an activity or step to a span status
+func ToStatusCode(status v1.ActivityStatusType) StatusCode {
+	switch status {
+	case v1.ActivityStatusTypeSucceeded:
+		return StatusCodeOK
+	case v1.ActivityStatusTypeFailed, v1.ActivityStatusTypeError, v1.ActivityStatusTypeAborted:
+		return StatusCodeError
+	default:
+		return StatusCodeUnset
+	}
+}
+
+func earliestStart(activity *v1.PipelineActivity) time.Time {
+	var answer time.Time
+	for _, step := range activity.Spec.Steps {
+		var core *v1.CoreActivityStep
+		switch {
+		case step.Stage != nil:
+			core = &step.Stage.CoreActivityStep
+		case step.Preview != nil:
+			core = &step.Preview.CoreActivityStep
+		case step.Promote != nil:
+			core = &step.Promote.CoreActivityStep
+		}
+		if core != nil && core.StartedTimestamp != nil {
+			if answer.IsZero() || core.StartedTimestamp.Time.Before(answer) {
+				answer = core.StartedTimestamp.Time
+			}
+		}
+	}
+	return answer
+}
+
+func setAttribute(span *Span, key string, value string) {
+	if value != "" {
+		span.Attributes[key] = value
+	}
+}
+
+// hashID returns a hex ID of the given number of bytes derived from the text
+func hashID(text string, size int) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:size])
+}
@@ -0,0 +1,186 @@
+package tracing_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var started = time.Date(2019, 1, 1, 10, 0, 0, 0, time.UTC)
+
+func TestFromActivity(t *testing.T) {
+	t.Parallel()
+	now := started.Add(time.Hour)
+	trace := tracing.FromActivity(createActivity(), now)
+
+	assert.Equal(t, "myorg/myapp", trace.ServiceName)
+	assert.Len(t, trace.TraceID, 32)
+	require.Len(t, trace.Spans, 6)
+
+	root := trace.Root()
+	assert.Equal(t, "myorg/myapp/master #1", root.Name)
+	assert.Equal(t, "", root.ParentSpanID)
+	assert.Equal(t, tracing.StatusCodeError, root.Status)
+	assert.Equal(t, 10*time.Minute, root.Duration())
+	assert.Equal(t, "master", root.Attributes[tracing.AttributeBranch])
+
+	stage := trace.Spans[1]
+	assert.Equal(t, "build", stage.Name)
+	assert.Equal(t, root.SpanID, stage.ParentSpanID)
+	assert.Equal(t, tracing.StatusCodeOK, stage.Status)
+
+	step := trace.Spans[2]
+	assert.Equal(t, "compile", step.Name)
+	assert.Equal(t, stage.SpanID, step.ParentSpanID)
+	assert.Equal(t, 2*time.Minute, step.Duration())
+
+	promote := trace.Spans[3]
+	assert.Equal(t, "promote: staging", promote.Name)
+	assert.Equal(t, "staging", promote.Attributes[tracing.AttributeEnvironment])
+
+	pr := trace.Spans[4]
+	assert.Equal(t, promote.SpanID, pr.ParentSpanID)
+	assert.Equal(t, "https://github.com/myorg/env-staging/pull/1", pr.Attributes[tracing.AttributePullRequestURL])
+
+	update := trace.Spans[5]
+	assert.Equal(t, promote.SpanID, update.ParentSpanID)
+	assert.Equal(t, now, update.End, "a running span should end at the export time")
+
+	again := tracing.FromActivity(createActivity(), now)
+	assert.Equal(t, trace.TraceID, again.TraceID)
+	assert.Equal(t, trace.Spans[2].SpanID, again.Spans[2].SpanID)
+}
+
+func TestOTLPExporter(t *testing.T) {
+	t.Parallel()
+	var body []byte
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		body, _ = ioutil.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	trace := tracing.FromActivity(createActivity(), started.Add(time.Hour))
+	err := tracing.NewOTLPExporter(server.URL, nil).Export(trace)
+	require.NoError(t, err)
+	assert.Equal(t, tracing.OTLPTracesPath, path)
+
+	var request tracing.OTLPTraces
+	require.NoError(t, json.Unmarshal(body, &request))
+	require.Len(t, request.ResourceSpans, 1)
+	resource := request.ResourceSpans[0]
+	assert.Equal(t, "service.name", resource.Resource.Attributes[0].Key)
+	assert.Equal(t, "myorg/myapp", resource.Resource.Attributes[0].Value.StringValue)
+	spans := resource.ScopeSpans[0].Spans
+	require.Len(t, spans, 6)
+	assert.Equal(t, trace.TraceID, spans[0].TraceID)
+	assert.Equal(t, "1546336800000000000", spans[0].StartTimeUnixNano)
+}
+
+func TestToJaeger(t *testing.T) {
+	t.Parallel()
+	trace := tracing.FromActivity(createActivity(), started.Add(time.Hour))
+	jaeger := tracing.ToJaeger(trace)
+
+	require.Len(t, jaeger.Data, 1)
+	spans := jaeger.Data[0].Spans
+	require.Len(t, spans, 6)
+	assert.Empty(t, spans[0].References)
+	assert.Equal(t, int64(600000000), spans[0].Duration)
+	require.Len(t, spans[1].References, 1)
+	assert.Equal(t, spans[0].SpanID, spans[1].References[0].SpanID)
+	assert.Equal(t, "myorg/myapp", jaeger.Data[0].Processes[spans[0].ProcessID].ServiceName)
+
+	hasError := false
+	for _, tag := range spans[0].Tags {
+		if tag.Key == "error" {
+			hasError = true
+		}
+	}
+	assert.True(t, hasError, "the failed pipeline span should be tagged as an error")
+}
+
+func createActivity() *v1.PipelineActivity {
+	return &v1.PipelineActivity{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myorg-myapp-master-1",
+			Namespace: "jx",
+		},
+		Spec: v1.PipelineActivitySpec{
+			Pipeline:           "myorg/myapp/master",
+			Build:              "1",
+			Status:             v1.ActivityStatusTypeFailed,
+			StartedTimestamp:   timestamp(0),
+			CompletedTimestamp: timestamp(10 * time.Minute),
+			Steps: []v1.PipelineActivityStep{
+				{
+					Kind: v1.ActivityStepKindTypeStage,
+					Stage: &v1.StageActivityStep{
+						CoreActivityStep: v1.CoreActivityStep{
+							Name:               "build",
+							Status:             v1.ActivityStatusTypeSucceeded,
+							StartedTimestamp:   timestamp(time.Minute),
+							CompletedTimestamp: timestamp(4 * time.Minute),
+						},
+						Steps: []v1.CoreActivityStep{
+							{
+								Name:               "compile",
+								Status:             v1.ActivityStatusTypeSucceeded,
+								StartedTimestamp:   timestamp(time.Minute),
+								CompletedTimestamp: timestamp(3 * time.Minute),
+							},
+							{
+								Name:   "not started",
+								Status: v1.ActivityStatusTypePending,
+							},
+						},
+					},
+				},
+				{
+					Kind: v1.ActivityStepKindTypePromote,
+					Promote: &v1.PromoteActivityStep{
+						CoreActivityStep: v1.CoreActivityStep{
+							Name:               "promote: staging",
+							Status:             v1.ActivityStatusTypeFailed,
+							StartedTimestamp:   timestamp(4 * time.Minute),
+							CompletedTimestamp: timestamp(10 * time.Minute),
+						},
+						Environment: "staging",
+						PullRequest: &v1.PromotePullRequestStep{
+							CoreActivityStep: v1.CoreActivityStep{
+								Name:               "PullRequest",
+								Status:             v1.ActivityStatusTypeSucceeded,
+								StartedTimestamp:   timestamp(4 * time.Minute),
+								CompletedTimestamp: timestamp(6 * time.Minute),
+							},
+							PullRequestURL: "https://github.com/myorg/env-staging/pull/1",
+						},
+						Update: &v1.PromoteUpdateStep{
+							CoreActivityStep: v1.CoreActivityStep{
+								Name:             "Update",
+								Status:           v1.ActivityStatusTypeRunning,
+								StartedTimestamp: timestamp(6 * time.Minute),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func timestamp(offset time.Duration) *metav1.Time {
+	answer := metav1.NewTime(started.Add(offset))
+	return &answer
+}
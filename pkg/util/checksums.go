@@ -0,0 +1,47 @@
+package util
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// SHA256File returns the hex encoded SHA256 checksum of the file
+func SHA256File(fileName string) (string, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	_, err = io.Copy(hash, file)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %s", fileName, err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// SHA256Sums returns the SHA256 checksums of the files in the format of the sha256sum tool, sorted by the base names
+// of the files which are used as the names of the files in the checksums
+func SHA256Sums(fileNames []string) (string, error) {
+	lines := []string{}
+	for _, fileName := range fileNames {
+		sum, err := SHA256File(fileName)
+		if err != nil {
+			return "", err
+		}
+		lines = append(lines, sum+"  "+filepath.Base(fileName))
+	}
+	sort.Slice(lines, func(i, j int) bool {
+		return lines[i][66:] < lines[j][66:]
+	})
+	if len(lines) == 0 {
+		return "", nil
+	}
+	return strings.Join(lines, "\n") + "\n", nil
+}
@@ -0,0 +1,37 @@
+package util_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSHA256Sums(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "test-sha256sums-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	cheese := filepath.Join(dir, "cheese.txt")
+	beer := filepath.Join(dir, "beer.txt")
+	require.NoError(t, ioutil.WriteFile(cheese, []byte("cheese\n"), 0644))
+	require.NoError(t, ioutil.WriteFile(beer, []byte(""), 0644))
+
+	sum, err := util.SHA256File(beer)
+	require.NoError(t, err)
+	assert.Equal(t, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", sum)
+
+	sums, err := util.SHA256Sums([]string{cheese, beer})
+	require.NoError(t, err)
+	cheeseSum, err := util.SHA256File(cheese)
+	require.NoError(t, err)
+	assert.Equal(t, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855  beer.txt\n"+cheeseSum+"  cheese.txt\n", sums)
+
+	_, err = util.SHA256Sums([]string{filepath.Join(dir, "missing.txt")})
+	assert.Error(t, err)
+}
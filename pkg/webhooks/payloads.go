@@ -0,0 +1,413 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// zeroSHA the commit of a branch which was created or deleted by a push
+const zeroSHA = "0000000000000000000000000000000000000000"
+
+// githubUser a user in a GitHub or Gitea payload
+type githubUser struct {
+	Login    string `json:"login"`
+	UserName string `json:"username"`
+	Name     string `json:"name"`
+}
+
+func (u *githubUser) name() string {
+	if u.Login != "" {
+		return u.Login
+	}
+	if u.UserName != "" {
+		return u.UserName
+	}
+	return u.Name
+}
+
+// githubRepository a repository in a GitHub or Gitea payload
+type githubRepository struct {
+	Name     string     `json:"name"`
+	FullName string     `json:"full_name"`
+	Owner    githubUser `json:"owner"`
+	CloneURL string     `json:"clone_url"`
+}
+
+type githubCommit struct {
+	Added    []string `json:"added"`
+	Removed  []string `json:"removed"`
+	Modified []string `json:"modified"`
+}
+
+type githubBranch struct {
+	Ref string `json:"ref"`
+	SHA string `json:"sha"`
+}
+
+// githubPayload the fields of GitHub and Gitea push and pull request payloads
+type githubPayload struct {
+	Ref         string           `json:"ref"`
+	After       string           `json:"after"`
+	Deleted     bool             `json:"deleted"`
+	Commits     []githubCommit   `json:"commits"`
+	Pusher      githubUser       `json:"pusher"`
+	Action      string           `json:"action"`
+	Number      int              `json:"number"`
+	Repository  githubRepository `json:"repository"`
+	Sender      githubUser       `json:"sender"`
+	PullRequest *struct {
+		Number int          `json:"number"`
+		Head   githubBranch `json:"head"`
+		Base   githubBranch `json:"base"`
+		User   githubUser   `json:"user"`
+	} `json:"pull_request"`
+}
+
+func parseGitHubEvent(event *Event, data []byte) error {
+	payload := githubPayload{}
+	err := json.Unmarshal(data, &payload)
+	if err != nil {
+		return err
+	}
+	repo := payload.Repository
+	event.Owner = repo.Owner.name()
+	event.Repo = repo.Name
+	if event.Owner == "" {
+		event.Owner, event.Repo = splitFullName(repo.FullName)
+	}
+	event.CloneURL = repo.CloneURL
+	switch event.Type {
+	case "push":
+		event.Kind = EventKindPush
+		event.setRef(payload.Ref)
+		event.SHA = payload.After
+		event.Deleted = payload.Deleted || payload.After == zeroSHA
+		event.Author = payload.Pusher.name()
+		if event.Author == "" {
+			event.Author = payload.Sender.name()
+		}
+		event.Changes = commitChanges(payload.Commits)
+	case "pull_request":
+		pr := payload.PullRequest
+		if pr == nil {
+			return nil
+		}
+		event.Kind = EventKindPullRequest
+		event.PullRequestNumber = pr.Number
+		if event.PullRequestNumber == 0 {
+			event.PullRequestNumber = payload.Number
+		}
+		event.Branch = pr.Head.Ref
+		event.SHA = pr.Head.SHA
+		event.BaseBranch = pr.Base.Ref
+		event.BaseSHA = pr.Base.SHA
+		event.Author = pr.User.name()
+		switch payload.Action {
+		case "synchronize", "synchronized":
+			event.Action = PullRequestActionSynchronized
+		default:
+			event.Action = payload.Action
+		}
+	}
+	return nil
+}
+
+// commitChanges returns the files added, removed or modified by the commits or nil if the payload does not list them
+func commitChanges(commits []githubCommit) []string {
+	var answer []string
+	for _, commit := range commits {
+		answer = append(answer, commit.Added...)
+		answer = append(answer, commit.Removed...)
+		answer = append(answer, commit.Modified...)
+	}
+	return answer
+}
+
+// gitlabPayload the fields of GitLab push and merge request payloads
+type gitlabPayload struct {
+	ObjectKind   string `json:"object_kind"`
+	Ref          string `json:"ref"`
+	After        string `json:"after"`
+	CheckoutSHA  string `json:"checkout_sha"`
+	UserUsername string `json:"user_username"`
+	User         struct {
+		UserName string `json:"username"`
+	} `json:"user"`
+	Project struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+		GitHTTPURL        string `json:"git_http_url"`
+	} `json:"project"`
+	Commits []githubCommit `json:"commits"`
+	// ObjectAttributes the merge request
+	ObjectAttributes struct {
+		IID          int    `json:"iid"`
+		SourceBranch string `json:"source_branch"`
+		TargetBranch string `json:"target_branch"`
+		Action       string `json:"action"`
+		OldRev       string `json:"oldrev"`
+		LastCommit   struct {
+			ID string `json:"id"`
+		} `json:"last_commit"`
+	} `json:"object_attributes"`
+}
+
+func parseGitLabEvent(event *Event, data []byte) error {
+	payload := gitlabPayload{}
+	err := json.Unmarshal(data, &payload)
+	if err != nil {
+		return err
+	}
+	// GitLab repositories can be nested in sub groups which are part of the owner
+	event.Owner, event.Repo = splitFullName(payload.Project.PathWithNamespace)
+	event.CloneURL = payload.Project.GitHTTPURL
+	switch payload.ObjectKind {
+	case "push", "tag_push":
+		event.Kind = EventKindPush
+		event.setRef(payload.Ref)
+		event.SHA = payload.After
+		event.Deleted = payload.After == zeroSHA
+		event.Author = payload.UserUsername
+		event.Changes = commitChanges(payload.Commits)
+	case "merge_request":
+		mr := payload.ObjectAttributes
+		event.Kind = EventKindPullRequest
+		event.PullRequestNumber = mr.IID
+		event.Branch = mr.SourceBranch
+		event.SHA = mr.LastCommit.ID
+		event.BaseBranch = mr.TargetBranch
+		event.Author = payload.User.UserName
+		switch mr.Action {
+		case "open":
+			event.Action = PullRequestActionOpened
+		case "reopen":
+			event.Action = PullRequestActionReopened
+		case "update":
+			// updates only have the previous revision if commits were pushed
+			if mr.OldRev != "" {
+				event.Action = PullRequestActionSynchronized
+			} else {
+				event.Action = PullRequestActionEdited
+			}
+		case "close", "merge":
+			event.Action = PullRequestActionClosed
+		default:
+			event.Action = mr.Action
+		}
+	}
+	return nil
+}
+
+type bitbucketCloudUser struct {
+	UserName string `json:"username"`
+	NickName string `json:"nickname"`
+}
+
+func (u *bitbucketCloudUser) name() string {
+	if u.UserName != "" {
+		return u.UserName
+	}
+	return u.NickName
+}
+
+type bitbucketCloudRef struct {
+	Branch struct {
+		Name string `json:"name"`
+	} `json:"branch"`
+	Commit struct {
+		Hash string `json:"hash"`
+	} `json:"commit"`
+}
+
+// bitbucketCloudPayload the fields of Bitbucket Cloud push and pull request payloads
+type bitbucketCloudPayload struct {
+	Actor      bitbucketCloudUser `json:"actor"`
+	Repository struct {
+		FullName string `json:"full_name"`
+		Links    struct {
+			HTML struct {
+				Href string `json:"href"`
+			} `json:"html"`
+		} `json:"links"`
+	} `json:"repository"`
+	Push *struct {
+		Changes []struct {
+			New *struct {
+				Type   string `json:"type"`
+				Name   string `json:"name"`
+				Target struct {
+					Hash string `json:"hash"`
+				} `json:"target"`
+			} `json:"new"`
+			Old *struct {
+				Type string `json:"type"`
+				Name string `json:"name"`
+			} `json:"old"`
+			Closed bool `json:"closed"`
+		} `json:"changes"`
+	} `json:"push"`
+	PullRequest *struct {
+		ID          int                `json:"id"`
+		Source      bitbucketCloudRef  `json:"source"`
+		Destination bitbucketCloudRef  `json:"destination"`
+		Author      bitbucketCloudUser `json:"author"`
+	} `json:"pullrequest"`
+}
+
+func parseBitbucketCloudEvent(event *Event, data []byte) error {
+	payload := bitbucketCloudPayload{}
+	err := json.Unmarshal(data, &payload)
+	if err != nil {
+		return err
+	}
+	event.Owner, event.Repo = splitFullName(payload.Repository.FullName)
+	if href := payload.Repository.Links.HTML.Href; href != "" {
+		event.CloneURL = strings.TrimSuffix(href, "/") + ".git"
+	}
+	switch {
+	case event.Type == "repo:push" && payload.Push != nil && len(payload.Push.Changes) > 0:
+		event.Kind = EventKindPush
+		event.Author = payload.Actor.name()
+		change := payload.Push.Changes[len(payload.Push.Changes)-1]
+		name, kind := "", ""
+		if change.New != nil {
+			name, kind = change.New.Name, change.New.Type
+			event.SHA = change.New.Target.Hash
+		} else if change.Old != nil {
+			name, kind = change.Old.Name, change.Old.Type
+		}
+		if kind == "tag" {
+			event.Tag = name
+		} else {
+			event.Branch = name
+		}
+		event.Deleted = change.Closed || change.New == nil
+	case strings.HasPrefix(event.Type, "pullrequest:") && payload.PullRequest != nil:
+		pr := payload.PullRequest
+		event.Kind = EventKindPullRequest
+		event.PullRequestNumber = pr.ID
+		event.Branch = pr.Source.Branch.Name
+		event.SHA = pr.Source.Commit.Hash
+		event.BaseBranch = pr.Destination.Branch.Name
+		event.BaseSHA = pr.Destination.Commit.Hash
+		event.Author = pr.Author.name()
+		switch strings.TrimPrefix(event.Type, "pullrequest:") {
+		case "created":
+			event.Action = PullRequestActionOpened
+		case "updated":
+			event.Action = PullRequestActionSynchronized
+		case "fulfilled", "rejected":
+			event.Action = PullRequestActionClosed
+		}
+	}
+	return nil
+}
+
+type bitbucketServerRepository struct {
+	Slug    string `json:"slug"`
+	Project struct {
+		Key string `json:"key"`
+	} `json:"project"`
+	Links struct {
+		Clone []struct {
+			Href string `json:"href"`
+			Name string `json:"name"`
+		} `json:"clone"`
+	} `json:"links"`
+}
+
+func (r *bitbucketServerRepository) cloneURL() string {
+	for _, link := range r.Links.Clone {
+		if strings.HasPrefix(link.Name, "http") {
+			return link.Href
+		}
+	}
+	return ""
+}
+
+type bitbucketServerRef struct {
+	ID           string                    `json:"id"`
+	DisplayID    string                    `json:"displayId"`
+	LatestCommit string                    `json:"latestCommit"`
+	Repository   bitbucketServerRepository `json:"repository"`
+}
+
+// bitbucketServerPayload the fields of Bitbucket Server push and pull request payloads
+type bitbucketServerPayload struct {
+	Actor struct {
+		Name string `json:"name"`
+	} `json:"actor"`
+	Repository bitbucketServerRepository `json:"repository"`
+	Changes    []struct {
+		Ref struct {
+			ID   string `json:"id"`
+			Type string `json:"type"`
+		} `json:"ref"`
+		ToHash string `json:"toHash"`
+		Type   string `json:"type"`
+	} `json:"changes"`
+	PullRequest *struct {
+		ID      int                `json:"id"`
+		FromRef bitbucketServerRef `json:"fromRef"`
+		ToRef   bitbucketServerRef `json:"toRef"`
+		Author  struct {
+			User struct {
+				Name string `json:"name"`
+			} `json:"user"`
+		} `json:"author"`
+	} `json:"pullRequest"`
+}
+
+func parseBitbucketServerEvent(event *Event, data []byte) error {
+	payload := bitbucketServerPayload{}
+	err := json.Unmarshal(data, &payload)
+	if err != nil {
+		return err
+	}
+	event.Author = payload.Actor.Name
+	switch {
+	case event.Type == "repo:refs_changed" && len(payload.Changes) > 0:
+		repo := payload.Repository
+		event.Kind = EventKindPush
+		event.Owner = repo.Project.Key
+		event.Repo = repo.Slug
+		event.CloneURL = repo.cloneURL()
+		change := payload.Changes[len(payload.Changes)-1]
+		event.setRef(change.Ref.ID)
+		event.SHA = change.ToHash
+		event.Deleted = change.Type == "DELETE" || change.ToHash == zeroSHA
+	case strings.HasPrefix(event.Type, "pr:") && payload.PullRequest != nil:
+		pr := payload.PullRequest
+		repo := pr.ToRef.Repository
+		event.Kind = EventKindPullRequest
+		event.Owner = repo.Project.Key
+		event.Repo = repo.Slug
+		event.CloneURL = repo.cloneURL()
+		event.PullRequestNumber = pr.ID
+		event.Branch = pr.FromRef.DisplayID
+		event.SHA = pr.FromRef.LatestCommit
+		event.BaseBranch = pr.ToRef.DisplayID
+		event.BaseSHA = pr.ToRef.LatestCommit
+		event.Author = pr.Author.User.Name
+		switch strings.TrimPrefix(event.Type, "pr:") {
+		case "opened":
+			event.Action = PullRequestActionOpened
+		case "from_ref_updated":
+			event.Action = PullRequestActionSynchronized
+		case "modified":
+			event.Action = PullRequestActionEdited
+		case "merged", "declined", "deleted":
+			event.Action = PullRequestActionClosed
+		}
+	}
+	return nil
+}
+
+// splitFullName splits the full name of a repository into its owner and name. The owner of a GitLab repository may
+// contain sub groups
+func splitFullName(fullName string) (string, string) {
+	i := strings.LastIndex(fullName, "/")
+	if i < 0 {
+		return "", fullName
+	}
+	return fullName[:i], fullName[i+1:]
+}
@@ -0,0 +1,53 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"hash"
+	"net/http"
+	"strings"
+)
+
+// validGitHubSignature verifies the SHA-256 signature of a GitHub webhook falling back to the SHA-1 signature sent by
+// older GitHub Enterprise servers
+func validGitHubSignature(payload []byte, header http.Header, secret []byte) bool {
+	if sig := header.Get("X-Hub-Signature-256"); sig != "" {
+		return validHubSignature(payload, sig, secret)
+	}
+	return validHubSignature(payload, header.Get("X-Hub-Signature"), secret)
+}
+
+// validHubSignature verifies a signature of the form sha1=<hex> or sha256=<hex>
+func validHubSignature(payload []byte, sig string, secret []byte) bool {
+	switch {
+	case strings.HasPrefix(sig, "sha256="):
+		return validMAC(sha256.New, payload, strings.TrimPrefix(sig, "sha256="), secret)
+	case strings.HasPrefix(sig, "sha1="):
+		return validMAC(sha1.New, payload, strings.TrimPrefix(sig, "sha1="), secret)
+	default:
+		return false
+	}
+}
+
+// validHMACSignature verifies a hex SHA-256 signature without a prefix as sent by Gitea
+func validHMACSignature(payload []byte, sig string, secret []byte) bool {
+	return validMAC(sha256.New, payload, sig, secret)
+}
+
+func validMAC(h func() hash.Hash, payload []byte, sig string, secret []byte) bool {
+	actual, err := hex.DecodeString(sig)
+	if err != nil || len(actual) == 0 {
+		return false
+	}
+	mac := hmac.New(h, secret)
+	mac.Write(payload)
+	return hmac.Equal(actual, mac.Sum(nil))
+}
+
+// validToken verifies a secret token sent with the webhook
+func validToken(token string, secret []byte) bool {
+	return token != "" && subtle.ConstantTimeCompare([]byte(token), secret) == 1
+}
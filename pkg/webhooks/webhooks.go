@@ -0,0 +1,189 @@
+package webhooks
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/pkg/errors"
+)
+
+// EventKind the kind of webhook event which can trigger pipelines
+type EventKind string
+
+const (
+	// EventKindPush a push of commits to a branch or tag
+	EventKindPush EventKind = "push"
+	// EventKindPullRequest a pull request was opened, updated, reopened or closed
+	EventKindPullRequest EventKind = "pull_request"
+)
+
+const (
+	// PullRequestActionOpened the pull request was opened
+	PullRequestActionOpened = "opened"
+	// PullRequestActionSynchronized new commits were pushed to the source branch of the pull request
+	PullRequestActionSynchronized = "synchronized"
+	// PullRequestActionReopened the pull request was reopened
+	PullRequestActionReopened = "reopened"
+	// PullRequestActionEdited the title, description or labels of the pull request were changed
+	PullRequestActionEdited = "edited"
+	// PullRequestActionClosed the pull request was closed or merged
+	PullRequestActionClosed = "closed"
+)
+
+// ErrInvalidSignature the error returned when a webhook is not signed with the secret
+var ErrInvalidSignature = errors.New("the webhook signature is missing or invalid")
+
+// Event a push or pull request webhook event of any git provider
+type Event struct {
+	// Kind is the kind of event or empty if the event cannot trigger pipelines
+	Kind EventKind
+	// GitKind is the kind of git provider which sent the event
+	GitKind string
+	// Type is the event type of the git provider such as pull_request or Merge Request Hook
+	Type string
+	// GUID is the unique ID of the delivery if the git provider sends one
+	GUID string
+	// Action is the normalized action of a pull request event such as opened or synchronized
+	Action string
+	// Owner is the organisation, user, group or project of the repository
+	Owner string
+	// Repo is the name of the repository
+	Repo string
+	// CloneURL is the HTTP(S) URL to clone the repository
+	CloneURL string
+	// Branch is the branch that was pushed or the source branch of a pull request
+	Branch string
+	// Tag is the tag that was pushed
+	Tag string
+	// SHA is the commit that was pushed or the head commit of a pull request
+	SHA string
+	// BaseBranch is the target branch of a pull request
+	BaseBranch string
+	// BaseSHA is the commit of the target branch of a pull request if known
+	BaseSHA string
+	// PullRequestNumber is the number of the pull request
+	PullRequestNumber int
+	// Author is the user who pushed the commits or opened the pull request
+	Author string
+	// Deleted is true if the branch or tag was deleted by the push
+	Deleted bool
+	// Changes are the files that were added, modified or removed by a push or nil if they are not known
+	Changes []string
+}
+
+// String returns a summary of the event for logging
+func (e *Event) String() string {
+	switch e.Kind {
+	case EventKindPush:
+		ref := e.Branch
+		if ref == "" {
+			ref = e.Tag
+		}
+		return fmt.Sprintf("%s push of %s to %s/%s %s", e.GitKind, e.SHA, e.Owner, e.Repo, ref)
+	case EventKindPullRequest:
+		return fmt.Sprintf("%s pull request %s/%s #%d %s", e.GitKind, e.Owner, e.Repo, e.PullRequestNumber, e.Action)
+	default:
+		return fmt.Sprintf("%s %s", e.GitKind, e.Type)
+	}
+}
+
+// Triggers returns true if the event should trigger pipelines: a push to a branch which was not deleted or a pull
+// request which was opened, reopened or has new commits
+func (e *Event) Triggers() bool {
+	switch e.Kind {
+	case EventKindPush:
+		return e.Branch != "" && !e.Deleted && e.SHA != ""
+	case EventKindPullRequest:
+		return e.Action == PullRequestActionOpened || e.Action == PullRequestActionSynchronized ||
+			e.Action == PullRequestActionReopened
+	default:
+		return false
+	}
+}
+
+// ParseWebhook verifies the signature of a webhook request from GitHub, GitLab, Gitea, Bitbucket Cloud or Bitbucket
+// Server with the secret and parses its push or pull request event.
+//
+// GitHub, Gitea and Bitbucket Server sign the payload with an HMAC of the secret whereas GitLab sends the secret as a
+// token. Bitbucket Cloud cannot sign webhooks so the secret has to be passed as the secret query parameter of the
+// webhook URL. Other events are returned with an empty Kind
+func ParseWebhook(r *http.Request, secret []byte) (*Event, error) {
+	if len(secret) == 0 {
+		return nil, errors.New("no secret has been configured to verify webhooks")
+	}
+	payload, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading the webhook payload")
+	}
+	header := r.Header
+	var gitKind, eventType, guid string
+	var valid bool
+	switch {
+	case header.Get("X-Gitea-Event") != "":
+		gitKind = gits.KindGitea
+		eventType = header.Get("X-Gitea-Event")
+		guid = header.Get("X-Gitea-Delivery")
+		valid = validHMACSignature(payload, header.Get("X-Gitea-Signature"), secret)
+	case header.Get("X-GitHub-Event") != "":
+		gitKind = gits.KindGitHub
+		eventType = header.Get("X-GitHub-Event")
+		guid = header.Get("X-GitHub-Delivery")
+		valid = validGitHubSignature(payload, header, secret)
+	case header.Get("X-Gitlab-Event") != "":
+		gitKind = gits.KindGitlab
+		eventType = header.Get("X-Gitlab-Event")
+		valid = validToken(header.Get("X-Gitlab-Token"), secret)
+	case header.Get("X-Event-Key") != "" && header.Get("X-Hook-UUID") != "":
+		gitKind = gits.KindBitBucketCloud
+		eventType = header.Get("X-Event-Key")
+		guid = header.Get("X-Request-UUID")
+		valid = validToken(r.URL.Query().Get("secret"), secret)
+	case header.Get("X-Event-Key") != "":
+		gitKind = gits.KindBitBucketServer
+		eventType = header.Get("X-Event-Key")
+		guid = header.Get("X-Request-Id")
+		valid = validHubSignature(payload, header.Get("X-Hub-Signature"), secret)
+	default:
+		return nil, errors.New("the request is not a webhook of a supported git provider")
+	}
+	if !valid {
+		return nil, errors.Wrapf(ErrInvalidSignature, "verifying the %s %s webhook", gitKind, eventType)
+	}
+	event := &Event{
+		GitKind: gitKind,
+		Type:    eventType,
+		GUID:    guid,
+	}
+	if strings.TrimSpace(string(payload)) == "" {
+		return event, nil
+	}
+	switch gitKind {
+	case gits.KindGitHub, gits.KindGitea:
+		err = parseGitHubEvent(event, payload)
+	case gits.KindGitlab:
+		err = parseGitLabEvent(event, payload)
+	case gits.KindBitBucketCloud:
+		err = parseBitbucketCloudEvent(event, payload)
+	case gits.KindBitBucketServer:
+		err = parseBitbucketServerEvent(event, payload)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing the %s %s webhook", gitKind, eventType)
+	}
+	return event, nil
+}
+
+// setRef sets the branch or tag of the event from a git ref such as refs/heads/master
+func (e *Event) setRef(ref string) {
+	switch {
+	case strings.HasPrefix(ref, "refs/heads/"):
+		e.Branch = strings.TrimPrefix(ref, "refs/heads/")
+	case strings.HasPrefix(ref, "refs/tags/"):
+		e.Tag = strings.TrimPrefix(ref, "refs/tags/")
+	default:
+		e.Branch = ref
+	}
+}
@@ -0,0 +1,233 @@
+package webhooks_test
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/webhooks"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var secret = []byte("my-secret")
+
+func TestParseWebhook(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name     string
+		file     string
+		url      string
+		headers  func(payload []byte) map[string]string
+		expected webhooks.Event
+	}{
+		{
+			name: "github push",
+			file: "github_push.json",
+			headers: func(payload []byte) map[string]string {
+				return map[string]string{
+					"X-GitHub-Event":      "push",
+					"X-GitHub-Delivery":   "72d3162e",
+					"X-Hub-Signature-256": "sha256=" + sign(sha256.New, payload),
+				}
+			},
+			expected: webhooks.Event{
+				Kind:     webhooks.EventKindPush,
+				GitKind:  gits.KindGitHub,
+				Type:     "push",
+				GUID:     "72d3162e",
+				Owner:    "jstrachan",
+				Repo:     "cheese",
+				CloneURL: "https://github.com/jstrachan/cheese.git",
+				Branch:   "master",
+				SHA:      "0d1a26e67d8f5eaf1f6ba5c57fc3c7d91ac0fd1c",
+				Author:   "jstrachan",
+				Changes:  []string{"charts/cheese/values.yaml", "README.md"},
+			},
+		},
+		{
+			name: "github pull request with a SHA-1 signature",
+			file: "github_pull_request.json",
+			headers: func(payload []byte) map[string]string {
+				return map[string]string{
+					"X-GitHub-Event":  "pull_request",
+					"X-Hub-Signature": "sha1=" + sign(sha1.New, payload),
+				}
+			},
+			expected: webhooks.Event{
+				Kind:              webhooks.EventKindPullRequest,
+				GitKind:           gits.KindGitHub,
+				Type:              "pull_request",
+				Action:            webhooks.PullRequestActionSynchronized,
+				Owner:             "jstrachan",
+				Repo:              "cheese",
+				CloneURL:          "https://github.com/jstrachan/cheese.git",
+				Branch:            "my-feature",
+				SHA:               "ec26c3e57ca3a959ca5aad62de7213c562f8c821",
+				BaseBranch:        "master",
+				BaseSHA:           "0d1a26e67d8f5eaf1f6ba5c57fc3c7d91ac0fd1c",
+				PullRequestNumber: 7,
+				Author:            "rawlingsj",
+			},
+		},
+		{
+			name: "gitea pull request",
+			file: "github_pull_request.json",
+			headers: func(payload []byte) map[string]string {
+				return map[string]string{
+					"X-Gitea-Event":     "pull_request",
+					"X-GitHub-Event":    "pull_request",
+					"X-Gitea-Signature": sign(sha256.New, payload),
+				}
+			},
+			expected: webhooks.Event{
+				Kind:              webhooks.EventKindPullRequest,
+				GitKind:           gits.KindGitea,
+				Type:              "pull_request",
+				Action:            webhooks.PullRequestActionSynchronized,
+				Owner:             "jstrachan",
+				Repo:              "cheese",
+				CloneURL:          "https://github.com/jstrachan/cheese.git",
+				Branch:            "my-feature",
+				SHA:               "ec26c3e57ca3a959ca5aad62de7213c562f8c821",
+				BaseBranch:        "master",
+				BaseSHA:           "0d1a26e67d8f5eaf1f6ba5c57fc3c7d91ac0fd1c",
+				PullRequestNumber: 7,
+				Author:            "rawlingsj",
+			},
+		},
+		{
+			name: "gitlab merge request in a sub group",
+			file: "gitlab_merge_request.json",
+			headers: func(payload []byte) map[string]string {
+				return map[string]string{
+					"X-Gitlab-Event": "Merge Request Hook",
+					"X-Gitlab-Token": string(secret),
+				}
+			},
+			expected: webhooks.Event{
+				Kind:              webhooks.EventKindPullRequest,
+				GitKind:           gits.KindGitlab,
+				Type:              "Merge Request Hook",
+				Action:            webhooks.PullRequestActionSynchronized,
+				Owner:             "jenkins-x/apps",
+				Repo:              "cheese",
+				CloneURL:          "https://gitlab.com/jenkins-x/apps/cheese.git",
+				Branch:            "my-feature",
+				SHA:               "ec26c3e57ca3a959ca5aad62de7213c562f8c821",
+				BaseBranch:        "master",
+				PullRequestNumber: 3,
+				Author:            "rawlingsj",
+			},
+		},
+		{
+			name: "bitbucket server push",
+			file: "bitbucketserver_refs_changed.json",
+			headers: func(payload []byte) map[string]string {
+				return map[string]string{
+					"X-Event-Key":     "repo:refs_changed",
+					"X-Request-Id":    "d5d5e7c6",
+					"X-Hub-Signature": "sha256=" + sign(sha256.New, payload),
+				}
+			},
+			expected: webhooks.Event{
+				Kind:     webhooks.EventKindPush,
+				GitKind:  gits.KindBitBucketServer,
+				Type:     "repo:refs_changed",
+				GUID:     "d5d5e7c6",
+				Owner:    "JX",
+				Repo:     "cheese",
+				CloneURL: "https://bitbucket.example.com/scm/jx/cheese.git",
+				Branch:   "master",
+				SHA:      "0d1a26e67d8f5eaf1f6ba5c57fc3c7d91ac0fd1c",
+				Author:   "jstrachan",
+			},
+		},
+		{
+			name: "bitbucket cloud pull request",
+			file: "bitbucketcloud_pull_request.json",
+			url:  "/hook?secret=my-secret",
+			headers: func(payload []byte) map[string]string {
+				return map[string]string{
+					"X-Event-Key":    "pullrequest:created",
+					"X-Hook-UUID":    "a1b2c3",
+					"X-Request-UUID": "e4f5a6",
+				}
+			},
+			expected: webhooks.Event{
+				Kind:              webhooks.EventKindPullRequest,
+				GitKind:           gits.KindBitBucketCloud,
+				Type:              "pullrequest:created",
+				GUID:              "e4f5a6",
+				Action:            webhooks.PullRequestActionOpened,
+				Owner:             "jstrachan",
+				Repo:              "cheese",
+				CloneURL:          "https://bitbucket.org/jstrachan/cheese.git",
+				Branch:            "my-feature",
+				SHA:               "ec26c3e57ca3",
+				BaseBranch:        "master",
+				BaseSHA:           "0d1a26e67d8f",
+				PullRequestNumber: 5,
+				Author:            "rawlingsj",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		payload, err := ioutil.ReadFile(filepath.Join("test_data", tc.file))
+		require.NoError(t, err, tc.name)
+
+		event, err := webhooks.ParseWebhook(newRequest(tc.url, payload, tc.headers(payload)), secret)
+		require.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expected, *event, tc.name)
+		assert.True(t, event.Triggers(), tc.name)
+
+		// lets check the webhook is rejected if the payload has been tampered with
+		tampered := bytes.Replace(payload, []byte("cheese"), []byte("wine"), -1)
+		_, err = webhooks.ParseWebhook(newRequest(tc.url, tampered, tc.headers(payload)), []byte("another-secret"))
+		require.Error(t, err, tc.name)
+		assert.Equal(t, webhooks.ErrInvalidSignature, errors.Cause(err), tc.name)
+	}
+}
+
+func TestParseWebhookIgnoresOtherEvents(t *testing.T) {
+	t.Parallel()
+	payload := []byte(`{"zen": "Keep it logically awesome.", "hook_id": 1}`)
+	event, err := webhooks.ParseWebhook(newRequest("", payload, map[string]string{
+		"X-GitHub-Event":  "ping",
+		"X-Hub-Signature": "sha1=" + sign(sha1.New, payload),
+	}), secret)
+	require.NoError(t, err)
+	assert.Equal(t, webhooks.EventKind(""), event.Kind)
+	assert.False(t, event.Triggers())
+
+	_, err = webhooks.ParseWebhook(newRequest("", payload, map[string]string{}), secret)
+	assert.Error(t, err, "should reject requests which are not webhooks")
+}
+
+func newRequest(url string, payload []byte, headers map[string]string) *http.Request {
+	if url == "" {
+		url = "/hook"
+	}
+	req := httptest.NewRequest(http.MethodPost, url, bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	return req
+}
+
+func sign(h func() hash.Hash, payload []byte) string {
+	mac := hmac.New(h, secret)
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,269 @@
+package workflow
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/cve"
+)
+
+const (
+	// GateSubjectCoverage the percentage of covered lines, or of the count type suffix such as 'coverage.branches',
+	// from the coverage Fact
+	GateSubjectCoverage = "coverage"
+	// GateSubjectTests the measurement suffix such as 'tests.failed' of the test results Fact
+	GateSubjectTests = "tests"
+	// GateSubjectCVE the most severe vulnerability of the images or, with a severity suffix such as 'cve.high', the
+	// number of vulnerabilities of that severity
+	GateSubjectCVE = "cve"
+	// GateSubjectStatement the statement suffix such as 'statement.approved' of any Fact
+	GateSubjectStatement = "statement"
+)
+
+var gateExpression = regexp.MustCompile(`^\s*([A-Za-z0-9_.\-/]+)\s*(==|!=|>=|<=|>|<)\s*(\S+)\s*$`)
+
+// Gate is a condition which must hold before a version can be promoted such as `coverage >= 80`, `tests.failed == 0`,
+// `cve <= Medium` or `statement.approved == true`. Any Fact measurement can be used with the subject
+// `<factType>/<measurement>`
+type Gate struct {
+	Expression string
+	Subject    string
+	Operator   string
+	Value      string
+}
+
+// GateInputs are what the gates are evaluated against
+type GateInputs struct {
+	// Facts about the pipeline of the version being promoted with the newest first
+	Facts []v1.Fact
+	// Vulnerabilities returns the vulnerabilities of the images being promoted. It is nil if there is no CVE provider
+	Vulnerabilities func() ([]cve.Vulnerability, error)
+
+	vulnerabilities []cve.Vulnerability
+	loaded          bool
+}
+
+// ParseGate parses the gate expression
+func ParseGate(expression string) (*Gate, error) {
+	groups := gateExpression.FindStringSubmatch(expression)
+	if groups == nil {
+		return nil, fmt.Errorf("invalid gate '%s' which should be of the form 'subject operator value' such as 'coverage >= 80'", expression)
+	}
+	gate := &Gate{
+		Expression: strings.TrimSpace(expression),
+		Subject:    groups[1],
+		Operator:   groups[2],
+		Value:      groups[3],
+	}
+	subject, suffix := gate.splitSubject()
+	switch {
+	case subject == GateSubjectCVE && suffix == "":
+		if cve.SeverityLevel(gate.Value) < 0 {
+			return nil, fmt.Errorf("invalid gate '%s' as %s is not one of the severities %s", expression, gate.Value, strings.Join(cve.Severities, ", "))
+		}
+	case subject == GateSubjectStatement:
+		_, err := strconv.ParseBool(gate.Value)
+		if err != nil || (gate.Operator != "==" && gate.Operator != "!=") {
+			return nil, fmt.Errorf("invalid gate '%s' as statements can only be compared to true or false using == or !=", expression)
+		}
+	default:
+		_, err := gate.number()
+		if err != nil {
+			return nil, fmt.Errorf("invalid gate '%s' as %s is not a number", expression, gate.Value)
+		}
+	}
+	return gate, nil
+}
+
+// EvaluateGates evaluates the gate expressions returning the result of each gate. It fails if an expression is invalid
+func EvaluateGates(expressions []string, inputs *GateInputs) ([]v1.GateResult, error) {
+	answer := []v1.GateResult{}
+	for _, expression := range expressions {
+		gate, err := ParseGate(expression)
+		if err != nil {
+			return answer, err
+		}
+		passed, message, err := gate.Evaluate(inputs)
+		if err != nil {
+			// a gate which cannot be evaluated blocks the promotion
+			passed = false
+			message = err.Error()
+		}
+		answer = append(answer, v1.GateResult{
+			Gate:    gate.Expression,
+			Passed:  passed,
+			Message: message,
+		})
+	}
+	return answer, nil
+}
+
+// FailedGatesMessage returns the reasons the gates of the results failed or an empty string if they all passed
+func FailedGatesMessage(results []v1.GateResult) string {
+	reasons := []string{}
+	for _, result := range results {
+		if !result.Passed {
+			reasons = append(reasons, fmt.Sprintf("%s: %s", result.Gate, result.Message))
+		}
+	}
+	return strings.Join(reasons, "; ")
+}
+
+// Evaluate returns whether the gate passes along with a message describing the value it was evaluated against
+func (g *Gate) Evaluate(inputs *GateInputs) (bool, string, error) {
+	subject, suffix := g.splitSubject()
+	switch subject {
+	case GateSubjectCoverage:
+		countType := v1.CodeCoverageCountTypeLines
+		if suffix != "" {
+			countType = suffix
+		}
+		return g.evaluateMeasurement(inputs, v1.FactTypeCoverage, v1.CodeCoverageMeasurementCoverage, v1.MeasurementPercent, countType)
+	case GateSubjectTests:
+		if suffix == "" {
+			return false, "", fmt.Errorf("invalid gate '%s' as the test measurement is missing such as 'tests.failed'", g.Expression)
+		}
+		return g.evaluateMeasurement(inputs, v1.FactTypeTestResults, suffix, "", "")
+	case GateSubjectCVE:
+		return g.evaluateCVE(inputs, suffix)
+	case GateSubjectStatement:
+		return g.evaluateStatement(inputs, suffix)
+	}
+	idx := strings.LastIndex(g.Subject, "/")
+	if idx <= 0 || idx == len(g.Subject)-1 {
+		return false, "", fmt.Errorf("invalid gate '%s' as %s is not one of %s, %s, %s, %s or of the form factType/measurement", g.Expression, g.Subject,
+			GateSubjectCoverage, GateSubjectTests, GateSubjectCVE, GateSubjectStatement)
+	}
+	return g.evaluateMeasurement(inputs, g.Subject[0:idx], g.Subject[idx+1:], "", "")
+}
+
+func (g *Gate) evaluateMeasurement(inputs *GateInputs, factType string, name string, measurementType string, tag string) (bool, string, error) {
+	fact := inputs.latestFact(factType)
+	if fact == nil {
+		return false, fmt.Sprintf("no %s Fact found", factType), nil
+	}
+	for _, m := range fact.Spec.Measurements {
+		if !strings.EqualFold(m.Name, name) || (measurementType != "" && m.MeasurementType != measurementType) {
+			continue
+		}
+		if tag != "" && (len(m.Tags) == 0 || !strings.EqualFold(m.Tags[0], tag)) {
+			continue
+		}
+		expected, err := g.number()
+		if err != nil {
+			return false, "", err
+		}
+		return compare(m.MeasurementValue, g.Operator, expected), fmt.Sprintf("%s is %d", g.Subject, m.MeasurementValue), nil
+	}
+	return false, fmt.Sprintf("the %s Fact %s has no %s measurement", factType, fact.Name, name), nil
+}
+
+func (g *Gate) evaluateCVE(inputs *GateInputs, severity string) (bool, string, error) {
+	vulnerabilities, err := inputs.loadVulnerabilities()
+	if err != nil {
+		return false, "", err
+	}
+	if severity != "" {
+		count := 0
+		for _, v := range vulnerabilities {
+			if strings.EqualFold(v.Severity, severity) {
+				count++
+			}
+		}
+		expected, err := g.number()
+		if err != nil {
+			return false, "", err
+		}
+		return compare(count, g.Operator, expected), fmt.Sprintf("found %d vulnerabilities with severity %s", count, severity), nil
+	}
+	mostSevere := cve.MostSevere(vulnerabilities)
+	if mostSevere == nil {
+		return compare(-1, g.Operator, cve.SeverityLevel(g.Value)), "found no vulnerabilities", nil
+	}
+	passed := compare(cve.SeverityLevel(mostSevere.Severity), g.Operator, cve.SeverityLevel(g.Value))
+	return passed, fmt.Sprintf("the most severe vulnerability %s in %s has severity %s", mostSevere.Vuln, mostSevere.Package, mostSevere.Severity), nil
+}
+
+func (g *Gate) evaluateStatement(inputs *GateInputs, name string) (bool, string, error) {
+	if name == "" {
+		return false, "", fmt.Errorf("invalid gate '%s' as the statement name is missing such as 'statement.approved'", g.Expression)
+	}
+	expected, err := strconv.ParseBool(g.Value)
+	if err != nil {
+		return false, "", err
+	}
+	for _, fact := range inputs.Facts {
+		for _, s := range fact.Spec.Statements {
+			if strings.EqualFold(s.Name, name) {
+				passed := s.MeasurementValue == expected
+				if g.Operator == "!=" {
+					passed = !passed
+				}
+				return passed, fmt.Sprintf("statement %s of Fact %s is %t", s.Name, fact.Name, s.MeasurementValue), nil
+			}
+		}
+	}
+	return false, fmt.Sprintf("no statement %s found", name), nil
+}
+
+// splitSubject splits the subject into the kind of subject and the optional suffix after the first dot
+func (g *Gate) splitSubject() (string, string) {
+	if strings.Contains(g.Subject, "/") {
+		return g.Subject, ""
+	}
+	paths := strings.SplitN(g.Subject, ".", 2)
+	if len(paths) == 1 {
+		return strings.ToLower(paths[0]), ""
+	}
+	return strings.ToLower(paths[0]), paths[1]
+}
+
+func (g *Gate) number() (int, error) {
+	return strconv.Atoi(strings.TrimSuffix(g.Value, "%"))
+}
+
+func (i *GateInputs) latestFact(factType string) *v1.Fact {
+	for j := range i.Facts {
+		if i.Facts[j].Spec.FactType == factType {
+			return &i.Facts[j]
+		}
+	}
+	return nil
+}
+
+func (i *GateInputs) loadVulnerabilities() ([]cve.Vulnerability, error) {
+	if i.loaded {
+		return i.vulnerabilities, nil
+	}
+	if i.Vulnerabilities == nil {
+		return nil, fmt.Errorf("no CVE provider is available to evaluate the cve gates")
+	}
+	vulnerabilities, err := i.Vulnerabilities()
+	if err != nil {
+		return nil, err
+	}
+	i.vulnerabilities = vulnerabilities
+	i.loaded = true
+	return vulnerabilities, nil
+}
+
+func compare(actual int, operator string, expected int) bool {
+	switch operator {
+	case "==":
+		return actual == expected
+	case "!=":
+		return actual != expected
+	case ">=":
+		return actual >= expected
+	case "<=":
+		return actual <= expected
+	case ">":
+		return actual > expected
+	case "<":
+		return actual < expected
+	}
+	return false
+}
@@ -0,0 +1,138 @@
+package workflow_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/cve"
+	"github.com/jenkins-x/jx/pkg/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseGate(t *testing.T) {
+	t.Parallel()
+	gate, err := workflow.ParseGate(" coverage >=80% ")
+	require.NoError(t, err)
+	assert.Equal(t, workflow.Gate{Expression: "coverage >=80%", Subject: "coverage", Operator: ">=", Value: "80%"}, *gate)
+
+	for _, expression := range []string{
+		"tests.failed == 0",
+		"cve <= High",
+		"cve.critical == 0",
+		"statement.approved == true",
+		"jx.staticProgramAnalysis/High < 3",
+	} {
+		_, err := workflow.ParseGate(expression)
+		assert.NoError(t, err, expression)
+	}
+	for _, expression := range []string{
+		"",
+		"coverage",
+		"coverage => 80",
+		"coverage >= high",
+		"cve <= Dangerous",
+		"statement.approved > false",
+		"statement.approved == yes",
+	} {
+		_, err := workflow.ParseGate(expression)
+		assert.Error(t, err, expression)
+	}
+}
+
+func TestEvaluateGates(t *testing.T) {
+	t.Parallel()
+	facts := []v1.Fact{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "jx-coverage-myorg-myapp-master-3"},
+			Spec: v1.FactSpec{
+				FactType: v1.FactTypeCoverage,
+				Measurements: []v1.Measurement{
+					{Name: v1.CodeCoverageMeasurementCoverage, MeasurementType: v1.MeasurementCount, MeasurementValue: 850, Tags: []string{v1.CodeCoverageCountTypeLines}},
+					{Name: v1.CodeCoverageMeasurementCoverage, MeasurementType: v1.MeasurementPercent, MeasurementValue: 85, Tags: []string{v1.CodeCoverageCountTypeLines}},
+					{Name: v1.CodeCoverageMeasurementCoverage, MeasurementType: v1.MeasurementPercent, MeasurementValue: 60, Tags: []string{v1.CodeCoverageCountTypeBranches}},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "jx-tests-myorg-myapp-master-3"},
+			Spec: v1.FactSpec{
+				FactType: v1.FactTypeTestResults,
+				Measurements: []v1.Measurement{
+					{Name: v1.TestResultsMeasurementTotal, MeasurementType: v1.MeasurementCount, MeasurementValue: 120},
+					{Name: v1.TestResultsMeasurementFailed, MeasurementType: v1.MeasurementCount, MeasurementValue: 0},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "jx-coverage-myorg-myapp-master-2"},
+			Spec: v1.FactSpec{
+				FactType: v1.FactTypeCoverage,
+				Measurements: []v1.Measurement{
+					{Name: v1.CodeCoverageMeasurementCoverage, MeasurementType: v1.MeasurementPercent, MeasurementValue: 10, Tags: []string{v1.CodeCoverageCountTypeLines}},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "approval-myorg-myapp-master-3"},
+			Spec: v1.FactSpec{
+				FactType: "approval",
+				Statements: []v1.Statement{
+					{Name: "approved", StatementType: "approval", MeasurementValue: true},
+				},
+			},
+		},
+	}
+	calls := 0
+	inputs := &workflow.GateInputs{
+		Facts: facts,
+		Vulnerabilities: func() ([]cve.Vulnerability, error) {
+			calls++
+			return []cve.Vulnerability{
+				{Vuln: "CVE-1", Package: "openssl", Severity: "Medium"},
+				{Vuln: "CVE-2", Package: "glibc", Severity: "High"},
+				{Vuln: "CVE-3", Package: "zlib", Severity: "Low"},
+			}, nil
+		},
+	}
+	testCases := []struct {
+		gate   string
+		passed bool
+	}{
+		{"coverage >= 80", true},
+		{"coverage >= 90", false},
+		{"coverage.branches >= 80", false},
+		{"tests.failed == 0", true},
+		{"tests.total > 100", true},
+		{"tests.skipped == 0", false},
+		{"cve <= High", true},
+		{"cve < High", false},
+		{"cve.high == 0", false},
+		{"cve.critical == 0", true},
+		{"statement.approved == true", true},
+		{"statement.released == true", false},
+		{"approval/missing == 1", false},
+		{"unknown >= 1", false},
+	}
+	for _, tc := range testCases {
+		results, err := workflow.EvaluateGates([]string{tc.gate}, inputs)
+		require.NoError(t, err, tc.gate)
+		require.Len(t, results, 1, tc.gate)
+		assert.Equal(t, tc.passed, results[0].Passed, fmt.Sprintf("%s: %s", tc.gate, results[0].Message))
+	}
+	assert.Equal(t, 1, calls, "the vulnerabilities are only loaded once")
+
+	results, err := workflow.EvaluateGates([]string{"coverage >= 90", "tests.failed == 0", "cve <= Medium"}, inputs)
+	require.NoError(t, err)
+	assert.Equal(t, "coverage >= 90: coverage is 85; cve <= Medium: the most severe vulnerability CVE-2 in glibc has severity High",
+		workflow.FailedGatesMessage(results))
+
+	results, err = workflow.EvaluateGates([]string{"cve <= High"}, &workflow.GateInputs{})
+	require.NoError(t, err)
+	assert.False(t, results[0].Passed, "no CVE provider")
+
+	_, err = workflow.EvaluateGates([]string{"coverage >= lots"}, inputs)
+	assert.Error(t, err)
+}